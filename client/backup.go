@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// CreateBackup fetches a backup archive of the entire registry from mcpjungle.
+func (c *Client) CreateBackup() (*types.BackupArchive, error) {
+	u, _ := c.constructGlobalAPIEndpoint("/backup")
+
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var archive types.BackupArchive
+	if err := json.NewDecoder(resp.Body).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &archive, nil
+}
+
+// RestoreBackup sends a backup archive to mcpjungle to be restored into its (empty) registry.
+func (c *Client) RestoreBackup(archive *types.BackupArchive) (*types.RestoreResult, error) {
+	u, _ := c.constructGlobalAPIEndpoint("/restore")
+
+	body, err := json.Marshal(archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize backup archive into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var result types.RestoreResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
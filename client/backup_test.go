@@ -0,0 +1,80 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestCreateBackup(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v0/backup" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		_ = json.NewEncoder(w).Encode(types.BackupArchive{
+			Version:    types.BackupArchiveVersion,
+			McpServers: []types.BackupMcpServer{{Name: "calc", Transport: "streamable_http"}},
+		})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test-token", http.DefaultClient)
+	archive, err := c.CreateBackup()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if archive.Version != types.BackupArchiveVersion || len(archive.McpServers) != 1 {
+		t.Errorf("unexpected archive: %+v", archive)
+	}
+}
+
+func TestCreateBackup_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"admin access required"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", http.DefaultClient)
+	if _, err := c.CreateBackup(); err == nil || err.Error() != "admin access required" {
+		t.Fatalf("expected API error, got %v", err)
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v0/restore" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var archive types.BackupArchive
+		if err := json.NewDecoder(r.Body).Decode(&archive); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		_ = json.NewEncoder(w).Encode(types.RestoreResult{McpServers: len(archive.McpServers)})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", http.DefaultClient)
+	result, err := c.RestoreBackup(&types.BackupArchive{
+		Version:    types.BackupArchiveVersion,
+		McpServers: []types.BackupMcpServer{{Name: "a"}, {Name: "b"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.McpServers != 2 {
+		t.Errorf("expected 2 restored servers, got %d", result.McpServers)
+	}
+}
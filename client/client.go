@@ -28,6 +28,9 @@ type Client struct {
 	baseURL     string
 	accessToken string
 	httpClient  *http.Client
+
+	// namespace is the namespace the client operates in, if any.
+	namespace string
 }
 
 func NewClient(baseURL string, accessToken string, httpClient *http.Client) *Client {
@@ -43,8 +46,30 @@ func (c *Client) BaseURL() string {
 	return c.baseURL
 }
 
-// constructAPIEndpoint constructs the full API endpoint URL where a request must be sent
+// SetNamespace makes the client operate in the given namespace.
+// All subsequent requests only manage the entities in the namespace and names are relative to it.
+// An empty namespace makes the client operate outside any namespace.
+func (c *Client) SetNamespace(namespace string) {
+	c.namespace = namespace
+}
+
+// Namespace returns the namespace the client operates in, or an empty string if it doesn't use any namespace.
+func (c *Client) Namespace() string {
+	return c.namespace
+}
+
+// constructAPIEndpoint constructs the full API endpoint URL where a request must be sent.
+// If the client operates in a namespace, the endpoint of the namespace is returned.
 func (c *Client) constructAPIEndpoint(suffixPath string) (string, error) {
+	if c.namespace != "" {
+		return url.JoinPath(c.baseURL, api.V0ApiPathPrefix, "ns", c.namespace, suffixPath)
+	}
+	return c.constructGlobalAPIEndpoint(suffixPath)
+}
+
+// constructGlobalAPIEndpoint constructs the full API endpoint URL for requests that affect the whole
+// mcpjungle server and are not available in namespaces.
+func (c *Client) constructGlobalAPIEndpoint(suffixPath string) (string, error) {
 	return url.JoinPath(c.baseURL, api.V0ApiPathPrefix, suffixPath)
 }
 
@@ -80,6 +80,34 @@ func TestConstructAPIEndpoint(t *testing.T) {
 	}
 }
 
+func TestConstructAPIEndpointWithNamespace(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://api.example.com", "token", &http.Client{})
+	client.SetNamespace("team-a")
+
+	if client.Namespace() != "team-a" {
+		t.Errorf("Expected namespace team-a, got %s", client.Namespace())
+	}
+
+	result, err := client.constructAPIEndpoint("/servers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "https://api.example.com/api/v0/ns/team-a/servers" {
+		t.Errorf("Expected namespaced endpoint, got %s", result)
+	}
+
+	// global endpoints ignore the namespace
+	result, err = client.constructGlobalAPIEndpoint("/namespaces")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "https://api.example.com/api/v0/namespaces" {
+		t.Errorf("Expected global endpoint, got %s", result)
+	}
+}
+
 func TestNewRequest(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestComplete(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complete prompt argument", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			if !strings.HasSuffix(r.URL.Path, "/complete") {
+				t.Errorf("Expected /complete, got %s", r.URL.Path)
+			}
+			body, _ := io.ReadAll(r.Body)
+			var req types.CompleteRequest
+			_ = json.Unmarshal(body, &req)
+			if req.Prompt != "github__review" || req.Argument != "repo" || req.Value != "mcp" ||
+				req.Context["owner"] != "octo" {
+				t.Errorf("Unexpected request: %+v", req)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.CompleteResult{Values: []string{"mcpjungle", "mcp-go"}, Total: 3, HasMore: true})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "token", &http.Client{})
+		result, err := client.Complete(&types.CompleteRequest{
+			Prompt:   "github__review",
+			Argument: "repo",
+			Value:    "mcp",
+			Context:  map[string]string{"owner": "octo"},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result.Values) != 2 || result.Values[0] != "mcpjungle" || result.Total != 3 || !result.HasMore {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"prompt github__review not found or is disabled"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "token", &http.Client{})
+		_, err := client.Complete(&types.CompleteRequest{Prompt: "github__review", Argument: "repo"})
+		if err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
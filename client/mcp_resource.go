@@ -37,6 +37,34 @@ func (c *Client) ListResources(server string) ([]*types.Resource, error) {
 	return resources, nil
 }
 
+// ListResourceTemplates fetches the list of resource templates, optionally filtered by server name.
+// If server is an empty string, this method fetches all resource templates.
+func (c *Client) ListResourceTemplates(server string) ([]*types.ResourceTemplate, error) {
+	u, _ := c.constructAPIEndpoint("/resource-templates")
+	req, _ := c.newRequest(http.MethodGet, u, nil)
+	if server != "" {
+		q := req.URL.Query()
+		q.Add("server", server)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", req.URL.String(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var templates []*types.ResourceTemplate
+	if err := json.NewDecoder(resp.Body).Decode(&templates); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return templates, nil
+}
+
 // GetResource retrieves resource metadata by URI.
 func (c *Client) GetResource(uri string) (*types.Resource, error) {
 	u, err := c.constructAPIEndpoint("/resources/get")
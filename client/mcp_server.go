@@ -14,14 +14,10 @@ import (
 func (c *Client) RegisterServer(server *types.RegisterServerInput, force bool) (*types.RegisterServerResult, error) {
 	u, _ := c.constructAPIEndpoint("/servers")
 	if force {
-		parsedURL, err := url.Parse(u)
-		if err != nil {
+		var err error
+		if u, err = withForceQueryParam(u); err != nil {
 			return nil, fmt.Errorf("failed to parse server registration endpoint: %w", err)
 		}
-		q := parsedURL.Query()
-		q.Set("force", "true")
-		parsedURL.RawQuery = q.Encode()
-		u = parsedURL.String()
 	}
 
 	body, err := json.Marshal(server)
@@ -52,6 +48,58 @@ func (c *Client) RegisterServer(server *types.RegisterServerInput, force bool) (
 	return &result, nil
 }
 
+// ValidateServer performs a dry-run registration of an MCP server.
+// The server is contacted to discover its capabilities, but it is not registered.
+// If force is true, an existing server with the same name is reported as being replaced.
+func (c *Client) ValidateServer(server *types.RegisterServerInput, force bool) (*types.ServerValidationReport, error) {
+	u, _ := c.constructAPIEndpoint("/servers/validate")
+	if force {
+		var err error
+		if u, err = withForceQueryParam(u); err != nil {
+			return nil, fmt.Errorf("failed to parse server validation endpoint: %w", err)
+		}
+	}
+
+	body, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize server data into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var report types.ServerValidationReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &report, nil
+}
+
+// withForceQueryParam adds the "force" query parameter to an API endpoint.
+func withForceQueryParam(u string) (string, error) {
+	parsedURL, err := url.Parse(u)
+	if err != nil {
+		return "", err
+	}
+	q := parsedURL.Query()
+	q.Set("force", "true")
+	parsedURL.RawQuery = q.Encode()
+	return parsedURL.String(), nil
+}
+
 // CompleteUpstreamOAuthSession finalizes a pending upstream OAuth registration
 // after the operator has approved the authorization request in the browser.
 func (c *Client) CompleteUpstreamOAuthSession(sessionID string, input *types.CompleteUpstreamOAuthSessionInput) (*types.RegisterServerResult, error) {
@@ -141,6 +189,75 @@ func (c *Client) GetServerConfigs() ([]*types.RegisterServerInput, error) {
 	return serverConfigs, nil
 }
 
+// UpdateServer updates the configuration of a registered MCP server in place.
+// The server is identified by the name in the configuration.
+func (c *Client) UpdateServer(server *types.RegisterServerInput) (*types.UpdateServerResult, error) {
+	u, _ := c.constructAPIEndpoint("/servers/" + server.Name)
+
+	body, err := json.Marshal(server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize server data into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var result types.UpdateServerResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// SetServerLogLevel sends API request to change the minimum level of the log messages that a server
+// sends to mcpjungle. An empty level unsets it.
+func (c *Client) SetServerLogLevel(name, level string) (*types.McpServer, error) {
+	u, err := c.constructAPIEndpoint("/servers/" + name + "/log-level")
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
+	}
+
+	body, err := json.Marshal(types.SetServerLogLevelInput{Level: level})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize log level into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var server types.McpServer
+	if err := json.NewDecoder(resp.Body).Decode(&server); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &server, nil
+}
+
 // DeregisterServer deletes a server by name.
 func (c *Client) DeregisterServer(name string) error {
 	u, _ := c.constructAPIEndpoint("/servers/" + name)
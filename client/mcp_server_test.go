@@ -382,6 +382,116 @@ func TestDeregisterServer(t *testing.T) {
 	})
 }
 
+func TestUpdateServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful update", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut || r.URL.Path != "/api/v0/servers/calc" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			var input types.RegisterServerInput
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.URL != "http://new" {
+				t.Errorf("unexpected input %+v (err: %v)", input, err)
+			}
+			_ = json.NewEncoder(w).Encode(types.UpdateServerResult{
+				Server:        &types.McpServer{Name: "calc", URL: "http://new"},
+				ConfigChanges: []string{"url"},
+				Tools:         types.ServerEntityChanges{Added: []string{"calc__mul"}},
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "test-token", &http.Client{})
+		result, err := client.UpdateServer(&types.RegisterServerInput{
+			Name: "calc", Transport: "streamable_http", URL: "http://new",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result.ConfigChanges) != 1 || result.Tools.Added[0] != "calc__mul" {
+			t.Errorf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"MCP server calc not found"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "test-token", &http.Client{})
+		if _, err := client.UpdateServer(&types.RegisterServerInput{Name: "calc"}); err == nil ||
+			!strings.Contains(err.Error(), "not found") {
+			t.Errorf("Expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestSetServerLogLevel(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v0/servers/calc/log-level" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var input types.SetServerLogLevelInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Errorf("unexpected input (err: %v)", err)
+		}
+		if input.Level == "verbose" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"unsupported log level: verbose"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.McpServer{Name: "calc", LogLevel: input.Level})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	result, err := client.SetServerLogLevel("calc", "warning")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.LogLevel != "warning" {
+		t.Errorf("Expected log level warning, got %q", result.LogLevel)
+	}
+
+	if _, err := client.SetServerLogLevel("calc", "verbose"); err == nil ||
+		!strings.Contains(err.Error(), "unsupported log level") {
+		t.Errorf("Expected unsupported log level error, got %v", err)
+	}
+}
+
+func TestValidateServer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v0/servers/validate" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("force") != "true" {
+			t.Errorf("expected force query parameter, got %q", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(types.ServerValidationReport{
+			Name:  "calc",
+			Valid: true,
+			Tools: []types.ValidatedEntity{{Name: "calc__sum"}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	report, err := client.ValidateServer(&types.RegisterServerInput{Name: "calc"}, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !report.Valid || len(report.Tools) != 1 || report.Tools[0].Name != "calc__sum" {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
 func TestGetServerConfigs(t *testing.T) {
 	t.Parallel()
 
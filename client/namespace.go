@@ -0,0 +1,90 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// CreateNamespace sends a request to create a new namespace in mcpjungle
+func (c *Client) CreateNamespace(ns *types.Namespace) (*types.Namespace, error) {
+	u, _ := c.constructGlobalAPIEndpoint("/namespaces")
+
+	body, err := json.Marshal(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(http.MethodPost, u, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var created types.Namespace
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &created, nil
+}
+
+// ListNamespaces sends a request to list all namespaces in mcpjungle
+func (c *Client) ListNamespaces() ([]types.Namespace, error) {
+	u, _ := c.constructGlobalAPIEndpoint("/namespaces")
+
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var namespaces []types.Namespace
+	if err := json.NewDecoder(resp.Body).Decode(&namespaces); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return namespaces, nil
+}
+
+// DeleteNamespace sends a request to delete an empty namespace from mcpjungle
+func (c *Client) DeleteNamespace(name string) error {
+	u, _ := c.constructGlobalAPIEndpoint("/namespaces/" + url.PathEscape(name))
+
+	req, err := c.newRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseErrorResponse(resp)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ListServerRevisions fetches the revision history of an MCP server, oldest first.
+func (c *Client) ListServerRevisions(name string) ([]types.Revision, error) {
+	return c.listRevisions("/servers/" + name)
+}
+
+// ListToolGroupRevisions fetches the revision history of a tool group, oldest first.
+func (c *Client) ListToolGroupRevisions(name string) ([]types.Revision, error) {
+	return c.listRevisions("/tool-groups/" + name)
+}
+
+// DiffServerRevisions compares two revisions of an MCP server.
+// A zero "to" selects the latest revision and a zero "from" the revision right before "to".
+func (c *Client) DiffServerRevisions(name string, from, to int) (*types.RevisionDiff, error) {
+	return c.diffRevisions("/servers/"+name, from, to)
+}
+
+// DiffToolGroupRevisions compares two revisions of a tool group.
+// A zero "to" selects the latest revision and a zero "from" the revision right before "to".
+func (c *Client) DiffToolGroupRevisions(name string, from, to int) (*types.RevisionDiff, error) {
+	return c.diffRevisions("/tool-groups/"+name, from, to)
+}
+
+// RollbackServer restores the configuration of an MCP server from an earlier revision.
+// If the restored server requires upstream OAuth authorization, the result contains the
+// authorization details and the server is registered once the authorization is completed.
+func (c *Client) RollbackServer(name string, input *types.RollbackInput) (*types.RollbackResult, error) {
+	return c.rollback("/servers/"+name, input)
+}
+
+// RollbackToolGroup restores the configuration of a tool group from an earlier revision.
+func (c *Client) RollbackToolGroup(name string, input *types.RollbackInput) (*types.RollbackResult, error) {
+	return c.rollback("/tool-groups/"+name, input)
+}
+
+func (c *Client) listRevisions(entityPath string) ([]types.Revision, error) {
+	u, _ := c.constructAPIEndpoint(entityPath + "/revisions")
+
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var revisions []types.Revision
+	if err := json.NewDecoder(resp.Body).Decode(&revisions); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return revisions, nil
+}
+
+func (c *Client) diffRevisions(entityPath string, from, to int) (*types.RevisionDiff, error) {
+	u, _ := c.constructAPIEndpoint(entityPath + "/revisions/diff")
+
+	params := url.Values{}
+	if from > 0 {
+		params.Add("from", strconv.Itoa(from))
+	}
+	if to > 0 {
+		params.Add("to", strconv.Itoa(to))
+	}
+	if len(params) > 0 {
+		u = u + "?" + params.Encode()
+	}
+
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var diff types.RevisionDiff
+	if err := json.NewDecoder(resp.Body).Decode(&diff); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &diff, nil
+}
+
+func (c *Client) rollback(entityPath string, input *types.RollbackInput) (*types.RollbackResult, error) {
+	u, _ := c.constructAPIEndpoint(entityPath + "/rollback")
+
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize rollback request into JSON: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var result types.RollbackResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestListToolGroupRevisions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v0/tool-groups/math/revisions" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode([]types.Revision{{Number: 1, Action: "created", Author: "alice"}})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", http.DefaultClient)
+	revisions, err := c.ListToolGroupRevisions("math")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Author != "alice" {
+		t.Errorf("unexpected revisions: %+v", revisions)
+	}
+}
+
+func TestDiffServerRevisions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/servers/calc/revisions/diff" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("from") != "1" || r.URL.Query().Has("to") {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(types.RevisionDiff{
+			Name: "calc", From: 1, To: 3,
+			Changes: []types.RevisionChange{{Field: "url", Old: "http://a", New: "http://b"}},
+		})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", http.DefaultClient)
+	diff, err := c.DiffServerRevisions("calc", 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.To != 3 || len(diff.Changes) != 1 || diff.Changes[0].Field != "url" {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+}
+
+func TestRollbackToolGroup(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v0/tool-groups/math/rollback" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var input types.RollbackInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Revision != 2 {
+			t.Errorf("unexpected rollback input %+v (err: %v)", input, err)
+		}
+		_ = json.NewEncoder(w).Encode(types.RollbackResult{Name: "math", RestoredRevision: 2, Revision: 5})
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", http.DefaultClient)
+	result, err := c.RollbackToolGroup("math", &types.RollbackInput{Revision: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Revision != 5 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRollbackServer_Error(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"revision not found"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "", http.DefaultClient)
+	if _, err := c.RollbackServer("calc", &types.RollbackInput{Revision: 7}); err == nil || err.Error() != "revision not found" {
+		t.Fatalf("expected API error, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var backupCmdOutputFile string
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up the entire mcpjungle registry into a file",
+	Long: "Create a portable backup archive of everything registered in mcpjungle:\n" +
+		"mcp servers, tools, prompts & resources (including their enabled state), tool groups,\n" +
+		"mcp clients, users, upstream OAuth tokens and the server config.\n\n" +
+		"The archive does not depend on the database backend, so it can be restored into\n" +
+		"an mcpjungle instance backed by either sqlite or postgres using 'mcpjungle restore'.\n" +
+		"This makes it the recommended way to migrate from sqlite to postgres.\n\n" +
+		"WARNING: The archive contains secrets like access tokens and upstream credentials. Store it securely.\n" +
+		"NOTE: In enterprise mode, you must be an admin to create a backup.",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "12",
+	},
+	RunE: runBackup,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [file]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Restore the mcpjungle registry from a backup file",
+	Long: "Restore a backup archive created by 'mcpjungle backup' into this mcpjungle instance.\n\n" +
+		"The target instance must be empty, ie, it must not have any mcp servers, tool groups or mcp clients.\n" +
+		"Upstream MCP servers are not contacted during the restore, their tools, prompts and resources\n" +
+		"are restored exactly as they were when the backup was taken.\n\n" +
+		"The server mode is decided when the target is started and initialized, so it is not changed by a restore.\n" +
+		"Users that already exist in the target (eg- the admin created by init-server) are kept.\n\n" +
+		"NOTE: In enterprise mode, you must be an admin to restore a backup.",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "13",
+	},
+	RunE: runRestore,
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(
+		&backupCmdOutputFile,
+		"output",
+		"o",
+		"",
+		"File to write the backup archive to (default \"mcpjungle-backup-<timestamp>.json\")",
+	)
+
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func runBackup(cmd *cobra.Command, args []string) error {
+	archive, err := apiClient.CreateBackup()
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	outFile := backupCmdOutputFile
+	if outFile == "" {
+		outFile = fmt.Sprintf("mcpjungle-backup-%s.json", archive.CreatedAt.UTC().Format("20060102-150405"))
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize backup archive: %w", err)
+	}
+	// the archive contains secrets, so keep it private to the current user
+	if err := os.WriteFile(outFile, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write backup file %s: %w", outFile, err)
+	}
+
+	cmd.Printf("Backup written to %s\n\n", outFile)
+	printBackupCounts(cmd, archive)
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	filePath := args[0]
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read backup file %s: %w", filePath, err)
+	}
+
+	var archive types.BackupArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return fmt.Errorf("failed to parse backup file: %w", err)
+	}
+
+	result, err := apiClient.RestoreBackup(&archive)
+	if err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	cmd.Printf("Backup from %s restored successfully!\n\n", archive.CreatedAt.Local().Format(time.RFC1123))
+	cmd.Printf("Namespaces: %d\n", result.Namespaces)
+	cmd.Printf("MCP servers: %d\n", result.McpServers)
+	cmd.Printf("Tools: %d\n", result.Tools)
+	cmd.Printf("Prompts: %d\n", result.Prompts)
+	cmd.Printf("Resources: %d\n", result.Resources)
+	cmd.Printf("Resource templates: %d\n", result.ResourceTemplates)
+	cmd.Printf("Tool groups: %d\n", result.ToolGroups)
+	cmd.Printf("MCP clients: %d\n", result.McpClients)
+	cmd.Printf("Users: %d\n", result.Users)
+	cmd.Printf("Upstream OAuth tokens: %d\n", result.UpstreamOAuthTokens)
+	cmd.Printf("Revisions: %d\n", result.Revisions)
+
+	if len(result.Warnings) > 0 {
+		cmd.Println()
+		cmd.Println("Warnings:")
+		for _, w := range result.Warnings {
+			cmd.Printf("  - %s\n", w)
+		}
+	}
+	return nil
+}
+
+// printBackupCounts prints the number of entities of each kind contained in a backup archive.
+func printBackupCounts(cmd *cobra.Command, archive *types.BackupArchive) {
+	cmd.Printf("Namespaces: %d\n", len(archive.Namespaces))
+	cmd.Printf("MCP servers: %d\n", len(archive.McpServers))
+	cmd.Printf("Tools: %d\n", len(archive.Tools))
+	cmd.Printf("Prompts: %d\n", len(archive.Prompts))
+	cmd.Printf("Resources: %d\n", len(archive.Resources))
+	cmd.Printf("Resource templates: %d\n", len(archive.ResourceTemplates))
+	cmd.Printf("Tool groups: %d\n", len(archive.ToolGroups))
+	cmd.Printf("MCP clients: %d\n", len(archive.McpClients))
+	cmd.Printf("Users: %d\n", len(archive.Users))
+	cmd.Printf("Upstream OAuth tokens: %d\n", len(archive.UpstreamOAuthTokens))
+	cmd.Printf("Revisions: %d\n", len(archive.Revisions))
+}
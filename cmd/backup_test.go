@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestBackupRestoreCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.TestCommandAnnotations(t, backupCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "12"},
+	})
+	testhelpers.TestCommandAnnotations(t, restoreCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "13"},
+	})
+	testhelpers.AssertNotNil(t, backupCmd.Flags().Lookup("output"))
+	testhelpers.AssertNotNil(t, restoreCmd.Args)
+}
+
+func TestRunBackupAndRestore(t *testing.T) {
+	var restored types.BackupArchive
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v0/backup":
+			_ = json.NewEncoder(w).Encode(types.BackupArchive{
+				Version:    types.BackupArchiveVersion,
+				McpServers: []types.BackupMcpServer{{Name: "calc", Transport: "streamable_http"}},
+				Tools:      []types.BackupTool{{Server: "calc", Name: "add", Enabled: true}},
+			})
+		case "/api/v0/restore":
+			_ = json.NewDecoder(r.Body).Decode(&restored)
+			_ = json.NewEncoder(w).Encode(types.RestoreResult{
+				McpServers: len(restored.McpServers),
+				Tools:      len(restored.Tools),
+				Warnings:   []string{"skipped user admin"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	origClient, origOut := apiClient, backupCmdOutputFile
+	defer func() {
+		apiClient, backupCmdOutputFile = origClient, origOut
+	}()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+	backupCmdOutputFile = filepath.Join(t.TempDir(), "backup.json")
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	testhelpers.AssertNoError(t, runBackup(cmd, nil))
+	testhelpers.AssertTrue(t, strings.Contains(out.String(), "Tools: 1"), "expected tool count in output")
+
+	info, err := os.Stat(backupCmdOutputFile)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, os.FileMode(0o600), info.Mode().Perm())
+
+	out.Reset()
+	testhelpers.AssertNoError(t, runRestore(cmd, []string{backupCmdOutputFile}))
+	testhelpers.AssertEqual(t, "add", restored.Tools[0].Name)
+	testhelpers.AssertTrue(t, strings.Contains(out.String(), "skipped user admin"), "expected warnings in output")
+}
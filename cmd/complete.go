@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+// resourceTemplateURIPrefix is the prefix of the URI templates of resource templates in mcpjungle.
+const resourceTemplateURIPrefix = "mcpj://tmpl/"
+
+var completeCmdContext map[string]string
+
+var completeCmd = &cobra.Command{
+	Use:   "complete <prompt name | resource template URI> <argument> [value]",
+	Short: "Get argument completions for a prompt or resource template",
+	Long: "Asks the MCP server that provides a prompt or resource template to suggest values for one of its arguments.\n" +
+		"Pass the name of a prompt, or the URI template of a resource template (mcpj://tmpl/...),\n" +
+		"the name of the argument and optionally the partial value typed so far.\n" +
+		"MCP servers that don't support completions don't suggest any values.",
+	Example: "  mcpjungle complete github__review_pr repo mcp --context owner=mcpjungle\n" +
+		"  mcpjungle complete 'mcpj://tmpl/github/repo://{owner}/{name}/readme' owner mcp",
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runComplete,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "17",
+	},
+}
+
+func init() {
+	completeCmd.Flags().StringToStringVar(
+		&completeCmdContext,
+		"context",
+		nil,
+		"Values of the arguments already resolved, in the form of 'key=value' (this flag can be specified multiple times)",
+	)
+	rootCmd.AddCommand(completeCmd)
+}
+
+func runComplete(cmd *cobra.Command, args []string) error {
+	request := &types.CompleteRequest{
+		Argument: args[1],
+		Context:  completeCmdContext,
+	}
+	if strings.HasPrefix(args[0], resourceTemplateURIPrefix) {
+		request.ResourceTemplate = args[0]
+	} else {
+		request.Prompt = args[0]
+	}
+	if len(args) == 3 {
+		request.Value = args[2]
+	}
+
+	result, err := apiClient.Complete(request)
+	if err != nil {
+		return fmt.Errorf("failed to get completions: %w", err)
+	}
+
+	if len(result.Values) == 0 {
+		cmd.Println("There are no completions")
+		return nil
+	}
+	for _, value := range result.Values {
+		cmd.Println(value)
+	}
+	if result.HasMore || result.Total > len(result.Values) {
+		if result.Total > 0 {
+			cmd.Printf("\n(showing %d of %d completions)\n", len(result.Values), result.Total)
+		} else {
+			cmd.Printf("\n(more completions are available)\n")
+		}
+	}
+	return nil
+}
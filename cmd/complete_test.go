@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "complete <prompt name | resource template URI> <argument> [value]", completeCmd.Use)
+	testhelpers.AssertTrue(t, len(completeCmd.Long) > 0, "Long description should not be empty")
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "17"},
+	}
+	testhelpers.TestCommandAnnotations(t, completeCmd.Annotations, annotationTests)
+
+	contextFlag := completeCmd.Flags().Lookup("context")
+	testhelpers.AssertNotNil(t, contextFlag)
+	testhelpers.AssertTrue(t, len(contextFlag.Usage) > 0, "Context flag should have usage description")
+}
+
+func TestRunComplete(t *testing.T) {
+	var seen []types.CompleteRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/complete" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		var req types.CompleteRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		seen = append(seen, req)
+		_ = json.NewEncoder(w).Encode(types.CompleteResult{Values: []string{"mcpjungle", "mcp-go"}, Total: 5})
+	}))
+	defer server.Close()
+
+	origClient := apiClient
+	origContext := completeCmdContext
+	defer func() {
+		apiClient = origClient
+		completeCmdContext = origContext
+	}()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cases := []struct {
+		name    string
+		args    []string
+		context map[string]string
+		want    types.CompleteRequest
+	}{
+		{
+			name:    "prompt argument",
+			args:    []string{"github__review_pr", "repo", "mcp"},
+			context: map[string]string{"owner": "mcpjungle"},
+			want: types.CompleteRequest{
+				Prompt: "github__review_pr", Argument: "repo", Value: "mcp", Context: map[string]string{"owner": "mcpjungle"},
+			},
+		},
+		{
+			name: "resource template variable without a value",
+			args: []string{"mcpj://tmpl/github/repo://{owner}/{name}/readme", "owner"},
+			want: types.CompleteRequest{ResourceTemplate: "mcpj://tmpl/github/repo://{owner}/{name}/readme", Argument: "owner"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			seen = nil
+			completeCmdContext = tc.context
+
+			cmd := &cobra.Command{}
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+
+			if err := runComplete(cmd, tc.args); err != nil {
+				t.Fatalf("runComplete returned error: %v", err)
+			}
+			if len(seen) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(seen))
+			}
+			got := seen[0]
+			if got.Prompt != tc.want.Prompt || got.ResourceTemplate != tc.want.ResourceTemplate ||
+				got.Argument != tc.want.Argument || got.Value != tc.want.Value ||
+				got.Context["owner"] != tc.want.Context["owner"] {
+				t.Fatalf("unexpected request: %+v", got)
+			}
+
+			output := out.String()
+			if !strings.Contains(output, "mcpjungle\nmcp-go\n") {
+				t.Fatalf("expected completions in output, got: %s", output)
+			}
+			if !strings.Contains(output, "showing 2 of 5 completions") {
+				t.Fatalf("expected total in output, got: %s", output)
+			}
+		})
+	}
+}
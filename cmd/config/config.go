@@ -17,6 +17,9 @@ type ClientConfig struct {
 	RegistryURL string `yaml:"registry_url"`
 	// AccessToken is the access token used for authentication with the MCPJungle server.
 	AccessToken string `yaml:"access_token"`
+	// Namespace is the namespace that CLI commands operate in by default.
+	// If empty, commands operate on the global (non-namespaced) entities.
+	Namespace string `yaml:"namespace,omitempty"`
 }
 
 // AbsPath returns the absolute path to the client configuration file.
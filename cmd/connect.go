@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/clientconfig"
+	"github.com/spf13/cobra"
+)
+
+const defaultConnectEntryName = "mcpjungle"
+
+var (
+	connectCmdGroup     string
+	connectCmdMcpClient string
+	connectCmdEntryName string
+	connectCmdWritePath string
+)
+
+var connectCmd = &cobra.Command{
+	Use:   "connect [client-kind]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Generate the config to connect an MCP client to mcpjungle",
+	Long: "Generate the config file fragment that points an MCP client at mcpjungle.\n" +
+		fmt.Sprintf(
+			"Supported client kinds: %s, %s, %s, %s, %s.\n\n",
+			clientconfig.ClientClaude, clientconfig.ClientCursor, clientconfig.ClientVSCode,
+			clientconfig.ClientCopilot, clientconfig.ClientGeneric,
+		) +
+		"By default, the client is connected to the global /mcp endpoint.\n" +
+		"Use --group to connect it to a tool group's endpoint instead.\n\n" +
+		"In enterprise mode, use --mcp-client to include the access token of an MCP client\n" +
+		"(created with 'mcpjungle create mcp-client') as the bearer token.\n\n" +
+		"The fragment is printed to stdout. Use --write to merge it into the client's config file in place,\n" +
+		"keeping all other servers and settings in the file intact.",
+	Example: "  mcpjungle connect cursor\n" +
+		"  mcpjungle connect copilot --group claude-tools --mcp-client copilot-laptop\n" +
+		"  mcpjungle connect cursor --mcp-client cursor --write .cursor/mcp.json",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "11",
+	},
+	RunE: runConnect,
+}
+
+func init() {
+	connectCmd.Flags().StringVar(
+		&connectCmdGroup,
+		"group",
+		"",
+		"Name of the tool group to connect to, instead of the global MCP endpoint",
+	)
+	connectCmd.Flags().StringVar(
+		&connectCmdMcpClient,
+		"mcp-client",
+		"",
+		"Name of the MCP client whose access token should be used (Enterprise mode)",
+	)
+	connectCmd.Flags().StringVar(
+		&connectCmdEntryName,
+		"name",
+		"",
+		"Name of the server entry in the client config (defaults to 'mcpjungle' or 'mcpjungle-<group>')",
+	)
+	connectCmd.Flags().StringVar(
+		&connectCmdWritePath,
+		"write",
+		"",
+		"Path to the client's config file to merge the generated entry into (created if it doesn't exist)",
+	)
+
+	rootCmd.AddCommand(connectCmd)
+}
+
+func runConnect(cmd *cobra.Command, args []string) error {
+	kind, err := clientconfig.ValidateClientKind(args[0])
+	if err != nil {
+		return err
+	}
+
+	conn := clientconfig.Connection{Name: connectCmdEntryName}
+	if conn.Name == "" {
+		conn.Name = defaultConnectEntryName
+		if connectCmdGroup != "" {
+			conn.Name = defaultConnectEntryName + "-" + connectCmdGroup
+		}
+	}
+
+	if connectCmdGroup != "" {
+		group, err := apiClient.GetToolGroup(connectCmdGroup)
+		if err != nil {
+			return fmt.Errorf("failed to get tool group %s: %w", connectCmdGroup, err)
+		}
+		if group.ToolGroupEndpoints == nil || group.StreamableHTTPEndpoint == "" {
+			return fmt.Errorf("mcpjungle did not return an endpoint for tool group %s", connectCmdGroup)
+		}
+		conn.URL = group.StreamableHTTPEndpoint
+	} else if ns := apiClient.Namespace(); ns != "" {
+		conn.URL = strings.TrimRight(apiClient.BaseURL(), "/") + "/v0/ns/" + url.PathEscape(ns) + "/mcp"
+	} else {
+		conn.URL = strings.TrimRight(apiClient.BaseURL(), "/") + "/mcp"
+	}
+
+	if connectCmdMcpClient != "" {
+		token, err := lookupMcpClientAccessToken(connectCmdMcpClient)
+		if err != nil {
+			return err
+		}
+		conn.AccessToken = token
+	}
+
+	if connectCmdWritePath == "" {
+		fragment, err := clientconfig.Fragment(kind, conn)
+		if err != nil {
+			return err
+		}
+		if f := clientconfig.DefaultConfigFile(kind); f != "" {
+			cmd.Printf("Add the following to your %s config (%s):\n\n", kind, f)
+		}
+		cmd.Print(string(fragment))
+		return nil
+	}
+
+	return writeConnectConfig(cmd, kind, conn)
+}
+
+// lookupMcpClientAccessToken fetches the access token of the given MCP client from mcpjungle.
+// Listing clients requires admin privileges in enterprise mode.
+func lookupMcpClientAccessToken(name string) (string, error) {
+	clients, err := apiClient.ListMcpClients()
+	if err != nil {
+		return "", fmt.Errorf("failed to list MCP clients: %w", err)
+	}
+	for _, c := range clients {
+		if c.Name != name {
+			continue
+		}
+		if c.AccessToken == "" {
+			return "", fmt.Errorf("access token of MCP client %s is not available", name)
+		}
+		return c.AccessToken, nil
+	}
+	return "", fmt.Errorf("MCP client %s not found", name)
+}
+
+// writeConnectConfig merges the mcpjungle entry into the client config file at connectCmdWritePath.
+// The file and its parent directory are created if they don't exist yet.
+func writeConnectConfig(cmd *cobra.Command, kind clientconfig.ClientKind, conn clientconfig.Connection) error {
+	path := connectCmdWritePath
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	updated, err := clientconfig.MergeIntoConfig(kind, existing, conn)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	// the file may contain an access token, so keep it private to the user
+	if err := os.WriteFile(path, updated, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", path, err)
+	}
+
+	cmd.Printf("Added server '%s' (%s) to %s\n", conn.Name, conn.URL, path)
+	return nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestConnectCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "connect [client-kind]", connectCmd.Use)
+	testhelpers.AssertNotNil(t, connectCmd.RunE)
+	testhelpers.AssertNotNil(t, connectCmd.Args)
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "11"},
+	}
+	testhelpers.TestCommandAnnotations(t, connectCmd.Annotations, annotationTests)
+
+	for _, name := range []string{"group", "mcp-client", "name", "write"} {
+		testhelpers.AssertNotNil(t, connectCmd.Flags().Lookup(name))
+	}
+}
+
+func newConnectTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v0/tool-groups/coding":
+			_ = json.NewEncoder(w).Encode(types.GetToolGroupResponse{
+				ToolGroup: &types.ToolGroup{Name: "coding"},
+				ToolGroupEndpoints: &types.ToolGroupEndpoints{
+					StreamableHTTPEndpoint: server.URL + "/v0/groups/coding/mcp",
+				},
+			})
+		case "/api/v0/clients":
+			_ = json.NewEncoder(w).Encode([]types.McpClient{
+				{Name: "cursor-laptop", AccessToken: "tok123"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	return server
+}
+
+func setConnectFlags(t *testing.T, group, mcpClient, writePath string) {
+	t.Helper()
+	origClient := apiClient
+	origGroup, origMcpClient, origName, origWrite := connectCmdGroup, connectCmdMcpClient, connectCmdEntryName, connectCmdWritePath
+	t.Cleanup(func() {
+		apiClient = origClient
+		connectCmdGroup, connectCmdMcpClient, connectCmdEntryName, connectCmdWritePath = origGroup, origMcpClient, origName, origWrite
+	})
+	connectCmdGroup = group
+	connectCmdMcpClient = mcpClient
+	connectCmdEntryName = ""
+	connectCmdWritePath = writePath
+}
+
+func TestRunConnect_PrintsFragment(t *testing.T) {
+	server := newConnectTestServer(t)
+	defer server.Close()
+
+	setConnectFlags(t, "coding", "cursor-laptop", "")
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	if err := runConnect(cmd, []string{"cursor"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{
+		".cursor/mcp.json",
+		`"mcpjungle-coding"`,
+		`"url": "` + server.URL + `/v0/groups/coding/mcp"`,
+		`"Authorization": "Bearer tok123"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunConnect_WritesConfigInPlace(t *testing.T) {
+	server := newConnectTestServer(t)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), ".vscode", "mcp.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"servers": {"other": {"type": "stdio", "command": "x"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	setConnectFlags(t, "", "", path)
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	if err := runConnect(cmd, []string{"copilot"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfg struct {
+		Servers map[string]map[string]any `json:"servers"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("written config is not valid JSON: %v", err)
+	}
+	testhelpers.AssertEqual(t, 2, len(cfg.Servers))
+	testhelpers.AssertEqual(t, server.URL+"/mcp", cfg.Servers["mcpjungle"]["url"])
+	testhelpers.AssertEqual(t, "x", cfg.Servers["other"]["command"])
+}
+
+func TestRunConnect_UnknownMcpClient(t *testing.T) {
+	server := newConnectTestServer(t)
+	defer server.Close()
+
+	setConnectFlags(t, "", "missing", "")
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	if err := runConnect(&cobra.Command{}, []string{"cursor"}); err == nil {
+		t.Fatal("expected an error for an unknown MCP client")
+	}
+}
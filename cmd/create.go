@@ -36,6 +36,9 @@ var createMcpClientCmd = &cobra.Command{
 		"You can also set a custom access token by using the --access-token flag.\n" +
 		"Use the --allow option to control which MCP servers the client can access:\n" +
 		"    --allow \"server1, server2, server3\" | --allow \"*\"\n" +
+		"Use the --groups option to bind the client to specific Tool Groups:\n" +
+		"    --groups \"group1, group2\"\n" +
+		"A client bound to groups can only connect to the MCP endpoints of those groups.\n" +
 		"It is mandatory to either specify the name or a config file.\n" +
 		"This command is only available in Enterprise mode.",
 	RunE: runCreateMcpClient,
@@ -76,14 +79,32 @@ var createToolGroupCmd = &cobra.Command{
 	RunE: runCreateToolGroup,
 }
 
+var createNamespaceCmd = &cobra.Command{
+	Use:   "namespace [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Create a namespace",
+	Long: "Create a new namespace in MCPJungle.\n" +
+		"A namespace is an isolated set of MCP servers, tool groups, MCP clients and users.\n" +
+		"Entities created in a namespace are only visible to the users & clients of that namespace.\n\n" +
+		"The MCP proxy of a namespace is accessible at the following streamable http endpoint:\n" +
+		"    /v0/ns/{namespace}/mcp\n\n" +
+		"Use the --namespace flag with other commands to operate in a namespace.\n" +
+		"This command can only be run by a global admin.",
+	RunE: runCreateNamespace,
+}
+
 var (
 	createMcpClientCmdAllowedServers string
+	createMcpClientCmdAllowedGroups  string
 	createMcpClientCmdDescription    string
 	createMcpClientCmdAccessToken    string
 	createMcpClientCmdConfigFilePath string
 
 	createUserCmdAccessToken    string
 	createUserCmdConfigFilePath string
+	createUserCmdAdmin          bool
+
+	createNamespaceCmdDescription string
 
 	createToolGroupConfigFilePath string
 )
@@ -96,6 +117,13 @@ func init() {
 		"Comma-separated list of MCP servers that this client is allowed to access.\n"+
 			"By default, the list is empty, meaning the client cannot access any MCP servers.",
 	)
+	createMcpClientCmd.Flags().StringVar(
+		&createMcpClientCmdAllowedGroups,
+		"groups",
+		"",
+		"Comma-separated list of Tool Groups that this client is bound to.\n"+
+			"If set, the client can only access the MCP endpoints of these groups and not the global MCP proxy.",
+	)
 	createMcpClientCmd.Flags().StringVar(
 		&createMcpClientCmdDescription,
 		"description",
@@ -134,6 +162,21 @@ func init() {
 			"All other flags will be ignored.",
 	)
 
+	createUserCmd.Flags().BoolVar(
+		&createUserCmdAdmin,
+		"admin",
+		false,
+		"Make the user an admin of the namespace it is created in (see --namespace).\n"+
+			"A namespace admin can manage all entities within the namespace.",
+	)
+
+	createNamespaceCmd.Flags().StringVar(
+		&createNamespaceCmdDescription,
+		"description",
+		"",
+		"Description of the namespace",
+	)
+
 	createToolGroupCmd.Flags().StringVarP(
 		&createToolGroupConfigFilePath,
 		"conf",
@@ -146,6 +189,7 @@ func init() {
 	createCmd.AddCommand(createMcpClientCmd)
 	createCmd.AddCommand(createUserCmd)
 	createCmd.AddCommand(createToolGroupCmd)
+	createCmd.AddCommand(createNamespaceCmd)
 
 	rootCmd.AddCommand(createCmd)
 }
@@ -158,9 +202,10 @@ func runCreateMcpClient(cmd *cobra.Command, args []string) error {
 		// no config file provided, use command line args
 		allowList := parseAllowList(createMcpClientCmdAllowedServers, cmd)
 		client = &types.McpClient{
-			Name:        args[0],
-			Description: createMcpClientCmdDescription,
-			AllowList:   allowList,
+			Name:          args[0],
+			Description:   createMcpClientCmdDescription,
+			AllowList:     allowList,
+			AllowedGroups: parseGroupList(createMcpClientCmdAllowedGroups),
 		}
 		if createMcpClientCmdAccessToken != "" {
 			client.AccessToken = createMcpClientCmdAccessToken
@@ -178,9 +223,10 @@ func runCreateMcpClient(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("config file must define a client name")
 		}
 		client = &types.McpClient{
-			Name:        config.Name,
-			Description: config.Description,
-			AllowList:   config.AllowMcpServers,
+			Name:          config.Name,
+			Description:   config.Description,
+			AllowList:     config.AllowMcpServers,
+			AllowedGroups: config.AllowedGroups,
 		}
 		accessToken, err := resolveAccessTokenFromConfig(config.AccessToken, config.AccessTokenRef)
 		if err != nil {
@@ -218,6 +264,9 @@ func runCreateMcpClient(cmd *cobra.Command, args []string) error {
 	} else {
 		cmd.Println("This client does not have access to any MCP servers.")
 	}
+	if len(client.AllowedGroups) > 0 {
+		cmd.Println("Groups accessible: " + strings.Join(client.AllowedGroups, ","))
+	}
 
 	if !client.IsCustomAccessToken {
 		// server generated the access token, display it to the user
@@ -258,6 +307,9 @@ func runCreateUser(cmd *cobra.Command, args []string) error {
 			AccessToken: accessToken,
 		}
 	}
+	if createUserCmdAdmin {
+		user.Role = string(types.UserRoleAdmin)
+	}
 	resp, err := apiClient.CreateUser(user)
 	if err != nil {
 		return err
@@ -275,6 +327,24 @@ func runCreateUser(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCreateNamespace(cmd *cobra.Command, args []string) error {
+	ns, err := apiClient.CreateNamespace(&types.Namespace{
+		Name:        args[0],
+		Description: createNamespaceCmdDescription,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create namespace: %w", err)
+	}
+
+	cmd.Printf("Namespace '%s' created successfully\n", ns.Name)
+	cmd.Println("Use the --namespace flag to create entities in this namespace, eg:")
+	cmd.Println()
+	cmd.Printf("    mcpjungle --namespace %s register -c ./server.json\n", ns.Name)
+	cmd.Println()
+
+	return nil
+}
+
 func runCreateToolGroup(cmd *cobra.Command, args []string) error {
 	group, err := readToolGroupConfig(createToolGroupConfigFilePath)
 	if err != nil {
@@ -366,6 +436,17 @@ func parseAllowList(input string, cmd *cobra.Command) []string {
 	return allowList
 }
 
+// parseGroupList parses a comma-separated string of tool group names into a slice.
+func parseGroupList(input string) []string {
+	groups := make([]string, 0)
+	for _, s := range strings.Split(input, ",") {
+		if trimmed := strings.TrimSpace(s); trimmed != "" {
+			groups = append(groups, trimmed)
+		}
+	}
+	return groups
+}
+
 // resolveAccessTokenFromConfig resolves the access token from the provided config.
 // Precedence:
 // 1. Direct access token string
@@ -27,7 +27,7 @@ func TestCreateCommandStructure(t *testing.T) {
 
 	// Test subcommands count
 	subcommands := createCmd.Commands()
-	testhelpers.AssertEqual(t, 3, len(subcommands))
+	testhelpers.AssertEqual(t, 4, len(subcommands))
 }
 
 func TestCreateMcpClientSubcommand(t *testing.T) {
@@ -48,6 +48,10 @@ func TestCreateMcpClientSubcommand(t *testing.T) {
 	testhelpers.AssertNotNil(t, allowFlag)
 	testhelpers.AssertTrue(t, len(allowFlag.Usage) > 0, "Allow flag should have usage description")
 
+	groupsFlag := createMcpClientCmd.Flags().Lookup("groups")
+	testhelpers.AssertNotNil(t, groupsFlag)
+	testhelpers.AssertTrue(t, len(groupsFlag.Usage) > 0, "Groups flag should have usage description")
+
 	descriptionFlag := createMcpClientCmd.Flags().Lookup("description")
 	testhelpers.AssertNotNil(t, descriptionFlag)
 	testhelpers.AssertTrue(t, len(descriptionFlag.Usage) > 0, "Description flag should have usage description")
@@ -76,6 +80,10 @@ func TestCreateUserSubcommand(t *testing.T) {
 	configFlag := createUserCmd.Flags().Lookup("conf")
 	testhelpers.AssertNotNil(t, configFlag)
 	testhelpers.AssertTrue(t, len(configFlag.Usage) > 0, "Config flag should have usage description")
+
+	adminFlag := createUserCmd.Flags().Lookup("admin")
+	testhelpers.AssertNotNil(t, adminFlag)
+	testhelpers.AssertEqual(t, "false", adminFlag.DefValue)
 }
 
 func TestCreateToolGroupSubcommand(t *testing.T) {
@@ -94,6 +102,21 @@ func TestCreateToolGroupSubcommand(t *testing.T) {
 	testhelpers.AssertTrue(t, len(confFlag.Usage) > 0, "Conf flag should have usage description")
 }
 
+func TestCreateNamespaceSubcommand(t *testing.T) {
+	// Test command properties
+	testhelpers.AssertEqual(t, "namespace [name]", createNamespaceCmd.Use)
+	testhelpers.AssertEqual(t, "Create a namespace", createNamespaceCmd.Short)
+	testhelpers.AssertTrue(t, len(createNamespaceCmd.Long) > 0, "Long description should not be empty")
+
+	// Test command functions
+	testhelpers.AssertNotNil(t, createNamespaceCmd.RunE)
+	testhelpers.AssertNotNil(t, createNamespaceCmd.Args)
+
+	// Test command flags
+	descFlag := createNamespaceCmd.Flags().Lookup("description")
+	testhelpers.AssertNotNil(t, descFlag)
+}
+
 func TestCreateCommandVariables(t *testing.T) {
 	// Test that command variables are properly initialized to empty values
 	testhelpers.AssertEqual(t, "", createMcpClientCmdAllowedServers)
@@ -150,7 +173,7 @@ func TestCreateCommandIntegration(t *testing.T) {
 
 	// Test all create subcommands are properly configured
 	subcommands := createCmd.Commands()
-	expectedSubcommands := []string{"mcp-client", "user", "group"}
+	expectedSubcommands := []string{"mcp-client", "user", "group", "namespace"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
@@ -321,6 +344,16 @@ func TestParseAllowListBasicCases(t *testing.T) {
 	}
 }
 
+func TestParseGroupList(t *testing.T) {
+	t.Parallel()
+
+	got := parseGroupList(" math, ,docs ")
+	testhelpers.AssertEqual(t, 2, len(got))
+	testhelpers.AssertEqual(t, "math", got[0])
+	testhelpers.AssertEqual(t, "docs", got[1])
+	testhelpers.AssertEqual(t, 0, len(parseGroupList("")))
+}
+
 func TestParseAllowListWildcardWarns(t *testing.T) {
 	t.Parallel()
 
@@ -44,10 +44,22 @@ var deleteToolGroupCmd = &cobra.Command{
 	RunE: runDeleteToolGroup,
 }
 
+var deleteNamespaceCmd = &cobra.Command{
+	Use:   "namespace [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Delete a namespace",
+	Long: "Delete a namespace from mcpjungle.\n" +
+		"Only empty namespaces can be deleted, so first remove all MCP servers, tool groups,\n" +
+		"MCP clients and users from the namespace.\n" +
+		"This command can only be run by a global admin.",
+	RunE: runDeleteNamespace,
+}
+
 func init() {
 	deleteCmd.AddCommand(deleteMcpClientCmd)
 	deleteCmd.AddCommand(deleteUserCmd)
 	deleteCmd.AddCommand(deleteToolGroupCmd)
+	deleteCmd.AddCommand(deleteNamespaceCmd)
 
 	rootCmd.AddCommand(deleteCmd)
 }
@@ -78,3 +90,12 @@ func runDeleteToolGroup(cmd *cobra.Command, args []string) error {
 	cmd.Printf("Tool group '%s' deleted successfully!\n", name)
 	return nil
 }
+
+func runDeleteNamespace(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := apiClient.DeleteNamespace(name); err != nil {
+		return fmt.Errorf("failed to delete the namespace: %w", err)
+	}
+	cmd.Printf("Namespace '%s' deleted successfully!\n", name)
+	return nil
+}
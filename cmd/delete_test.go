@@ -22,7 +22,7 @@ func TestDeleteCommandStructure(t *testing.T) {
 
 	// Test subcommands count
 	subcommands := deleteCmd.Commands()
-	testhelpers.AssertEqual(t, 3, len(subcommands))
+	testhelpers.AssertEqual(t, 4, len(subcommands))
 }
 
 func TestDeleteMcpClientSubcommand(t *testing.T) {
@@ -101,6 +101,18 @@ func TestDeleteToolGroupSubcommand(t *testing.T) {
 	}
 }
 
+func TestDeleteNamespaceSubcommand(t *testing.T) {
+	// Test command properties
+	testhelpers.AssertEqual(t, "namespace [name]", deleteNamespaceCmd.Use)
+	testhelpers.AssertEqual(t, "Delete a namespace", deleteNamespaceCmd.Short)
+	testhelpers.AssertNotNil(t, deleteNamespaceCmd.RunE)
+	testhelpers.AssertNotNil(t, deleteNamespaceCmd.Args)
+
+	// Test long description content
+	testhelpers.AssertTrue(t, testhelpers.Contains(deleteNamespaceCmd.Long, "Only empty namespaces can be deleted"),
+		"Expected long description to explain that only empty namespaces can be deleted")
+}
+
 // Integration tests for delete commands
 func TestDeleteCommandIntegration(t *testing.T) {
 	// Verify that deleteCmd is properly initialized
@@ -108,7 +120,7 @@ func TestDeleteCommandIntegration(t *testing.T) {
 
 	// Test all delete subcommands are properly configured
 	subcommands := deleteCmd.Commands()
-	expectedSubcommands := []string{"mcp-client", "user", "group"}
+	expectedSubcommands := []string{"mcp-client", "user", "group", "namespace"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
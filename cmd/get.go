@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -132,14 +133,56 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 	}
 	cmd.Println()
 
+	printGroupList(cmd, "Included Groups", group.IncludedGroups)
+	printGroupList(cmd, "Included Tool Selectors", selectorStrings(group.IncludedToolSelectors))
+	printGroupList(cmd, "Excluded Tool Selectors", selectorStrings(group.ExcludedToolSelectors))
+	printGroupList(cmd, "Included Prompts", group.IncludedPrompts)
+	printGroupList(cmd, "Excluded Prompts", group.ExcludedPrompts)
+	printGroupList(cmd, "Included Resources", group.IncludedResources)
+	printGroupList(cmd, "Excluded Resources", group.ExcludedResources)
+	printGroupList(cmd, "Tool Overrides", toolOverrideStrings(group.ToolOverrides))
+
 	cmd.Println(
-		"NOTE: If a tool in this group is disabled globally or has been deleted, " +
+		"NOTE: If a tool, prompt or resource in this group is disabled globally or has been deleted, " +
 			"then it will not be available via the group's MCP endpoint.",
 	)
 
 	return nil
 }
 
+// printGroupList prints one of the lists in a tool group's configuration.
+// Lists of included groups, tool selectors, prompts and resources are only printed if they are not empty,
+// since most groups only contain tools.
+func printGroupList(cmd *cobra.Command, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	cmd.Println(title + ":")
+	for i, item := range items {
+		cmd.Printf("%d. %s\n", i+1, item)
+	}
+	cmd.Println()
+}
+
+// selectorStrings returns the printable form of tool selectors.
+func selectorStrings(selectors []types.ToolSelector) []string {
+	out := make([]string, 0, len(selectors))
+	for _, sel := range selectors {
+		out = append(out, sel.String())
+	}
+	return out
+}
+
+// toolOverrideStrings returns the printable form of tool overrides, sorted by tool name.
+func toolOverrideStrings(overrides map[string]types.ToolOverride) []string {
+	out := make([]string, 0, len(overrides))
+	for tool, o := range overrides {
+		out = append(out, tool+": "+o.String())
+	}
+	sort.Strings(out)
+	return out
+}
+
 func runGetPrompt(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyCmdDiff bool
+	historyCmdFrom int
+	historyCmdTo   int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the revision history of an MCP server or tool group",
+	Long: "Every change to the configuration of an MCP server or a tool group is recorded as a numbered revision,\n" +
+		"along with its author and timestamp. Revisions are kept after the server or group is deleted.\n\n" +
+		"Use --diff to compare two revisions, and 'mcpjungle rollback' to restore an earlier revision.\n\n" +
+		"NOTE: In enterprise mode, you must be an admin to view the revision history.",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "15",
+	},
+}
+
+var historyServerCmd = &cobra.Command{
+	Use:   "server [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Show the revision history of an MCP server",
+	RunE:  runHistoryServer,
+}
+
+var historyGroupCmd = &cobra.Command{
+	Use:   "group [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Show the revision history of a tool group",
+	RunE:  runHistoryGroup,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{historyServerCmd, historyGroupCmd} {
+		c.Flags().BoolVar(
+			&historyCmdDiff,
+			"diff",
+			false,
+			"Show the changes between two revisions instead of listing all revisions (defaults to the latest change)",
+		)
+		c.Flags().IntVar(&historyCmdFrom, "from", 0, "Older revision to compare (implies --diff)")
+		c.Flags().IntVar(&historyCmdTo, "to", 0, "Newer revision to compare, defaults to the latest revision (implies --diff)")
+		historyCmd.AddCommand(c)
+	}
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistoryServer(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if historyCmdDiff || historyCmdFrom > 0 || historyCmdTo > 0 {
+		diff, err := apiClient.DiffServerRevisions(name, historyCmdFrom, historyCmdTo)
+		if err != nil {
+			return fmt.Errorf("failed to compare revisions of server %s: %w", name, err)
+		}
+		printRevisionDiff(cmd, diff)
+		return nil
+	}
+
+	revisions, err := apiClient.ListServerRevisions(name)
+	if err != nil {
+		return fmt.Errorf("failed to get revision history of server %s: %w", name, err)
+	}
+	return printRevisions(cmd, "server", name, revisions)
+}
+
+func runHistoryGroup(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if historyCmdDiff || historyCmdFrom > 0 || historyCmdTo > 0 {
+		diff, err := apiClient.DiffToolGroupRevisions(name, historyCmdFrom, historyCmdTo)
+		if err != nil {
+			return fmt.Errorf("failed to compare revisions of group %s: %w", name, err)
+		}
+		printRevisionDiff(cmd, diff)
+		return nil
+	}
+
+	revisions, err := apiClient.ListToolGroupRevisions(name)
+	if err != nil {
+		return fmt.Errorf("failed to get revision history of group %s: %w", name, err)
+	}
+	return printRevisions(cmd, "group", name, revisions)
+}
+
+func printRevisions(cmd *cobra.Command, kind, name string, revisions []types.Revision) error {
+	if len(revisions) == 0 {
+		cmd.Printf("No revisions recorded for %s %s.\n", kind, name)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "REVISION\tACTION\tAUTHOR\tDATE\tNOTE")
+	for _, r := range revisions {
+		author := r.Author
+		if author == "" {
+			author = "-"
+		}
+		_, _ = fmt.Fprintf(
+			w, "%d\t%s\t%s\t%s\t%s\n",
+			r.Number, r.Action, author, r.CreatedAt.Local().Format(time.RFC3339), r.Note,
+		)
+	}
+	return w.Flush()
+}
+
+func printRevisionDiff(cmd *cobra.Command, diff *types.RevisionDiff) {
+	cmd.Printf("Changes from revision %d to revision %d of %s:\n", diff.From, diff.To, diff.Name)
+	if len(diff.Changes) == 0 {
+		cmd.Println("  (no changes)")
+		return
+	}
+	for _, c := range diff.Changes {
+		switch {
+		case c.Old == nil:
+			cmd.Printf("  + %s: %s\n", c.Field, formatRevisionValue(c.New))
+		case c.New == nil:
+			cmd.Printf("  - %s: %s\n", c.Field, formatRevisionValue(c.Old))
+		default:
+			cmd.Printf("  ~ %s: %s -> %s\n", c.Field, formatRevisionValue(c.Old), formatRevisionValue(c.New))
+		}
+	}
+}
+
+func formatRevisionValue(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestHistoryCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.TestCommandAnnotations(t, historyCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "15"},
+	})
+	for _, c := range []*cobra.Command{historyServerCmd, historyGroupCmd} {
+		testhelpers.AssertNotNil(t, c.Flags().Lookup("diff"))
+		testhelpers.AssertNotNil(t, c.Flags().Lookup("from"))
+		testhelpers.AssertNotNil(t, c.Flags().Lookup("to"))
+	}
+	testhelpers.AssertEqual(t, 2, len(historyCmd.Commands()))
+}
+
+func TestRunHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v0/tool-groups/math/revisions":
+			_ = json.NewEncoder(w).Encode([]types.Revision{
+				{Number: 1, Action: "created", Author: "alice", CreatedAt: time.Now()},
+				{Number: 2, Action: "rolled_back", Note: "restored revision 1", CreatedAt: time.Now()},
+			})
+		case "/api/v0/servers/calc/revisions/diff":
+			if r.URL.Query().Get("from") != "1" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(types.RevisionDiff{
+				Name: "calc", From: 1, To: 2,
+				Changes: []types.RevisionChange{
+					{Field: "url", Old: "http://a", New: "http://b"},
+					{Field: "bearer_token", New: "secret"},
+					{Field: "description", Old: "calculator"},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	origClient, origDiff, origFrom, origTo := apiClient, historyCmdDiff, historyCmdFrom, historyCmdTo
+	defer func() {
+		apiClient, historyCmdDiff, historyCmdFrom, historyCmdTo = origClient, origDiff, origFrom, origTo
+	}()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	historyCmdDiff, historyCmdFrom, historyCmdTo = false, 0, 0
+	testhelpers.AssertNoError(t, runHistoryGroup(cmd, []string{"math"}))
+	testhelpers.AssertStringContains(t, out.String(), "REVISION")
+	testhelpers.AssertStringContains(t, out.String(), "alice")
+	testhelpers.AssertStringContains(t, out.String(), "restored revision 1")
+
+	out.Reset()
+	historyCmdFrom = 1
+	testhelpers.AssertNoError(t, runHistoryServer(cmd, []string{"calc"}))
+	testhelpers.AssertStringContains(t, out.String(), `~ url: "http://a" -> "http://b"`)
+	testhelpers.AssertStringContains(t, out.String(), `+ bearer_token: "secret"`)
+	testhelpers.AssertStringContains(t, out.String(), `- description: "calculator"`)
+
+	historyCmdFrom = 0
+	err := runHistoryServer(cmd, []string{"unknown"})
+	testhelpers.AssertTrue(t, err != nil && strings.Contains(err.Error(), "unknown"), "expected error for unknown server")
+}
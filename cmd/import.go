@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/clientconfig"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importCmdFrom   string
+	importCmdDryRun bool
+	importCmdForce  bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Import MCP servers from a Claude Desktop, Cursor or VS Code config file",
+	Long: "Import all MCP servers declared in another MCP client's configuration file into mcpjungle.\n" +
+		"Supported formats:\n" +
+		"  claude - claude_desktop_config.json (\"mcpServers\" object)\n" +
+		"  cursor - .cursor/mcp.json (\"mcpServers\" object)\n" +
+		"  vscode - .vscode/mcp.json (\"servers\" object), also accepted as copilot\n\n" +
+		"The servers found in the file are previewed first and then registered one by one.\n" +
+		"Server names that are not valid in mcpjungle are sanitized, eg- \"My Server\" becomes \"My-Server\".\n" +
+		"Placeholders of the form ${VAR} and ${env:VAR} are resolved from your environment.\n\n" +
+		"Use --dry-run to only preview the servers without registering them.",
+	Example: "  mcpjungle import --from claude ~/Library/Application\\ Support/Claude/claude_desktop_config.json\n" +
+		"  mcpjungle import --from vscode .vscode/mcp.json --dry-run",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "10",
+	},
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(
+		&importCmdFrom,
+		"from",
+		"",
+		fmt.Sprintf(
+			"Format of the config file (one of '%s', '%s', '%s', '%s')",
+			clientconfig.ClientClaude, clientconfig.ClientCursor, clientconfig.ClientVSCode, clientconfig.ClientCopilot,
+		),
+	)
+	_ = importCmd.MarkFlagRequired("from")
+
+	importCmd.Flags().BoolVar(
+		&importCmdDryRun,
+		"dry-run",
+		false,
+		"Only preview the servers that would be imported, without registering them",
+	)
+	importCmd.Flags().BoolVar(
+		&importCmdForce,
+		"force",
+		false,
+		"Replace existing servers with the same name",
+	)
+
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	kind, err := clientconfig.ValidateClientKind(importCmdFrom)
+	if err != nil {
+		return err
+	}
+
+	filePath := args[0]
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", filePath, err)
+	}
+
+	servers, err := clientconfig.ParseServers(kind, data)
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		cmd.Printf("No MCP servers found in %s\n", filePath)
+		return nil
+	}
+
+	cmd.Printf("Found %d MCP server(s) in %s:\n\n", len(servers), filePath)
+	importable := printImportPreview(cmd, servers)
+
+	if importCmdDryRun {
+		cmd.Printf("\nDry run: %d of %d server(s) can be imported, nothing was registered.\n", importable, len(servers))
+		return nil
+	}
+	if importable == 0 {
+		return fmt.Errorf("none of the servers in %s can be imported", filePath)
+	}
+
+	cmd.Println()
+	cmd.Println("Registering servers...")
+
+	succeeded, failed := 0, 0
+	for _, s := range servers {
+		if s.Err != nil {
+			continue
+		}
+		if err := importServer(s.Input); err != nil {
+			cmd.Printf("  [FAILED] %s: %v\n", s.Input.Name, err)
+			failed++
+			continue
+		}
+		cmd.Printf("  [OK]     %s\n", s.Input.Name)
+		succeeded++
+	}
+
+	skipped := len(servers) - importable
+	cmd.Printf("\nImported %d server(s), %d failed, %d skipped.\n", succeeded, failed, skipped)
+	if failed > 0 {
+		return fmt.Errorf("failed to import %d server(s)", failed)
+	}
+	return nil
+}
+
+// printImportPreview prints every server found in the client config along with the transport
+// details it will be registered with. It returns the number of servers that can be imported.
+func printImportPreview(cmd *cobra.Command, servers []clientconfig.ImportedServer) int {
+	importable := 0
+	for i, s := range servers {
+		if s.Err != nil {
+			cmd.Printf("%d. %s (skipped)\n", i+1, s.OriginalName)
+			cmd.Printf("   error: %v\n", s.Err)
+			continue
+		}
+		importable++
+
+		cmd.Printf("%d. %s (%s)\n", i+1, s.Input.Name, s.Input.Transport)
+		if types.McpServerTransport(s.Input.Transport) == types.TransportStdio {
+			cmd.Printf("   command: %s\n", strings.Join(append([]string{s.Input.Command}, s.Input.Args...), " "))
+			if len(s.Input.Env) > 0 {
+				cmd.Printf("   env: %d variable(s)\n", len(s.Input.Env))
+			}
+		} else {
+			cmd.Printf("   url: %s\n", s.Input.URL)
+			if len(s.Input.Headers) > 0 {
+				cmd.Printf("   headers: %d\n", len(s.Input.Headers))
+			}
+		}
+		for _, w := range s.Warnings {
+			cmd.Printf("   note: %s\n", w)
+		}
+	}
+	return importable
+}
+
+// importServer registers a single imported server.
+// Interactive upstream OAuth is not performed during bulk import, so such servers are
+// reported as failures with a hint to register them individually.
+func importServer(input *types.RegisterServerInput) error {
+	result, err := apiClient.RegisterServer(input, importCmdForce)
+	if err != nil {
+		if shouldRetryRegisterWithOAuthCallback(err, input) {
+			return fmt.Errorf("upstream server requires OAuth authorization, register it individually with 'mcpjungle register --conf'")
+		}
+		return err
+	}
+	if result.AuthorizationRequired != nil {
+		return fmt.Errorf("upstream OAuth authorization required. Open this URL to continue: %s", result.AuthorizationRequired.AuthorizationURL)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestImportCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "import [file]", importCmd.Use)
+	testhelpers.AssertNotNil(t, importCmd.RunE)
+	testhelpers.AssertNotNil(t, importCmd.Args)
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "10"},
+	}
+	testhelpers.TestCommandAnnotations(t, importCmd.Annotations, annotationTests)
+
+	for _, name := range []string{"from", "dry-run", "force"} {
+		testhelpers.AssertNotNil(t, importCmd.Flags().Lookup(name))
+	}
+}
+
+func writeImportFixture(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "claude_desktop_config.json")
+	content := `{
+		"mcpServers": {
+			"calculator": {"url": "http://127.0.0.1:8000/mcp"},
+			"filesystem": {"command": "npx", "args": ["-y", "@modelcontextprotocol/server-filesystem", "."]},
+			"broken": {}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+func setImportFlags(t *testing.T, from string, dryRun bool) {
+	t.Helper()
+	origClient := apiClient
+	origFrom, origDryRun, origForce := importCmdFrom, importCmdDryRun, importCmdForce
+	t.Cleanup(func() {
+		apiClient = origClient
+		importCmdFrom, importCmdDryRun, importCmdForce = origFrom, origDryRun, origForce
+	})
+	importCmdFrom = from
+	importCmdDryRun = dryRun
+	importCmdForce = false
+}
+
+func TestRunImport_DryRunDoesNotRegister(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request during dry run: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	setImportFlags(t, "claude", true)
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	if err := runImport(cmd, []string{writeImportFixture(t)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := out.String()
+	for _, want := range []string{
+		"Found 3 MCP server(s)",
+		"broken (skipped)",
+		"calculator (streamable_http)",
+		"filesystem (stdio)",
+		"command: npx -y @modelcontextprotocol/server-filesystem .",
+		"Dry run: 2 of 3 server(s) can be imported",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunImport_ReportsPerServerResults(t *testing.T) {
+	var registered []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v0/servers" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var input types.RegisterServerInput
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if input.Name == "filesystem" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"server already exists"}`))
+			return
+		}
+		registered = append(registered, input.Name)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(types.RegisterServerResult{
+			Server: &types.McpServer{Name: input.Name, Transport: input.Transport},
+		})
+	}))
+	defer server.Close()
+
+	setImportFlags(t, "cursor", false)
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	err := runImport(cmd, []string{writeImportFixture(t)})
+	if err == nil {
+		t.Fatal("expected an error because one server failed to register")
+	}
+
+	testhelpers.AssertEqual(t, 1, len(registered))
+	testhelpers.AssertEqual(t, "calculator", registered[0])
+
+	output := out.String()
+	for _, want := range []string{
+		"[OK]     calculator",
+		"[FAILED] filesystem: server already exists",
+		"Imported 1 server(s), 1 failed, 1 skipped.",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunImport_InvalidKind(t *testing.T) {
+	setImportFlags(t, "windsurf", false)
+	cmd := &cobra.Command{}
+	if err := runImport(cmd, []string{writeImportFixture(t)}); err == nil {
+		t.Fatal("expected an error for an unsupported client kind")
+	}
+}
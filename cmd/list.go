@@ -23,8 +23,9 @@ var (
 )
 
 var (
-	listPromptsCmdServerName   string
-	listResourcesCmdServerName string
+	listPromptsCmdServerName           string
+	listResourcesCmdServerName         string
+	listResourceTemplatesCmdServerName string
 )
 
 var listToolsCmd = &cobra.Command{
@@ -53,6 +54,14 @@ var listResourcesCmd = &cobra.Command{
 	RunE:  runListResources,
 }
 
+var listResourceTemplatesCmd = &cobra.Command{
+	Use:   "resource-templates",
+	Short: "List available resource templates",
+	Long: "List resource templates available either from a specific MCP server or across all MCP servers in mcpjungle.\n" +
+		"MCP clients read the resources described by a template by expanding its URI template.",
+	RunE: runListResourceTemplates,
+}
+
 var listServersCmd = &cobra.Command{
 	Use:   "servers",
 	Short: "List registered MCP servers",
@@ -80,6 +89,13 @@ var listGroupsCmd = &cobra.Command{
 	RunE:  runListGroups,
 }
 
+var listNamespacesCmd = &cobra.Command{
+	Use:   "namespaces",
+	Short: "List namespaces",
+	Long:  "List all namespaces in MCPJungle.\nThis command can only be run by a global admin.",
+	RunE:  runListNamespaces,
+}
+
 func init() {
 	listToolsCmd.Flags().StringVar(
 		&listToolsCmdServerName,
@@ -108,13 +124,22 @@ func init() {
 		"Filter resources by server name",
 	)
 
+	listResourceTemplatesCmd.Flags().StringVar(
+		&listResourceTemplatesCmdServerName,
+		"server",
+		"",
+		"Filter resource templates by server name",
+	)
+
 	listCmd.AddCommand(listToolsCmd)
 	listCmd.AddCommand(listPromptsCmd)
 	listCmd.AddCommand(listResourcesCmd)
+	listCmd.AddCommand(listResourceTemplatesCmd)
 	listCmd.AddCommand(listServersCmd)
 	listCmd.AddCommand(listMcpClientsCmd)
 	listCmd.AddCommand(listUsersCmd)
 	listCmd.AddCommand(listGroupsCmd)
+	listCmd.AddCommand(listNamespacesCmd)
 
 	rootCmd.AddCommand(listCmd)
 }
@@ -244,6 +269,25 @@ func runListServers(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		if len(s.Labels) > 0 {
+			fmt.Println("Labels: " + strings.Join(s.Labels, ", "))
+		}
+		if len(s.ToolOverrides) > 0 {
+			fmt.Println("Tool overrides: " + strings.Join(toolOverrideStrings(s.ToolOverrides), "; "))
+		}
+		if len(s.AllowedRoots) > 0 {
+			fmt.Println("Allowed roots: " + strings.Join(s.AllowedRoots, ", "))
+		}
+		if s.LogLevel != "" {
+			fmt.Println("Log level: " + s.LogLevel)
+		}
+		if info := s.ServerInfo; info != nil {
+			fmt.Printf("Upstream server: %s %s (MCP protocol version %s)\n", info.Name, info.Version, info.ProtocolVersion)
+			if info.Instructions != "" {
+				fmt.Println("Instructions: " + info.Instructions)
+			}
+		}
+
 		if i < len(servers)-1 {
 			fmt.Println()
 		}
@@ -274,6 +318,9 @@ func runListMcpClients(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Println("This client does not have access to any MCP servers.")
 		}
+		if len(c.AllowedGroups) > 0 {
+			fmt.Println("Allowed groups: " + strings.Join(c.AllowedGroups, ","))
+		}
 
 		if i < len(clients)-1 {
 			fmt.Println()
@@ -294,7 +341,9 @@ func runListUsers(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 	for i, u := range users {
-		if u.Role == string(types.UserRoleAdmin) {
+		if u.Role == string(types.UserRoleAdmin) && u.Namespace != "" {
+			cmd.Printf("%d. %s  [NAMESPACE ADMIN]\n", i+1, u.Username)
+		} else if u.Role == string(types.UserRoleAdmin) {
 			cmd.Printf("%d. %s  [ADMIN]\n", i+1, u.Username)
 		} else {
 			cmd.Printf("%d. %s\n", i+1, u.Username)
@@ -332,6 +381,30 @@ func runListGroups(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runListNamespaces(cmd *cobra.Command, args []string) error {
+	namespaces, err := apiClient.ListNamespaces()
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	if len(namespaces) == 0 {
+		cmd.Println("There are no namespaces in the registry")
+		return nil
+	}
+	for i, ns := range namespaces {
+		cmd.Printf("%d. %s\n", i+1, ns.Name)
+		if ns.Description != "" {
+			cmd.Println(ns.Description)
+		}
+
+		if i < len(namespaces)-1 {
+			cmd.Println()
+		}
+	}
+
+	return nil
+}
+
 func runListPrompts(cmd *cobra.Command, args []string) error {
 	prompts, err := apiClient.ListPrompts(listPromptsCmdServerName)
 	if err != nil {
@@ -380,3 +453,25 @@ func runListResources(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runListResourceTemplates(cmd *cobra.Command, args []string) error {
+	templates, err := apiClient.ListResourceTemplates(listResourceTemplatesCmdServerName)
+	if err != nil {
+		return fmt.Errorf("failed to list resource templates: %w", err)
+	}
+
+	if len(templates) == 0 {
+		cmd.Println("No resource templates found")
+		return nil
+	}
+	for i, rt := range templates {
+		cmd.Printf("%d. %s\n", i+1, rt.Name)
+		cmd.Printf("   URI template: %s\n", rt.URITemplate)
+		if rt.Description != "" {
+			cmd.Println("   Description: ", rt.Description)
+		}
+		cmd.Println()
+	}
+
+	return nil
+}
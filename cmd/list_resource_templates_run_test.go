@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestRunListResourceTemplates_PrintsNamesAndURITemplates(t *testing.T) {
+	var seenServer string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v0/resource-templates":
+			seenServer = r.URL.Query().Get("server")
+			_ = json.NewEncoder(w).Encode([]*types.ResourceTemplate{
+				{
+					Name:        "github__readme",
+					URITemplate: "mcpj://tmpl/github/repo://{owner}/{name}/readme",
+					Description: "README of a repository",
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	origClient := apiClient
+	origServer := listResourceTemplatesCmdServerName
+	defer func() {
+		apiClient = origClient
+		listResourceTemplatesCmdServerName = origServer
+	}()
+
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+	listResourceTemplatesCmdServerName = "github"
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+
+	if err := runListResourceTemplates(cmd, nil); err != nil {
+		t.Fatalf("runListResourceTemplates returned error: %v", err)
+	}
+
+	if seenServer != "github" {
+		t.Fatalf("expected the templates to be filtered by server, got server=%q", seenServer)
+	}
+	output := out.String()
+	if !strings.Contains(output, "1. github__readme") {
+		t.Fatalf("expected template name in output, got: %s", output)
+	}
+	if !strings.Contains(output, "URI template: mcpj://tmpl/github/repo://{owner}/{name}/readme") {
+		t.Fatalf("expected URI template in output, got: %s", output)
+	}
+	if !strings.Contains(output, "README of a repository") {
+		t.Fatalf("expected template description in output, got: %s", output)
+	}
+}
@@ -113,7 +113,7 @@ func TestListCommandIntegration(t *testing.T) {
 
 	// Test all list subcommands are properly configured
 	subcommands := listCmd.Commands()
-	expectedSubcommands := []string{"tools", "prompts", "resources", "servers", "mcp-clients", "users", "groups"}
+	expectedSubcommands := []string{"tools", "prompts", "resources", "resource-templates", "servers", "mcp-clients", "users", "groups", "namespaces"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
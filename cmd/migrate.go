@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateUpCmdTarget  int
+	migrateDownCmdSteps int
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the database schema of the mcpjungle server",
+	Long: "Inspect and apply versioned database migrations.\n" +
+		"These commands connect directly to the database used by 'mcpjungle start', configured via\n" +
+		fmt.Sprintf("the %s or postgres environment variables, or the sqlite database otherwise.\n\n", DBUrlEnvVar) +
+		"NOTE: 'mcpjungle start' automatically applies pending migrations, so you only need these commands\n" +
+		"to inspect the schema, migrate ahead of a deployment or roll back after a downgrade.\n" +
+		"Stop the mcpjungle server before rolling back migrations.",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "14",
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show applied and pending migrations",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateStatus,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations",
+	Args:  cobra.NoArgs,
+	RunE:  runMigrateUp,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the most recently applied migrations",
+	Long: "Roll back the most recently applied migrations, newest first.\n" +
+		"Some migrations, like the baseline that creates all tables, cannot be rolled back.",
+	Args: cobra.NoArgs,
+	RunE: runMigrateDown,
+}
+
+func init() {
+	migrateCmd.PersistentFlags().StringVar(
+		&startServerCmdSQLiteDBPath,
+		"sqlite-db-path",
+		"",
+		fmt.Sprintf(
+			"path to a custom SQLite database file to use, if not using postgres; defaults to ./mcpjungle.db (overrides env var %s)",
+			SQLiteDBPathEnvVar,
+		),
+	)
+	migrateUpCmd.Flags().IntVar(
+		&migrateUpCmdTarget,
+		"to",
+		0,
+		"Version to migrate up to (defaults to the latest version)",
+	)
+	migrateDownCmd.Flags().IntVar(
+		&migrateDownCmdSteps,
+		"steps",
+		1,
+		"Number of migrations to roll back",
+	)
+
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+
+	rootCmd.AddCommand(migrateCmd)
+}
+
+func runMigrateStatus(cmd *cobra.Command, args []string) error {
+	_ = godotenv.Load()
+
+	dbConn, err := connectToDB()
+	if err != nil {
+		return err
+	}
+	current, err := migrations.CurrentVersion(dbConn)
+	if err != nil {
+		return err
+	}
+	statuses, err := migrations.Status(dbConn)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Current schema version: %d (latest known version: %d)\n\n", current, migrations.LatestVersion())
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tREVERSIBLE")
+	for _, s := range statuses {
+		status := "pending"
+		if s.Applied {
+			status = "applied " + s.AppliedAt.Local().Format(time.RFC3339)
+		}
+		reversible := "no"
+		if s.Reversible {
+			reversible = "yes"
+		}
+		if s.Unknown {
+			status += " (unknown to this mcpjungle version)"
+			reversible = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Version, s.Name, status, reversible)
+	}
+	return w.Flush()
+}
+
+func runMigrateUp(cmd *cobra.Command, args []string) error {
+	_ = godotenv.Load()
+
+	dbConn, err := connectToDB()
+	if err != nil {
+		return err
+	}
+	applied, err := migrations.MigrateUp(dbConn, migrateUpCmdTarget)
+	for _, m := range applied {
+		cmd.Printf("Applied migration %d (%s)\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		cmd.Println("Database schema is already up to date.")
+	}
+	return nil
+}
+
+func runMigrateDown(cmd *cobra.Command, args []string) error {
+	_ = godotenv.Load()
+
+	dbConn, err := connectToDB()
+	if err != nil {
+		return err
+	}
+	reverted, err := migrations.MigrateDown(dbConn, migrateDownCmdSteps)
+	for _, m := range reverted {
+		cmd.Printf("Rolled back migration %d (%s)\n", m.Version, m.Name)
+	}
+	return err
+}
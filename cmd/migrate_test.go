@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/spf13/cobra"
+)
+
+func TestMigrateCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.TestCommandAnnotations(t, migrateCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "14"},
+	})
+
+	subcommands := map[string]bool{}
+	for _, c := range migrateCmd.Commands() {
+		subcommands[c.Name()] = true
+	}
+	for _, name := range []string{"status", "up", "down"} {
+		testhelpers.AssertTrue(t, subcommands[name], "missing migrate subcommand "+name)
+	}
+	testhelpers.AssertNotNil(t, migrateCmd.PersistentFlags().Lookup("sqlite-db-path"))
+	testhelpers.AssertNotNil(t, migrateUpCmd.Flags().Lookup("to"))
+	testhelpers.AssertNotNil(t, migrateDownCmd.Flags().Lookup("steps"))
+}
+
+func TestRunMigrate_UpStatusDown(t *testing.T) {
+	t.Setenv(DBUrlEnvVar, "")
+	t.Setenv(SQLiteDBPathEnvVar, "")
+
+	origPath, origTarget, origSteps := startServerCmdSQLiteDBPath, migrateUpCmdTarget, migrateDownCmdSteps
+	defer func() {
+		startServerCmdSQLiteDBPath, migrateUpCmdTarget, migrateDownCmdSteps = origPath, origTarget, origSteps
+	}()
+	startServerCmdSQLiteDBPath = filepath.Join(t.TempDir(), "migrate.db")
+	migrateUpCmdTarget = 0
+	migrateDownCmdSteps = 1
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	testhelpers.AssertNoError(t, runMigrateUp(cmd, nil))
+	testhelpers.AssertTrue(t, strings.Contains(out.String(), "Applied migration 1 (baseline)"), out.String())
+
+	out.Reset()
+	testhelpers.AssertNoError(t, runMigrateUp(cmd, nil))
+	testhelpers.AssertTrue(t, strings.Contains(out.String(), "already up to date"), out.String())
+
+	out.Reset()
+	testhelpers.AssertNoError(t, runMigrateDown(cmd, nil))
+	testhelpers.AssertTrue(t, strings.Contains(out.String(), "Rolled back migration"), out.String())
+
+	out.Reset()
+	testhelpers.AssertNoError(t, runMigrateStatus(cmd, nil))
+	testhelpers.AssertTrue(t, strings.Contains(out.String(), "pending"), out.String())
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/mcpjungle/mcpjungle/client"
@@ -29,6 +30,7 @@ var (
 
 	registerCmdServerConfigFilePath string
 	registerCmdForce                bool
+	registerCmdDryRun               bool
 )
 
 var registerMCPServerCmd = &cobra.Command{
@@ -92,6 +94,12 @@ func init() {
 		false,
 		"Forcefully register the server even if a server with the same name already exists. This will de-register the existing server, then register the new one.",
 	)
+	registerMCPServerCmd.Flags().BoolVar(
+		&registerCmdDryRun,
+		"dry-run",
+		false,
+		"Validate the server configuration and preview the tools, prompts and resources the server provides without registering it.",
+	)
 
 	registerMCPServerCmd.Flags().StringVarP(
 		&registerCmdServerConfigFilePath,
@@ -127,6 +135,10 @@ func runRegisterMCPServer(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if registerCmdDryRun {
+		return runRegisterDryRun(cmd, &input)
+	}
+
 	var callbackSrv *oauthCallbackServer
 	result, err := apiClient.RegisterServer(&input, registerCmdForce)
 	if err != nil && shouldRetryRegisterWithOAuthCallback(err, &input) {
@@ -150,33 +162,9 @@ func runRegisterMCPServer(cmd *cobra.Command, args []string) error {
 		if callbackSrv == nil {
 			return fmt.Errorf("upstream OAuth authorization required. Open this URL to continue: %s", result.AuthorizationRequired.AuthorizationURL)
 		}
-		cmd.Printf("OAuth authorization required. Opening browser for upstream server approval.\n")
-		openBrowser(result.AuthorizationRequired.AuthorizationURL)
-
-		timeout := time.Until(result.AuthorizationRequired.ExpiresAt)
-		if timeout <= 0 {
-			timeout = 5 * time.Minute
-		}
-		params, err := callbackSrv.Wait(timeout)
+		result, err = completeUpstreamOAuthAuthorization(cmd, callbackSrv, result.AuthorizationRequired)
 		if err != nil {
-			return fmt.Errorf("failed waiting for OAuth callback: %w", err)
-		}
-
-		code := params["code"]
-		state := params["state"]
-		if code == "" || state == "" {
-			return fmt.Errorf("OAuth callback did not include both code and state")
-		}
-
-		result, err = apiClient.CompleteUpstreamOAuthSession(
-			result.AuthorizationRequired.SessionID,
-			&types.CompleteUpstreamOAuthSessionInput{
-				Code:  code,
-				State: state,
-			},
-		)
-		if err != nil {
-			return fmt.Errorf("failed to complete upstream OAuth registration: %w", err)
+			return err
 		}
 	}
 
@@ -189,6 +177,117 @@ func runRegisterMCPServer(cmd *cobra.Command, args []string) error {
 	return printRegisteredServerSummary(cmd, s)
 }
 
+// runRegisterDryRun validates the server configuration and prints what registering it would result in.
+func runRegisterDryRun(cmd *cobra.Command, input *types.RegisterServerInput) error {
+	report, err := apiClient.ValidateServer(input, registerCmdForce)
+	if err != nil {
+		return fmt.Errorf("failed to validate server: %w", err)
+	}
+	printServerValidationReport(cmd, report)
+	if !report.Valid {
+		return fmt.Errorf("server %s cannot be registered with this configuration", report.Name)
+	}
+	return nil
+}
+
+func printServerValidationReport(cmd *cobra.Command, report *types.ServerValidationReport) {
+	cmd.Printf("Dry run: server %s was not registered.\n", report.Name)
+
+	if info := report.ServerInfo; info != nil {
+		cmd.Println()
+		cmd.Printf("Upstream server: %s %s (MCP protocol version %s)\n", info.Name, info.Version, info.ProtocolVersion)
+		if len(info.Capabilities) > 0 {
+			cmd.Printf("Capabilities: %s\n", strings.Join(info.Capabilities, ", "))
+		}
+		if info.Instructions != "" {
+			cmd.Printf("Instructions: %s\n", info.Instructions)
+		}
+	}
+
+	if len(report.Tools) > 0 {
+		cmd.Println()
+		cmd.Println("The following tools would be registered:")
+		for i, tool := range report.Tools {
+			cmd.Printf("%d. %s: %s\n", i+1, tool.Name, tool.Description)
+		}
+	}
+	if len(report.Prompts) > 0 {
+		cmd.Println()
+		cmd.Println("The following prompts would be registered:")
+		for i, prompt := range report.Prompts {
+			cmd.Printf("%d. %s: %s\n", i+1, prompt.Name, prompt.Description)
+		}
+	}
+	if len(report.Resources) > 0 {
+		cmd.Println()
+		cmd.Println("The following resources would be registered:")
+		for i, resource := range report.Resources {
+			cmd.Printf("%d. %s\n", i+1, resource.Name)
+			cmd.Printf("   URI: %s\n", resource.URI)
+		}
+	}
+	if len(report.ResourceTemplates) > 0 {
+		cmd.Println()
+		cmd.Println("The server also provides the following resource templates:")
+		for i, template := range report.ResourceTemplates {
+			cmd.Printf("%d. %s: %s\n", i+1, template.Name, template.URITemplate)
+		}
+	}
+
+	if len(report.Warnings) > 0 {
+		cmd.Println()
+		cmd.Println("Warnings:")
+		for _, w := range report.Warnings {
+			cmd.Printf("- %s\n", w)
+		}
+	}
+	if len(report.Errors) > 0 {
+		cmd.Println()
+		cmd.Println("Errors:")
+		for _, e := range report.Errors {
+			cmd.Printf("- %s\n", e)
+		}
+	}
+}
+
+// completeUpstreamOAuthAuthorization opens the upstream authorization URL in a browser, waits for
+// the OAuth callback on the local callback server and completes the pending registration.
+func completeUpstreamOAuthAuthorization(
+	cmd *cobra.Command,
+	callbackSrv *oauthCallbackServer,
+	auth *types.UpstreamOAuthAuthorizationRequired,
+) (*types.RegisterServerResult, error) {
+	cmd.Printf("OAuth authorization required. Opening browser for upstream server approval.\n")
+	openBrowser(auth.AuthorizationURL)
+
+	timeout := time.Until(auth.ExpiresAt)
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+	params, err := callbackSrv.Wait(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for OAuth callback: %w", err)
+	}
+
+	code := params["code"]
+	state := params["state"]
+	if code == "" || state == "" {
+		return nil, fmt.Errorf("OAuth callback did not include both code and state")
+	}
+
+	result, err := apiClient.CompleteUpstreamOAuthSession(
+		auth.SessionID,
+		&types.CompleteUpstreamOAuthSessionInput{
+			Code:  code,
+			State: state,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete upstream OAuth registration: %w", err)
+	}
+	return result, nil
+}
+
 func readMcpServerConfig(filePath string) (types.RegisterServerInput, error) {
 	var input types.RegisterServerInput
 
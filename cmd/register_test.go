@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
 )
 
 func TestRegisterCommandStructure(t *testing.T) {
@@ -94,6 +102,14 @@ func TestRegisterCommandFlags(t *testing.T) {
 		}
 	})
 
+	t.Run("register command has dry-run flag", func(t *testing.T) {
+		if dryRunFlag := registerMCPServerCmd.Flags().Lookup("dry-run"); dryRunFlag == nil {
+			t.Fatal("Register command missing 'dry-run' flag")
+		} else if dryRunFlag.Usage == "" {
+			t.Error("Dry-run flag should have usage description")
+		}
+	})
+
 	t.Run("register command has conf flag with short form", func(t *testing.T) {
 		// The StringVarP creates both "conf" and "c" flags
 		confFlag := registerMCPServerCmd.Flags().Lookup("conf")
@@ -330,3 +346,46 @@ func TestRunRegisterMCPServerFunctional(t *testing.T) {
 		}
 	})
 }
+
+func TestRunRegisterDryRun(t *testing.T) {
+	report := types.ServerValidationReport{
+		Name:  "calc",
+		Valid: true,
+		ServerInfo: &types.UpstreamServerInfo{
+			Name: "calculator", Version: "1.2.0", ProtocolVersion: "2025-06-18", Capabilities: []string{"tools", "logging"},
+		},
+		Tools:    []types.ValidatedEntity{{Name: "calc__sum", Description: "Add numbers"}},
+		Warnings: []string{"server calc is already registered and will be replaced"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v0/servers/validate" {
+			// registering the server during a dry run is a bug
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	}))
+	defer server.Close()
+
+	origClient := apiClient
+	defer func() { apiClient = origClient }()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	input := &types.RegisterServerInput{Name: "calc", Transport: "streamable_http", URL: "http://localhost:9000/mcp"}
+	testhelpers.AssertNoError(t, runRegisterDryRun(cmd, input))
+	testhelpers.AssertStringContains(t, out.String(), "Dry run: server calc was not registered")
+	testhelpers.AssertStringContains(t, out.String(), "Upstream server: calculator 1.2.0")
+	testhelpers.AssertStringContains(t, out.String(), "Capabilities: tools, logging")
+	testhelpers.AssertStringContains(t, out.String(), "1. calc__sum: Add numbers")
+	testhelpers.AssertStringContains(t, out.String(), "- server calc is already registered and will be replaced")
+
+	out.Reset()
+	report = types.ServerValidationReport{Name: "calc", Errors: []string{"failed to connect to MCP server calc"}}
+	err := runRegisterDryRun(cmd, input)
+	testhelpers.AssertTrue(t, err != nil && strings.Contains(err.Error(), "cannot be registered"), "expected an error for an invalid report")
+	testhelpers.AssertStringContains(t, out.String(), "Errors:\n- failed to connect to MCP server calc")
+}
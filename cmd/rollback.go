@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmdRevision int
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Restore an MCP server or tool group to an earlier revision",
+	Long: "Restore the configuration of an MCP server or tool group from an earlier revision.\n" +
+		"Use 'mcpjungle history' to find the revision to restore.\n\n" +
+		"The rollback itself is recorded as a new revision, so it can be undone as well.\n" +
+		"Deleted servers and groups can also be brought back by rolling back to a revision before the deletion.\n\n" +
+		"NOTE: In enterprise mode, you must be an admin to roll back.",
+	Annotations: map[string]string{
+		"group": string(subCommandGroupAdvanced),
+		"order": "16",
+	},
+}
+
+var rollbackServerCmd = &cobra.Command{
+	Use:   "server [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Restore an MCP server to an earlier revision",
+	Long: "Restore an MCP server to an earlier revision.\n" +
+		"The server is updated in place with the restored configuration, like 'update server'.\n" +
+		"If the restored configuration uses a different transport, or the server was deleted,\n" +
+		"it is registered again instead.",
+	RunE: runRollbackServer,
+}
+
+var rollbackGroupCmd = &cobra.Command{
+	Use:   "group [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Restore a tool group to an earlier revision",
+	Long: "Restore a tool group to an earlier revision.\n" +
+		"If the group exists, it is updated in place without any downtime for its MCP clients.",
+	RunE: runRollbackGroup,
+}
+
+func init() {
+	for _, c := range []*cobra.Command{rollbackServerCmd, rollbackGroupCmd} {
+		c.Flags().IntVar(&rollbackCmdRevision, "to", 0, "Revision to restore")
+		_ = c.MarkFlagRequired("to")
+		rollbackCmd.AddCommand(c)
+	}
+
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+func runRollbackServer(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	// the restored server may require upstream OAuth authorization again, in which case
+	// the OAuth redirect URI stored in the revision most likely points to a callback server
+	// of an earlier CLI invocation, so a fresh local callback server is provided instead.
+	callbackSrv, err := newOAuthCallbackServer()
+	if err != nil {
+		return fmt.Errorf("failed to start local OAuth callback server: %w", err)
+	}
+	defer callbackSrv.Close()
+
+	result, err := apiClient.RollbackServer(name, &types.RollbackInput{
+		Revision:         rollbackCmdRevision,
+		OAuthRedirectURI: callbackSrv.RedirectURI(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to roll back server %s: %w", name, err)
+	}
+
+	if result.AuthorizationRequired != nil {
+		if _, err := completeUpstreamOAuthAuthorization(cmd, callbackSrv, result.AuthorizationRequired); err != nil {
+			return fmt.Errorf("failed to roll back server %s: %w", name, err)
+		}
+	}
+
+	printRollbackResult(cmd, "server", result)
+	return nil
+}
+
+func runRollbackGroup(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	result, err := apiClient.RollbackToolGroup(name, &types.RollbackInput{Revision: rollbackCmdRevision})
+	if err != nil {
+		return fmt.Errorf("failed to roll back group %s: %w", name, err)
+	}
+	printRollbackResult(cmd, "group", result)
+	return nil
+}
+
+func printRollbackResult(cmd *cobra.Command, kind string, result *types.RollbackResult) {
+	cmd.Printf("Restored %s %s to revision %d.\n", kind, result.Name, result.RestoredRevision)
+	if result.Revision > 0 {
+		cmd.Printf("The rollback was recorded as revision %d.\n", result.Revision)
+	}
+}
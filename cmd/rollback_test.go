@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestRollbackCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.TestCommandAnnotations(t, rollbackCmd.Annotations, []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupAdvanced)},
+		{Key: "order", Expected: "16"},
+	})
+	for _, c := range []*cobra.Command{rollbackServerCmd, rollbackGroupCmd} {
+		f := c.Flags().Lookup("to")
+		testhelpers.AssertNotNil(t, f)
+		testhelpers.AssertEqual(t, "true", strings.Join(f.Annotations[cobra.BashCompOneRequiredFlag], ""))
+	}
+}
+
+func TestRunRollback(t *testing.T) {
+	var serverInput, groupInput types.RollbackInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v0/servers/calc/rollback":
+			_ = json.NewDecoder(r.Body).Decode(&serverInput)
+			_ = json.NewEncoder(w).Encode(types.RollbackResult{Name: "calc", RestoredRevision: 1, Revision: 4})
+		case "/api/v0/tool-groups/math/rollback":
+			_ = json.NewDecoder(r.Body).Decode(&groupInput)
+			_ = json.NewEncoder(w).Encode(types.RollbackResult{Name: "math", RestoredRevision: 2, Revision: 3})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	origClient, origRevision := apiClient, rollbackCmdRevision
+	defer func() {
+		apiClient, rollbackCmdRevision = origClient, origRevision
+	}()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	rollbackCmdRevision = 1
+	testhelpers.AssertNoError(t, runRollbackServer(cmd, []string{"calc"}))
+	testhelpers.AssertEqual(t, 1, serverInput.Revision)
+	testhelpers.AssertTrue(
+		t,
+		strings.HasPrefix(serverInput.OAuthRedirectURI, "http://127.0.0.1:"),
+		"expected local OAuth callback URI to be sent",
+	)
+	testhelpers.AssertStringContains(t, out.String(), "Restored server calc to revision 1.")
+	testhelpers.AssertStringContains(t, out.String(), "recorded as revision 4")
+
+	out.Reset()
+	rollbackCmdRevision = 2
+	testhelpers.AssertNoError(t, runRollbackGroup(cmd, []string{"math"}))
+	testhelpers.AssertEqual(t, 2, groupInput.Revision)
+	testhelpers.AssertEqual(t, "", groupInput.OAuthRedirectURI)
+	testhelpers.AssertStringContains(t, out.String(), "Restored group math to revision 2.")
+}
@@ -61,6 +61,8 @@ var ErrSilent = errors.New("SilentErr")
 
 var registryServerURL string
 
+var namespaceName string
+
 // apiClient is the global API client used by command handlers to interact with the MCPJungle registry server.
 // It is not the best choice to rely on a global variable, but cobra doesn't seem to provide any neat way to
 // pass an object down the command tree.
@@ -107,6 +109,12 @@ func Execute() error {
 		"http://127.0.0.1:"+BindPortDefault,
 		"Base URL of the MCPJungle registry server",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&namespaceName,
+		"namespace",
+		"",
+		"Namespace to operate in. If not set, the namespace from the client config file is used (if any).",
+	)
 
 	// Initialize the API client with the registry server URL & client configuration (if any)
 	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
@@ -137,6 +145,13 @@ func Execute() error {
 		}
 
 		apiClient = client.NewClient(u, cfg.AccessToken, http.DefaultClient)
+
+		// precedence for namespace: command line flag > config file
+		if cmd.Flags().Changed("namespace") {
+			apiClient.SetNamespace(namespaceName)
+		} else {
+			apiClient.SetNamespace(cfg.Namespace)
+		}
 	}
 
 	return rootCmd.Execute()
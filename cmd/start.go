@@ -21,15 +21,20 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/migrations"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/backup"
 	"github.com/mcpjungle/mcpjungle/internal/service/config"
 	"github.com/mcpjungle/mcpjungle/internal/service/dashboard"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
+	"github.com/mcpjungle/mcpjungle/internal/service/replica"
+	"github.com/mcpjungle/mcpjungle/internal/service/revision"
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/version"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 )
 
 const (
@@ -63,6 +68,14 @@ const (
 
 	// SessionIdleTimeoutSecondsDefault is the default idle timeout in seconds for stateful sessions.
 	SessionIdleTimeoutSecondsDefault = -1
+
+	// RegistrySyncIntervalSecEnvVar is the environment variable for configuring the interval at which
+	// the database is polled for registry changes made by other mcpjungle replicas.
+	RegistrySyncIntervalSecEnvVar = "REGISTRY_SYNC_INTERVAL_SEC"
+
+	// ComposeServerInstructionsEnvVar is the environment variable for enabling the composition of
+	// the instructions of upstream MCP servers into the instructions of the proxy servers.
+	ComposeServerInstructionsEnvVar = "COMPOSE_SERVER_INSTRUCTIONS"
 )
 
 var (
@@ -89,7 +102,11 @@ var startServerCmd = &cobra.Command{
 		"This is useful when you register a MCP server (usually stdio, like filesystem) that may take some time to start up.\n\n" +
 		"Finally, you can also configure the idle timeout (in seconds) for stateful sessions.\n" +
 		"Set the SESSION_IDLE_TIMEOUT_SEC environment variable to an integer (default is -1, meaning no timeout).\n" +
-		"This is useful to automatically clean up idle sessions after a certain period of inactivity.",
+		"This is useful to automatically clean up idle sessions after a certain period of inactivity.\n\n" +
+		"Multiple mcpjungle instances can run against the same Postgres database, eg- behind a load balancer.\n" +
+		"Changes made through one instance are propagated to the others using Postgres LISTEN/NOTIFY.\n" +
+		"The database is also polled for changes, set the REGISTRY_SYNC_INTERVAL_SEC environment variable\n" +
+		"to an integer to configure the polling interval (default is 5).",
 	RunE: runStartServer,
 	Annotations: map[string]string{
 		"group": string(subCommandGroupBasic),
@@ -133,30 +150,29 @@ func init() {
 	rootCmd.AddCommand(startServerCmd)
 }
 
-func newProxyServers() (*server.MCPServer, *server.MCPServer) {
+// newProxyServer creates the gateway's MCP proxy server.
+// The same proxy server is served over both the streamable HTTP (/mcp) and SSE (/sse) transports,
+// regardless of the transports of the upstream MCP servers.
+func newProxyServer(completions *mcp.ProxyCompletionProvider) *server.MCPServer {
 	// Tie the advertised proxy version to the mcpjungle server version (from
-	// pkg/version) so the proxies always report the same version as the host
+	// pkg/version) so the proxy always reports the same version as the host
 	// process, instead of a hardcoded string.
 	proxyVersion := version.GetVersion()
 
-	mcpProxyServer := server.NewMCPServer(
+	return server.NewMCPServer(
 		"MCPJungle Proxy MCP Server",
 		proxyVersion,
-		server.WithResourceCapabilities(false, false),
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
-		server.WithToolFilter(mcp.ProxyToolFilter),
-	)
-	sseMcpProxyServer := server.NewMCPServer(
-		"MCPJungle Proxy MCP Server for SSE transport",
-		proxyVersion,
-		server.WithResourceCapabilities(false, false),
+		server.WithResourceCapabilities(true, false),
 		server.WithToolCapabilities(true),
 		server.WithPromptCapabilities(true),
 		server.WithToolFilter(mcp.ProxyToolFilter),
+		server.WithPromptFilter(mcp.ProxyPromptFilter),
+		server.WithHooks(mcp.ProxyResourceFilterHooks()),
+		server.WithCompletions(),
+		server.WithLogging(),
+		server.WithPromptCompletionProvider(completions),
+		server.WithResourceCompletionProvider(completions),
 	)
-
-	return mcpProxyServer, sseMcpProxyServer
 }
 
 // getDesiredServerMode returns the desired server mode for mcpjungle server.
@@ -247,6 +263,26 @@ func getSQLiteDBPathOverride() string {
 	return strings.TrimSpace(os.Getenv(SQLiteDBPathEnvVar))
 }
 
+// connectToDB connects to the database configured via the environment.
+// DATABASE_URL takes precedence, followed by the postgres-specific env vars.
+// If neither is set, the sqlite database is used.
+func connectToDB() (*gorm.DB, error) {
+	dsn := os.Getenv(DBUrlEnvVar)
+
+	if dsn == "" {
+		// If DATABASE_URL isn't set, try to construct a Postgres DSN if postgres-specific env vars are set.
+		pgDSN, ok, err := getPostgresDSN()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get postgres DSN: %w", err)
+		}
+		if ok {
+			dsn = pgDSN
+		}
+	}
+
+	return db.NewDBConnection(dsn, getSQLiteDBPathOverride())
+}
+
 // getEnvOrFile returns the value of the given environment variable.
 // If the environment variable is not set, it checks for a corresponding
 // _FILE environment variable and reads the value from the file if it exists.
@@ -336,6 +372,25 @@ func getMcpServerInitReqTimeout() (int, error) {
 	return timeout, nil
 }
 
+// getComposeServerInstructions returns whether the instructions of upstream MCP servers are composed
+// into the instructions that the proxy servers return to MCP clients. It is disabled by default.
+func getComposeServerInstructions() (bool, error) {
+	value := strings.ToLower(strings.TrimSpace(os.Getenv(ComposeServerInstructionsEnvVar)))
+	switch value {
+	case "":
+		return false, nil
+	case "true", "1":
+		return true, nil
+	case "false", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf(
+			"invalid value for %s environment variable: '%s', valid values are 'true' or 'false'",
+			ComposeServerInstructionsEnvVar, value,
+		)
+	}
+}
+
 // getSessionIdleTimeout returns the idle timeout (in seconds) for stateful sessions.
 func getSessionIdleTimeout() (int, error) {
 	timeoutStr := strings.TrimSpace(os.Getenv(SessionIdleTimeoutSecEnvVar))
@@ -352,6 +407,21 @@ func getSessionIdleTimeout() (int, error) {
 	return timeout, nil
 }
 
+// getRegistrySyncInterval returns the interval at which the database is polled for changes made by other replicas.
+func getRegistrySyncInterval() (time.Duration, error) {
+	intervalStr := strings.TrimSpace(os.Getenv(RegistrySyncIntervalSecEnvVar))
+	if intervalStr == "" {
+		return replica.DefaultPollInterval, nil
+	}
+	interval, err := strconv.Atoi(intervalStr)
+	if err != nil || interval < 1 {
+		return 0, fmt.Errorf(
+			"invalid value for %s: '%s', must be a positive integer", RegistrySyncIntervalSecEnvVar, intervalStr,
+		)
+	}
+	return time.Duration(interval) * time.Second, nil
+}
+
 func runStartServer(cmd *cobra.Command, args []string) error {
 	_ = godotenv.Load()
 
@@ -396,33 +466,33 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 	}
 
 	// connect to the DB and run migrations
-	dsn := os.Getenv(DBUrlEnvVar)
-
-	if dsn == "" {
-		// If DATABASE_URL isn't set, try to construct a Postgres DSN if postgres-specific env vars are set.
-		pgDSN, ok, err := getPostgresDSN()
-		if err != nil {
-			return fmt.Errorf("failed to get postgres DSN: %w", err)
-		}
-		if ok {
-			dsn = pgDSN
-		}
-	}
-
-	dbConn, err := db.NewDBConnection(dsn, getSQLiteDBPathOverride())
+	dbConn, err := connectToDB()
 	if err != nil {
 		return err
 	}
-	// Migrations should ideally be decoupled from both the server and the startup phase
-	// (should be run as a separate command).
-	// However, for the user's convenience, we run them as part of startup command for now.
+	// Migrations can also be applied separately with "mcpjungle migrate up".
+	// For the user's convenience, pending migrations are applied as part of startup as well.
+	// This refuses to start against a database that was migrated by a newer version of mcpjungle.
 	if err := migrations.Migrate(dbConn); err != nil {
 		return fmt.Errorf("failed to run migrations: %v", err)
 	}
 
 	bindPort := getBindPort()
 
-	mcpProxyServer, sseMcpProxyServer := newProxyServers()
+	// The syncer keeps this instance in sync with other instances sharing the same database.
+	// It is created before the registry is loaded, so that no change made by another instance in the meantime is missed.
+	syncInterval, err := getRegistrySyncInterval()
+	if err != nil {
+		return err
+	}
+	syncer, err := replica.NewSyncer(dbConn, syncInterval)
+	if err != nil {
+		return fmt.Errorf("failed to create registry syncer: %v", err)
+	}
+
+	// the completion provider of the proxy server is bound to the MCP service once it is created below
+	proxyCompletions := mcp.NewProxyCompletionProvider()
+	mcpProxyServer := newProxyServer(proxyCompletions)
 
 	timeout, err := getMcpServerInitReqTimeout()
 	if err != nil {
@@ -447,42 +517,63 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 		InitReqTimeoutSec: timeout,
 	})
 
+	composeServerInstructions, err := getComposeServerInstructions()
+	if err != nil {
+		return err
+	}
+	if composeServerInstructions {
+		log.Printf("[server] instructions of MCP servers will be composed into the proxy server instructions\n")
+	}
+
 	mcpServiceConfig := &mcp.ServiceConfig{
-		DB:                      dbConn,
-		McpProxyServer:          mcpProxyServer,
-		SseMcpProxyServer:       sseMcpProxyServer,
-		Metrics:                 mcpMetrics,
-		McpServerInitReqTimeout: timeout,
-		SessionManager:          sessionManager,
+		DB:                        dbConn,
+		McpProxyServer:            mcpProxyServer,
+		Metrics:                   mcpMetrics,
+		McpServerInitReqTimeout:   timeout,
+		SessionManager:            sessionManager,
+		ComposeServerInstructions: composeServerInstructions,
 	}
 	mcpService, err := mcp.NewMCPService(mcpServiceConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create MCP service: %v", err)
 	}
+	mcpService.AttachProxyServer(mcpProxyServer)
+	mcpService.ServeServerInstructions(mcpProxyServer, nil)
+	proxyCompletions.Bind(mcpService)
 
 	mcpClientService := mcpclient.NewMCPClientService(dbConn)
 
 	configService := config.NewServerConfigService(dbConn)
 	userService := user.NewUserService(dbConn)
 	dashboardService := dashboard.NewService(dbConn, otelProviders.IsEnabled())
+	backupService := backup.NewBackupService(dbConn)
+	revisionService := revision.NewRevisionService(dbConn)
+	namespaceService := namespace.NewNamespaceService(dbConn)
 
 	toolGroupService, err := toolgroup.NewToolGroupService(dbConn, mcpService)
 	if err != nil {
 		return fmt.Errorf("failed to create Tool Group service: %v", err)
 	}
 
+	syncer.Register(mcpService, toolGroupService)
+	syncCtx, stopSync := context.WithCancel(context.Background())
+	defer stopSync()
+	go syncer.Run(syncCtx)
+
 	// create the API server
 	opts := &api.ServerOptions{
-		MCPProxyServer:    mcpProxyServer,
-		SseMcpProxyServer: sseMcpProxyServer,
-		MCPService:        mcpService,
-		MCPClientService:  mcpClientService,
-		ConfigService:     configService,
-		UserService:       userService,
-		ToolGroupService:  toolGroupService,
-		DashboardService:  dashboardService,
-		OtelProviders:     otelProviders,
-		Metrics:           mcpMetrics,
+		MCPProxyServer:   mcpProxyServer,
+		MCPService:       mcpService,
+		MCPClientService: mcpClientService,
+		ConfigService:    configService,
+		UserService:      userService,
+		ToolGroupService: toolGroupService,
+		DashboardService: dashboardService,
+		BackupService:    backupService,
+		RevisionService:  revisionService,
+		NamespaceService: namespaceService,
+		OtelProviders:    otelProviders,
+		Metrics:          mcpMetrics,
 	}
 	s, err := api.NewServer(opts)
 	if err != nil {
@@ -561,6 +652,9 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 	sig := <-quit
 	log.Printf("[server] Received signal %v, initiating graceful shutdown...\n", sig)
 
+	// Stop applying changes made by other instances before shutting down the services
+	stopSync()
+
 	// Gracefully shutdown the MCP service (closes all stateful sessions)
 	mcpService.Shutdown()
 
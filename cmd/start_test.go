@@ -4,7 +4,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/replica"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/version"
 )
@@ -76,8 +79,8 @@ func TestStartCommandFlags(t *testing.T) {
 	})
 }
 
-func TestNewProxyServers_AdvertiseCurrentVersion(t *testing.T) {
-	mcpProxyServer, sseMcpProxyServer := newProxyServers()
+func TestNewProxyServer_AdvertisesCurrentVersion(t *testing.T) {
+	mcpProxyServer := newProxyServer(mcp.NewProxyCompletionProvider())
 
 	testhelpers.AssertMCPServerInfo(
 		t,
@@ -85,12 +88,6 @@ func TestNewProxyServers_AdvertiseCurrentVersion(t *testing.T) {
 		"MCPJungle Proxy MCP Server",
 		version.GetVersion(),
 	)
-	testhelpers.AssertMCPServerInfo(
-		t,
-		sseMcpProxyServer,
-		"MCPJungle Proxy MCP Server for SSE transport",
-		version.GetVersion(),
-	)
 }
 
 // Helper to set and unset env vars for a test
@@ -395,3 +392,87 @@ func TestGetMcpServerInitReqTimeout(t *testing.T) {
 		}
 	})
 }
+
+func TestGetRegistrySyncInterval(t *testing.T) {
+	t.Run("returns default when unset or empty", func(t *testing.T) {
+		withEnv(map[string]string{
+			RegistrySyncIntervalSecEnvVar: "",
+		}, func() {
+			v, err := getRegistrySyncInterval()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v != replica.DefaultPollInterval {
+				t.Fatalf("expected default %s, got %s", replica.DefaultPollInterval, v)
+			}
+		})
+	})
+
+	t.Run("parses valid integer value", func(t *testing.T) {
+		withEnv(map[string]string{
+			RegistrySyncIntervalSecEnvVar: " 30 ",
+		}, func() {
+			v, err := getRegistrySyncInterval()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v != 30*time.Second {
+				t.Fatalf("expected 30s, got %s", v)
+			}
+		})
+	})
+
+	t.Run("returns error for invalid values", func(t *testing.T) {
+		for _, c := range []string{"abc", "0", "-1"} {
+			withEnv(map[string]string{
+				RegistrySyncIntervalSecEnvVar: c,
+			}, func() {
+				if _, err := getRegistrySyncInterval(); err == nil {
+					t.Fatalf("expected error for value %q, got nil", c)
+				}
+			})
+		}
+	})
+}
+
+func TestGetComposeServerInstructions(t *testing.T) {
+	t.Run("is disabled when unset or empty", func(t *testing.T) {
+		withEnv(map[string]string{
+			ComposeServerInstructionsEnvVar: "",
+		}, func() {
+			v, err := getComposeServerInstructions()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v {
+				t.Fatal("expected composing server instructions to be disabled")
+			}
+		})
+	})
+
+	t.Run("parses valid boolean values", func(t *testing.T) {
+		for value, expected := range map[string]bool{"true": true, " TRUE ": true, "1": true, "false": false, "0": false} {
+			withEnv(map[string]string{
+				ComposeServerInstructionsEnvVar: value,
+			}, func() {
+				v, err := getComposeServerInstructions()
+				if err != nil {
+					t.Fatalf("unexpected error for value %q: %v", value, err)
+				}
+				if v != expected {
+					t.Fatalf("expected %v for value %q, got %v", expected, value, v)
+				}
+			})
+		}
+	})
+
+	t.Run("returns error for invalid values", func(t *testing.T) {
+		withEnv(map[string]string{
+			ComposeServerInstructionsEnvVar: "yes please",
+		}, func() {
+			if _, err := getComposeServerInstructions(); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	})
+}
@@ -31,6 +31,30 @@ var updateToolGroupCmd = &cobra.Command{
 	RunE: runUpdateGroup,
 }
 
+var updateServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Update an MCP server",
+	Long: "Update the configuration of a registered MCP server in place\n" +
+		"This option allows you to supply the modified configuration file of an existing MCP server, " +
+		"eg- to rotate its bearer token or change its URL, headers, args or session mode.\n" +
+		"The new configuration completely overrides the existing one and is validated by connecting to the server.\n" +
+		"Note that you cannot change the name or transport of a server once it is registered.\n\n" +
+		"Unlike 'register --force', tools that are still provided by the server keep their enabled state " +
+		"and remain in the tool groups that include them. New tools are added and tools that no longer exist are removed.",
+	RunE: runUpdateServer,
+}
+
+var updateServerLogLevelCmd = &cobra.Command{
+	Use:   "log-level [server] [level]",
+	Args:  cobra.RangeArgs(1, 2),
+	Short: "Update the log level of an MCP server",
+	Long: "Change the minimum level of the log messages that an MCP server sends to mcpjungle\n" +
+		"Supported levels are debug, info, notice, warning, error, critical, alert and emergency.\n" +
+		"Omit the level to unset it, so that the server sends the log messages of its own default level.\n" +
+		"The level is applied with logging/setLevel the next time the server's stateful session is used.",
+	RunE: runUpdateServerLogLevel,
+}
+
 var updateMcpClientCmd = &cobra.Command{
 	Use:   "mcp-client [name]",
 	Args:  cobra.ExactArgs(1),
@@ -53,6 +77,8 @@ var updateUserCmd = &cobra.Command{
 var (
 	updateToolGroupConfigFilePath string
 
+	updateServerConfigFilePath string
+
 	updateMcpClientAccessToken string
 
 	updateUserAccessToken string
@@ -68,6 +94,15 @@ func init() {
 	)
 	_ = updateToolGroupCmd.MarkFlagRequired("conf")
 
+	updateServerCmd.Flags().StringVarP(
+		&updateServerConfigFilePath,
+		"conf",
+		"c",
+		"",
+		"Path to new JSON configuration file for the MCP server",
+	)
+	_ = updateServerCmd.MarkFlagRequired("conf")
+
 	updateMcpClientCmd.Flags().StringVar(
 		&updateMcpClientAccessToken,
 		"access-token",
@@ -85,6 +120,8 @@ func init() {
 	_ = updateUserCmd.MarkFlagRequired("access-token")
 
 	updateCmd.AddCommand(updateToolGroupCmd)
+	updateCmd.AddCommand(updateServerCmd)
+	updateCmd.AddCommand(updateServerLogLevelCmd)
 	updateCmd.AddCommand(updateMcpClientCmd)
 	updateCmd.AddCommand(updateUserCmd)
 
@@ -111,7 +148,35 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 	noChangeInServers := len(serversAdded) == 0 && len(serversRemoved) == 0
 	noChangeInExcluded := len(excludedAdded) == 0 && len(excludedRemoved) == 0
 
-	if resp.Old.Description == resp.New.Description && noChangeInTools && noChangeInServers && noChangeInExcluded {
+	// prompts and resources are reported the same way as excluded_tools
+	listChanges := []groupListChange{
+		newGroupListChange("Groups", "included_groups", resp.Old.IncludedGroups, resp.New.IncludedGroups),
+		newGroupListChange(
+			"Tool selectors", "included_tool_selectors",
+			selectorStrings(resp.Old.IncludedToolSelectors), selectorStrings(resp.New.IncludedToolSelectors),
+		),
+		newGroupListChange(
+			"Tool selectors", "excluded_tool_selectors",
+			selectorStrings(resp.Old.ExcludedToolSelectors), selectorStrings(resp.New.ExcludedToolSelectors),
+		),
+		newGroupListChange("Prompts", "included_prompts", resp.Old.IncludedPrompts, resp.New.IncludedPrompts),
+		newGroupListChange("Prompts", "excluded_prompts", resp.Old.ExcludedPrompts, resp.New.ExcludedPrompts),
+		newGroupListChange("Resources", "included_resources", resp.Old.IncludedResources, resp.New.IncludedResources),
+		newGroupListChange("Resources", "excluded_resources", resp.Old.ExcludedResources, resp.New.ExcludedResources),
+		newGroupListChange(
+			"Tool overrides", "tool_overrides",
+			toolOverrideStrings(resp.Old.ToolOverrides), toolOverrideStrings(resp.New.ToolOverrides),
+		),
+	}
+	noChangeInOtherLists := true
+	for _, lc := range listChanges {
+		if len(lc.added) > 0 || len(lc.removed) > 0 {
+			noChangeInOtherLists = false
+		}
+	}
+
+	if resp.Old.Description == resp.New.Description && noChangeInTools && noChangeInServers && noChangeInExcluded &&
+		noChangeInOtherLists {
 		cmd.Printf("No changes detected for Tool Group %s. Nothing was updated.\n", resp.Name)
 		return nil
 	}
@@ -175,6 +240,119 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 		cmd.Println()
 	}
 
+	for _, lc := range listChanges {
+		lc.print(cmd)
+	}
+
+	return nil
+}
+
+// groupListChange describes the changes made to one of the lists in a tool group's configuration.
+type groupListChange struct {
+	entity         string
+	field          string
+	added, removed []string
+}
+
+func newGroupListChange(entity, field string, oldList, newList []string) groupListChange {
+	added, removed := util.DiffTools(oldList, newList)
+	return groupListChange{entity: entity, field: field, added: added, removed: removed}
+}
+
+// print reports the change, if there is any.
+func (lc groupListChange) print(cmd *cobra.Command) {
+	if len(lc.added) == 0 && len(lc.removed) == 0 {
+		return
+	}
+	if len(lc.removed) > 0 {
+		cmd.Printf("* %s removed from %s:\n", lc.entity, lc.field)
+		for _, e := range lc.removed {
+			cmd.Printf("    - %s\n", e)
+		}
+	}
+	if len(lc.added) > 0 {
+		cmd.Printf("* %s added to %s:\n", lc.entity, lc.field)
+		for _, e := range lc.added {
+			cmd.Printf("    - %s\n", e)
+		}
+	}
+	cmd.Println()
+}
+
+func runUpdateServer(cmd *cobra.Command, args []string) error {
+	input, err := readMcpServerConfig(updateServerConfigFilePath)
+	if err != nil {
+		return err
+	}
+	if input.Name == "" {
+		return fmt.Errorf("config file %s must contain the name of the server to update", updateServerConfigFilePath)
+	}
+
+	resp, err := apiClient.UpdateServer(&input)
+	if err != nil {
+		return fmt.Errorf("failed to update server %s: %w", input.Name, err)
+	}
+
+	noEntityChanges := resp.Tools.IsEmpty() && resp.Prompts.IsEmpty() && resp.Resources.IsEmpty()
+	if len(resp.ConfigChanges) == 0 && noEntityChanges {
+		cmd.Printf("No changes detected for server %s. Nothing was updated.\n", input.Name)
+		return nil
+	}
+
+	cmd.Printf("Server %s updated successfully\n\n", input.Name)
+
+	if len(resp.ConfigChanges) > 0 {
+		cmd.Println("* Configuration changed:")
+		for _, f := range resp.ConfigChanges {
+			cmd.Printf("    - %s\n", f)
+		}
+	}
+	printServerEntityChanges(cmd, "Tools", &resp.Tools)
+	printServerEntityChanges(cmd, "Prompts", &resp.Prompts)
+	printServerEntityChanges(cmd, "Resources", &resp.Resources)
+
+	if resp.SessionClosed {
+		cmd.Println("\nThe stateful session using the old configuration was closed.")
+	}
+	return nil
+}
+
+func printServerEntityChanges(cmd *cobra.Command, kind string, changes *types.ServerEntityChanges) {
+	sections := []struct {
+		verb  string
+		names []string
+	}{
+		{"added", changes.Added},
+		{"removed", changes.Removed},
+		{"updated", changes.Updated},
+	}
+	for _, section := range sections {
+		if len(section.names) == 0 {
+			continue
+		}
+		cmd.Printf("* %s %s:\n", kind, section.verb)
+		for _, n := range section.names {
+			cmd.Printf("    - %s\n", n)
+		}
+	}
+}
+
+func runUpdateServerLogLevel(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	level := ""
+	if len(args) > 1 {
+		level = args[1]
+	}
+
+	if _, err := apiClient.SetServerLogLevel(name, level); err != nil {
+		return fmt.Errorf("failed to update log level of server %s: %w", name, err)
+	}
+
+	if level == "" {
+		cmd.Printf("Log level of server %s unset successfully.\n", name)
+	} else {
+		cmd.Printf("Log level of server %s set to %s successfully.\n", name, level)
+	}
 	return nil
 }
 
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/client"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestUpdateServerCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	var found bool
+	for _, c := range updateCmd.Commands() {
+		if c == updateServerCmd {
+			found = true
+		}
+	}
+	testhelpers.AssertTrue(t, found, "expected server subcommand of update")
+
+	f := updateServerCmd.Flags().Lookup("conf")
+	testhelpers.AssertNotNil(t, f)
+	testhelpers.AssertEqual(t, "c", f.Shorthand)
+	testhelpers.AssertEqual(t, "true", strings.Join(f.Annotations[cobra.BashCompOneRequiredFlag], ""))
+}
+
+func TestRunUpdateServer(t *testing.T) {
+	var received types.RegisterServerInput
+	result := types.UpdateServerResult{
+		Server:        &types.McpServer{Name: "calc"},
+		ConfigChanges: []string{"bearer_token"},
+		Tools:         types.ServerEntityChanges{Added: []string{"calc__mul"}, Removed: []string{"calc__sum"}},
+		SessionClosed: true,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v0/servers/calc" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	origClient, origPath := apiClient, updateServerConfigFilePath
+	defer func() {
+		apiClient, updateServerConfigFilePath = origClient, origPath
+	}()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	confPath := filepath.Join(t.TempDir(), "calc.json")
+	testhelpers.AssertNoError(t, os.WriteFile(
+		confPath,
+		[]byte(`{"name":"calc","transport":"streamable_http","url":"http://localhost:9000/mcp","bearer_token":"new"}`),
+		0o600,
+	))
+	updateServerConfigFilePath = confPath
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	testhelpers.AssertNoError(t, runUpdateServer(cmd, nil))
+	testhelpers.AssertEqual(t, "new", received.BearerToken)
+	testhelpers.AssertStringContains(t, out.String(), "Server calc updated successfully")
+	testhelpers.AssertStringContains(t, out.String(), "- bearer_token")
+	testhelpers.AssertStringContains(t, out.String(), "* Tools added:\n    - calc__mul")
+	testhelpers.AssertStringContains(t, out.String(), "* Tools removed:\n    - calc__sum")
+	testhelpers.AssertStringContains(t, out.String(), "stateful session")
+
+	out.Reset()
+	result = types.UpdateServerResult{Server: &types.McpServer{Name: "calc"}}
+	testhelpers.AssertNoError(t, runUpdateServer(cmd, nil))
+	testhelpers.AssertStringContains(t, out.String(), "No changes detected for server calc")
+
+	testhelpers.AssertNoError(t, os.WriteFile(confPath, []byte(`{"transport":"stdio","command":"echo"}`), 0o600))
+	err := runUpdateServer(cmd, nil)
+	testhelpers.AssertTrue(t, err != nil && strings.Contains(err.Error(), "name"), "expected missing name error")
+}
+
+func TestRunUpdateServerLogLevel(t *testing.T) {
+	var received types.SetServerLogLevelInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/v0/servers/calc/log-level" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		_ = json.NewEncoder(w).Encode(types.McpServer{Name: "calc", LogLevel: received.Level})
+	}))
+	defer server.Close()
+
+	origClient := apiClient
+	defer func() { apiClient = origClient }()
+	apiClient = client.NewClient(server.URL, "", http.DefaultClient)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	testhelpers.AssertNoError(t, runUpdateServerLogLevel(cmd, []string{"calc", "warning"}))
+	testhelpers.AssertEqual(t, "warning", received.Level)
+	testhelpers.AssertStringContains(t, out.String(), "Log level of server calc set to warning")
+
+	out.Reset()
+	testhelpers.AssertNoError(t, runUpdateServerLogLevel(cmd, []string{"calc"}))
+	testhelpers.AssertEqual(t, "", received.Level)
+	testhelpers.AssertStringContains(t, out.String(), "Log level of server calc unset")
+
+	err := runUpdateServerLogLevel(cmd, []string{"missing", "info"})
+	testhelpers.AssertTrue(t, err != nil, "expected an error for an unknown server")
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func (s *Server) createBackupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		archive, err := s.backupService.CreateBackup()
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, archive)
+	}
+}
+
+func (s *Server) restoreBackupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var archive types.BackupArchive
+		if err := c.ShouldBindJSON(&archive); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid backup archive: " + err.Error()})
+			return
+		}
+
+		result, err := s.backupService.RestoreBackup(&archive)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// The restored entities only exist in the database at this point.
+		// Load them into the MCP proxy servers so that they are served without a restart.
+		if err := s.mcpService.LoadProxyFromDB(); err != nil {
+			handleServiceError(c, fmt.Errorf("backup was restored but failed to load it into the MCP proxy, restart mcpjungle: %w", err))
+			return
+		}
+		if err := s.toolGroupService.LoadToolGroupsFromDB(); err != nil {
+			handleServiceError(c, fmt.Errorf("backup was restored but failed to load tool groups, restart mcpjungle: %w", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/clientconfig"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// dashboardConnectHandler returns the config fragment that connects the requested kind of MCP client
+// to the global MCP endpoint, or to a tool group's endpoint if the "group" query param is set.
+// The dashboard only runs in development mode, so the fragment never contains an access token.
+func (s *Server) dashboardConnectHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		kind, err := clientconfig.ValidateClientKind(c.Param("client"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		conn := clientconfig.Connection{
+			Name: "mcpjungle",
+			URL:  requestBaseURL(c) + "/mcp",
+		}
+		if groupName := c.Query("group"); groupName != "" {
+			if _, err := s.toolGroupService.GetToolGroup(groupName); err != nil {
+				handleServiceError(c, err)
+				return
+			}
+			conn.Name = "mcpjungle-" + groupName
+			conn.URL = getToolGroupEndpoints(c, groupName).StreamableHTTPEndpoint
+		}
+
+		fragment, err := clientconfig.Fragment(kind, conn)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, types.DashboardClientConnection{
+			Client:     string(kind),
+			Endpoint:   conn.URL,
+			ConfigFile: clientconfig.DefaultConfigFile(kind),
+			Fragment:   fragment,
+		})
+	}
+}
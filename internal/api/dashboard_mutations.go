@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
@@ -45,11 +46,11 @@ func (s *Server) dashboardRegisterServerHandler() gin.HandlerFunc {
 			return
 		}
 
-		err = s.mcpService.RegisterMcpServerWithOAuthSupport(c, &input, server, false, "dashboard")
+		err = s.mcpService.RegisterMcpServerWithOAuthSupport(c, &input, server, false, dashboardAuthor)
 		if err != nil {
 			if errors.Is(err, apierrors.ErrUpstreamOAuthRequired) {
 				input.OAuthRedirectURI = requestBaseURL(c) + "/api/dashboard/oauth/callback"
-				err = s.mcpService.RegisterMcpServerWithOAuthSupport(c, &input, server, false, "dashboard")
+				err = s.mcpService.RegisterMcpServerWithOAuthSupport(c, &input, server, false, dashboardAuthor)
 			}
 		}
 		if err != nil {
@@ -67,6 +68,14 @@ func (s *Server) dashboardRegisterServerHandler() gin.HandlerFunc {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(
+			model.RevisionEntityServer,
+			server.Name,
+			model.RevisionActionCreated,
+			dashboardAuthor,
+			nil,
+			s.serverSnapshot(server.Name),
+		)
 
 		c.JSON(http.StatusCreated, dashboardRegisterServerResponse{
 			Name:        server.Name,
@@ -79,10 +88,13 @@ func (s *Server) dashboardRegisterServerHandler() gin.HandlerFunc {
 
 func (s *Server) dashboardDeleteServerHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if err := s.mcpService.DeregisterMcpServer(c.Param("name")); err != nil {
+		name := c.Param("name")
+		previous := s.serverSnapshot(name)
+		if err := s.mcpService.DeregisterMcpServer(name); err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(model.RevisionEntityServer, name, model.RevisionActionDeleted, dashboardAuthor, previous, nil)
 		c.JSON(http.StatusOK, gin.H{"deleted": true})
 	}
 }
@@ -4,16 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"html"
+	"log"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 )
 
-const dashboardOAuthResultRetention = 10 * time.Minute
-
 func (s *Server) dashboardOAuthCallbackHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		code := c.Query("code")
@@ -37,24 +37,33 @@ func (s *Server) dashboardOAuthCallbackHandler() gin.HandlerFunc {
 			return
 		}
 
-		_, err = s.mcpService.CompleteUpstreamOAuthSession(c, session.SessionID, code, state)
+		server, err := s.mcpService.CompleteUpstreamOAuthSession(c, session.SessionID, code, state)
 		if err != nil {
-			s.storeDashboardOAuthResult(session.SessionID, dashboardOAuthSessionResult{
+			s.storeDashboardOAuthResult(c, &model.UpstreamOAuthSessionResult{
+				SessionID:  session.SessionID,
 				Status:     dashboardOAuthStatusForError(err),
 				Error:      safeOAuthCallbackError(err),
 				ServerName: session.ServerName,
 				ExpiresAt:  session.ExpiresAt,
-				UpdatedAt:  time.Now(),
 			})
 			renderDashboardOAuthHTML(c, dashboardOAuthErrorStatus(err), "Authorization failed", safeOAuthCallbackError(err))
 			return
 		}
 
-		s.storeDashboardOAuthResult(session.SessionID, dashboardOAuthSessionResult{
+		s.recordRevision(
+			model.RevisionEntityServer,
+			server.Name,
+			model.RevisionActionCreated,
+			dashboardAuthor,
+			nil,
+			s.serverSnapshot(server.Name),
+		)
+
+		s.storeDashboardOAuthResult(c, &model.UpstreamOAuthSessionResult{
+			SessionID:  session.SessionID,
 			Status:     "completed",
 			ServerName: session.ServerName,
 			ExpiresAt:  session.ExpiresAt,
-			UpdatedAt:  time.Now(),
 		})
 		renderDashboardOAuthHTML(c, http.StatusOK, "Authorization successful", "Authorization successful. You can close this tab and return to MCPJungle.")
 	}
@@ -68,7 +77,7 @@ func (s *Server) dashboardOAuthSessionHandler() gin.HandlerFunc {
 			return
 		}
 
-		if result, ok := s.getDashboardOAuthResult(sessionID); ok {
+		if result, ok := s.getDashboardOAuthResult(c, sessionID); ok {
 			c.JSON(http.StatusOK, dashboardOAuthSessionResponse{
 				SessionID:  sessionID,
 				Status:     result.Status,
@@ -87,12 +96,12 @@ func (s *Server) dashboardOAuthSessionHandler() gin.HandlerFunc {
 
 		if time.Now().After(session.ExpiresAt) {
 			_ = s.mcpService.DeletePendingUpstreamOAuthSession(c, session.SessionID)
-			s.storeDashboardOAuthResult(session.SessionID, dashboardOAuthSessionResult{
+			s.storeDashboardOAuthResult(c, &model.UpstreamOAuthSessionResult{
+				SessionID:  session.SessionID,
 				Status:     "expired",
 				Error:      "OAuth authorization expired. Start registration again.",
 				ServerName: session.ServerName,
 				ExpiresAt:  session.ExpiresAt,
-				UpdatedAt:  time.Now(),
 			})
 			c.JSON(http.StatusOK, dashboardOAuthSessionResponse{
 				SessionID:  session.SessionID,
@@ -137,32 +146,25 @@ func renderDashboardOAuthHTML(c *gin.Context, status int, title, message string)
 </html>`, html.EscapeString(title), html.EscapeString(title), html.EscapeString(message)))
 }
 
-func (s *Server) getDashboardOAuthResult(sessionID string) (dashboardOAuthSessionResult, bool) {
-	s.dashboardOAuthMu.Lock()
-	defer s.dashboardOAuthMu.Unlock()
-
-	s.cleanupDashboardOAuthResultsLocked(time.Now())
-	result, ok := s.dashboardOAuthResults[sessionID]
-	return result, ok
-}
-
-func (s *Server) storeDashboardOAuthResult(sessionID string, result dashboardOAuthSessionResult) {
-	s.dashboardOAuthMu.Lock()
-	defer s.dashboardOAuthMu.Unlock()
-
-	s.cleanupDashboardOAuthResultsLocked(time.Now())
-	s.dashboardOAuthResults[sessionID] = result
+// getDashboardOAuthResult returns the final outcome of a dashboard OAuth session, if there is one.
+// The outcome is stored in the DB, so it is available to every replica regardless of which one
+// received the OAuth callback.
+func (s *Server) getDashboardOAuthResult(c *gin.Context, sessionID string) (*model.UpstreamOAuthSessionResult, bool) {
+	result, err := s.mcpService.GetUpstreamOAuthSessionResult(c, sessionID)
+	if err != nil {
+		if !errors.Is(err, apierrors.ErrNotFound) {
+			log.Printf("[WARN] failed to get result of dashboard OAuth session %s: %v", sessionID, err)
+		}
+		return nil, false
+	}
+	return result, true
 }
 
-func (s *Server) cleanupDashboardOAuthResultsLocked(now time.Time) {
-	for sessionID, result := range s.dashboardOAuthResults {
-		if !result.ExpiresAt.IsZero() && now.After(result.ExpiresAt.Add(dashboardOAuthResultRetention)) {
-			delete(s.dashboardOAuthResults, sessionID)
-			continue
-		}
-		if !result.UpdatedAt.IsZero() && now.After(result.UpdatedAt.Add(dashboardOAuthResultRetention)) {
-			delete(s.dashboardOAuthResults, sessionID)
-		}
+// storeDashboardOAuthResult records the final outcome of a dashboard OAuth session for the dashboard to poll.
+// The authorization itself has already been processed at this point, so failing to record it is only logged.
+func (s *Server) storeDashboardOAuthResult(c *gin.Context, result *model.UpstreamOAuthSessionResult) {
+	if err := s.mcpService.StoreUpstreamOAuthSessionResult(c, result); err != nil {
+		log.Printf("[WARN] failed to store result of dashboard OAuth session %s: %v", result.SessionID, err)
 	}
 }
 
@@ -174,12 +176,12 @@ func (s *Server) tryMarkDashboardOAuthFailed(c *gin.Context, state, oauthError,
 	if err != nil {
 		return
 	}
-	s.storeDashboardOAuthResult(session.SessionID, dashboardOAuthSessionResult{
+	s.storeDashboardOAuthResult(c, &model.UpstreamOAuthSessionResult{
+		SessionID:  session.SessionID,
 		Status:     "failed",
 		Error:      safeOAuthErrorMessage(oauthError, errorDescription),
 		ServerName: session.ServerName,
 		ExpiresAt:  session.ExpiresAt,
-		UpdatedAt:  time.Now(),
 	})
 	_ = s.mcpService.DeletePendingUpstreamOAuthSession(c, session.SessionID)
 }
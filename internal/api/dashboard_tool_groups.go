@@ -114,6 +114,7 @@ func (s *Server) dashboardCreateToolGroupHandler() gin.HandlerFunc {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(model.RevisionEntityToolGroup, group.Name, model.RevisionActionCreated, dashboardAuthor, nil, toolGroupSnapshot(group))
 
 		resp, err := s.buildDashboardToolGroup(c, *group)
 		if err != nil {
@@ -126,16 +127,21 @@ func (s *Server) dashboardCreateToolGroupHandler() gin.HandlerFunc {
 
 func (s *Server) dashboardDeleteToolGroupHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if err := s.toolGroupService.DeleteToolGroup(c.Param("name")); err != nil {
+		name := c.Param("name")
+		previous := s.currentToolGroupSnapshot(name)
+		if err := s.toolGroupService.DeleteToolGroup(name); err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		if previous != nil {
+			s.recordRevision(model.RevisionEntityToolGroup, name, model.RevisionActionDeleted, dashboardAuthor, previous, nil)
+		}
 		c.JSON(http.StatusOK, gin.H{"deleted": true})
 	}
 }
 
 func (s *Server) buildDashboardToolGroup(c *gin.Context, group model.ToolGroup) (dashboardToolGroup, error) {
-	toolNames, err := group.ResolveEffectiveTools(s.mcpService)
+	toolNames, err := s.toolGroupService.ResolveEffectiveTools(group.Name)
 	if err != nil {
 		return dashboardToolGroup{}, err
 	}
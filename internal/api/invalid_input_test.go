@@ -23,11 +23,9 @@ func setupInvalidInputServer(t *testing.T) *Server {
 	t.Cleanup(setup.Cleanup)
 
 	mcpProxy := mcpserver.NewMCPServer("test", "0.0.1")
-	sseMcpProxy := mcpserver.NewMCPServer("test-sse", "0.0.1")
 	mcpSvc, err := mcp.NewMCPService(&mcp.ServiceConfig{
 		DB:                      setup.DB,
 		McpProxyServer:          mcpProxy,
-		SseMcpProxyServer:       sseMcpProxy,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 5,
 	})
@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
@@ -14,6 +15,12 @@ func (s *Server) listMcpClientsHandler() gin.HandlerFunc {
 			handleServiceError(c, err)
 			return
 		}
+
+		// only the clients in the request's namespace are listed
+		clients = slices.DeleteFunc(clients, func(client *model.McpClient) bool { return !inScope(c, client.Name) })
+		for _, client := range clients {
+			client.Name = localName(c, client.Name)
+		}
 		c.JSON(http.StatusOK, clients)
 	}
 }
@@ -29,12 +36,14 @@ func (s *Server) createMcpClientHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
 			return
 		}
+		req.Name = qualifiedName(c, req.Name)
 		// TODO: if allow list in the request is null, convert it to an empty JSON array
 		client, err := s.mcpClientService.CreateClient(req)
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		client.Name = localName(c, client.Name)
 		c.JSON(http.StatusCreated, client)
 	}
 }
@@ -46,7 +55,7 @@ func (s *Server) deleteMcpClientHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
 			return
 		}
-		if err := s.mcpClientService.DeleteClient(name); err != nil {
+		if err := s.mcpClientService.DeleteClient(qualifiedName(c, name)); err != nil {
 			handleServiceError(c, err)
 			return
 		}
@@ -66,13 +75,14 @@ func (s *Server) updateMcpClientHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 			return
 		}
-		req.Name = name // Ensure the name from the URL is used
+		req.Name = qualifiedName(c, name) // Ensure the name from the URL is used
 
 		resp, err := s.mcpClientService.UpdateClient(req)
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		resp.Name = localName(c, resp.Name)
 		c.JSON(http.StatusOK, resp)
 	}
 }
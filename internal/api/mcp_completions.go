@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// completeHandler returns the completions suggested by the upstream MCP server for the value of
+// a prompt argument or of a variable in the URI template of a resource template.
+func (s *Server) completeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request types.CompleteRequest
+		if err := json.NewDecoder(c.Request.Body).Decode(&request); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to decode request body: " + err.Error()})
+			return
+		}
+
+		if (request.Prompt == "") == (request.ResourceTemplate == "") {
+			c.JSON(
+				http.StatusBadRequest,
+				gin.H{"error": "exactly one of 'prompt' and 'resource_template' fields must be set in request body"},
+			)
+			return
+		}
+		if request.Argument == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'argument' field in request body"})
+			return
+		}
+
+		argument := mcpgo.CompleteArgument{Name: request.Argument, Value: request.Value}
+		completeCtx := mcpgo.CompleteContext{Arguments: request.Context}
+
+		var (
+			completion *mcpgo.Completion
+			err        error
+		)
+		if request.Prompt != "" {
+			completion, err = s.mcpService.CompletePromptArgument(c, qualifiedName(c, request.Prompt), argument, completeCtx)
+		} else {
+			if !s.resourceTemplateInScope(c, request.ResourceTemplate) {
+				handleServiceError(c, fmt.Errorf(
+					"failed to get completions: resource template %s not found: %w",
+					request.ResourceTemplate, apierrors.ErrNotFound,
+				))
+				return
+			}
+			completion, err = s.mcpService.CompleteResourceTemplateArgument(c, request.ResourceTemplate, argument, completeCtx)
+		}
+		if err != nil {
+			handleServiceError(c, fmt.Errorf("failed to get completions: %w", err))
+			return
+		}
+
+		c.JSON(http.StatusOK, types.CompleteResult{
+			Values:  completion.Values,
+			Total:   completion.Total,
+			HasMore: completion.HasMore,
+		})
+	}
+}
+
+// resourceTemplateInScope returns true if the resource template with the given URI template belongs to
+// an MCP server in the namespace the API request is scoped to.
+// Like resources, templates that don't exist are considered in scope, so that they are reported as not found.
+func (s *Server) resourceTemplateInScope(c *gin.Context, uriTemplate string) bool {
+	if scopedNamespace(c) == "" {
+		return true
+	}
+	templates, err := s.mcpService.ListResourceTemplates()
+	if err != nil {
+		return true
+	}
+	for _, rt := range templates {
+		if rt.URITemplate == uriTemplate {
+			return inScope(c, rt.Server.Name)
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestCompleteHandler_InvalidInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := setupInvalidInputServer(t)
+
+	router := gin.New()
+	router.POST("/complete", s.completeHandler())
+
+	cases := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "neither prompt nor resource template",
+			body:       `{"argument":"owner"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "exactly one of 'prompt' and 'resource_template'",
+		},
+		{
+			name:       "both prompt and resource template",
+			body:       `{"prompt":"github__review","resource_template":"mcpj://tmpl/github/repo://{owner}","argument":"owner"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "exactly one of 'prompt' and 'resource_template'",
+		},
+		{
+			name:       "missing argument",
+			body:       `{"prompt":"github__review"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "missing 'argument' field",
+		},
+		{
+			name:       "invalid prompt name",
+			body:       `{"prompt":"review","argument":"owner"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "does not contain a __ separator",
+		},
+		{
+			name:       "unknown prompt",
+			body:       `{"prompt":"github__review","argument":"owner"}`,
+			wantStatus: http.StatusNotFound,
+			wantBody:   "not found",
+		},
+		{
+			name:       "invalid resource template URI",
+			body:       `{"resource_template":"repo://{owner}","argument":"owner"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "not a valid MCPJungle resource template URI",
+		},
+		{
+			name:       "unknown resource template",
+			body:       `{"resource_template":"mcpj://tmpl/github/repo://{owner}","argument":"owner"}`,
+			wantStatus: http.StatusNotFound,
+			wantBody:   "not found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/complete", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			testhelpers.AssertEqual(t, tc.wantStatus, w.Code)
+			testhelpers.AssertStringContains(t, w.Body.String(), tc.wantBody)
+		})
+	}
+}
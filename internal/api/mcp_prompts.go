@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
@@ -22,12 +23,18 @@ func (s *Server) listPromptsHandler() gin.HandlerFunc {
 			prompts, err = s.mcpService.ListPrompts()
 		} else {
 			// server specified, list prompts for that server
-			prompts, err = s.mcpService.ListPromptsByServer(server)
+			prompts, err = s.mcpService.ListPromptsByServer(qualifiedName(c, server))
 		}
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+
+		// only the prompts of the MCP servers in the request's namespace are listed
+		prompts = slices.DeleteFunc(prompts, func(p model.Prompt) bool { return !inScope(c, p.Name) })
+		for i := range prompts {
+			prompts[i].Name = localName(c, prompts[i].Name)
+		}
 		c.JSON(http.StatusOK, prompts)
 	}
 }
@@ -42,11 +49,12 @@ func (s *Server) getPromptHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'name' query parameter"})
 			return
 		}
-		prompt, err := s.mcpService.GetPrompt(name)
+		prompt, err := s.mcpService.GetPrompt(qualifiedName(c, name))
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to get prompt: %w", err))
 			return
 		}
+		prompt.Name = localName(c, prompt.Name)
 
 		c.JSON(http.StatusOK, prompt)
 	}
@@ -75,7 +83,7 @@ func (s *Server) getPromptWithArgsHandler() gin.HandlerFunc {
 			args[k] = v
 		}
 
-		resp, err := s.mcpService.GetPromptWithArgs(c, request.Name, args)
+		resp, err := s.mcpService.GetPromptWithArgs(c, qualifiedName(c, request.Name), args)
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to get prompt: %w", err))
 			return
@@ -93,12 +101,12 @@ func (s *Server) enablePromptsHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'entity' query parameter"})
 			return
 		}
-		enabledPrompts, err := s.mcpService.EnablePrompts(entity)
+		enabledPrompts, err := s.mcpService.EnablePrompts(qualifiedName(c, entity))
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to enable prompt(s): %w", err))
 			return
 		}
-		c.JSON(http.StatusOK, enabledPrompts)
+		c.JSON(http.StatusOK, localNames(c, enabledPrompts))
 	}
 }
 
@@ -110,11 +118,11 @@ func (s *Server) disablePromptsHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'entity' query parameter"})
 			return
 		}
-		disabledPrompts, err := s.mcpService.DisablePrompts(entity)
+		disabledPrompts, err := s.mcpService.DisablePrompts(qualifiedName(c, entity))
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to disable prompt(s): %w", err))
 			return
 		}
-		c.JSON(http.StatusOK, disabledPrompts)
+		c.JSON(http.StatusOK, localNames(c, disabledPrompts))
 	}
 }
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -21,16 +23,51 @@ func (s *Server) listResourcesHandler() gin.HandlerFunc {
 		if server == "" {
 			resources, err = s.mcpService.ListResources()
 		} else {
-			resources, err = s.mcpService.ListResourcesByServer(server)
+			resources, err = s.mcpService.ListResourcesByServer(qualifiedName(c, server))
 		}
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+
+		// only the resources of the MCP servers in the request's namespace are listed.
+		// resource URIs are unique across mcpjungle, so they are not relative to the namespace.
+		resources = slices.DeleteFunc(resources, func(r model.Resource) bool { return !inScope(c, r.Server.Name) })
+		for i := range resources {
+			resources[i].Name = localName(c, resources[i].Name)
+		}
 		c.JSON(http.StatusOK, resources)
 	}
 }
 
+// listResourceTemplatesHandler returns a list of all resource templates,
+// or all resource templates of a given mcp server if "server" query param is provided
+func (s *Server) listResourceTemplatesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		server := c.Query("server")
+		var (
+			templates []model.ResourceTemplate
+			err       error
+		)
+		if server == "" {
+			templates, err = s.mcpService.ListResourceTemplates()
+		} else {
+			templates, err = s.mcpService.ListResourceTemplatesByServer(qualifiedName(c, server))
+		}
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		// like resource URIs, the URI templates are unique across mcpjungle and not relative to the namespace.
+		templates = slices.DeleteFunc(templates, func(rt model.ResourceTemplate) bool { return !inScope(c, rt.Server.Name) })
+		for i := range templates {
+			templates[i].Name = localName(c, templates[i].Name)
+		}
+		c.JSON(http.StatusOK, templates)
+	}
+}
+
 // getResourceHandler returns resource metadata for the given URI.
 func (s *Server) getResourceHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -46,10 +83,14 @@ func (s *Server) getResourceHandler() gin.HandlerFunc {
 		}
 
 		resource, err := s.mcpService.GetResource(request.URI)
+		if err == nil && !inScope(c, resource.Server.Name) {
+			err = fmt.Errorf("resource %s not found: %w", request.URI, apierrors.ErrNotFound)
+		}
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to get resource: %w", err))
 			return
 		}
+		resource.Name = localName(c, resource.Name)
 
 		c.JSON(http.StatusOK, resource)
 	}
@@ -69,6 +110,13 @@ func (s *Server) readResourceHandler() gin.HandlerFunc {
 			return
 		}
 
+		if !s.resourceInScope(c, request.URI) {
+			handleServiceError(c, fmt.Errorf(
+				"failed to read resource: resource %s not found: %w", request.URI, apierrors.ErrNotFound,
+			))
+			return
+		}
+
 		resp, err := s.mcpService.ReadResource(c, request.URI)
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to read resource: %w", err))
@@ -78,3 +126,17 @@ func (s *Server) readResourceHandler() gin.HandlerFunc {
 		c.JSON(http.StatusOK, resp)
 	}
 }
+
+// resourceInScope returns true if the resource with the given URI belongs to an MCP server
+// in the namespace the API request is scoped to.
+// Resources that don't exist are considered in scope, so that reading them reports them as not found.
+func (s *Server) resourceInScope(c *gin.Context, uri string) bool {
+	if scopedNamespace(c) == "" {
+		return true
+	}
+	resource, err := s.mcpService.GetResource(uri)
+	if err != nil {
+		return true
+	}
+	return inScope(c, resource.Server.Name)
+}
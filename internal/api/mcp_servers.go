@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/revision"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -27,6 +29,7 @@ func (s *Server) registerServerHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		input.Name = qualifiedName(c, input.Name)
 
 		server, err := createServerModelFromInput(&input)
 		if err != nil {
@@ -34,9 +37,12 @@ func (s *Server) registerServerHandler() gin.HandlerFunc {
 			return
 		}
 
+		// previous is the configuration of the server being replaced, if any
+		var previous *types.RegisterServerInput
 		if force {
 			// If "force" option is set, we check if a server with the same name already exists. If it does, we deregister it before registering the new one.
 			if _, err := s.mcpService.GetMcpServer(input.Name); err == nil {
+				previous = s.serverSnapshot(input.Name)
 				log.Printf("[INFO] force=true: deregistering existing MCP server %s before re-registration", input.Name)
 				if err := s.mcpService.DeregisterMcpServer(input.Name); err != nil {
 					c.JSON(
@@ -54,12 +60,7 @@ func (s *Server) registerServerHandler() gin.HandlerFunc {
 			}
 		}
 
-		initiatedBy := ""
-		if authenticatedUser, exists := c.Get("user"); exists {
-			if u, ok := authenticatedUser.(*model.User); ok {
-				initiatedBy = u.Username
-			}
-		}
+		initiatedBy := requestAuthor(c)
 
 		if err := s.mcpService.RegisterMcpServerWithOAuthSupport(c, &input, server, force, initiatedBy); err != nil {
 			var oauthErr *mcp.UpstreamOAuthAuthorizationPendingError
@@ -81,20 +82,126 @@ func (s *Server) registerServerHandler() gin.HandlerFunc {
 			return
 		}
 
+		action := model.RevisionActionCreated
+		if previous != nil {
+			action = model.RevisionActionUpdated
+		}
+		s.recordRevision(model.RevisionEntityServer, server.Name, action, initiatedBy, previous, s.serverSnapshot(server.Name))
+
+		serverInfo, _ := server.GetServerInfo()
 		c.JSON(http.StatusCreated, types.RegisterServerResult{Server: &types.McpServer{
-			Name:        server.Name,
-			Transport:   string(server.Transport),
-			Enabled:     server.Enabled,
-			Description: server.Description,
-			SessionMode: string(server.SessionMode),
-			URL:         input.URL,
-			Command:     input.Command,
-			Args:        input.Args,
-			Env:         input.Env,
+			Name:          localName(c, server.Name),
+			Transport:     string(server.Transport),
+			Enabled:       server.Enabled,
+			Description:   server.Description,
+			SessionMode:   string(server.SessionMode),
+			Labels:        input.Labels,
+			ToolOverrides: input.ToolOverrides,
+			AllowedRoots:  input.AllowedRoots,
+			LogLevel:      server.LogLevel,
+			ServerInfo:    serverInfo,
+			URL:           input.URL,
+			Command:       input.Command,
+			Args:          input.Args,
+			Env:           input.Env,
 		}})
 	}
 }
 
+// updateServerHandler updates the configuration of a registered MCP server in place.
+// Unlike a forced re-registration, the server's tools keep their enabled state and tool group membership.
+func (s *Server) updateServerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		var input types.RegisterServerInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if input.Name != "" && input.Name != name {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("server name %s in the configuration does not match %s, a server cannot be renamed", input.Name, name),
+			})
+			return
+		}
+		input.Name = qualifiedName(c, name)
+		name = input.Name
+
+		server, err := createServerModelFromInput(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		previous := s.serverSnapshot(name)
+		result, err := s.mcpService.UpdateMcpServer(c, server)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		record, err := s.mcpService.GetMcpServer(name)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		result.Server = mcpServerFromRecord(record)
+		result.Server.Name = localName(c, record.Name)
+		for _, changes := range []*types.ServerEntityChanges{&result.Tools, &result.Prompts} {
+			changes.Added = localNames(c, changes.Added)
+			changes.Removed = localNames(c, changes.Removed)
+			changes.Updated = localNames(c, changes.Updated)
+		}
+
+		snapshot := s.serverSnapshot(name)
+		changes, err := revision.CompareSnapshots(previous, snapshot)
+		if err != nil {
+			log.Printf("[WARN] failed to compare configurations of server %s: %v", name, err)
+		}
+		for _, change := range changes {
+			result.ConfigChanges = append(result.ConfigChanges, change.Field)
+		}
+		s.recordRevision(model.RevisionEntityServer, name, model.RevisionActionUpdated, requestAuthor(c), previous, snapshot)
+
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// validateServerHandler performs a dry-run registration of an MCP server.
+// It reports the capabilities discovered upstream and any problems with the configuration without registering it.
+// Like registration, it accepts the "force" query parameter to check the replacement of an existing server.
+func (s *Server) validateServerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		force, err := parseForceQueryParam(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		var input types.RegisterServerInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		input.Name = qualifiedName(c, input.Name)
+
+		server, err := createServerModelFromInput(&input)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		report, err := s.mcpService.ValidateMcpServer(c, server, force)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		report.Name = localName(c, report.Name)
+		c.JSON(http.StatusOK, report)
+	}
+}
+
 func parseForceQueryParam(c *gin.Context) (bool, error) {
 	if c.Query("force") == "" {
 		return false, nil
@@ -123,46 +230,68 @@ func (s *Server) completeUpstreamOAuthSessionHandler() gin.HandlerFunc {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(
+			model.RevisionEntityServer,
+			server.Name,
+			model.RevisionActionCreated,
+			requestAuthor(c),
+			nil,
+			s.serverSnapshot(server.Name),
+		)
 
-		resp := &types.McpServer{
-			Name:        server.Name,
-			Transport:   string(server.Transport),
-			Enabled:     server.Enabled,
-			Description: server.Description,
-			SessionMode: string(server.SessionMode),
+		resp := mcpServerFromRecord(server)
+		resp.Name = localName(c, server.Name)
+		c.JSON(http.StatusCreated, types.RegisterServerResult{Server: resp})
+	}
+}
+
+// mcpServerFromRecord converts a registered MCP server into its API representation.
+// Transport-specific details are left empty if the server's config cannot be read.
+func mcpServerFromRecord(server *model.McpServer) *types.McpServer {
+	resp := &types.McpServer{
+		Name:        server.Name,
+		Transport:   string(server.Transport),
+		Enabled:     server.Enabled,
+		Description: server.Description,
+		SessionMode: string(server.SessionMode),
+	}
+	resp.Labels, _ = server.GetLabels()
+	resp.ToolOverrides, _ = server.GetToolOverrides()
+	resp.AllowedRoots, _ = server.GetAllowedRoots()
+	resp.LogLevel = server.LogLevel
+	resp.ServerInfo, _ = server.GetServerInfo()
+	switch server.Transport {
+	case types.TransportStreamableHTTP:
+		conf, confErr := server.GetStreamableHTTPConfig()
+		if confErr == nil {
+			resp.URL = conf.URL
 		}
-		switch server.Transport {
-		case types.TransportStreamableHTTP:
-			conf, confErr := server.GetStreamableHTTPConfig()
-			if confErr == nil {
-				resp.URL = conf.URL
-			}
-		case types.TransportStdio:
-			conf, confErr := server.GetStdioConfig()
-			if confErr == nil {
-				resp.Command = conf.Command
-				resp.Args = conf.Args
-				resp.Env = conf.Env
-			}
-		case types.TransportSSE:
-			conf, confErr := server.GetSSEConfig()
-			if confErr == nil {
-				resp.URL = conf.URL
-			}
+	case types.TransportStdio:
+		conf, confErr := server.GetStdioConfig()
+		if confErr == nil {
+			resp.Command = conf.Command
+			resp.Args = conf.Args
+			resp.Env = conf.Env
+		}
+	case types.TransportSSE:
+		conf, confErr := server.GetSSEConfig()
+		if confErr == nil {
+			resp.URL = conf.URL
 		}
-
-		c.JSON(http.StatusCreated, types.RegisterServerResult{Server: resp})
 	}
+	return resp
 }
 
 func (s *Server) deregisterServerHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		name := c.Param("name")
+		name := qualifiedName(c, c.Param("name"))
 
+		previous := s.serverSnapshot(name)
 		if err := s.mcpService.DeregisterMcpServer(name); err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(model.RevisionEntityServer, name, model.RevisionActionDeleted, requestAuthor(c), previous, nil)
 
 		c.Status(http.StatusNoContent)
 	}
@@ -176,16 +305,22 @@ func (s *Server) listServersHandler() gin.HandlerFunc {
 			return
 		}
 
+		records = slices.DeleteFunc(records, func(record model.McpServer) bool { return !inScope(c, record.Name) })
 		servers := make([]*types.McpServer, len(records))
 
 		for i, record := range records {
 			servers[i] = &types.McpServer{
-				Name:        record.Name,
+				Name:        localName(c, record.Name),
 				Transport:   string(record.Transport),
 				Enabled:     record.Enabled,
 				Description: record.Description,
 				SessionMode: string(record.SessionMode),
 			}
+			servers[i].Labels, _ = record.GetLabels()
+			servers[i].ToolOverrides, _ = record.GetToolOverrides()
+			servers[i].AllowedRoots, _ = record.GetAllowedRoots()
+			servers[i].LogLevel = record.LogLevel
+			servers[i].ServerInfo, _ = record.GetServerInfo()
 
 			switch record.Transport {
 			case types.TransportStreamableHTTP:
@@ -238,7 +373,7 @@ func (s *Server) enableServerHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		name := c.Param("name")
 
-		tools, prompts, err := s.mcpService.EnableMcpServer(name)
+		tools, prompts, err := s.mcpService.EnableMcpServer(qualifiedName(c, name))
 		if err != nil {
 			handleServiceError(c, err)
 			return
@@ -246,8 +381,8 @@ func (s *Server) enableServerHandler() gin.HandlerFunc {
 
 		result := types.EnableDisableServerResult{
 			Name:            name,
-			ToolsAffected:   tools,
-			PromptsAffected: prompts,
+			ToolsAffected:   localNames(c, tools),
+			PromptsAffected: localNames(c, prompts),
 		}
 		c.JSON(http.StatusOK, result)
 	}
@@ -257,7 +392,7 @@ func (s *Server) disableServerHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		name := c.Param("name")
 
-		tools, prompts, err := s.mcpService.DisableMcpServer(name)
+		tools, prompts, err := s.mcpService.DisableMcpServer(qualifiedName(c, name))
 		if err != nil {
 			handleServiceError(c, err)
 			return
@@ -265,13 +400,43 @@ func (s *Server) disableServerHandler() gin.HandlerFunc {
 
 		result := types.EnableDisableServerResult{
 			Name:            name,
-			ToolsAffected:   tools,
-			PromptsAffected: prompts,
+			ToolsAffected:   localNames(c, tools),
+			PromptsAffected: localNames(c, prompts),
 		}
 		c.JSON(http.StatusOK, result)
 	}
 }
 
+// setServerLogLevelHandler changes the minimum level of the log messages that an MCP server sends to mcpjungle.
+// It returns the updated server.
+func (s *Server) setServerLogLevelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := qualifiedName(c, c.Param("name"))
+
+		var input types.SetServerLogLevelInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		previous := s.serverSnapshot(name)
+		if err := s.mcpService.SetMcpServerLogLevel(name, input.Level); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		s.recordRevision(model.RevisionEntityServer, name, model.RevisionActionUpdated, requestAuthor(c), previous, s.serverSnapshot(name))
+
+		record, err := s.mcpService.GetMcpServer(name)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		server := mcpServerFromRecord(record)
+		server.Name = localName(c, record.Name)
+		c.JSON(http.StatusOK, server)
+	}
+}
+
 // getServerConfigsHandler returns the configurations of all registered MCP servers.
 // This is different from listServersHandler because it returns the complete configuration of each server
 // used to register them, including potentially sensitive information.
@@ -284,74 +449,91 @@ func (s *Server) getServerConfigsHandler() gin.HandlerFunc {
 			return
 		}
 
+		records = slices.DeleteFunc(records, func(record model.McpServer) bool { return !inScope(c, record.Name) })
 		servers := make([]*types.RegisterServerInput, len(records))
-
-		for i, record := range records {
-			servers[i] = &types.RegisterServerInput{
-				Name:        record.Name,
-				Transport:   string(record.Transport),
-				Description: record.Description,
-				SessionMode: string(record.SessionMode),
+		for i := range records {
+			conf, err := s.serverConfigFromRecord(&records[i])
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
 			}
+			conf.Name = localName(c, conf.Name)
+			servers[i] = conf
+		}
 
-			switch record.Transport {
-			case types.TransportStreamableHTTP:
-				conf, err := record.GetStreamableHTTPConfig()
-				if err != nil {
-					c.JSON(
-						http.StatusInternalServerError,
-						gin.H{
-							"error": fmt.Sprintf("Error getting streamable HTTP config for server %s: %v", record.Name, err),
-						},
-					)
-					return
-				}
-				servers[i].URL = conf.URL
-				servers[i].BearerToken = conf.BearerToken
-				servers[i].Headers = conf.Headers
-			case types.TransportStdio:
-				conf, err := record.GetStdioConfig()
-				if err != nil {
-					c.JSON(
-						http.StatusInternalServerError,
-						gin.H{
-							"error": fmt.Sprintf("Error getting stdio config for server %s: %v", record.Name, err),
-						},
-					)
-					return
-				}
-				servers[i].Command = conf.Command
-				servers[i].Args = conf.Args
-				servers[i].Env = conf.Env
-			default:
-				// transport is SSE
-				conf, err := record.GetSSEConfig()
-				if err != nil {
-					c.JSON(
-						http.StatusInternalServerError,
-						gin.H{
-							"error": fmt.Sprintf("Error getting SSE config for server %s: %v", record.Name, err),
-						},
-					)
-					return
-				}
-				servers[i].URL = conf.URL
-				servers[i].BearerToken = conf.BearerToken
-			}
+		c.JSON(http.StatusOK, servers)
+	}
+}
 
-			if oauthToken, err := s.mcpService.GetUpstreamOAuthToken(record.Name); err == nil {
-				servers[i].OAuthRedirectURI = oauthToken.RedirectURI
-				servers[i].OAuthClientID = oauthToken.ClientID
-				servers[i].OAuthClientSecret = oauthToken.ClientSecret
-				scopes, scopeErr := mcp.ScopesFromJSONForAPI(oauthToken.Scopes)
-				if scopeErr == nil {
-					servers[i].OAuthScopes = scopes
-				}
-			}
+// serverConfigFromRecord converts a registered MCP server back into the configuration
+// that can be used to register it again, including its upstream OAuth client settings.
+func (s *Server) serverConfigFromRecord(record *model.McpServer) (*types.RegisterServerInput, error) {
+	conf := &types.RegisterServerInput{
+		Name:        record.Name,
+		Transport:   string(record.Transport),
+		Description: record.Description,
+		SessionMode: string(record.SessionMode),
+	}
+	labels, err := record.GetLabels()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels of server %s: %v", record.Name, err)
+	}
+	if len(labels) > 0 {
+		conf.Labels = labels
+	}
+	overrides, err := record.GetToolOverrides()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool overrides of server %s: %v", record.Name, err)
+	}
+	if len(overrides) > 0 {
+		conf.ToolOverrides = overrides
+	}
+	allowedRoots, err := record.GetAllowedRoots()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allowed roots of server %s: %v", record.Name, err)
+	}
+	if len(allowedRoots) > 0 {
+		conf.AllowedRoots = allowedRoots
+	}
+	conf.LogLevel = record.LogLevel
+
+	switch record.Transport {
+	case types.TransportStreamableHTTP:
+		httpConf, err := record.GetStreamableHTTPConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get streamable HTTP config for server %s: %v", record.Name, err)
+		}
+		conf.URL = httpConf.URL
+		conf.BearerToken = httpConf.BearerToken
+		conf.Headers = httpConf.Headers
+	case types.TransportStdio:
+		stdioConf, err := record.GetStdioConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stdio config for server %s: %v", record.Name, err)
 		}
+		conf.Command = stdioConf.Command
+		conf.Args = stdioConf.Args
+		conf.Env = stdioConf.Env
+	default:
+		// transport is SSE
+		sseConf, err := record.GetSSEConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SSE config for server %s: %v", record.Name, err)
+		}
+		conf.URL = sseConf.URL
+		conf.BearerToken = sseConf.BearerToken
+	}
 
-		c.JSON(http.StatusOK, servers)
+	if oauthToken, err := s.mcpService.GetUpstreamOAuthToken(record.Name); err == nil {
+		conf.OAuthRedirectURI = oauthToken.RedirectURI
+		conf.OAuthClientID = oauthToken.ClientID
+		conf.OAuthClientSecret = oauthToken.ClientSecret
+		scopes, scopeErr := mcp.ScopesFromJSONForAPI(oauthToken.Scopes)
+		if scopeErr == nil {
+			conf.OAuthScopes = scopes
+		}
 	}
+	return conf, nil
 }
 
 func createServerModelFromInput(input *types.RegisterServerInput) (*model.McpServer, error) {
@@ -365,9 +547,10 @@ func createServerModelFromInput(input *types.RegisterServerInput) (*model.McpSer
 		return nil, err
 	}
 
+	var server *model.McpServer
 	switch transport {
 	case types.TransportStreamableHTTP:
-		server, err := model.NewStreamableHTTPServer(
+		server, err = model.NewStreamableHTTPServer(
 			input.Name,
 			input.Description,
 			input.URL,
@@ -378,9 +561,8 @@ func createServerModelFromInput(input *types.RegisterServerInput) (*model.McpSer
 		if err != nil {
 			return nil, fmt.Errorf("error creating streamable http server: %v", err)
 		}
-		return server, nil
 	case types.TransportStdio:
-		server, err := model.NewStdioServer(
+		server, err = model.NewStdioServer(
 			input.Name,
 			input.Description,
 			input.Command,
@@ -391,9 +573,8 @@ func createServerModelFromInput(input *types.RegisterServerInput) (*model.McpSer
 		if err != nil {
 			return nil, fmt.Errorf("error creating stdio server: %v", err)
 		}
-		return server, nil
 	default:
-		server, err := model.NewSSEServer(
+		server, err = model.NewSSEServer(
 			input.Name,
 			input.Description,
 			input.URL,
@@ -403,6 +584,19 @@ func createServerModelFromInput(input *types.RegisterServerInput) (*model.McpSer
 		if err != nil {
 			return nil, fmt.Errorf("error creating SSE server: %v", err)
 		}
-		return server, nil
 	}
+
+	if err := server.SetLabels(input.Labels); err != nil {
+		return nil, fmt.Errorf("invalid labels: %v", err)
+	}
+	if err := server.SetToolOverrides(input.ToolOverrides); err != nil {
+		return nil, fmt.Errorf("invalid tool overrides: %v", err)
+	}
+	if err := server.SetAllowedRoots(input.AllowedRoots); err != nil {
+		return nil, fmt.Errorf("invalid allowed roots: %v", err)
+	}
+	if err := server.SetLogLevel(input.LogLevel); err != nil {
+		return nil, fmt.Errorf("invalid log level: %v", err)
+	}
+	return server, nil
 }
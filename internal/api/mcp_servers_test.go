@@ -1,11 +1,14 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/mcp"
 	mcpserver "github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	mcpSvc "github.com/mcpjungle/mcpjungle/internal/service/mcp"
@@ -292,11 +295,9 @@ func TestDeregisterServerHandler_NotFound(t *testing.T) {
 	defer setup.Cleanup()
 
 	mcpProxy := mcpserver.NewMCPServer("test", "0.0.1")
-	sseMcpProxy := mcpserver.NewMCPServer("test-sse", "0.0.1")
 	svc, err := mcpSvc.NewMCPService(&mcpSvc.ServiceConfig{
 		DB:                      setup.DB,
 		McpProxyServer:          mcpProxy,
-		SseMcpProxyServer:       sseMcpProxy,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 5,
 	})
@@ -315,3 +316,106 @@ func TestDeregisterServerHandler_NotFound(t *testing.T) {
 	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
 	testhelpers.AssertStringContains(t, w.Body.String(), "not found")
 }
+
+// newTestUpstream starts a streamable http MCP server that provides the given tools.
+func newTestUpstream(t *testing.T, tools ...string) *httptest.Server {
+	t.Helper()
+	upstream := mcpserver.NewMCPServer("upstream", "0.0.1", mcpserver.WithToolCapabilities(true))
+	for _, name := range tools {
+		upstream.AddTool(
+			mcp.NewTool(name),
+			func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("ok"), nil
+			},
+		)
+	}
+	httpServer := httptest.NewServer(mcpserver.NewStreamableHTTPServer(upstream))
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func TestUpdateServerHandler(t *testing.T) {
+	router, db := setupRevisionServer(t)
+
+	oldUpstream := newTestUpstream(t, "search", "fetch")
+	srv, err := model.NewStreamableHTTPServer("remote", "", oldUpstream.URL, "", nil, "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.Create(srv).Error)
+	for _, name := range []string{"search", "fetch"} {
+		tool := model.Tool{ServerID: srv.ID, Name: name, InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		testhelpers.AssertNoError(t, db.Create(&tool).Error)
+	}
+
+	newUpstream := newTestUpstream(t, "search", "summarize")
+	w := serveJSON(t, router, http.MethodPut, "/servers/remote", `{
+		"transport": "streamable_http",
+		"url": "`+newUpstream.URL+`",
+		"bearer_token": "rotated"
+	}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	var result types.UpdateServerResult
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	testhelpers.AssertEqual(t, newUpstream.URL, result.Server.URL)
+	testhelpers.AssertEqual(t, 2, len(result.ConfigChanges))
+	testhelpers.AssertEqual(t, "bearer_token", result.ConfigChanges[0])
+	testhelpers.AssertEqual(t, "url", result.ConfigChanges[1])
+	testhelpers.AssertEqual(t, "remote__summarize", result.Tools.Added[0])
+	testhelpers.AssertEqual(t, "remote__fetch", result.Tools.Removed[0])
+
+	// the previous configuration is kept as baseline revision before the update
+	var revisions []types.Revision
+	w = serveJSON(t, router, http.MethodGet, "/servers/remote/revisions", "")
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &revisions))
+	testhelpers.AssertEqual(t, 2, len(revisions))
+	testhelpers.AssertEqual(t, "baseline", revisions[0].Action)
+	testhelpers.AssertEqual(t, "updated", revisions[1].Action)
+
+	w = serveJSON(t, router, http.MethodPut, "/servers/remote", `{"name":"other","transport":"streamable_http","url":"http://x"}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	w = serveJSON(t, router, http.MethodPut, "/servers/missing", `{"transport":"streamable_http","url":"`+newUpstream.URL+`"}`)
+	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
+}
+
+func TestValidateServerHandler(t *testing.T) {
+	router, db := setupRevisionServer(t)
+	upstream := newTestUpstream(t, "search")
+
+	w := serveJSON(t, router, http.MethodPost, "/servers/validate", `{
+		"name": "remote",
+		"transport": "streamable_http",
+		"url": "`+upstream.URL+`"
+	}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	var report types.ServerValidationReport
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	testhelpers.AssertTrue(t, report.Valid, "expected a valid report")
+	testhelpers.AssertEqual(t, "upstream", report.ServerInfo.Name)
+	testhelpers.AssertEqual(t, 1, len(report.Tools))
+	testhelpers.AssertEqual(t, "remote__search", report.Tools[0].Name)
+
+	var count int64
+	testhelpers.AssertNoError(t, db.Model(&model.McpServer{}).Where("name = ?", "remote").Count(&count).Error)
+	testhelpers.AssertEqual(t, int64(0), count)
+
+	// the "calc" server is already registered by the test setup
+	body := `{"name":"calc","transport":"streamable_http","url":"` + upstream.URL + `"}`
+	w = serveJSON(t, router, http.MethodPost, "/servers/validate", body)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	report = types.ServerValidationReport{}
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	testhelpers.AssertTrue(t, !report.Valid, "expected an invalid report for an existing server")
+
+	w = serveJSON(t, router, http.MethodPost, "/servers/validate?force=true", body)
+	report = types.ServerValidationReport{}
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	testhelpers.AssertTrue(t, report.Valid, "expected a valid report when forcing the replacement")
+
+	w = serveJSON(t, router, http.MethodPost, "/servers/validate?force=maybe", body)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	w = serveJSON(t, router, http.MethodPost, "/servers/validate", `{"name":"remote","transport":"carrier_pigeon"}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+)
+
+// mcpProxyFunc returns the proxy MCP server that serves the requests sent to an MCP endpoint,
+// along with the SSE server serving them if the endpoint uses the SSE transport.
+type mcpProxyFunc func(c *gin.Context) (*server.MCPServer, *server.SSEServer, error)
+
+// subscriptionRequest is a request of an MCP client to subscribe to a resource or to unsubscribe from it.
+type subscriptionRequest struct {
+	ID     mcpgo.RequestId `json:"id"`
+	Method string          `json:"method"`
+	Params struct {
+		URI string `json:"uri"`
+	} `json:"params"`
+}
+
+// serveResourceSubscriptions is middleware for the MCP endpoints.
+// It serves the requests of MCP clients to subscribe to resources and to unsubscribe from them,
+// which the proxy MCP servers don't serve themselves, and passes all other requests on to the proxy servers.
+func (s *Server) serveResourceSubscriptions(proxyFor mcpProxyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodPost || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// most requests are tool calls and the like, which are passed on without decoding them here
+		if !bytes.Contains(body, []byte(mcp.MethodResourcesSubscribe)) &&
+			!bytes.Contains(body, []byte(mcp.MethodResourcesUnsubscribe)) {
+			c.Next()
+			return
+		}
+
+		// the MCP transports don't serve JSON-RPC batches, but subscriptions must not be mistaken for other requests
+		if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+			var batch []subscriptionRequest
+			if json.Unmarshal(body, &batch) == nil && slices.ContainsFunc(batch, isSubscriptionRequest) {
+				c.AbortWithStatusJSON(
+					http.StatusBadRequest,
+					mcpgo.NewJSONRPCError(
+						mcpgo.NewRequestId(nil), mcpgo.INVALID_REQUEST,
+						"resources/subscribe and resources/unsubscribe requests cannot be batched", nil,
+					),
+				)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		var request subscriptionRequest
+		if json.Unmarshal(body, &request) != nil || !isSubscriptionRequest(request) {
+			c.Next()
+			return
+		}
+
+		proxy, sseServer, err := proxyFor(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		sessionID := c.GetHeader(server.HeaderKeySessionID)
+		if sseServer != nil {
+			sessionID = c.Query("sessionId")
+		}
+
+		// like the MCP transports, reject the requests of unknown sessions outright
+		invalidSession := func() {
+			status := http.StatusNotFound
+			if sseServer != nil {
+				status = http.StatusBadRequest
+			}
+			c.AbortWithStatusJSON(status, mcpgo.NewJSONRPCError(request.ID, mcpgo.INVALID_PARAMS, "Invalid session ID", nil))
+		}
+		if !s.mcpService.HasProxySession(proxy, sessionID) {
+			invalidSession()
+			return
+		}
+
+		var response any = mcpgo.NewJSONRPCResultResponse(request.ID, mcpgo.EmptyResult{})
+		if err := s.updateResourceSubscription(c, proxy, sessionID, &request); errors.Is(err, mcp.ErrInvalidSession) {
+			// the session ended in the meantime
+			invalidSession()
+			return
+		} else if err != nil {
+			code := mcpgo.INTERNAL_ERROR
+			if errors.Is(err, apierrors.ErrNotFound) {
+				code = mcpgo.RESOURCE_NOT_FOUND
+			} else if errors.Is(err, apierrors.ErrInvalidInput) {
+				code = mcpgo.INVALID_PARAMS
+			}
+			response = mcpgo.NewJSONRPCError(request.ID, code, err.Error(), nil)
+		}
+
+		if sseServer == nil {
+			c.AbortWithStatusJSON(http.StatusOK, response)
+			return
+		}
+		// responses to the requests sent to an SSE endpoint are sent on the client's event stream
+		if err := sseServer.SendEventToSession(sessionID, response); err != nil {
+			invalidSession()
+			return
+		}
+		c.AbortWithStatus(http.StatusAccepted)
+	}
+}
+
+// updateResourceSubscription subscribes the MCP client session to the requested resource or unsubscribes it.
+// The resources of a tool group can only be subscribed to through the group's MCP endpoints.
+func (s *Server) updateResourceSubscription(
+	c *gin.Context, proxy *server.MCPServer, sessionID string, request *subscriptionRequest,
+) error {
+	uri := request.Params.URI
+	if request.Method == mcp.MethodResourcesUnsubscribe {
+		return s.mcpService.UnsubscribeResource(proxy, sessionID, uri)
+	}
+
+	if c.Param("name") != "" {
+		groupName := proxyGroupName(c)
+		resources, err := s.toolGroupService.ResolveEffectiveResources(groupName)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(resources, uri) {
+			return fmt.Errorf("resource %s not found in tool group %s: %w", uri, groupName, apierrors.ErrNotFound)
+		}
+	}
+	return s.mcpService.SubscribeResource(c.Request.Context(), proxy, sessionID, uri)
+}
+
+// isSubscriptionRequest reports whether the request subscribes to a resource or unsubscribes from it.
+func isSubscriptionRequest(request subscriptionRequest) bool {
+	return request.Method == mcp.MethodResourcesSubscribe || request.Method == mcp.MethodResourcesUnsubscribe
+}
+
+// globalProxy returns an mcpProxyFunc that always returns the given proxy MCP server and SSE server.
+func globalProxy(proxy *server.MCPServer, sseServer *server.SSEServer) mcpProxyFunc {
+	return func(*gin.Context) (*server.MCPServer, *server.SSEServer, error) {
+		return proxy, sseServer, nil
+	}
+}
+
+// groupProxy returns the proxy MCP server of the tool group that a request to a streamable HTTP endpoint is for.
+func (s *Server) groupProxy(c *gin.Context) (*server.MCPServer, *server.SSEServer, error) {
+	groupName := proxyGroupName(c)
+	proxy, exists := s.toolGroupService.GetToolGroupMCPServer(groupName)
+	if !exists {
+		return nil, nil, fmt.Errorf("tool group not found: %s", groupName)
+	}
+	return proxy, nil, nil
+}
+
+// groupSseProxy returns the proxy MCP server of the tool group that a request to an SSE endpoint is for,
+// along with the group's SSE server.
+func (s *Server) groupSseProxy(c *gin.Context) (*server.MCPServer, *server.SSEServer, error) {
+	groupName := proxyGroupName(c)
+	proxy, exists := s.toolGroupService.GetToolGroupMCPServer(groupName)
+	if !exists {
+		return nil, nil, fmt.Errorf("tool group not found: %s", groupName)
+	}
+	sseServer, err := s.getGroupSseServer(groupName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proxy, sseServer, nil
+}
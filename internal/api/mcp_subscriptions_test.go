@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+// testSession is an MCP client session registered with a proxy MCP server.
+type testSession struct {
+	id            string
+	notifications chan mcpgo.JSONRPCNotification
+}
+
+func (s *testSession) Initialize()       {}
+func (s *testSession) Initialized() bool { return true }
+func (s *testSession) SessionID() string { return s.id }
+func (s *testSession) NotificationChannel() chan<- mcpgo.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestServeResourceSubscriptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	s := setupInvalidInputServer(t)
+
+	proxy := mcpserver.NewMCPServer("test", "0.0.1", mcpserver.WithHooks(&mcpserver.Hooks{}))
+	s.mcpService.AttachProxyServer(proxy)
+	session := &testSession{id: "session-1", notifications: make(chan mcpgo.JSONRPCNotification, 1)}
+	testhelpers.AssertNoError(t, proxy.RegisterSession(context.Background(), session))
+	router := gin.New()
+	router.POST(
+		"/mcp",
+		func(c *gin.Context) {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), "mode", model.ModeDev))
+		},
+		s.serveResourceSubscriptions(globalProxy(proxy, nil)),
+		func(c *gin.Context) {
+			c.String(http.StatusTeapot, "served by the proxy")
+		},
+	)
+
+	cases := []struct {
+		name       string
+		body       string
+		sessionID  string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "other requests are served by the proxy",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+			wantStatus: http.StatusTeapot,
+			wantBody:   "served by the proxy",
+		},
+		{
+			name:       "requests mentioning subscriptions are served by the proxy",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"docs__search","arguments":{"q":"resources/subscribe"}}}`,
+			wantStatus: http.StatusTeapot,
+			wantBody:   "served by the proxy",
+		},
+		{
+			name:       "unknown resource",
+			body:       `{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"mcpj://res/docs/cmVhZG1l"}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"code":-32002`,
+		},
+		{
+			name:       "unsubscribing from a resource that isn't subscribed to",
+			body:       `{"jsonrpc":"2.0","id":3,"method":"resources/unsubscribe","params":{"uri":"mcpj://res/docs/cmVhZG1l"}}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"result":{}`,
+		},
+		{
+			name:       "unknown session",
+			body:       `{"jsonrpc":"2.0","id":4,"method":"resources/subscribe","params":{"uri":"mcpj://res/docs/cmVhZG1l"}}`,
+			sessionID:  "session-2",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "Invalid session ID",
+		},
+		{
+			name:       "unknown session unsubscribing",
+			body:       `{"jsonrpc":"2.0","id":5,"method":"resources/unsubscribe","params":{"uri":"mcpj://res/docs/cmVhZG1l"}}`,
+			sessionID:  "session-2",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "Invalid session ID",
+		},
+		{
+			name: "batched subscription",
+			body: `[{"jsonrpc":"2.0","id":6,"method":"resources/list"},` +
+				`{"jsonrpc":"2.0","id":7,"method":"resources/subscribe","params":{"uri":"mcpj://res/docs/cmVhZG1l"}}]`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"code":-32600`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(tc.body))
+			sessionID := tc.sessionID
+			if sessionID == "" {
+				sessionID = session.id
+			}
+			req.Header.Set(mcpserver.HeaderKeySessionID, sessionID)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			testhelpers.AssertEqual(t, tc.wantStatus, w.Code)
+			testhelpers.AssertStringContains(t, w.Body.String(), tc.wantBody)
+		})
+	}
+}
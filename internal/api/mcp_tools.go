@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
@@ -22,12 +23,18 @@ func (s *Server) listToolsHandler() gin.HandlerFunc {
 			tools, err = s.mcpService.ListTools()
 		} else {
 			// server specified, list tools for that server
-			tools, err = s.mcpService.ListToolsByServer(server)
+			tools, err = s.mcpService.ListToolsByServer(qualifiedName(c, server))
 		}
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+
+		// only the tools of the MCP servers in the request's namespace are listed
+		tools = slices.DeleteFunc(tools, func(t model.Tool) bool { return !inScope(c, t.Name) })
+		for i := range tools {
+			tools[i].Name = localName(c, tools[i].Name)
+		}
 		c.JSON(http.StatusOK, tools)
 	}
 }
@@ -58,7 +65,7 @@ func (s *Server) invokeToolHandler() gin.HandlerFunc {
 		// remove name from args since it was an input for the api, not for the tool
 		delete(args, "name")
 
-		resp, err := s.mcpService.InvokeTool(c, name, args)
+		resp, err := s.mcpService.InvokeTool(c, qualifiedName(c, name), args)
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to invoke tool: %w", err))
 			return
@@ -79,11 +86,12 @@ func (s *Server) getToolHandler() gin.HandlerFunc {
 			return
 		}
 
-		tool, err := s.mcpService.GetTool(name)
+		tool, err := s.mcpService.GetTool(qualifiedName(c, name))
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to get tool: %w", err))
 			return
 		}
+		tool.Name = localName(c, tool.Name)
 
 		c.JSON(http.StatusOK, tool)
 	}
@@ -97,12 +105,12 @@ func (s *Server) enableToolsHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'entity' query parameter"})
 			return
 		}
-		enabledTools, err := s.mcpService.EnableTools(entity)
+		enabledTools, err := s.mcpService.EnableTools(qualifiedName(c, entity))
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to enable tool(s): %w", err))
 			return
 		}
-		c.JSON(http.StatusOK, enabledTools)
+		c.JSON(http.StatusOK, localNames(c, enabledTools))
 	}
 }
 
@@ -114,11 +122,11 @@ func (s *Server) disableToolsHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'entity' query parameter"})
 			return
 		}
-		disabledTools, err := s.mcpService.DisableTools(entity)
+		disabledTools, err := s.mcpService.DisableTools(qualifiedName(c, entity))
 		if err != nil {
 			handleServiceError(c, fmt.Errorf("failed to disable tool(s): %w", err))
 			return
 		}
-		c.JSON(http.StatusOK, disabledTools)
+		c.JSON(http.StatusOK, localNames(c, disabledTools))
 	}
 }
@@ -199,8 +199,57 @@ func (s *Server) checkAuthForMcpProxyAccess() gin.HandlerFunc {
 
 		// inject the authenticated MCP client in context for the proxy to use
 		ctx = context.WithValue(c.Request.Context(), "client", client)
+		if client.Namespace != "" {
+			// a client in a namespace can only ever access the MCP servers of its namespace
+			ctx = context.WithValue(ctx, "namespace", client.Namespace)
+		}
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
 	}
 }
+
+// denyGroupBoundMcpClients is middleware for the global and namespaced MCP proxy endpoints.
+// It rejects MCP clients that are bound to tool groups, since they may only connect to the endpoints of their groups.
+// It assumes that checkAuthForMcpProxyAccess middleware has already run.
+func (s *Server) denyGroupBoundMcpClients() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client, ok := c.Request.Context().Value("client").(*model.McpClient)
+		if ok && client.IsBoundToGroups() {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				gin.H{"error": "MCP client is bound to tool groups and can only access their MCP endpoints"},
+			)
+			return
+		}
+		c.Next()
+	}
+}
+
+// authorizeMcpClientGroupAccess is middleware for the MCP endpoints of tool groups.
+// It rejects MCP clients that are bound to other tool groups.
+// For clients bound to the requested group, the group grants access to the MCP servers of its entities,
+// so it marks the request with the group's name for the proxy to use.
+// It assumes that checkAuthForMcpProxyAccess middleware has already run.
+func (s *Server) authorizeMcpClientGroupAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		client, ok := c.Request.Context().Value("client").(*model.McpClient)
+		if !ok {
+			c.Next()
+			return
+		}
+		groupName := proxyGroupName(c)
+		if !client.CheckHasGroupAccess(groupName) {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				gin.H{"error": fmt.Sprintf("MCP client is not authorized to access tool group %s", c.Param("name"))},
+			)
+			return
+		}
+		if client.IsBoundToGroups() {
+			ctx := context.WithValue(c.Request.Context(), "group", groupName)
+			c.Request = c.Request.WithContext(ctx)
+		}
+		c.Next()
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -435,6 +437,52 @@ func TestCheckAuthForMcpProxyAccess(t *testing.T) {
 	}
 }
 
+func TestGroupBoundMcpClientAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	bound := &model.McpClient{Name: "bound", AllowedGroups: datatypes.JSON(`["math"]`)}
+	unbound := &model.McpClient{Name: "unbound", AllowList: datatypes.JSON(`["*"]`)}
+
+	tests := []struct {
+		name           string
+		client         *model.McpClient
+		path           string
+		expectedStatus int
+		expectedGroup  string
+	}{
+		{"bound client denied global proxy", bound, "/mcp", http.StatusForbidden, ""},
+		{"unbound client allowed global proxy", unbound, "/mcp", http.StatusOK, ""},
+		{"bound client allowed its group", bound, "/groups/math/mcp", http.StatusOK, "math"},
+		{"bound client denied other group", bound, "/groups/docs/mcp", http.StatusForbidden, ""},
+		{"unbound client allowed any group", unbound, "/groups/docs/mcp", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				ctx := context.WithValue(c.Request.Context(), "client", tt.client)
+				c.Request = c.Request.WithContext(ctx)
+			})
+			server := &Server{}
+
+			var gotGroup string
+			handler := func(c *gin.Context) {
+				gotGroup, _ = c.Request.Context().Value("group").(string)
+				c.Status(http.StatusOK)
+			}
+			router.GET("/mcp", server.denyGroupBoundMcpClients(), handler)
+			router.GET("/groups/:name/mcp", server.authorizeMcpClientGroupAccess(), handler)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			testhelpers.AssertEqual(t, tt.expectedStatus, w.Code)
+			testhelpers.AssertEqual(t, tt.expectedGroup, gotGroup)
+		})
+	}
+}
+
 func TestMiddlewareIntegration(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	setup := testhelpers.SetupTestDB(t)
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// scopeAPINamespace is middleware that determines the namespace an API request is scoped to.
+// The namespace is taken from the ":ns" path parameter of namespaced routes.
+// Users that belong to a namespace are always scoped to it, even when they use the global routes,
+// and are not allowed to access any other namespace.
+// It assumes that verifyUserAuthForAPIAccess middleware has already run and set the user in context.
+func (s *Server) scopeAPINamespace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ns := c.Param("ns")
+
+		if u, ok := c.Get("user"); ok {
+			if user, ok := u.(*model.User); ok && user.Namespace != "" {
+				if ns == "" {
+					ns = user.Namespace
+				} else if ns != user.Namespace {
+					c.AbortWithStatusJSON(
+						http.StatusForbidden,
+						gin.H{"error": fmt.Sprintf("user is not authorized to access namespace %s", ns)},
+					)
+					return
+				}
+			}
+		}
+
+		if ns != "" && !s.namespaceExists(c, ns) {
+			return
+		}
+		c.Set("namespace", ns)
+		c.Next()
+	}
+}
+
+// requireGlobalScope is middleware that rejects API requests scoped to a namespace.
+// It is used for operations that affect the whole mcpjungle server, like taking backups.
+func (s *Server) requireGlobalScope() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ns := scopedNamespace(c); ns != "" {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				gin.H{"error": fmt.Sprintf("this request is not allowed in namespace %s", ns)},
+			)
+			return
+		}
+		c.Next()
+	}
+}
+
+// scopeMcpProxyNamespace is middleware for the namespaced MCP proxy endpoints.
+// It restricts the proxy request to the MCP servers in the namespace from the ":ns" path parameter.
+// MCP clients that belong to a namespace can only access the endpoints of their own namespace.
+// It assumes that checkAuthForMcpProxyAccess middleware has already run.
+func (s *Server) scopeMcpProxyNamespace() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ns := c.Param("ns")
+		if !s.namespaceExists(c, ns) {
+			return
+		}
+
+		client, ok := c.Request.Context().Value("client").(*model.McpClient)
+		if ok && client.Namespace != "" && client.Namespace != ns {
+			c.AbortWithStatusJSON(
+				http.StatusForbidden,
+				gin.H{"error": fmt.Sprintf("MCP client is not authorized to access namespace %s", ns)},
+			)
+			return
+		}
+
+		ctx := context.WithValue(c.Request.Context(), "namespace", ns)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// namespaceExists checks that the namespace exists and aborts the request if it doesn't.
+func (s *Server) namespaceExists(c *gin.Context, ns string) bool {
+	if _, err := s.namespaceService.GetNamespace(ns); err != nil {
+		if errors.Is(err, apierrors.ErrNotFound) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return false
+	}
+	return true
+}
+
+// namespacePathPrefix returns the path prefix of the MCP proxy endpoints of the given namespace.
+// For the empty namespace, it is the prefix of the global endpoints.
+func namespacePathPrefix(ns string) string {
+	if ns == "" {
+		return V0PathPrefix
+	}
+	return V0PathPrefix + "/ns/" + ns
+}
+
+// scopedNamespace returns the namespace the API request is scoped to.
+// It is empty if the request is not scoped to any namespace.
+func scopedNamespace(c *gin.Context) string {
+	return c.GetString("namespace")
+}
+
+// qualifiedName returns the canonical name of an MCP server, tool group, MCP client, tool or prompt
+// given its name within the namespace the API request is scoped to.
+func qualifiedName(c *gin.Context, name string) string {
+	return model.QualifiedName(scopedNamespace(c), name)
+}
+
+// localName returns the name of an entity within the namespace the API request is scoped to,
+// given its canonical name.
+func localName(c *gin.Context, name string) string {
+	return model.LocalName(name, scopedNamespace(c))
+}
+
+// localNames returns the names of the entities within the namespace the API request is scoped to.
+func localNames(c *gin.Context, names []string) []string {
+	if scopedNamespace(c) == "" {
+		return names
+	}
+	local := make([]string, len(names))
+	for i, name := range names {
+		local[i] = localName(c, name)
+	}
+	return local
+}
+
+// inScope returns true if the entity with the given canonical name can be accessed by the API request,
+// ie, it belongs to the namespace the request is scoped to.
+func inScope(c *gin.Context, name string) bool {
+	return model.InNamespace(name, scopedNamespace(c))
+}
+
+func (s *Server) createNamespaceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input types.Namespace
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		ns := &model.Namespace{Name: input.Name, Description: input.Description}
+		if err := s.namespaceService.CreateNamespace(ns); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, types.Namespace{Name: ns.Name, Description: ns.Description})
+	}
+}
+
+func (s *Server) listNamespacesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		records, err := s.namespaceService.ListNamespaces()
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		namespaces := make([]types.Namespace, len(records))
+		for i, ns := range records {
+			namespaces[i] = types.Namespace{Name: ns.Name, Description: ns.Description}
+		}
+		c.JSON(http.StatusOK, namespaces)
+	}
+}
+
+func (s *Server) deleteNamespaceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.namespaceService.DeleteNamespace(c.Param("name")); err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
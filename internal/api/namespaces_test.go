@@ -0,0 +1,210 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func newNamespaceTestServer(t *testing.T) (*Server, func()) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	setup := testhelpers.SetupTestDB(t)
+
+	s := &Server{namespaceService: namespace.NewNamespaceService(setup.DB)}
+	testhelpers.AssertNoError(t, s.namespaceService.CreateNamespace(&model.Namespace{Name: "team-a"}))
+	testhelpers.AssertNoError(t, s.namespaceService.CreateNamespace(&model.Namespace{Name: "team-b"}))
+	return s, setup.Cleanup
+}
+
+func TestScopeAPINamespace(t *testing.T) {
+	s, cleanup := newNamespaceTestServer(t)
+	defer cleanup()
+
+	tests := []struct {
+		name          string
+		path          string
+		user          *model.User
+		wantStatus    int
+		wantNamespace string
+	}{
+		{
+			name:       "global route without user",
+			path:       "/servers",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:          "namespaced route for global admin",
+			path:          "/ns/team-a/servers",
+			user:          &model.User{Username: "admin", Role: types.UserRoleAdmin},
+			wantStatus:    http.StatusOK,
+			wantNamespace: "team-a",
+		},
+		{
+			name:          "namespaced user is scoped to its namespace on global routes",
+			path:          "/servers",
+			user:          &model.User{Username: "team-a.alice", Role: types.UserRoleUser, Namespace: "team-a"},
+			wantStatus:    http.StatusOK,
+			wantNamespace: "team-a",
+		},
+		{
+			name:       "namespaced user cannot access other namespaces",
+			path:       "/ns/team-b/servers",
+			user:       &model.User{Username: "team-a.alice", Role: types.UserRoleUser, Namespace: "team-a"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "unknown namespace",
+			path:       "/ns/team-c/servers",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			setUser := func(c *gin.Context) {
+				if tt.user != nil {
+					c.Set("user", tt.user)
+				}
+			}
+			handler := func(c *gin.Context) {
+				c.String(http.StatusOK, scopedNamespace(c))
+			}
+			router.GET("/servers", setUser, s.scopeAPINamespace(), handler)
+			router.GET("/ns/:ns/servers", setUser, s.scopeAPINamespace(), handler)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			testhelpers.AssertEqual(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				testhelpers.AssertEqual(t, tt.wantNamespace, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireGlobalScope(t *testing.T) {
+	s, cleanup := newNamespaceTestServer(t)
+	defer cleanup()
+
+	router := gin.New()
+	handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/backup", s.scopeAPINamespace(), s.requireGlobalScope(), handler)
+	router.GET("/ns/:ns/backup", s.scopeAPINamespace(), s.requireGlobalScope(), handler)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/backup", nil))
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ns/team-a/backup", nil))
+	testhelpers.AssertEqual(t, http.StatusForbidden, w.Code)
+}
+
+func TestScopeMcpProxyNamespace(t *testing.T) {
+	s, cleanup := newNamespaceTestServer(t)
+	defer cleanup()
+
+	tests := []struct {
+		name       string
+		path       string
+		client     *model.McpClient
+		wantStatus int
+	}{
+		{name: "no client", path: "/ns/team-a/mcp", wantStatus: http.StatusOK},
+		{
+			name:       "client of the namespace",
+			path:       "/ns/team-a/mcp",
+			client:     &model.McpClient{Name: "team-a.claude", Namespace: "team-a"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "global client",
+			path:       "/ns/team-a/mcp",
+			client:     &model.McpClient{Name: "claude"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "client of another namespace",
+			path:       "/ns/team-b/mcp",
+			client:     &model.McpClient{Name: "team-a.claude", Namespace: "team-a"},
+			wantStatus: http.StatusForbidden,
+		},
+		{name: "unknown namespace", path: "/ns/team-c/mcp", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			setClient := func(c *gin.Context) {
+				if tt.client != nil {
+					ctx := context.WithValue(c.Request.Context(), "client", tt.client)
+					c.Request = c.Request.WithContext(ctx)
+				}
+			}
+			router.POST("/ns/:ns/mcp", setClient, s.scopeMcpProxyNamespace(), func(c *gin.Context) {
+				ns, _ := c.Request.Context().Value("namespace").(string)
+				c.String(http.StatusOK, ns)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+
+			testhelpers.AssertEqual(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				testhelpers.AssertEqual(t, "team-a", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNamespaceHandlers(t *testing.T) {
+	s, cleanup := newNamespaceTestServer(t)
+	defer cleanup()
+
+	router := gin.New()
+	router.POST("/namespaces", s.createNamespaceHandler())
+	router.GET("/namespaces", s.listNamespacesHandler())
+	router.DELETE("/namespaces/:name", s.deleteNamespaceHandler())
+
+	req := httptest.NewRequest(http.MethodPost, "/namespaces",
+		strings.NewReader(`{"name":"team-c","description":"Team C"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	testhelpers.AssertEqual(t, http.StatusCreated, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/namespaces", strings.NewReader(`{"name":"team_c"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/namespaces", nil))
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var namespaces []types.Namespace
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &namespaces))
+	testhelpers.AssertEqual(t, 3, len(namespaces))
+	testhelpers.AssertEqual(t, "team-c", namespaces[2].Name)
+	testhelpers.AssertEqual(t, "Team C", namespaces[2].Description)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/namespaces/team-c", nil))
+	testhelpers.AssertEqual(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/namespaces/team-c", nil))
+	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
+}
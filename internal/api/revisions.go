@@ -0,0 +1,331 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// dashboardAuthor is recorded as the author of changes made through the dashboard,
+// which is only available in development mode and has no notion of users.
+const dashboardAuthor = "dashboard"
+
+// requestAuthor returns the username of the user making the request.
+// It returns an empty string if the request is not authenticated, eg- in development mode.
+func requestAuthor(c *gin.Context) string {
+	authenticatedUser, exists := c.Get("user")
+	if !exists {
+		return ""
+	}
+	if u, ok := authenticatedUser.(*model.User); ok {
+		return u.Username
+	}
+	return ""
+}
+
+// recordRevision records a change to a server or tool group in its revision history.
+// previous is the configuration of the entity before the change (nil if it did not exist),
+// it becomes the baseline revision of entities that have no history yet.
+// The change has already been applied at this point, so failing to record it is only logged.
+func (s *Server) recordRevision(
+	entityType model.RevisionEntityType,
+	name string,
+	action model.RevisionAction,
+	author string,
+	previous, snapshot any,
+) *model.Revision {
+	if s.revisionService == nil {
+		return nil
+	}
+	s.recordBaseline(entityType, name, previous)
+	rev, err := s.revisionService.Record(entityType, name, action, author, snapshot)
+	if err != nil {
+		log.Printf("[WARN] failed to record %s revision of %s %s: %v", action, entityType, name, err)
+		return nil
+	}
+	return rev
+}
+
+func (s *Server) recordBaseline(entityType model.RevisionEntityType, name string, previous any) {
+	if err := s.revisionService.RecordBaseline(entityType, name, previous); err != nil {
+		log.Printf("[WARN] failed to record baseline revision of %s %s: %v", entityType, name, err)
+	}
+}
+
+// serverSnapshot returns the current configuration of a server for its revision history.
+// It returns nil if the server does not exist.
+func (s *Server) serverSnapshot(name string) *types.RegisterServerInput {
+	record, err := s.mcpService.GetMcpServer(name)
+	if err != nil {
+		return nil
+	}
+	conf, err := s.serverConfigFromRecord(record)
+	if err != nil {
+		log.Printf("[WARN] failed to read configuration of server %s for its revision history: %v", name, err)
+		return nil
+	}
+	return conf
+}
+
+// toolGroupSnapshot returns the configuration of a tool group for its revision history.
+// It returns nil if the group is nil.
+func toolGroupSnapshot(group *model.ToolGroup) *types.ToolGroup {
+	if group == nil {
+		return nil
+	}
+	snapshot := &types.ToolGroup{Name: group.Name, Description: group.Description}
+	var err error
+	if snapshot.IncludedTools, err = group.GetTools(); err != nil {
+		log.Printf("[WARN] failed to read included tools of group %s for its revision history: %v", group.Name, err)
+	}
+	if snapshot.IncludedServers, err = group.GetServers(); err != nil {
+		log.Printf("[WARN] failed to read included servers of group %s for its revision history: %v", group.Name, err)
+	}
+	if snapshot.ExcludedTools, err = group.GetExcludedTools(); err != nil {
+		log.Printf("[WARN] failed to read excluded tools of group %s for its revision history: %v", group.Name, err)
+	}
+	if err = setGroupDefinitionLists(snapshot, group); err != nil {
+		log.Printf("[WARN] failed to read the definition of group %s for its revision history: %v", group.Name, err)
+	}
+	return snapshot
+}
+
+// currentToolGroupSnapshot returns the current configuration of a tool group, or nil if it does not exist.
+func (s *Server) currentToolGroupSnapshot(name string) *types.ToolGroup {
+	group, err := s.toolGroupService.GetToolGroup(name)
+	if err != nil {
+		return nil
+	}
+	return toolGroupSnapshot(group)
+}
+
+func (s *Server) listRevisionsHandler(entityType model.RevisionEntityType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		revisions, err := s.revisionService.ListRevisions(entityType, qualifiedName(c, c.Param("name")))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		resp := make([]types.Revision, len(revisions))
+		for i, r := range revisions {
+			resp[i] = types.Revision{
+				Number:    r.Number,
+				Action:    string(r.Action),
+				Author:    r.Author,
+				Note:      r.Note,
+				CreatedAt: r.CreatedAt,
+				Snapshot:  json.RawMessage(r.Snapshot),
+			}
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// diffRevisionsHandler compares two revisions given by the "from" and "to" query parameters.
+// By default, the latest revision is compared with the one before it.
+func (s *Server) diffRevisionsHandler(entityType model.RevisionEntityType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		from, err := parseRevisionQueryParam(c, "from")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		to, err := parseRevisionQueryParam(c, "to")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		diff, err := s.revisionService.Diff(entityType, qualifiedName(c, c.Param("name")), from, to)
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+func parseRevisionQueryParam(c *gin.Context, param string) (int, error) {
+	v := c.Query(param)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s query parameter: must be a positive revision number", param)
+	}
+	return n, nil
+}
+
+// getRestorableRevision returns the revision requested to be restored by a rollback.
+// It writes an error response and returns nil if the revision cannot be restored.
+func (s *Server) getRestorableRevision(
+	c *gin.Context, entityType model.RevisionEntityType, name string, input *types.RollbackInput,
+) *model.Revision {
+	if input.Revision < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "revision must be a positive revision number"})
+		return nil
+	}
+
+	rev, err := s.revisionService.GetRevision(entityType, name, input.Revision)
+	if err != nil {
+		handleServiceError(c, err)
+		return nil
+	}
+	if rev.Action == model.RevisionActionDeleted {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("revision %d records the deletion of %s and cannot be restored", rev.Number, name),
+		})
+		return nil
+	}
+	return rev
+}
+
+// rollbackServerHandler restores the configuration of an MCP server from an earlier revision.
+// An existing server is updated in place, unless the restored configuration uses a different transport,
+// in which case the server is re-registered. Deleted servers are registered again.
+func (s *Server) rollbackServerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := qualifiedName(c, c.Param("name"))
+		var input types.RollbackInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rev := s.getRestorableRevision(c, model.RevisionEntityServer, name, &input)
+		if rev == nil {
+			return
+		}
+
+		var conf types.RegisterServerInput
+		if err := json.Unmarshal(rev.Snapshot, &conf); err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("failed to read configuration of revision %d: %v", rev.Number, err)},
+			)
+			return
+		}
+		conf.Name = name
+		if input.OAuthRedirectURI != "" {
+			conf.OAuthRedirectURI = input.OAuthRedirectURI
+		}
+
+		server, err := createServerModelFromInput(&conf)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		author := requestAuthor(c)
+		result := types.RollbackResult{Name: localName(c, name), RestoredRevision: rev.Number}
+
+		if previous := s.serverSnapshot(name); previous != nil {
+			s.recordBaseline(model.RevisionEntityServer, name, previous)
+
+			// a server whose transport did not change is updated in place,
+			// so its tools keep their enabled state and upstream OAuth tokens are kept.
+			if previous.Transport == conf.Transport {
+				if _, err := s.mcpService.UpdateMcpServer(c, server); err != nil {
+					handleServiceError(c, err)
+					return
+				}
+				if newRev := s.recordRollback(author, rev); newRev != nil {
+					result.Revision = newRev.Number
+				}
+				c.JSON(http.StatusOK, result)
+				return
+			}
+
+			if err := s.mcpService.DeregisterMcpServer(name); err != nil {
+				handleServiceError(c, err)
+				return
+			}
+		}
+
+		if err := s.mcpService.RegisterMcpServerWithOAuthSupport(c, &conf, server, true, author); err != nil {
+			var oauthErr *mcp.UpstreamOAuthAuthorizationPendingError
+			if errors.As(err, &oauthErr) {
+				// the restored configuration is only registered once the authorization is completed
+				result.AuthorizationRequired = &types.UpstreamOAuthAuthorizationRequired{
+					SessionID:        oauthErr.SessionID,
+					AuthorizationURL: oauthErr.AuthorizationURL,
+					ExpiresAt:        oauthErr.ExpiresAt,
+				}
+				c.JSON(http.StatusAccepted, result)
+				return
+			}
+			handleServiceError(c, err)
+			return
+		}
+
+		if newRev := s.recordRollback(author, rev); newRev != nil {
+			result.Revision = newRev.Number
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+// rollbackToolGroupHandler restores the configuration of a tool group from an earlier revision.
+// If the group still exists, it is updated in place without downtime, otherwise it is re-created.
+func (s *Server) rollbackToolGroupHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := qualifiedName(c, c.Param("name"))
+		var input types.RollbackInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rev := s.getRestorableRevision(c, model.RevisionEntityToolGroup, name, &input)
+		if rev == nil {
+			return
+		}
+
+		var group model.ToolGroup
+		if err := json.Unmarshal(rev.Snapshot, &group); err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("failed to read configuration of revision %d: %v", rev.Number, err)},
+			)
+			return
+		}
+		group.Name = name
+
+		existing, err := s.toolGroupService.GetToolGroup(name)
+		switch {
+		case err == nil:
+			s.recordBaseline(model.RevisionEntityToolGroup, name, toolGroupSnapshot(existing))
+			_, err = s.toolGroupService.UpdateToolGroup(name, &group)
+		case errors.Is(err, toolgroup.ErrToolGroupNotFound):
+			err = s.toolGroupService.CreateToolGroup(&group)
+		}
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		result := types.RollbackResult{Name: localName(c, name), RestoredRevision: rev.Number}
+		if newRev := s.recordRollback(requestAuthor(c), rev); newRev != nil {
+			result.Revision = newRev.Number
+		}
+		c.JSON(http.StatusOK, result)
+	}
+}
+
+func (s *Server) recordRollback(author string, restored *model.Revision) *model.Revision {
+	rev, err := s.revisionService.RecordRollback(author, restored)
+	if err != nil {
+		log.Printf("[WARN] failed to record rollback of %s %s: %v", restored.EntityType, restored.EntityName, err)
+		return nil
+	}
+	return rev
+}
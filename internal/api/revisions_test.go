@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	mcpSvc "github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/revision"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// setupRevisionServer creates a Server with real tool group and revision services,
+// backed by an in-memory DB that contains a server "calc" with the tools "sum" and "mul".
+func setupRevisionServer(t *testing.T) (*gin.Engine, *gorm.DB) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	setup := testhelpers.SetupTestDB(t)
+	t.Cleanup(setup.Cleanup)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, setup.DB.Create(srv).Error)
+	for _, name := range []string{"sum", "mul"} {
+		tool := model.Tool{ServerID: srv.ID, Name: name, InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		testhelpers.AssertNoError(t, setup.DB.Create(&tool).Error)
+	}
+
+	mcpService, err := mcpSvc.NewMCPService(&mcpSvc.ServiceConfig{
+		DB:                      setup.DB,
+		McpProxyServer:          mcpserver.NewMCPServer("test", "0.0.1"),
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 5,
+	})
+	testhelpers.AssertNoError(t, err)
+	tgSvc, err := toolgroup.NewToolGroupService(setup.DB, mcpService)
+	testhelpers.AssertNoError(t, err)
+
+	s := &Server{
+		mcpService:       mcpService,
+		toolGroupService: tgSvc,
+		revisionService:  revision.NewRevisionService(setup.DB),
+	}
+
+	router := gin.New()
+	router.POST("/tool-groups", s.createToolGroupHandler())
+	router.PUT("/tool-groups/:name", s.updateToolGroupHandler())
+	router.DELETE("/tool-groups/:name", s.deleteToolGroupHandler())
+	router.GET("/tool-groups/:name", s.getToolGroupHandler())
+	router.GET("/tool-groups/:name/revisions", s.listRevisionsHandler(model.RevisionEntityToolGroup))
+	router.GET("/tool-groups/:name/revisions/diff", s.diffRevisionsHandler(model.RevisionEntityToolGroup))
+	router.POST("/tool-groups/:name/rollback", s.rollbackToolGroupHandler())
+	router.POST("/servers/validate", s.validateServerHandler())
+	router.PUT("/servers/:name", s.updateServerHandler())
+	router.GET("/servers/:name/revisions", s.listRevisionsHandler(model.RevisionEntityServer))
+	router.POST("/servers/:name/rollback", s.rollbackServerHandler())
+	return router, setup.DB
+}
+
+func serveJSON(t *testing.T, router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestToolGroupRevisionsAndRollback(t *testing.T) {
+	router, _ := setupRevisionServer(t)
+
+	w := serveJSON(t, router, http.MethodPost, "/tool-groups", `{"name":"math","included_tools":["calc__sum"]}`)
+	testhelpers.AssertEqual(t, http.StatusCreated, w.Code)
+
+	w = serveJSON(t, router, http.MethodPut, "/tool-groups/math",
+		`{"name":"math","description":"all math","included_servers":["calc"]}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	w = serveJSON(t, router, http.MethodDelete, "/tool-groups/math", "")
+	testhelpers.AssertEqual(t, http.StatusNoContent, w.Code)
+
+	w = serveJSON(t, router, http.MethodGet, "/tool-groups/math/revisions", "")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var revisions []types.Revision
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &revisions))
+	testhelpers.AssertEqual(t, 3, len(revisions))
+	testhelpers.AssertEqual(t, "created", revisions[0].Action)
+	testhelpers.AssertEqual(t, "updated", revisions[1].Action)
+	testhelpers.AssertEqual(t, "deleted", revisions[2].Action)
+
+	w = serveJSON(t, router, http.MethodGet, "/tool-groups/math/revisions/diff?from=1&to=2", "")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var diff types.RevisionDiff
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &diff))
+	fields := make([]string, len(diff.Changes))
+	for i, c := range diff.Changes {
+		fields[i] = c.Field
+	}
+	testhelpers.AssertEqual(t, "description,included_servers,included_tools", strings.Join(fields, ","))
+
+	w = serveJSON(t, router, http.MethodGet, "/tool-groups/math/revisions/diff?from=zero", "")
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	// the deletion itself cannot be restored
+	w = serveJSON(t, router, http.MethodPost, "/tool-groups/math/rollback", `{"revision":3}`)
+	testhelpers.AssertEqual(t, http.StatusBadRequest, w.Code)
+
+	w = serveJSON(t, router, http.MethodPost, "/tool-groups/math/rollback", `{"revision":9}`)
+	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
+
+	// rolling back re-creates the deleted group
+	w = serveJSON(t, router, http.MethodPost, "/tool-groups/math/rollback", `{"revision":1}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var result types.RollbackResult
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	testhelpers.AssertEqual(t, 1, result.RestoredRevision)
+	testhelpers.AssertEqual(t, 4, result.Revision)
+
+	// rolling back an existing group updates it in place
+	w = serveJSON(t, router, http.MethodPost, "/tool-groups/math/rollback", `{"revision":2}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	w = serveJSON(t, router, http.MethodGet, "/tool-groups/math", "")
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var group types.GetToolGroupResponse
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &group))
+	testhelpers.AssertEqual(t, "all math", group.Description)
+	testhelpers.AssertEqual(t, 0, len(group.IncludedTools))
+	testhelpers.AssertEqual(t, "calc", strings.Join(group.IncludedServers, ","))
+}
+
+func TestToolGroupUpdate_RecordsBaselineForGroupsWithoutHistory(t *testing.T) {
+	router, db := setupRevisionServer(t)
+
+	w := serveJSON(t, router, http.MethodPost, "/tool-groups", `{"name":"math","included_tools":["calc__sum"]}`)
+	testhelpers.AssertEqual(t, http.StatusCreated, w.Code)
+
+	// simulate a group that was created before revisions were recorded
+	testhelpers.AssertNoError(t, db.Unscoped().Where("1 = 1").Delete(&model.Revision{}).Error)
+
+	w = serveJSON(t, router, http.MethodPut, "/tool-groups/math", `{"name":"math","included_tools":["calc__mul"]}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	w = serveJSON(t, router, http.MethodGet, "/tool-groups/math/revisions", "")
+	var revisions []types.Revision
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &revisions))
+	testhelpers.AssertEqual(t, 2, len(revisions))
+	testhelpers.AssertEqual(t, "baseline", revisions[0].Action)
+	testhelpers.AssertStringContains(t, string(revisions[0].Snapshot), "calc__sum")
+	testhelpers.AssertEqual(t, "updated", revisions[1].Action)
+	testhelpers.AssertStringContains(t, string(revisions[1].Snapshot), "calc__mul")
+}
+
+func TestServerRollback_UpdatesServerInPlace(t *testing.T) {
+	router, db := setupRevisionServer(t)
+
+	oldUpstream := newTestUpstream(t, "search")
+	srv, err := model.NewStreamableHTTPServer("remote", "", oldUpstream.URL, "", nil, "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.Create(srv).Error)
+	tool := model.Tool{ServerID: srv.ID, Name: "search", InputSchema: []byte(`{"type":"object"}`), Enabled: false}
+	testhelpers.AssertNoError(t, db.Create(&tool).Error)
+	testhelpers.AssertNoError(t, db.Model(&tool).Update("enabled", false).Error)
+
+	newUpstream := newTestUpstream(t, "search")
+	w := serveJSON(t, router, http.MethodPut, "/servers/remote", `{"transport":"streamable_http","url":"`+newUpstream.URL+`"}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	w = serveJSON(t, router, http.MethodPost, "/servers/remote/rollback", `{"revision":1}`)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+	var result types.RollbackResult
+	testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	testhelpers.AssertEqual(t, 3, result.Revision)
+
+	var restored model.McpServer
+	testhelpers.AssertNoError(t, db.Where("name = ?", "remote").First(&restored).Error)
+	testhelpers.AssertEqual(t, srv.ID, restored.ID)
+	conf, err := restored.GetStreamableHTTPConfig()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, oldUpstream.URL, conf.URL)
+
+	// the server was not re-registered, so its disabled tool stays disabled
+	var restoredTool model.Tool
+	testhelpers.AssertNoError(t, db.Where("server_id = ? AND name = ?", srv.ID, "search").First(&restoredTool).Error)
+	testhelpers.AssertTrue(t, !restoredTool.Enabled, "expected tool to remain disabled")
+}
@@ -5,16 +5,18 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/dashboardui"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/backup"
 	"github.com/mcpjungle/mcpjungle/internal/service/config"
 	"github.com/mcpjungle/mcpjungle/internal/service/dashboard"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
+	"github.com/mcpjungle/mcpjungle/internal/service/revision"
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
@@ -30,15 +32,10 @@ const (
 )
 
 type ServerOptions struct {
-	// MCPProxyServer is the MCP proxy server instance that contains tools for all MCP servers
-	// using the stdio or streamable http transport.
+	// MCPProxyServer is the MCP proxy server instance that contains the tools, prompts and resources of all
+	// MCP servers. It is served over both the streamable HTTP and SSE transports, whatever the transports of
+	// the upstream MCP servers are.
 	MCPProxyServer *server.MCPServer
-	// SseMcpProxyServer is the MCP proxy server instance that contains tools for all MCP servers
-	// using the SSE transport.
-	// sse tools are kept separate because SSE is supported for backward compatibility reasons, and
-	// we don't want it to interfere with the usual mcp proxy server.
-	// Both sse & streamable http use http, and we don't want to mix them up either.
-	SseMcpProxyServer *server.MCPServer
 
 	MCPService       *mcp.MCPService
 	MCPClientService *mcpclient.McpClientService
@@ -46,6 +43,9 @@ type ServerOptions struct {
 	UserService      *user.UserService
 	ToolGroupService *toolgroup.ToolGroupService
 	DashboardService *dashboard.Service
+	BackupService    *backup.BackupService
+	RevisionService  *revision.RevisionService
+	NamespaceService *namespace.NamespaceService
 
 	OtelProviders *telemetry.Providers
 	Metrics       telemetry.CustomMetrics
@@ -55,8 +55,7 @@ type ServerOptions struct {
 type Server struct {
 	router *gin.Engine
 
-	mcpProxyServer    *server.MCPServer
-	sseMcpProxyServer *server.MCPServer
+	mcpProxyServer *server.MCPServer
 
 	mcpService       *mcp.MCPService
 	mcpClientService *mcpclient.McpClientService
@@ -65,6 +64,9 @@ type Server struct {
 	userService      *user.UserService
 	toolGroupService *toolgroup.ToolGroupService
 	dashboardService *dashboard.Service
+	backupService    *backup.BackupService
+	revisionService  *revision.RevisionService
+	namespaceService *namespace.NamespaceService
 
 	otelProviders *telemetry.Providers
 	metrics       telemetry.CustomMetrics
@@ -73,42 +75,23 @@ type Server struct {
 	// These instances serve the requests made to tool groups' SSE tools.
 	// We need to maintain one instance for each group for sse to work correctly.
 	groupSseServers sync.Map
-
-	// dashboardOAuthMu guards dashboardOAuthResults, which is a short-lived
-	// in-memory cache used by the browser-based dashboard OAuth flow.
-	dashboardOAuthMu sync.Mutex
-	// dashboardOAuthResults stores terminal dashboard-facing OAuth status for a
-	// session ID (completed/failed/expired) so the frontend can poll for
-	// progress after opening the upstream authorization URL.
-	dashboardOAuthResults map[string]dashboardOAuthSessionResult
-}
-
-// dashboardOAuthSessionResult is the dashboard-facing terminal state for an
-// upstream OAuth registration attempt. This is not the source of truth for the
-// pending OAuth session itself; it is a lightweight cache used to coordinate
-// callback completion and frontend polling.
-type dashboardOAuthSessionResult struct {
-	Status     string
-	Error      string
-	ServerName string
-	ExpiresAt  time.Time
-	UpdatedAt  time.Time
 }
 
 // NewServer initializes a new Gin server for MCPJungle registry and MCP proxy
 func NewServer(opts *ServerOptions) (*Server, error) {
 	s := &Server{
-		mcpProxyServer:        opts.MCPProxyServer,
-		sseMcpProxyServer:     opts.SseMcpProxyServer,
-		mcpService:            opts.MCPService,
-		mcpClientService:      opts.MCPClientService,
-		configService:         opts.ConfigService,
-		userService:           opts.UserService,
-		toolGroupService:      opts.ToolGroupService,
-		dashboardService:      opts.DashboardService,
-		otelProviders:         opts.OtelProviders,
-		metrics:               opts.Metrics,
-		dashboardOAuthResults: make(map[string]dashboardOAuthSessionResult),
+		mcpProxyServer:   opts.MCPProxyServer,
+		mcpService:       opts.MCPService,
+		mcpClientService: opts.MCPClientService,
+		configService:    opts.ConfigService,
+		userService:      opts.UserService,
+		toolGroupService: opts.ToolGroupService,
+		dashboardService: opts.DashboardService,
+		backupService:    opts.BackupService,
+		revisionService:  opts.RevisionService,
+		namespaceService: opts.NamespaceService,
+		otelProviders:    opts.OtelProviders,
+		metrics:          opts.Metrics,
 	}
 
 	// Set up the router after the server is fully initialized
@@ -195,7 +178,6 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 
 	r.POST("/init", s.registerInitServerHandler())
 
-	requireEnterpriseMode := s.requireServerMode(model.ModeEnterprise)
 	requireDashboardMode := s.requireDashboardMode()
 
 	if s.dashboardService != nil {
@@ -214,6 +196,8 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		"/mcp",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.denyGroupBoundMcpClients(),
+		s.serveResourceSubscriptions(globalProxy(s.mcpProxyServer, nil)),
 		gin.WrapH(streamableHTTPServer),
 	)
 
@@ -221,21 +205,26 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		V0PathPrefix+"/groups/:name/mcp",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.authorizeMcpClientGroupAccess(),
+		s.serveResourceSubscriptions(s.groupProxy),
 		s.toolGroupMCPServerCallHandler(),
 	)
 
 	// Set up the SSE transport-based MCP proxy server for the global /sse endpoint
-	sseServer := server.NewSSEServer(s.sseMcpProxyServer)
+	sseServer := server.NewSSEServer(s.mcpProxyServer)
 	r.Any(
 		"/sse",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.denyGroupBoundMcpClients(),
 		gin.WrapH(sseServer.SSEHandler()),
 	)
 	r.Any(
 		"/message",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.denyGroupBoundMcpClients(),
+		s.serveResourceSubscriptions(globalProxy(s.mcpProxyServer, sseServer)),
 		gin.WrapH(sseServer.MessageHandler()),
 	)
 
@@ -243,24 +232,129 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		V0PathPrefix+"/groups/:name/sse",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.authorizeMcpClientGroupAccess(),
 		s.toolGroupSseMCPServerCallHandler(),
 	)
 	r.Any(
 		V0PathPrefix+"/groups/:name/message",
 		s.requireInitialized(),
 		s.checkAuthForMcpProxyAccess(),
+		s.authorizeMcpClientGroupAccess(),
+		s.serveResourceSubscriptions(s.groupSseProxy),
 		s.toolGroupSseMCPServerCallMessageHandler(),
 	)
 
+	// Set up the namespaced MCP proxy endpoints.
+	// They serve the same proxy MCP servers as the global endpoints, restricted to the MCP servers of the namespace.
+	nsProxy := r.Group(
+		V0PathPrefix+"/ns/:ns",
+		s.requireInitialized(),
+		s.checkAuthForMcpProxyAccess(),
+		s.scopeMcpProxyNamespace(),
+	)
+	{
+		nsProxy.Any(
+			"/mcp",
+			s.denyGroupBoundMcpClients(),
+			s.serveResourceSubscriptions(globalProxy(s.mcpProxyServer, nil)),
+			gin.WrapH(streamableHTTPServer),
+		)
+
+		// a single SSE server serves all namespaces, the message endpoint it advertises depends on the namespace
+		nsSseServer := server.NewSSEServer(
+			s.mcpProxyServer,
+			server.WithDynamicBasePath(func(r *http.Request, sessionID string) string {
+				ns, _ := r.Context().Value("namespace").(string)
+				return namespacePathPrefix(ns)
+			}),
+		)
+		nsProxy.Any("/sse", s.denyGroupBoundMcpClients(), gin.WrapH(nsSseServer.SSEHandler()))
+		nsProxy.Any(
+			"/message",
+			s.denyGroupBoundMcpClients(),
+			s.serveResourceSubscriptions(globalProxy(s.mcpProxyServer, nsSseServer)),
+			gin.WrapH(nsSseServer.MessageHandler()),
+		)
+
+		nsProxy.Any(
+			"/groups/:name/mcp",
+			s.authorizeMcpClientGroupAccess(),
+			s.serveResourceSubscriptions(s.groupProxy),
+			s.toolGroupMCPServerCallHandler(),
+		)
+		nsProxy.Any("/groups/:name/sse", s.authorizeMcpClientGroupAccess(), s.toolGroupSseMCPServerCallHandler())
+		nsProxy.Any(
+			"/groups/:name/message",
+			s.authorizeMcpClientGroupAccess(),
+			s.serveResourceSubscriptions(s.groupSseProxy),
+			s.toolGroupSseMCPServerCallMessageHandler(),
+		)
+	}
+
 	// Setup /v0 API endpoints
 	apiV0 := r.Group(
 		V0ApiPathPrefix,
 		s.requireInitialized(),
 		s.verifyUserAuthForAPIAccess(),
+		s.scopeAPINamespace(),
 	)
+	s.setupRegistryAPI(apiV0)
+
+	// The same endpoints are available for each namespace, where they only manage the entities in the namespace.
+	// Users that belong to a namespace are restricted to it.
+	s.setupRegistryAPI(apiV0.Group("/ns/:ns"))
+
+	// endpoints that affect the whole mcpjungle server, only accessible by an admin that isn't part of any namespace
+	globalAdminAPI := apiV0.Group("/", s.requireAdminUser(), s.requireGlobalScope())
+	{
+		// endpoints for managing namespaces
+		globalAdminAPI.GET("/namespaces", s.listNamespacesHandler())
+		globalAdminAPI.POST("/namespaces", s.createNamespaceHandler())
+		globalAdminAPI.DELETE("/namespaces/:name", s.deleteNamespaceHandler())
+
+		// backups contain secrets like access tokens and upstream credentials, so they are admin-only
+		globalAdminAPI.GET("/backup", s.createBackupHandler())
+		globalAdminAPI.POST("/restore", s.restoreBackupHandler())
+	}
+
+	if s.dashboardService != nil {
+		dashboardAPI := r.Group(
+			"/api/dashboard",
+			s.requireInitialized(),
+			requireDashboardMode,
+		)
+		{
+			dashboardAPI.GET("/overview", s.dashboardOverviewHandler())
+			dashboardAPI.GET("/servers", s.dashboardServersHandler())
+			dashboardAPI.POST("/servers", s.dashboardRegisterServerHandler())
+			dashboardAPI.GET("/oauth/callback", s.dashboardOAuthCallbackHandler())
+			dashboardAPI.GET("/oauth/session/:id", s.dashboardOAuthSessionHandler())
+			dashboardAPI.DELETE("/servers/:name", s.dashboardDeleteServerHandler())
+			dashboardAPI.PATCH("/servers/:name/enabled", s.dashboardSetServerEnabledHandler())
+			dashboardAPI.GET("/tools", s.dashboardToolsHandler())
+			dashboardAPI.PATCH("/tools/:name/enabled", s.dashboardSetToolEnabledHandler())
+			dashboardAPI.GET("/tool-groups", s.dashboardToolGroupsHandler())
+			dashboardAPI.POST("/tool-groups", s.dashboardCreateToolGroupHandler())
+			dashboardAPI.GET("/tool-groups/:name", s.dashboardGetToolGroupHandler())
+			dashboardAPI.DELETE("/tool-groups/:name", s.dashboardDeleteToolGroupHandler())
+			dashboardAPI.GET("/prompts", s.dashboardPromptsHandler())
+			dashboardAPI.PATCH("/prompts/:name/enabled", s.dashboardSetPromptEnabledHandler())
+			dashboardAPI.GET("/resources", s.dashboardResourcesHandler())
+			dashboardAPI.GET("/diagnostics", s.dashboardDiagnosticsHandler())
+			dashboardAPI.GET("/connect/:client", s.dashboardConnectHandler())
+		}
+	}
+
+	return r, nil
+}
+
+// setupRegistryAPI sets up the API endpoints for managing the registry on the given router group.
+// The endpoints only operate on the namespace the requests are scoped to, if any (see scopeAPINamespace).
+func (s *Server) setupRegistryAPI(api *gin.RouterGroup) {
+	requireEnterpriseMode := s.requireServerMode(model.ModeEnterprise)
 
 	// endpoints accessible by a standard user in enterprise mode or anyone in development mode
-	userAPI := apiV0.Group("/")
+	userAPI := api.Group("/")
 	{
 		userAPI.GET("/servers", s.listServersHandler())
 
@@ -271,23 +365,35 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		userAPI.GET("/resources", s.listResourcesHandler())
 		userAPI.POST("/resources/get", s.getResourceHandler())
 		userAPI.POST("/resources/read", s.readResourceHandler())
+		userAPI.GET("/resource-templates", s.listResourceTemplatesHandler())
 
 		// Prompt endpoints
 		userAPI.GET("/prompts", s.listPromptsHandler())
 		userAPI.GET("/prompt", s.getPromptHandler())
 		userAPI.POST("/prompts/render", s.getPromptWithArgsHandler())
 
+		// argument completions of prompts and resource templates
+		userAPI.POST("/complete", s.completeHandler())
+
 		userAPI.GET("/users/whoami", requireEnterpriseMode, s.whoAmIHandler())
 	}
 
 	// endpoints only accessible by an admin user in enterprise mode or anyone in development mode
-	adminAPI := apiV0.Group("/", s.requireAdminUser())
+	adminAPI := api.Group("/", s.requireAdminUser())
 	{
 		adminAPI.POST("/servers", s.registerServerHandler())
+		adminAPI.POST("/servers/validate", s.validateServerHandler())
 		adminAPI.POST("/upstream_oauth/sessions/:id/complete", s.completeUpstreamOAuthSessionHandler())
+		adminAPI.PUT("/servers/:name", s.updateServerHandler())
 		adminAPI.DELETE("/servers/:name", s.deregisterServerHandler())
 		adminAPI.POST("/servers/:name/enable", s.enableServerHandler())
 		adminAPI.POST("/servers/:name/disable", s.disableServerHandler())
+		adminAPI.PUT("/servers/:name/log-level", s.setServerLogLevelHandler())
+
+		// revision snapshots contain the full server configuration, including secrets
+		adminAPI.GET("/servers/:name/revisions", s.listRevisionsHandler(model.RevisionEntityServer))
+		adminAPI.GET("/servers/:name/revisions/diff", s.diffRevisionsHandler(model.RevisionEntityServer))
+		adminAPI.POST("/servers/:name/rollback", s.rollbackServerHandler())
 
 		// this endpoint is restricted to admins only because it can potentially expose sensitive information
 		// like bearer tokens.
@@ -347,37 +453,14 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		adminAPI.POST("/tool-groups", s.createToolGroupHandler())
 		adminAPI.GET("/tool-groups/:name", s.getToolGroupHandler())
 		adminAPI.GET("/tool-groups/:name/effective-tools", s.getToolGroupEffectiveToolsHandler())
+		adminAPI.GET("/tool-groups/:name/effective-prompts", s.getToolGroupEffectivePromptsHandler())
+		adminAPI.GET("/tool-groups/:name/effective-resources", s.getToolGroupEffectiveResourcesHandler())
 		adminAPI.GET("/tool-groups", s.listToolGroupsHandler())
 		adminAPI.DELETE("/tool-groups/:name", s.deleteToolGroupHandler())
 		adminAPI.PUT("/tool-groups/:name", s.updateToolGroupHandler())
-	}
+		adminAPI.GET("/tool-groups/:name/revisions", s.listRevisionsHandler(model.RevisionEntityToolGroup))
+		adminAPI.GET("/tool-groups/:name/revisions/diff", s.diffRevisionsHandler(model.RevisionEntityToolGroup))
+		adminAPI.POST("/tool-groups/:name/rollback", s.rollbackToolGroupHandler())
 
-	if s.dashboardService != nil {
-		dashboardAPI := r.Group(
-			"/api/dashboard",
-			s.requireInitialized(),
-			requireDashboardMode,
-		)
-		{
-			dashboardAPI.GET("/overview", s.dashboardOverviewHandler())
-			dashboardAPI.GET("/servers", s.dashboardServersHandler())
-			dashboardAPI.POST("/servers", s.dashboardRegisterServerHandler())
-			dashboardAPI.GET("/oauth/callback", s.dashboardOAuthCallbackHandler())
-			dashboardAPI.GET("/oauth/session/:id", s.dashboardOAuthSessionHandler())
-			dashboardAPI.DELETE("/servers/:name", s.dashboardDeleteServerHandler())
-			dashboardAPI.PATCH("/servers/:name/enabled", s.dashboardSetServerEnabledHandler())
-			dashboardAPI.GET("/tools", s.dashboardToolsHandler())
-			dashboardAPI.PATCH("/tools/:name/enabled", s.dashboardSetToolEnabledHandler())
-			dashboardAPI.GET("/tool-groups", s.dashboardToolGroupsHandler())
-			dashboardAPI.POST("/tool-groups", s.dashboardCreateToolGroupHandler())
-			dashboardAPI.GET("/tool-groups/:name", s.dashboardGetToolGroupHandler())
-			dashboardAPI.DELETE("/tool-groups/:name", s.dashboardDeleteToolGroupHandler())
-			dashboardAPI.GET("/prompts", s.dashboardPromptsHandler())
-			dashboardAPI.PATCH("/prompts/:name/enabled", s.dashboardSetPromptEnabledHandler())
-			dashboardAPI.GET("/resources", s.dashboardResourcesHandler())
-			dashboardAPI.GET("/diagnostics", s.dashboardDiagnosticsHandler())
-		}
 	}
-
-	return r, nil
 }
@@ -18,10 +18,20 @@ func (s *Server) createToolGroupHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		input.Name = qualifiedName(c, input.Name)
 		if err := s.toolGroupService.CreateToolGroup(&input); err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(
+			model.RevisionEntityToolGroup,
+			input.Name,
+			model.RevisionActionCreated,
+			requestAuthor(c),
+			nil,
+			toolGroupSnapshot(&input),
+		)
+
 		resp := &types.CreateToolGroupResponse{
 			ToolGroupEndpoints: getToolGroupEndpoints(c, input.Name),
 		}
@@ -39,12 +49,16 @@ func (s *Server) listToolGroupsHandler() gin.HandlerFunc {
 			return
 		}
 
-		resp := make([]*types.ToolGroup, len(groups))
-		for i, g := range groups {
-			resp[i] = &types.ToolGroup{
-				Name:        g.Name,
+		resp := make([]*types.ToolGroup, 0, len(groups))
+		for _, g := range groups {
+			if !inScope(c, g.Name) {
+				continue
+			}
+			group := &types.ToolGroup{
+				Name:        localName(c, g.Name),
 				Description: g.Description,
 			}
+			resp = append(resp, group)
 
 			gTools, err := g.GetTools()
 			if err != nil {
@@ -54,7 +68,7 @@ func (s *Server) listToolGroupsHandler() gin.HandlerFunc {
 				)
 				return
 			}
-			resp[i].IncludedTools = gTools
+			group.IncludedTools = gTools
 
 			gServers, err := g.GetServers()
 			if err != nil {
@@ -64,7 +78,7 @@ func (s *Server) listToolGroupsHandler() gin.HandlerFunc {
 				)
 				return
 			}
-			resp[i].IncludedServers = gServers
+			group.IncludedServers = gServers
 
 			gExcluded, err := g.GetExcludedTools()
 			if err != nil {
@@ -74,7 +88,15 @@ func (s *Server) listToolGroupsHandler() gin.HandlerFunc {
 				)
 				return
 			}
-			resp[i].ExcludedTools = gExcluded
+			group.ExcludedTools = gExcluded
+
+			if err := setGroupDefinitionLists(group, &g); err != nil {
+				c.JSON(
+					http.StatusInternalServerError,
+					gin.H{"error": fmt.Sprintf("error getting the definition of group %s: %s", g.Name, err.Error())},
+				)
+				return
+			}
 		}
 
 		c.JSON(http.StatusOK, resp)
@@ -89,7 +111,7 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 			return
 		}
 
-		group, err := s.toolGroupService.GetToolGroup(name)
+		group, err := s.toolGroupService.GetToolGroup(qualifiedName(c, name))
 		if err != nil {
 			handleServiceError(c, err)
 			return
@@ -97,7 +119,7 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 
 		resp := &types.GetToolGroupResponse{
 			ToolGroup: &types.ToolGroup{
-				Name:        group.Name,
+				Name:        localName(c, group.Name),
 				Description: group.Description,
 			},
 			ToolGroupEndpoints: getToolGroupEndpoints(c, group.Name),
@@ -139,6 +161,15 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.ExcludedTools = excludedTools
 
+		// Get included & excluded prompts and resources
+		if err := setGroupDefinitionLists(resp.ToolGroup, group); err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting the definition of group: %s", err.Error())},
+			)
+			return
+		}
+
 		c.JSON(http.StatusOK, resp)
 	}
 }
@@ -151,16 +182,84 @@ func (s *Server) getToolGroupEffectiveToolsHandler() gin.HandlerFunc {
 			return
 		}
 
-		tools, err := s.toolGroupService.ResolveEffectiveTools(name)
+		tools, err := s.toolGroupService.ResolveEffectiveTools(qualifiedName(c, name))
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{"tools": tools})
+		c.JSON(http.StatusOK, gin.H{"tools": localNames(c, tools)})
 	}
 }
 
+func (s *Server) getToolGroupEffectivePromptsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+			return
+		}
+
+		prompts, err := s.toolGroupService.ResolveEffectivePrompts(qualifiedName(c, name))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"prompts": localNames(c, prompts)})
+	}
+}
+
+func (s *Server) getToolGroupEffectiveResourcesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+			return
+		}
+
+		// resource URIs are not relative to the namespace, so they are returned as they are
+		resources, err := s.toolGroupService.ResolveEffectiveResources(qualifiedName(c, name))
+		if err != nil {
+			handleServiceError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"resources": resources})
+	}
+}
+
+// setGroupDefinitionLists copies the included groups, the tool selectors, the included and excluded
+// prompts and resources and the tool overrides of a tool group into its API representation.
+func setGroupDefinitionLists(dst *types.ToolGroup, src *model.ToolGroup) error {
+	var err error
+	if dst.IncludedGroups, err = src.GetGroups(); err != nil {
+		return fmt.Errorf("included groups: %w", err)
+	}
+	if dst.IncludedToolSelectors, err = src.GetToolSelectors(); err != nil {
+		return fmt.Errorf("included tool selectors: %w", err)
+	}
+	if dst.ExcludedToolSelectors, err = src.GetExcludedToolSelectors(); err != nil {
+		return fmt.Errorf("excluded tool selectors: %w", err)
+	}
+	if dst.IncludedPrompts, err = src.GetPrompts(); err != nil {
+		return fmt.Errorf("included prompts: %w", err)
+	}
+	if dst.ExcludedPrompts, err = src.GetExcludedPrompts(); err != nil {
+		return fmt.Errorf("excluded prompts: %w", err)
+	}
+	if dst.IncludedResources, err = src.GetResources(); err != nil {
+		return fmt.Errorf("included resources: %w", err)
+	}
+	if dst.ExcludedResources, err = src.GetExcludedResources(); err != nil {
+		return fmt.Errorf("excluded resources: %w", err)
+	}
+	if dst.ToolOverrides, err = src.GetToolOverrides(); err != nil {
+		return fmt.Errorf("tool overrides: %w", err)
+	}
+	return nil
+}
+
 func (s *Server) deleteToolGroupHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		name := c.Param("name")
@@ -168,12 +267,17 @@ func (s *Server) deleteToolGroupHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
 			return
 		}
+		name = qualifiedName(c, name)
 
+		previous := s.currentToolGroupSnapshot(name)
 		err := s.toolGroupService.DeleteToolGroup(name)
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		if previous != nil {
+			s.recordRevision(model.RevisionEntityToolGroup, name, model.RevisionActionDeleted, requestAuthor(c), previous, nil)
+		}
 
 		// TODO: return 404 if the group did not exist.
 		//  The tool group service should return ErrToolGroupNotFound if the group does not exist.
@@ -196,21 +300,29 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 			return
 		}
 
-		originalConf, err := s.toolGroupService.UpdateToolGroup(name, &input)
+		originalConf, err := s.toolGroupService.UpdateToolGroup(qualifiedName(c, name), &input)
 		if err != nil {
 			handleServiceError(c, err)
 			return
 		}
+		s.recordRevision(
+			model.RevisionEntityToolGroup,
+			originalConf.Name,
+			model.RevisionActionUpdated,
+			requestAuthor(c),
+			toolGroupSnapshot(originalConf),
+			s.currentToolGroupSnapshot(originalConf.Name),
+		)
 
 		// create and send response object
 		resp := &types.UpdateToolGroupResponse{
 			Name: name,
 			Old: &types.ToolGroup{
-				Name:        originalConf.Name,
+				Name:        localName(c, originalConf.Name),
 				Description: originalConf.Description,
 			},
 			New: &types.ToolGroup{
-				Name:        input.Name,
+				Name:        localName(c, input.Name),
 				Description: input.Description,
 			},
 		}
@@ -248,6 +360,14 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.Old.ExcludedTools = origExcluded
 
+		if err := setGroupDefinitionLists(resp.Old, originalConf); err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting the definition of the original group config: %s", err.Error())},
+			)
+			return
+		}
+
 		var newTools []string
 		newTools, err = input.GetTools()
 		if err != nil {
@@ -281,6 +401,14 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.New.ExcludedTools = newExcluded
 
+		if err := setGroupDefinitionLists(resp.New, &input); err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting the definition of the new group config: %s", err.Error())},
+			)
+			return
+		}
+
 		c.JSON(http.StatusOK, resp)
 	}
 }
@@ -289,7 +417,7 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 func (s *Server) toolGroupMCPServerCallHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// get the Proxy MCP server for the specified tool group
-		groupName := c.Param("name")
+		groupName := proxyGroupName(c)
 		groupMcpServer, exists := s.toolGroupService.GetToolGroupMCPServer(groupName)
 		if !exists {
 			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("tool group not found: %s", groupName)})
@@ -314,18 +442,21 @@ func (s *Server) getGroupSseServer(groupName string) (*server.SSEServer, error)
 		return serverVal.(*server.SSEServer), nil
 	}
 
-	// Get the sse MCP proxy server for the group
-	groupSseMcpServer, exists := s.toolGroupService.GetToolGroupSseMCPServer(groupName)
+	// Get the MCP proxy server for the group, which is served over SSE as well
+	groupMcpServer, exists := s.toolGroupService.GetToolGroupMCPServer(groupName)
 	if !exists {
 		return nil, fmt.Errorf("tool group not found: %s", groupName)
 	}
 
 	// Create new server with the correct dynamic base path
+	// the group of a namespace is served on the namespaced endpoints, under its name relative to the namespace
+	ns, localName := model.SplitQualifiedName(groupName)
+	basePath := fmt.Sprintf("%s/groups/%s", namespacePathPrefix(ns), localName)
 	sseServer := server.NewSSEServer(
-		groupSseMcpServer,
+		groupMcpServer,
 		server.WithDynamicBasePath(func(r *http.Request, sessionID string) string {
 			// Return the group-specific base path
-			return fmt.Sprintf("%s/groups/%s", V0PathPrefix, groupName)
+			return basePath
 		}),
 	)
 
@@ -338,7 +469,7 @@ func (s *Server) getGroupSseServer(groupName string) (*server.SSEServer, error)
 // toolGroupSseMCPServerCallHandler handles SSE connection requests (/sse) for a specific tool group.
 func (s *Server) toolGroupSseMCPServerCallHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		groupName := c.Param("name")
+		groupName := proxyGroupName(c)
 
 		groupSseMcpServer, err := s.getGroupSseServer(groupName)
 		if err != nil {
@@ -356,7 +487,7 @@ func (s *Server) toolGroupSseMCPServerCallHandler() gin.HandlerFunc {
 // toolGroupSseMCPServerCallHandler handles SSE connection requests (/message) for a specific tool group.
 func (s *Server) toolGroupSseMCPServerCallMessageHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		groupName := c.Param("name")
+		groupName := proxyGroupName(c)
 
 		groupSseMcpServer, err := s.getGroupSseServer(groupName)
 		if err != nil {
@@ -371,11 +502,19 @@ func (s *Server) toolGroupSseMCPServerCallMessageHandler() gin.HandlerFunc {
 	}
 }
 
+// proxyGroupName returns the canonical name of the tool group addressed by an MCP proxy request.
+// On the namespaced proxy endpoints, the group's name is relative to the namespace.
+func proxyGroupName(c *gin.Context) string {
+	return model.QualifiedName(c.Param("ns"), c.Param("name"))
+}
+
 // getToolGroupEndpoints deduces the proxy MCP server endpoint URLs for a given tool group.
-// It returns the streamable HTTP endpoint and the SSE endpoints
+// It returns the streamable HTTP endpoint and the SSE endpoints.
+// The endpoints of a group in a namespace are the namespaced ones.
 func getToolGroupEndpoints(c *gin.Context, groupName string) *types.ToolGroupEndpoints {
 	// This logic of creating the API endpoints is duplicated from internal/api/server.go
 	// TODO: centralize this logic into one place and use that everywhere.
+	ns, local := model.SplitQualifiedName(groupName)
 	scheme := "http"
 	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
 		scheme = "https"
@@ -383,7 +522,7 @@ func getToolGroupEndpoints(c *gin.Context, groupName string) *types.ToolGroupEnd
 	endpointURL := &url.URL{
 		Scheme: scheme,
 		Host:   c.Request.Host,
-		Path:   fmt.Sprintf("%s/groups/%s", V0PathPrefix, groupName),
+		Path:   fmt.Sprintf("%s/groups/%s", namespacePathPrefix(ns), local),
 	}
 	baseEndpoint := endpointURL.String()
 
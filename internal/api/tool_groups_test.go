@@ -1,13 +1,17 @@
 package api
 
 import (
+	"bufio"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
 	mcpSvc "github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
@@ -21,11 +25,9 @@ func setupToolGroupServer(t *testing.T) *Server {
 	t.Cleanup(setup.Cleanup)
 
 	mcpProxy := mcpserver.NewMCPServer("test", "0.0.1")
-	sseMcpProxy := mcpserver.NewMCPServer("test-sse", "0.0.1")
 	svc, err := mcpSvc.NewMCPService(&mcpSvc.ServiceConfig{
 		DB:                      setup.DB,
 		McpProxyServer:          mcpProxy,
-		SseMcpProxyServer:       sseMcpProxy,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 5,
 	})
@@ -72,3 +74,47 @@ func TestUpdateToolGroupHandler_NotFound(t *testing.T) {
 	testhelpers.AssertEqual(t, http.StatusNotFound, w.Code)
 	testhelpers.AssertStringContains(t, w.Body.String(), "not found")
 }
+
+func TestGetGroupSseServer_NamespacedBasePath(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	t.Cleanup(setup.Cleanup)
+
+	svc, err := mcpSvc.NewMCPService(&mcpSvc.ServiceConfig{
+		DB:                      setup.DB,
+		McpProxyServer:          mcpserver.NewMCPServer("test", "0.0.1"),
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 5,
+	})
+	testhelpers.AssertNoError(t, err)
+	tgSvc, err := toolgroup.NewToolGroupService(setup.DB, svc)
+	testhelpers.AssertNoError(t, err)
+
+	// reloading a group stored by another instance creates its proxy server
+	testhelpers.AssertNoError(t, setup.DB.Create(&model.ToolGroup{Name: "team.docs", Namespace: "team"}).Error)
+	testhelpers.AssertNoError(t, tgSvc.ReloadToolGroup("team.docs"))
+
+	s := &Server{toolGroupService: tgSvc}
+	sseServer, err := s.getGroupSseServer("team.docs")
+	testhelpers.AssertNoError(t, err)
+
+	httpServer := httptest.NewServer(sseServer.SSEHandler())
+	defer httpServer.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, httpServer.URL, nil)
+	testhelpers.AssertNoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	testhelpers.AssertNoError(t, err)
+	defer resp.Body.Close()
+
+	// the endpoint event tells the client where to post its messages
+	var endpoint string
+	scanner := bufio.NewScanner(resp.Body)
+	for endpoint == "" && scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			endpoint = data
+		}
+	}
+	testhelpers.AssertStringContains(t, endpoint, "/v0/ns/team/groups/docs/message?sessionId=")
+}
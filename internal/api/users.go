@@ -1,10 +1,12 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -15,6 +17,8 @@ func (s *Server) createUserHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		// users are created in the namespace the request is scoped to
+		input.Namespace = scopedNamespace(c)
 
 		newUser, err := s.userService.CreateUser(&input)
 		if err != nil {
@@ -39,12 +43,17 @@ func (s *Server) listUsersHandler() gin.HandlerFunc {
 			return
 		}
 
-		resp := make([]*types.User, len(users))
-		for i, u := range users {
-			resp[i] = &types.User{
-				Username: u.Username,
-				Role:     string(u.Role),
+		resp := make([]*types.User, 0, len(users))
+		for _, u := range users {
+			if ns := scopedNamespace(c); ns != "" && u.Namespace != ns {
+				// only the users in the request's namespace are listed
+				continue
 			}
+			resp = append(resp, &types.User{
+				Username:  u.Username,
+				Role:      string(u.Role),
+				Namespace: u.Namespace,
+			})
 		}
 
 		c.JSON(http.StatusOK, resp)
@@ -64,6 +73,10 @@ func (s *Server) updateUserHandler() gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		input.Username = username // Ensure the username from the URL is used
+		if !s.userInScope(c, username) {
+			return
+		}
 
 		updatedUser, err := s.userService.UpdateUser(&input)
 		if err != nil {
@@ -88,6 +101,10 @@ func (s *Server) deleteUserHandler() gin.HandlerFunc {
 			return
 		}
 
+		if !s.userInScope(c, username) {
+			return
+		}
+
 		err := s.userService.DeleteUser(username)
 		if err != nil {
 			handleServiceError(c, err)
@@ -113,9 +130,28 @@ func (s *Server) whoAmIHandler() gin.HandlerFunc {
 		}
 
 		resp := types.User{
-			Username: u.Username,
-			Role:     string(u.Role),
+			Username:  u.Username,
+			Role:      string(u.Role),
+			Namespace: u.Namespace,
 		}
 		c.JSON(http.StatusOK, resp)
 	}
 }
+
+// userInScope checks that the user belongs to the namespace the request is scoped to.
+// Users outside the namespace are reported as not found and the request is aborted.
+func (s *Server) userInScope(c *gin.Context, username string) bool {
+	ns := scopedNamespace(c)
+	if ns == "" {
+		return true
+	}
+	u, err := s.userService.GetUser(username)
+	if err == nil && u.Namespace != ns {
+		err = fmt.Errorf("user with username %s not found: %w", username, apierrors.ErrNotFound)
+	}
+	if err != nil {
+		handleServiceError(c, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,334 @@
+// Package clientconfig understands the MCP configuration files used by popular MCP clients
+// such as Claude Desktop, Cursor and VS Code.
+// It converts the server definitions in those files into mcpjungle registration inputs.
+package clientconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/configresolver"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ClientKind identifies the MCP client whose configuration file format is being handled.
+type ClientKind string
+
+const (
+	ClientClaude ClientKind = "claude"
+	ClientCursor ClientKind = "cursor"
+	ClientVSCode ClientKind = "vscode"
+
+	// ClientCopilot is GitHub Copilot in VS Code, which uses the same config file format as ClientVSCode.
+	ClientCopilot ClientKind = "copilot"
+
+	// ClientGeneric is any other MCP client that can connect to a streamable http endpoint.
+	// It has no config file format of its own, so it can only be used to generate connection details.
+	ClientGeneric ClientKind = "generic"
+)
+
+// ValidateClientKind validates the input string and returns the corresponding ClientKind.
+func ValidateClientKind(input string) (ClientKind, error) {
+	switch ClientKind(strings.ToLower(strings.TrimSpace(input))) {
+	case ClientClaude:
+		return ClientClaude, nil
+	case ClientCursor:
+		return ClientCursor, nil
+	case ClientVSCode:
+		return ClientVSCode, nil
+	case ClientCopilot:
+		return ClientCopilot, nil
+	case ClientGeneric:
+		return ClientGeneric, nil
+	default:
+		return "", fmt.Errorf(
+			"unsupported client kind %q (acceptable values: '%s', '%s', '%s', '%s', '%s')",
+			input, ClientClaude, ClientCursor, ClientVSCode, ClientCopilot, ClientGeneric,
+		)
+	}
+}
+
+// ImportedServer is a single server definition found in an MCP client's configuration file.
+type ImportedServer struct {
+	// OriginalName is the key under which the server was declared in the client config.
+	OriginalName string
+
+	// Input is the registration input derived from the client config.
+	// It is nil if the entry could not be converted.
+	Input *types.RegisterServerInput
+
+	// Warnings contains non-fatal notes about the conversion, eg- a renamed server or ignored fields.
+	Warnings []string
+
+	// Err is set if the entry cannot be registered in mcpjungle.
+	Err error
+}
+
+// serverEntry is the union of the server entry shapes used by Claude Desktop, Cursor and VS Code.
+type serverEntry struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+}
+
+// claudeConfig is the file shape shared by Claude Desktop (claude_desktop_config.json)
+// and Cursor (.cursor/mcp.json).
+type claudeConfig struct {
+	McpServers map[string]serverEntry `json:"mcpServers"`
+}
+
+// vscodeConfig is the file shape used by VS Code (.vscode/mcp.json).
+// VS Code's settings.json nests the same object under the "mcp" key, which is also accepted.
+type vscodeConfig struct {
+	Servers map[string]serverEntry `json:"servers"`
+	Mcp     *struct {
+		Servers map[string]serverEntry `json:"servers"`
+	} `json:"mcp"`
+}
+
+// vscodeEnvPlaceholder matches VS Code & Cursor style ${env:VAR} placeholders.
+var vscodeEnvPlaceholder = regexp.MustCompile(`\$\{env:([^}]+)\}`)
+
+// invalidServerNameChars matches every character that is not allowed in an mcpjungle server name.
+var invalidServerNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ParseServers parses the contents of an MCP client configuration file and converts every
+// server declared in it into a registration input.
+// The result is sorted by server name so that previews and imports are deterministic.
+// An error is returned only if the file itself cannot be parsed. Problems with individual
+// entries are reported through ImportedServer.Err so that the rest can still be imported.
+func ParseServers(kind ClientKind, data []byte) ([]ImportedServer, error) {
+	entries, err := decodeEntries(kind, data)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]ImportedServer, 0, len(names))
+	for _, name := range names {
+		result = append(result, convertEntry(kind, name, entries[name]))
+	}
+
+	// entries whose names sanitize to the same server name would overwrite each other when imported
+	namesakes := make(map[string][]string, len(result))
+	for _, s := range result {
+		if s.Err == nil {
+			namesakes[s.Input.Name] = append(namesakes[s.Input.Name], s.OriginalName)
+		}
+	}
+	for i := range result {
+		s := &result[i]
+		if s.Err != nil || len(namesakes[s.Input.Name]) < 2 {
+			continue
+		}
+		s.Err = fmt.Errorf(
+			"server name %q conflicts with %s, rename the entries to import them",
+			s.Input.Name, quoteOthers(namesakes[s.Input.Name], s.OriginalName),
+		)
+		s.Input = nil
+	}
+	return result, nil
+}
+
+// quoteOthers returns the quoted names, except the given one, as a comma separated list.
+func quoteOthers(names []string, except string) string {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		if n != except {
+			quoted = append(quoted, strconv.Quote(n))
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func decodeEntries(kind ClientKind, data []byte) (map[string]serverEntry, error) {
+	switch kind {
+	case ClientClaude, ClientCursor:
+		var cfg claudeConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse %s config: %w", kind, err)
+		}
+		if cfg.McpServers == nil {
+			return nil, fmt.Errorf("%s config does not contain an \"mcpServers\" object", kind)
+		}
+		return cfg.McpServers, nil
+	case ClientVSCode, ClientCopilot:
+		var cfg vscodeConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse %s config: %w", kind, err)
+		}
+		if cfg.Servers != nil {
+			return cfg.Servers, nil
+		}
+		if cfg.Mcp != nil && cfg.Mcp.Servers != nil {
+			return cfg.Mcp.Servers, nil
+		}
+		return nil, fmt.Errorf("%s config does not contain a \"servers\" object", kind)
+	case ClientGeneric:
+		return nil, fmt.Errorf("%s client configs cannot be imported, choose a specific client kind", kind)
+	default:
+		_, err := ValidateClientKind(string(kind))
+		return nil, err
+	}
+}
+
+func convertEntry(kind ClientKind, name string, e serverEntry) ImportedServer {
+	s := ImportedServer{OriginalName: name}
+
+	serverName := SanitizeServerName(name)
+	if serverName == "" {
+		s.Err = fmt.Errorf("cannot derive a valid mcpjungle server name from %q", name)
+		return s
+	}
+	if serverName != name {
+		s.Warnings = append(s.Warnings, fmt.Sprintf("renamed from %q to %q", name, serverName))
+	}
+
+	transport, err := inferTransport(e)
+	if err != nil {
+		s.Err = err
+		return s
+	}
+
+	input := &types.RegisterServerInput{
+		Name:        serverName,
+		Transport:   string(transport),
+		Description: fmt.Sprintf("Imported from %s config", kind),
+	}
+
+	switch transport {
+	case types.TransportStdio:
+		input.Command = e.Command
+		input.Args = e.Args
+		input.Env = e.Env
+		if e.URL != "" || len(e.Headers) > 0 {
+			s.Warnings = append(s.Warnings, "ignored url and headers because the server uses stdio transport")
+		}
+	case types.TransportStreamableHTTP:
+		input.URL = e.URL
+		input.Headers = e.Headers
+	case types.TransportSSE:
+		// mcpjungle only supports a bearer token for SSE upstreams, so the Authorization header
+		// is converted and any other headers are dropped.
+		input.URL = e.URL
+		headerNames := make([]string, 0, len(e.Headers))
+		for k := range e.Headers {
+			headerNames = append(headerNames, k)
+		}
+		sort.Strings(headerNames)
+		for _, k := range headerNames {
+			v := e.Headers[k]
+			if strings.EqualFold(k, "Authorization") && strings.HasPrefix(v, "Bearer ") {
+				input.BearerToken = strings.TrimPrefix(v, "Bearer ")
+				continue
+			}
+			s.Warnings = append(s.Warnings, fmt.Sprintf("ignored header %q because sse servers only support a bearer token", k))
+		}
+	}
+
+	if err := resolvePlaceholders(input); err != nil {
+		s.Err = err
+		return s
+	}
+
+	s.Input = input
+	return s
+}
+
+// inferTransport determines the mcpjungle transport for a client config entry.
+// An explicit "type" always wins. Otherwise a command implies stdio and a URL implies
+// streamable http, unless the URL path ends with /sse.
+func inferTransport(e serverEntry) (types.McpServerTransport, error) {
+	switch strings.ToLower(e.Type) {
+	case "stdio":
+		if e.Command == "" {
+			return "", fmt.Errorf("stdio server does not specify a command")
+		}
+		return types.TransportStdio, nil
+	case "http", "streamable-http", "streamable_http", "streamablehttp":
+		if e.URL == "" {
+			return "", fmt.Errorf("http server does not specify a url")
+		}
+		return types.TransportStreamableHTTP, nil
+	case "sse":
+		if e.URL == "" {
+			return "", fmt.Errorf("sse server does not specify a url")
+		}
+		return types.TransportSSE, nil
+	case "":
+		// infer from the fields present in the entry
+	default:
+		return "", fmt.Errorf("unsupported server type %q", e.Type)
+	}
+
+	if e.Command != "" {
+		return types.TransportStdio, nil
+	}
+	if e.URL == "" {
+		return "", fmt.Errorf("server specifies neither a command nor a url")
+	}
+	if u, err := url.Parse(e.URL); err == nil && strings.HasSuffix(strings.TrimRight(u.Path, "/"), "/sse") {
+		return types.TransportSSE, nil
+	}
+	return types.TransportStreamableHTTP, nil
+}
+
+// resolvePlaceholders converts ${env:VAR} placeholders into mcpjungle's ${VAR} form and
+// resolves them from the current environment.
+// VS Code ${input:...} placeholders are interactive prompts and cannot be resolved here.
+func resolvePlaceholders(input *types.RegisterServerInput) error {
+	var unsupported error
+	rewrite := func(v string) string {
+		if strings.Contains(v, "${input:") && unsupported == nil {
+			unsupported = fmt.Errorf("value %q uses a VS Code input variable, which is not supported; replace it with ${env:VAR}", v)
+		}
+		return vscodeEnvPlaceholder.ReplaceAllString(v, "$${$1}")
+	}
+
+	input.Command = rewrite(input.Command)
+	input.URL = rewrite(input.URL)
+	input.BearerToken = rewrite(input.BearerToken)
+	for i, a := range input.Args {
+		input.Args[i] = rewrite(a)
+	}
+	for k, v := range input.Env {
+		input.Env[k] = rewrite(v)
+	}
+	for k, v := range input.Headers {
+		input.Headers[k] = rewrite(v)
+	}
+	if unsupported != nil {
+		return unsupported
+	}
+
+	if err := configresolver.ResolveEnvVars(input); err != nil {
+		return fmt.Errorf("failed to resolve environment variables: %w", err)
+	}
+	return nil
+}
+
+// SanitizeServerName converts an arbitrary client config key into a valid mcpjungle server name.
+// Invalid characters are replaced with hyphens, consecutive underscores are collapsed and
+// trailing underscores are removed. An empty string is returned if nothing usable remains.
+func SanitizeServerName(name string) string {
+	s := invalidServerNameChars.ReplaceAllString(strings.TrimSpace(name), "-")
+	for strings.Contains(s, "__") {
+		s = strings.ReplaceAll(s, "__", "_")
+	}
+	s = strings.Trim(s, "-")
+	s = strings.TrimRight(s, "_")
+	return s
+}
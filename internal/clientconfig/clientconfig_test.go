@@ -0,0 +1,223 @@
+package clientconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestValidateClientKind(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    ClientKind
+		wantErr bool
+	}{
+		{"claude", ClientClaude, false},
+		{"Cursor", ClientCursor, false},
+		{" vscode ", ClientVSCode, false},
+		{"windsurf", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ValidateClientKind(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ValidateClientKind(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ValidateClientKind(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseServers_Claude(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "ghp_test")
+
+	data := []byte(`{
+		"mcpServers": {
+			"filesystem": {
+				"command": "npx",
+				"args": ["-y", "@modelcontextprotocol/server-filesystem", "/tmp"],
+				"env": {"DEBUG": "1"}
+			},
+			"github": {
+				"url": "https://api.githubcopilot.com/mcp",
+				"headers": {"Authorization": "Bearer ${env:GITHUB_TOKEN}"}
+			},
+			"legacy": {
+				"url": "http://localhost:9000/sse",
+				"headers": {"Authorization": "Bearer abc", "X-Trace": "1"}
+			}
+		}
+	}`)
+
+	servers, err := ParseServers(ClientClaude, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 3 {
+		t.Fatalf("expected 3 servers, got %d", len(servers))
+	}
+
+	fs := servers[0]
+	if fs.Err != nil || fs.Input == nil {
+		t.Fatalf("filesystem: unexpected error: %v", fs.Err)
+	}
+	if fs.Input.Name != "filesystem" || fs.Input.Transport != string(types.TransportStdio) {
+		t.Errorf("filesystem: unexpected input %+v", fs.Input)
+	}
+	if fs.Input.Command != "npx" || len(fs.Input.Args) != 3 || fs.Input.Env["DEBUG"] != "1" {
+		t.Errorf("filesystem: stdio fields not mapped: %+v", fs.Input)
+	}
+
+	gh := servers[1]
+	if gh.Err != nil || gh.Input == nil {
+		t.Fatalf("github: unexpected error: %v", gh.Err)
+	}
+	if gh.Input.Transport != string(types.TransportStreamableHTTP) {
+		t.Errorf("github: expected streamable_http transport, got %s", gh.Input.Transport)
+	}
+	if gh.Input.Headers["Authorization"] != "Bearer ghp_test" {
+		t.Errorf("github: expected env placeholder to be resolved, got %q", gh.Input.Headers["Authorization"])
+	}
+
+	legacy := servers[2]
+	if legacy.Err != nil || legacy.Input == nil {
+		t.Fatalf("legacy: unexpected error: %v", legacy.Err)
+	}
+	if legacy.Input.Transport != string(types.TransportSSE) {
+		t.Errorf("legacy: expected sse transport, got %s", legacy.Input.Transport)
+	}
+	if legacy.Input.BearerToken != "abc" {
+		t.Errorf("legacy: expected bearer token to be extracted, got %q", legacy.Input.BearerToken)
+	}
+	if len(legacy.Warnings) != 1 || !strings.Contains(legacy.Warnings[0], "X-Trace") {
+		t.Errorf("legacy: expected a warning about the dropped header, got %v", legacy.Warnings)
+	}
+}
+
+func TestParseServers_VSCode(t *testing.T) {
+	data := []byte(`{
+		"inputs": [{"type": "promptString", "id": "token"}],
+		"servers": {
+			"My Server": {"type": "http", "url": "https://example.com/mcp"},
+			"prompted": {"type": "http", "url": "https://example.com/mcp", "headers": {"Authorization": "Bearer ${input:token}"}},
+			"events": {"type": "sse", "url": "https://example.com/events"},
+			"weird": {"type": "websocket", "url": "wss://example.com"}
+		}
+	}`)
+
+	servers, err := ParseServers(ClientVSCode, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byName := map[string]ImportedServer{}
+	for _, s := range servers {
+		byName[s.OriginalName] = s
+	}
+
+	renamed := byName["My Server"]
+	if renamed.Err != nil || renamed.Input.Name != "My-Server" {
+		t.Fatalf("expected server to be renamed to My-Server, got %+v", renamed)
+	}
+	if len(renamed.Warnings) != 1 {
+		t.Errorf("expected a rename warning, got %v", renamed.Warnings)
+	}
+
+	if byName["prompted"].Err == nil {
+		t.Error("expected an error for a VS Code input variable")
+	}
+	if byName["events"].Err != nil || byName["events"].Input.Transport != string(types.TransportSSE) {
+		t.Errorf("expected explicit sse type to be honoured, got %+v", byName["events"])
+	}
+	if byName["weird"].Err == nil {
+		t.Error("expected an error for an unsupported server type")
+	}
+}
+
+func TestParseServers_VSCodeSettings(t *testing.T) {
+	data := []byte(`{"mcp": {"servers": {"calc": {"command": "calc-mcp"}}}}`)
+	servers, err := ParseServers(ClientVSCode, data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 1 || servers[0].Input == nil || servers[0].Input.Command != "calc-mcp" {
+		t.Fatalf("unexpected result: %+v", servers)
+	}
+}
+
+func TestParseServers_Errors(t *testing.T) {
+	if _, err := ParseServers(ClientCursor, []byte(`{`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+	if _, err := ParseServers(ClientCursor, []byte(`{"servers": {}}`)); err == nil {
+		t.Error("expected an error when mcpServers is missing")
+	}
+	if _, err := ParseServers(ClientVSCode, []byte(`{"mcpServers": {}}`)); err == nil {
+		t.Error("expected an error when servers is missing")
+	}
+
+	servers, err := ParseServers(ClientCursor, []byte(`{"mcpServers": {
+		"empty": {},
+		"unset": {"command": "run", "env": {"KEY": "${MCPJ_CLIENTCONFIG_UNSET_VAR}"}}
+	}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, s := range servers {
+		if s.Err == nil {
+			t.Errorf("expected an error for entry %q", s.OriginalName)
+		}
+	}
+}
+
+func TestParseServers_ConflictingNames(t *testing.T) {
+	servers, err := ParseServers(ClientCursor, []byte(`{"mcpServers": {
+		"my server": {"command": "first"},
+		"my-server": {"command": "second"},
+		"my_server": {"command": "third"},
+		"my?server": {"type": "websocket"}
+	}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 4 {
+		t.Fatalf("expected 4 servers, got %d", len(servers))
+	}
+
+	// entries that cannot be imported anyway don't conflict with the others
+	for _, s := range servers[:2] {
+		if s.Err == nil || s.Input != nil {
+			t.Fatalf("%s: expected a conflict error, got input %+v", s.OriginalName, s.Input)
+		}
+	}
+	if !strings.Contains(servers[0].Err.Error(), `server name "my-server" conflicts with "my-server"`) {
+		t.Errorf("my server: unexpected error: %v", servers[0].Err)
+	}
+	if !strings.Contains(servers[1].Err.Error(), `conflicts with "my server"`) {
+		t.Errorf("my-server: unexpected error: %v", servers[1].Err)
+	}
+	if !strings.Contains(servers[2].Err.Error(), "unsupported server type") {
+		t.Errorf("my?server: unexpected error: %v", servers[2].Err)
+	}
+	if servers[3].Err != nil || servers[3].Input.Name != "my_server" {
+		t.Errorf("my_server: expected to be importable, got %+v", servers[3])
+	}
+}
+
+func TestSanitizeServerName(t *testing.T) {
+	tests := map[string]string{
+		"github":        "github",
+		"My Server":     "My-Server",
+		"a__b":          "a_b",
+		"trailing_":     "trailing",
+		"@scope/pkg":    "scope-pkg",
+		"  spaced out ": "spaced-out",
+		"!!!":           "",
+	}
+	for in, want := range tests {
+		if got := SanitizeServerName(in); got != want {
+			t.Errorf("SanitizeServerName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package clientconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Connection describes how an MCP client should connect to an mcpjungle MCP endpoint.
+type Connection struct {
+	// Name is the key under which the mcpjungle entry is added to the client config.
+	Name string
+
+	// URL is the streamable http endpoint of mcpjungle, eg- http://localhost:8080/mcp
+	// or http://localhost:8080/v0/groups/<name>/mcp
+	URL string
+
+	// AccessToken is the bearer token of an MCP client registered in mcpjungle.
+	// It is only required in enterprise mode.
+	AccessToken string
+}
+
+// DefaultConfigFile returns the conventional location of the MCP config file of the given client,
+// or an empty string if the client has no config file format.
+func DefaultConfigFile(kind ClientKind) string {
+	switch kind {
+	case ClientClaude:
+		return "claude_desktop_config.json"
+	case ClientCursor:
+		return ".cursor/mcp.json"
+	case ClientVSCode, ClientCopilot:
+		return ".vscode/mcp.json"
+	default:
+		return ""
+	}
+}
+
+// serversKey returns the top-level key under which the given client lists its MCP servers.
+func serversKey(kind ClientKind) string {
+	switch kind {
+	case ClientClaude, ClientCursor:
+		return "mcpServers"
+	case ClientVSCode, ClientCopilot:
+		return "servers"
+	default:
+		return ""
+	}
+}
+
+// ServerEntry returns the config entry that points the given client at mcpjungle.
+// For clients with a config file format, this is the value to place under the client's
+// servers object. For the generic client, it is a plain description of the endpoint.
+func ServerEntry(kind ClientKind, conn Connection) (map[string]any, error) {
+	if conn.URL == "" {
+		return nil, fmt.Errorf("endpoint url is required")
+	}
+
+	var headers map[string]string
+	if conn.AccessToken != "" {
+		headers = map[string]string{"Authorization": "Bearer " + conn.AccessToken}
+	}
+
+	switch kind {
+	case ClientClaude:
+		// Claude Desktop only launches stdio servers from its config file, so mcp-remote is used
+		// to bridge stdio to mcpjungle's streamable http endpoint.
+		args := []any{"-y", "mcp-remote", conn.URL}
+		if u, err := url.Parse(conn.URL); err == nil && u.Scheme == "http" {
+			args = append(args, "--allow-http")
+		}
+		entry := map[string]any{"command": "npx"}
+		if conn.AccessToken != "" {
+			// The header value is passed through an env var because Claude Desktop does not
+			// reliably preserve spaces inside args on all platforms.
+			args = append(args, "--header", "Authorization:${AUTH_HEADER}")
+			entry["env"] = map[string]any{"AUTH_HEADER": "Bearer " + conn.AccessToken}
+		}
+		entry["args"] = args
+		return entry, nil
+	case ClientCursor:
+		entry := map[string]any{"url": conn.URL}
+		if headers != nil {
+			entry["headers"] = headers
+		}
+		return entry, nil
+	case ClientVSCode, ClientCopilot:
+		entry := map[string]any{"type": "http", "url": conn.URL}
+		if headers != nil {
+			entry["headers"] = headers
+		}
+		return entry, nil
+	case ClientGeneric:
+		entry := map[string]any{"transport": "streamable_http", "url": conn.URL}
+		if headers != nil {
+			entry["headers"] = headers
+		}
+		return entry, nil
+	default:
+		_, err := ValidateClientKind(string(kind))
+		return nil, err
+	}
+}
+
+// Fragment returns the JSON config fragment that points the given client at mcpjungle.
+// For clients with a config file format, the fragment can be pasted into (or merged with)
+// the client's config file as-is.
+func Fragment(kind ClientKind, conn Connection) ([]byte, error) {
+	entry, err := ServerEntry(kind, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var fragment any = entry
+	if key := serversKey(kind); key != "" {
+		if conn.Name == "" {
+			return nil, fmt.Errorf("entry name is required for %s configs", kind)
+		}
+		fragment = map[string]any{key: map[string]any{conn.Name: entry}}
+	}
+	return marshalConfig(fragment)
+}
+
+// MergeIntoConfig adds the mcpjungle entry to the contents of an existing client config file
+// and returns the updated contents. All other settings and servers in the file are preserved.
+// An existing entry with the same name is replaced.
+// If existing is empty, a new config containing only the mcpjungle entry is returned.
+func MergeIntoConfig(kind ClientKind, existing []byte, conn Connection) ([]byte, error) {
+	key := serversKey(kind)
+	if key == "" {
+		return nil, fmt.Errorf("%s client has no config file to merge into", kind)
+	}
+	if conn.Name == "" {
+		return nil, fmt.Errorf("entry name is required for %s configs", kind)
+	}
+
+	entry, err := ServerEntry(kind, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := map[string]any{}
+	if len(bytes.TrimSpace(existing)) > 0 {
+		if err := json.Unmarshal(existing, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse existing %s config: %w", kind, err)
+		}
+		if cfg == nil {
+			cfg = map[string]any{}
+		}
+	}
+
+	servers := map[string]any{}
+	if raw, ok := cfg[key]; ok && raw != nil {
+		servers, ok = raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("existing %s config has an invalid %q value, expected an object", kind, key)
+		}
+	}
+	servers[conn.Name] = entry
+	cfg[key] = servers
+
+	return marshalConfig(cfg)
+}
+
+func marshalConfig(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// tokens and urls must stay readable in the generated config files
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to serialize config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
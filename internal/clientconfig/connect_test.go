@@ -0,0 +1,124 @@
+package clientconfig
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFragment(t *testing.T) {
+	conn := Connection{Name: "mcpjungle", URL: "http://localhost:8080/mcp", AccessToken: "secret"}
+
+	tests := []struct {
+		kind ClientKind
+		want string
+	}{
+		{
+			kind: ClientCursor,
+			want: `{"mcpServers":{"mcpjungle":{"headers":{"Authorization":"Bearer secret"},"url":"http://localhost:8080/mcp"}}}`,
+		},
+		{
+			kind: ClientCopilot,
+			want: `{"servers":{"mcpjungle":{"headers":{"Authorization":"Bearer secret"},"type":"http","url":"http://localhost:8080/mcp"}}}`,
+		},
+		{
+			kind: ClientClaude,
+			want: `{"mcpServers":{"mcpjungle":{"args":["-y","mcp-remote","http://localhost:8080/mcp","--allow-http","--header","Authorization:${AUTH_HEADER}"],"command":"npx","env":{"AUTH_HEADER":"Bearer secret"}}}}`,
+		},
+		{
+			kind: ClientGeneric,
+			want: `{"headers":{"Authorization":"Bearer secret"},"transport":"streamable_http","url":"http://localhost:8080/mcp"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind), func(t *testing.T) {
+			got, err := Fragment(tt.kind, conn)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if compactJSON(t, got) != tt.want {
+				t.Errorf("Fragment() = %s, want %s", compactJSON(t, got), tt.want)
+			}
+		})
+	}
+}
+
+func TestFragment_WithoutToken(t *testing.T) {
+	got, err := Fragment(ClientVSCode, Connection{Name: "tools", URL: "https://mcp.example.com/v0/groups/tools/mcp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(got), "headers") {
+		t.Errorf("expected no headers without an access token, got %s", got)
+	}
+
+	claude, err := Fragment(ClientClaude, Connection{Name: "tools", URL: "https://mcp.example.com/mcp"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(claude), "--allow-http") || strings.Contains(string(claude), "AUTH_HEADER") {
+		t.Errorf("unexpected flags for https endpoint without token: %s", claude)
+	}
+}
+
+func TestFragment_Errors(t *testing.T) {
+	if _, err := Fragment(ClientCursor, Connection{Name: "x"}); err == nil {
+		t.Error("expected an error for a missing url")
+	}
+	if _, err := Fragment(ClientCursor, Connection{URL: "http://localhost/mcp"}); err == nil {
+		t.Error("expected an error for a missing entry name")
+	}
+	if _, err := Fragment("windsurf", Connection{Name: "x", URL: "http://localhost/mcp"}); err == nil {
+		t.Error("expected an error for an unsupported client kind")
+	}
+}
+
+func TestMergeIntoConfig(t *testing.T) {
+	existing := []byte(`{
+		"theme": "dark",
+		"mcpServers": {
+			"filesystem": {"command": "npx"},
+			"mcpjungle": {"url": "http://old/mcp"}
+		}
+	}`)
+	conn := Connection{Name: "mcpjungle", URL: "http://localhost:8080/mcp"}
+
+	got, err := MergeIntoConfig(ClientCursor, existing, conn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"mcpServers":{"filesystem":{"command":"npx"},"mcpjungle":{"url":"http://localhost:8080/mcp"}},"theme":"dark"}`
+	if compactJSON(t, got) != want {
+		t.Errorf("MergeIntoConfig() = %s, want %s", compactJSON(t, got), want)
+	}
+
+	created, err := MergeIntoConfig(ClientVSCode, nil, conn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = `{"servers":{"mcpjungle":{"type":"http","url":"http://localhost:8080/mcp"}}}`
+	if compactJSON(t, created) != want {
+		t.Errorf("MergeIntoConfig() = %s, want %s", compactJSON(t, created), want)
+	}
+
+	if _, err := MergeIntoConfig(ClientCursor, []byte(`{"mcpServers": []}`), conn); err == nil {
+		t.Error("expected an error when the servers value is not an object")
+	}
+	if _, err := MergeIntoConfig(ClientGeneric, nil, conn); err == nil {
+		t.Error("expected an error for the generic client")
+	}
+}
+
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal JSON: %v", err)
+	}
+	return string(out)
+}
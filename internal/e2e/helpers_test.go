@@ -127,16 +127,10 @@ func setupE2EServer(t *testing.T, mode model.ServerMode) *e2eEnv {
 		server.WithPromptCapabilities(true),
 		server.WithToolFilter(mcpSvc.ProxyToolFilter),
 	)
-	sseMcpProxy := server.NewMCPServer("MCPJungle SSE", "0.0.1",
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
-		server.WithToolFilter(mcpSvc.ProxyToolFilter),
-	)
 
 	mcpService, err := mcpSvc.NewMCPService(&mcpSvc.ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          mcpProxy,
-		SseMcpProxyServer:       sseMcpProxy,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 30,
 	})
@@ -148,15 +142,14 @@ func setupE2EServer(t *testing.T, mode model.ServerMode) *e2eEnv {
 	require.NoError(t, err)
 
 	apiServer, err := api.NewServer(&api.ServerOptions{
-		MCPProxyServer:    mcpProxy,
-		SseMcpProxyServer: sseMcpProxy,
-		MCPService:        mcpService,
-		MCPClientService:  mcpclient.NewMCPClientService(db),
-		ConfigService:     cfgSvc,
-		DashboardService:  dashboard.NewService(db, false),
-		UserService:       usrSvc,
-		ToolGroupService:  tgSvc,
-		Metrics:           telemetry.NewNoopCustomMetrics(),
+		MCPProxyServer:   mcpProxy,
+		MCPService:       mcpService,
+		MCPClientService: mcpclient.NewMCPClientService(db),
+		ConfigService:    cfgSvc,
+		DashboardService: dashboard.NewService(db, false),
+		UserService:      usrSvc,
+		ToolGroupService: tgSvc,
+		Metrics:          telemetry.NewNoopCustomMetrics(),
 	})
 	require.NoError(t, err)
 
@@ -34,19 +34,12 @@ func TestPromptsIntegration(t *testing.T) {
 		server.WithToolCapabilities(true),
 		server.WithPromptCapabilities(true),
 	)
-	sseMcpProxyServer := server.NewMCPServer(
-		"MCPJungle Proxy MCP Server for SSE transport",
-		"0.0.1",
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
-	)
 	mcpMetrics := telemetry.NewNoopCustomMetrics()
 
 	// Create MCP service
 	conf := &mcpService.ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          mcpProxyServer,
-		SseMcpProxyServer:       sseMcpProxyServer,
 		Metrics:                 mcpMetrics,
 		McpServerInitReqTimeout: 10,
 	}
@@ -133,12 +126,6 @@ func TestResourcesIntegration(t *testing.T) {
 		server.WithToolCapabilities(true),
 		server.WithPromptCapabilities(true),
 	)
-	sseMcpProxyServer := server.NewMCPServer(
-		"MCPJungle Proxy MCP Server for SSE transport",
-		"0.0.1",
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
-	)
 	testServer, err := model.NewStdioServer(
 		"github",
 		"GitHub MCP server",
@@ -171,7 +158,6 @@ func TestResourcesIntegration(t *testing.T) {
 	conf := &mcpService.ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          mcpProxyServer,
-		SseMcpProxyServer:       sseMcpProxyServer,
 		Metrics:                 mcpMetrics,
 		McpServerInitReqTimeout: 10,
 	}
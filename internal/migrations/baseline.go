@@ -0,0 +1,177 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// The baseline* structs are snapshots of the models as they were before versioned migrations were introduced.
+// They must never change: columns added to the models later are added by their own migrations.
+
+type baselineMcpServer struct {
+	gorm.Model
+
+	Name        string         `gorm:"uniqueIndex;not null"`
+	Transport   string         `gorm:"type:varchar(30);not null"`
+	Enabled     bool           `gorm:"default:true"`
+	Description string         ``
+	Config      datatypes.JSON `gorm:"type:jsonb;not null"`
+	SessionMode string         `gorm:"type:varchar(20);default:'stateless'"`
+}
+
+func (baselineMcpServer) TableName() string { return "mcp_servers" }
+
+type baselineTool struct {
+	gorm.Model
+
+	Name        string         `gorm:"not null"`
+	Enabled     bool           `gorm:"default:true"`
+	Description string         ``
+	InputSchema datatypes.JSON `gorm:"type:jsonb"`
+	Annotations datatypes.JSON `gorm:"type:jsonb"`
+
+	ServerID uint              `gorm:"not null"`
+	Server   baselineMcpServer `gorm:"foreignKey:ServerID;references:ID"`
+}
+
+func (baselineTool) TableName() string { return "tools" }
+
+type baselineServerConfig struct {
+	gorm.Model
+
+	Mode        string `gorm:"type:varchar(12);not null"`
+	Initialized bool   `gorm:"not null;default:false"`
+}
+
+func (baselineServerConfig) TableName() string { return "server_configs" }
+
+type baselineUser struct {
+	gorm.Model
+
+	Username    string `gorm:"unique; not null"`
+	Role        string `gorm:"not null"`
+	AccessToken string `gorm:"unique; not null"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+type baselineMcpClient struct {
+	gorm.Model
+
+	Name        string         `gorm:"uniqueIndex;not null"`
+	Description string         ``
+	AccessToken string         `gorm:"unique; not null"`
+	AllowList   datatypes.JSON `gorm:"type:jsonb; not null"`
+}
+
+func (baselineMcpClient) TableName() string { return "mcp_clients" }
+
+type baselineToolGroup struct {
+	gorm.Model
+
+	Name            string         `gorm:"unique; not null"`
+	Description     string         ``
+	IncludedTools   datatypes.JSON `gorm:"type:jsonb"`
+	IncludedServers datatypes.JSON `gorm:"type:jsonb"`
+	ExcludedTools   datatypes.JSON `gorm:"type:jsonb"`
+}
+
+func (baselineToolGroup) TableName() string { return "tool_groups" }
+
+type baselinePrompt struct {
+	gorm.Model
+
+	Name        string         `gorm:"not null"`
+	Enabled     bool           `gorm:"default:true"`
+	Description string         ``
+	Arguments   datatypes.JSON `gorm:"type:jsonb"`
+
+	ServerID uint              `gorm:"not null"`
+	Server   baselineMcpServer `gorm:"foreignKey:ServerID;references:ID"`
+}
+
+func (baselinePrompt) TableName() string { return "prompts" }
+
+type baselineResource struct {
+	gorm.Model
+
+	URI         string         `gorm:"not null"`
+	OriginalURI string         `gorm:"not null"`
+	Name        string         `gorm:"not null"`
+	Enabled     bool           `gorm:"default:true"`
+	Description string         ``
+	MIMEType    string         ``
+	Annotations datatypes.JSON `gorm:"type:jsonb"`
+	Meta        datatypes.JSON `gorm:"type:jsonb"`
+
+	ServerID uint              `gorm:"not null"`
+	Server   baselineMcpServer `gorm:"foreignKey:ServerID;references:ID"`
+}
+
+func (baselineResource) TableName() string { return "resources" }
+
+type baselineUpstreamOAuthPendingSession struct {
+	gorm.Model
+
+	SessionID    string         `gorm:"uniqueIndex;not null"`
+	ServerName   string         `gorm:"index;not null"`
+	Transport    string         `gorm:"type:varchar(30);not null"`
+	ServerInput  datatypes.JSON `gorm:"type:jsonb;not null"`
+	Force        bool           `gorm:"not null;default:false"`
+	RedirectURI  string         ``
+	ClientID     string         ``
+	ClientSecret string         ``
+	Scopes       datatypes.JSON `gorm:"type:jsonb"`
+	State        string         `gorm:"not null"`
+	CodeVerifier string         `gorm:"not null"`
+	ExpiresAt    time.Time      `gorm:"index;not null"`
+	InitiatedBy  string         ``
+}
+
+func (baselineUpstreamOAuthPendingSession) TableName() string {
+	return "upstream_o_auth_pending_sessions"
+}
+
+type baselineUpstreamOAuthToken struct {
+	gorm.Model
+
+	ServerName   string         `gorm:"uniqueIndex;not null"`
+	Transport    string         `gorm:"type:varchar(30);not null"`
+	ClientID     string         ``
+	ClientSecret string         ``
+	RedirectURI  string         ``
+	Scopes       datatypes.JSON `gorm:"type:jsonb"`
+	AccessToken  string         ``
+	TokenType    string         ``
+	RefreshToken string         ``
+	Scope        string         ``
+	ExpiresAt    time.Time      ``
+}
+
+func (baselineUpstreamOAuthToken) TableName() string { return "upstream_o_auth_tokens" }
+
+// baselineUp creates the schema as it existed before versioned migrations were introduced.
+// Databases created by older versions of mcpjungle already have exactly this schema, so AutoMigrate finds
+// nothing to add to them and the baseline is safely applied to them as well.
+func baselineUp(tx *gorm.DB) error {
+	for _, m := range []any{
+		&baselineMcpServer{},
+		&baselineTool{},
+		&baselineServerConfig{},
+		&baselineUser{},
+		&baselineMcpClient{},
+		&baselineToolGroup{},
+		&baselinePrompt{},
+		&baselineResource{},
+		&baselineUpstreamOAuthPendingSession{},
+		&baselineUpstreamOAuthToken{},
+	} {
+		if err := tx.AutoMigrate(m); err != nil {
+			return fmt.Errorf("auto-migration failed for baseline table %T: %v", m, err)
+		}
+	}
+	return nil
+}
@@ -1,44 +1,237 @@
 // Package migrations provides database migration functionality for the MCPJungle application.
+//
+// Migrations are ordered by version and every applied migration is recorded in the
+// schema_migrations table. A migration can change the schema, transform existing data or both.
+// New migrations must always be appended to the registry with the next version number;
+// released migrations must never be modified.
 package migrations
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
-	"github.com/mcpjungle/mcpjungle/internal/model"
 	"gorm.io/gorm"
 )
 
-// Migrate performs the database migration for the application.
+// ErrSchemaTooNew is returned when the database has been migrated by a newer version of mcpjungle.
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of mcpjungle supports")
+
+// Migration is a single, versioned change to the database.
+type Migration struct {
+	// Version uniquely identifies the migration and determines the order in which migrations are applied.
+	Version int
+	// Name is a short, human-readable description of the migration.
+	Name string
+
+	// Up applies the migration.
+	Up func(tx *gorm.DB) error
+	// Down reverts the migration. It is nil if the migration cannot be reverted.
+	Down func(tx *gorm.DB) error
+}
+
+// Reversible returns true if the migration can be rolled back.
+func (m Migration) Reversible() bool {
+	return m.Down != nil
+}
+
+// SchemaMigration is the record of an applied migration, stored in the schema_migrations table.
+type SchemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus describes whether a migration has been applied to the database.
+type MigrationStatus struct {
+	Version    int
+	Name       string
+	Applied    bool
+	AppliedAt  time.Time
+	Reversible bool
+
+	// Unknown is true if the migration was applied by a newer version of mcpjungle
+	// and is not known to this version.
+	Unknown bool
+}
+
+// Migrate applies all pending migrations to the database.
+// It refuses to run against a database whose schema is newer than this version of mcpjungle.
 func Migrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&model.McpServer{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for McpServer model: %v", err)
+	_, err := MigrateUp(db, 0)
+	return err
+}
+
+// LatestVersion returns the version of the newest migration known to this version of mcpjungle.
+func LatestVersion() int {
+	if len(registry) == 0 {
+		return 0
 	}
-	if err := db.AutoMigrate(&model.Tool{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Tool model: %v", err)
+	return registry[len(registry)-1].Version
+}
+
+// CurrentVersion returns the version of the newest migration applied to the database.
+// It returns 0 if no migrations have been applied yet.
+func CurrentVersion(db *gorm.DB) (int, error) {
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return 0, nil
 	}
-	if err := db.AutoMigrate(&model.ServerConfig{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for ServerConfig model: %v", err)
+	var version int
+	if err := db.Model(&SchemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read current schema version: %w", err)
 	}
-	if err := db.AutoMigrate(&model.User{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for User model: %v", err)
+	return version, nil
+}
+
+// CheckSchemaVersion returns ErrSchemaTooNew if the database has been migrated by a newer version of mcpjungle.
+// Running an older mcpjungle against such a database could corrupt data, so startup must be refused.
+func CheckSchemaVersion(db *gorm.DB) error {
+	current, err := CurrentVersion(db)
+	if err != nil {
+		return err
 	}
-	if err := db.AutoMigrate(&model.McpClient{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for McpClient model: %v", err)
+	if latest := LatestVersion(); current > latest {
+		return fmt.Errorf(
+			"%w: database is at version %d but the latest version known to this mcpjungle is %d, upgrade mcpjungle",
+			ErrSchemaTooNew, current, latest,
+		)
 	}
-	if err := db.AutoMigrate(&model.ToolGroup{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for ToolGroup model: %v", err)
+	return nil
+}
+
+// Status returns the status of all known migrations, followed by any applied migrations
+// that are unknown to this version of mcpjungle.
+func Status(db *gorm.DB) ([]MigrationStatus, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
 	}
-	if err := db.AutoMigrate(&model.Prompt{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Prompt model: %v", err)
+
+	result := make([]MigrationStatus, 0, len(registry))
+	for _, m := range registry {
+		s := MigrationStatus{Version: m.Version, Name: m.Name, Reversible: m.Reversible()}
+		if rec, ok := applied[m.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = rec.AppliedAt
+			delete(applied, m.Version)
+		}
+		result = append(result, s)
 	}
-	if err := db.AutoMigrate(&model.Resource{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Resource model: %v", err)
+
+	unknown := make([]MigrationStatus, 0, len(applied))
+	for _, rec := range applied {
+		unknown = append(unknown, MigrationStatus{
+			Version:   rec.Version,
+			Name:      rec.Name,
+			Applied:   true,
+			AppliedAt: rec.AppliedAt,
+			Unknown:   true,
+		})
 	}
-	if err := db.AutoMigrate(&model.UpstreamOAuthPendingSession{}); err != nil {
-		return fmt.Errorf("auto-migration failed for UpstreamOAuthPendingSession model: %v", err)
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+
+	return append(result, unknown...), nil
+}
+
+// MigrateUp applies all pending migrations up to and including the target version.
+// A target of 0 means the latest version. The applied migrations are returned in order.
+func MigrateUp(db *gorm.DB, target int) ([]Migration, error) {
+	if err := CheckSchemaVersion(db); err != nil {
+		return nil, err
 	}
-	if err := db.AutoMigrate(&model.UpstreamOAuthToken{}); err != nil {
-		return fmt.Errorf("auto-migration failed for UpstreamOAuthToken model: %v", err)
+	if target == 0 {
+		target = LatestVersion()
 	}
-	return nil
+	if target > LatestVersion() {
+		return nil, fmt.Errorf("unknown target version %d, the latest version is %d", target, LatestVersion())
+	}
+
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range registry {
+		if m.Version > target {
+			break
+		}
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the given number of most recently applied migrations, newest first.
+// The reverted migrations are returned in the order they were reverted.
+func MigrateDown(db *gorm.DB, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("number of migrations to revert must be at least 1")
+	}
+	if err := CheckSchemaVersion(db); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(registry) - 1; i >= 0 && len(done) < steps; i-- {
+		m := registry[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if !m.Reversible() {
+			return done, fmt.Errorf("migration %d (%s) cannot be reverted", m.Version, m.Name)
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, m.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("reverting migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	if len(done) == 0 {
+		return nil, fmt.Errorf("no applied migrations to revert")
+	}
+	return done, nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int]SchemaMigration, error) {
+	applied := make(map[int]SchemaMigration)
+	if !db.Migrator().HasTable(&SchemaMigration{}) {
+		return applied, nil
+	}
+	var records []SchemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	for _, r := range records {
+		applied[r.Version] = r
+	}
+	return applied, nil
 }
@@ -0,0 +1,316 @@
+package migrations
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	return db
+}
+
+func TestRegistryIsOrdered(t *testing.T) {
+	for i, m := range registry {
+		testhelpers.AssertEqual(t, i+1, m.Version)
+		testhelpers.AssertTrue(t, m.Name != "", "migration must have a name")
+		testhelpers.AssertNotNil(t, m.Up)
+	}
+}
+
+func TestMigrate_FreshDatabase(t *testing.T) {
+	db := newTestDB(t)
+
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	version, err := CurrentVersion(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, LatestVersion(), version)
+	testhelpers.AssertTrue(t, db.Migrator().HasTable(&model.McpServer{}), "expected mcp_servers table")
+
+	// running again is a no-op
+	applied, err := MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(applied))
+}
+
+func TestBaseline_CreatesOnlyTheSchemaBeforeVersionedMigrations(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, baselineUp(db))
+
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpServer{}, "SessionMode"), "expected session mode column")
+	for _, col := range []string{"Namespace", "Labels", "ToolOverrides", "AllowedRoots", "LogLevel", "ServerInfo"} {
+		testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, col), "unexpected mcp server column "+col)
+	}
+	for _, col := range []string{"Namespace", "IncludedGroups", "IncludedToolSelectors", "IncludedPrompts"} {
+		testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.ToolGroup{}, col), "unexpected tool group column "+col)
+	}
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpClient{}, "AllowedGroups"), "unexpected allowed groups column")
+}
+
+func TestMigrate_CreatesEveryModelColumn(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	for _, m := range []any{
+		&model.McpServer{}, &model.Tool{}, &model.ServerConfig{}, &model.User{}, &model.McpClient{},
+		&model.ToolGroup{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{},
+		&model.UpstreamOAuthPendingSession{}, &model.UpstreamOAuthToken{}, &model.UpstreamOAuthSessionResult{},
+		&model.Revision{}, &model.RegistryChange{}, &model.Namespace{},
+	} {
+		stmt := &gorm.Statement{DB: db}
+		testhelpers.AssertNoError(t, stmt.Parse(m))
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			testhelpers.AssertTrue(
+				t, db.Migrator().HasColumn(m, field.DBName),
+				"expected column "+field.DBName+" of table "+stmt.Schema.Table,
+			)
+		}
+	}
+}
+
+func TestMigrate_LegacyDatabase(t *testing.T) {
+	db := newTestDB(t)
+
+	// simulate a database created by AutoMigrate before versioned migrations existed
+	testhelpers.AssertNoError(t, baselineUp(db))
+	testhelpers.AssertNoError(t, db.Exec(
+		"INSERT INTO mcp_clients (name, access_token, allow_list) VALUES (?, ?, ?), (?, ?, ?)",
+		"legacy", "legacy-token", "null", "ok", "ok-token", `["calc"]`,
+	).Error)
+
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	var legacy, ok model.McpClient
+	testhelpers.AssertNoError(t, db.Where("name = ?", "legacy").First(&legacy).Error)
+	testhelpers.AssertEqual(t, "[]", string(legacy.AllowList))
+	testhelpers.AssertNoError(t, db.Where("name = ?", "ok").First(&ok).Error)
+	testhelpers.AssertEqual(t, `["calc"]`, string(ok.AllowList))
+}
+
+func TestMigrateUpToTargetAndDown(t *testing.T) {
+	db := newTestDB(t)
+
+	applied, err := MigrateUp(db, 1)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(applied))
+
+	statuses, err := Status(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, len(registry), len(statuses))
+	testhelpers.AssertTrue(t, statuses[0].Applied, "expected baseline to be applied")
+	testhelpers.AssertTrue(t, !statuses[1].Applied, "expected second migration to be pending")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+
+	reverted, err := MigrateDown(db, 1)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, LatestVersion(), reverted[0].Version)
+
+	version, err := CurrentVersion(db)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, LatestVersion()-1, version)
+
+	// the baseline is irreversible
+	_, err = MigrateDown(db, len(registry))
+	testhelpers.AssertError(t, err)
+
+	_, err = MigrateUp(db, LatestVersion()+1)
+	testhelpers.AssertError(t, err)
+}
+
+func TestMigrate_AddNamespaces(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+	testhelpers.AssertTrue(t, db.Migrator().HasTable(&model.Namespace{}), "expected namespaces table")
+
+	// revert down to the version right before namespaces were added
+	_, err := MigrateDown(db, LatestVersion()-4)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasTable(&model.Namespace{}), "expected namespaces table to be dropped")
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, "Namespace"),
+		"expected namespace column to be dropped")
+
+	// records created before namespaces existed end up outside any namespace
+	testhelpers.AssertNoError(t, db.Exec(
+		"INSERT INTO mcp_clients (name, access_token, allow_list) VALUES (?, ?, ?)", "old", "old-token", "[]",
+	).Error)
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	for _, m := range namespacedModels {
+		testhelpers.AssertTrue(t, db.Migrator().HasColumn(m, "Namespace"), "expected namespace column")
+	}
+
+	var old model.McpClient
+	testhelpers.AssertNoError(t, db.Where("name = ?", "old").First(&old).Error)
+	testhelpers.AssertEqual(t, "", old.Namespace)
+}
+
+func TestMigrate_AddToolGroupPromptsAndResources(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-5)
+	testhelpers.AssertNoError(t, err)
+	for _, col := range toolGroupPromptAndResourceColumns {
+		testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.ToolGroup{}, col), "expected column to be dropped: "+col)
+	}
+
+	testhelpers.AssertNoError(t, db.Exec(
+		"INSERT INTO tool_groups (name, included_tools) VALUES (?, ?)", "old", `["a__b"]`,
+	).Error)
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	for _, col := range toolGroupPromptAndResourceColumns {
+		testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.ToolGroup{}, col), "expected column: "+col)
+	}
+
+	// groups created before this migration expose no prompts or resources
+	var old model.ToolGroup
+	testhelpers.AssertNoError(t, db.Where("name = ?", "old").First(&old).Error)
+	prompts, err := old.GetPrompts()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(prompts))
+}
+
+func TestMigrate_AddToolSelectorsAndServerLabels(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-6)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, "Labels"), "expected labels column to be dropped")
+	for _, col := range toolSelectorColumns {
+		testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.ToolGroup{}, col), "expected column to be dropped: "+col)
+	}
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpServer{}, "Labels"), "expected labels column")
+	for _, col := range toolSelectorColumns {
+		testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.ToolGroup{}, col), "expected column: "+col)
+	}
+}
+
+func TestMigrate_AddToolGroupIncludedGroups(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-7)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.ToolGroup{}, "IncludedGroups"), "expected included groups column to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.ToolGroup{}, "IncludedGroups"), "expected included groups column")
+}
+
+func TestMigrate_AddMcpClientAllowedGroups(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-8)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpClient{}, "AllowedGroups"), "expected allowed groups column to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpClient{}, "AllowedGroups"), "expected allowed groups column")
+}
+
+func TestMigrate_AddToolOverrides(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-9)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, "ToolOverrides"), "expected server tool overrides column to be dropped")
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.ToolGroup{}, "ToolOverrides"), "expected group tool overrides column to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpServer{}, "ToolOverrides"), "expected server tool overrides column")
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.ToolGroup{}, "ToolOverrides"), "expected group tool overrides column")
+}
+
+func TestMigrate_AddMcpServerAllowedRoots(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-10)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, "AllowedRoots"), "expected allowed roots column to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpServer{}, "AllowedRoots"), "expected allowed roots column")
+}
+
+func TestMigrate_AddResourceTemplates(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+	testhelpers.AssertTrue(t, db.Migrator().HasTable(&model.ResourceTemplate{}), "expected resource templates table")
+
+	_, err := MigrateDown(db, LatestVersion()-11)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasTable(&model.ResourceTemplate{}), "expected resource templates table to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasTable(&model.ResourceTemplate{}), "expected resource templates table")
+}
+
+func TestMigrate_AddMcpServerLogLevel(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-12)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, "LogLevel"), "expected log level column to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpServer{}, "LogLevel"), "expected log level column")
+}
+
+func TestMigrate_AddMcpServerInfo(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	_, err := MigrateDown(db, LatestVersion()-13)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, !db.Migrator().HasColumn(&model.McpServer{}, "ServerInfo"), "expected server info column to be dropped")
+
+	_, err = MigrateUp(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, db.Migrator().HasColumn(&model.McpServer{}, "ServerInfo"), "expected server info column")
+}
+
+func TestCheckSchemaVersion_RefusesNewerSchema(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, Migrate(db))
+
+	testhelpers.AssertNoError(t, db.Create(&SchemaMigration{Version: LatestVersion() + 1, Name: "from_the_future"}).Error)
+
+	err := CheckSchemaVersion(db)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrSchemaTooNew), "expected ErrSchemaTooNew")
+	testhelpers.AssertTrue(t, errors.Is(Migrate(db), ErrSchemaTooNew), "expected Migrate to refuse a newer schema")
+
+	statuses, err := Status(db)
+	testhelpers.AssertNoError(t, err)
+	last := statuses[len(statuses)-1]
+	testhelpers.AssertTrue(t, last.Unknown, "expected unknown migration to be reported")
+	testhelpers.AssertEqual(t, "from_the_future", last.Name)
+}
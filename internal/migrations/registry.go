@@ -0,0 +1,357 @@
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// registry is the ordered list of all migrations known to this version of mcpjungle.
+var registry = []Migration{
+	{
+		Version: 1,
+		Name:    "baseline",
+		Up:      baselineUp,
+		// the baseline creates every table, reverting it would destroy all data
+		Down: nil,
+	},
+	{
+		Version: 2,
+		Name:    "normalize_mcp_client_allow_list",
+		Up:      normalizeMcpClientAllowListUp,
+		// a null allow list and an empty one both deny access to all servers,
+		// so there is nothing to undo
+		Down: func(tx *gorm.DB) error { return nil },
+	},
+	{
+		Version: 3,
+		Name:    "create_revisions",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&model.Revision{}); err != nil {
+				return fmt.Errorf("auto-migration failed for Revision model: %v", err)
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&model.Revision{})
+		},
+	},
+	{
+		Version: 4,
+		Name:    "create_replica_shared_state",
+		Up: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&model.RegistryChange{}); err != nil {
+				return fmt.Errorf("auto-migration failed for RegistryChange model: %v", err)
+			}
+			if err := tx.AutoMigrate(&model.UpstreamOAuthSessionResult{}); err != nil {
+				return fmt.Errorf("auto-migration failed for UpstreamOAuthSessionResult model: %v", err)
+			}
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&model.RegistryChange{}, &model.UpstreamOAuthSessionResult{})
+		},
+	},
+	{
+		Version: 5,
+		Name:    "add_namespaces",
+		Up:      addNamespacesUp,
+		Down:    addNamespacesDown,
+	},
+	{
+		Version: 6,
+		Name:    "add_tool_group_prompts_and_resources",
+		Up:      addToolGroupPromptsAndResourcesUp,
+		Down:    addToolGroupPromptsAndResourcesDown,
+	},
+	{
+		Version: 7,
+		Name:    "add_tool_selectors_and_server_labels",
+		Up:      addToolSelectorsAndServerLabelsUp,
+		Down:    addToolSelectorsAndServerLabelsDown,
+	},
+	{
+		Version: 8,
+		Name:    "add_tool_group_included_groups",
+		Up:      addToolGroupIncludedGroupsUp,
+		Down:    addToolGroupIncludedGroupsDown,
+	},
+	{
+		Version: 9,
+		Name:    "add_mcp_client_allowed_groups",
+		Up:      addMcpClientAllowedGroupsUp,
+		Down:    addMcpClientAllowedGroupsDown,
+	},
+	{
+		Version: 10,
+		Name:    "add_tool_overrides",
+		Up:      addToolOverridesUp,
+		Down:    addToolOverridesDown,
+	},
+	{
+		Version: 11,
+		Name:    "add_mcp_server_allowed_roots",
+		Up:      addMcpServerAllowedRootsUp,
+		Down:    addMcpServerAllowedRootsDown,
+	},
+	{
+		Version: 12,
+		Name:    "add_resource_templates",
+		Up:      addResourceTemplatesUp,
+		Down:    addResourceTemplatesDown,
+	},
+	{
+		Version: 13,
+		Name:    "add_mcp_server_log_level",
+		Up:      addMcpServerLogLevelUp,
+		Down:    addMcpServerLogLevelDown,
+	},
+	{
+		Version: 14,
+		Name:    "add_mcp_server_info",
+		Up:      addMcpServerInfoUp,
+		Down:    addMcpServerInfoDown,
+	},
+}
+
+// toolGroupPromptAndResourceColumns are the tool group columns that select prompts and resources.
+var toolGroupPromptAndResourceColumns = []string{
+	"IncludedPrompts", "ExcludedPrompts", "IncludedResources", "ExcludedResources",
+}
+
+// addToolGroupPromptsAndResourcesUp adds the prompt and resource selection columns to tool groups.
+// Existing groups keep exposing tools only, since their new columns are empty.
+func addToolGroupPromptsAndResourcesUp(tx *gorm.DB) error {
+	for _, col := range toolGroupPromptAndResourceColumns {
+		if err := tx.Migrator().AddColumn(&model.ToolGroup{}, col); err != nil {
+			return fmt.Errorf("failed to add column %s to tool groups: %w", col, err)
+		}
+	}
+	return nil
+}
+
+func addToolGroupPromptsAndResourcesDown(tx *gorm.DB) error {
+	for _, col := range toolGroupPromptAndResourceColumns {
+		if err := tx.Migrator().DropColumn(&model.ToolGroup{}, col); err != nil {
+			return fmt.Errorf("failed to drop column %s from tool groups: %w", col, err)
+		}
+	}
+	return nil
+}
+
+// namespacedModels are the models whose records belong to a namespace.
+var namespacedModels = []any{&model.McpServer{}, &model.ToolGroup{}, &model.McpClient{}, &model.User{}}
+
+// addNamespacesUp creates the namespaces table and adds the namespace column to the namespaced models.
+// Existing records are not part of any namespace, which the column's default value reflects.
+func addNamespacesUp(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&model.Namespace{}); err != nil {
+		return fmt.Errorf("auto-migration failed for Namespace model: %v", err)
+	}
+	for _, m := range namespacedModels {
+		if err := tx.Migrator().AddColumn(m, "Namespace"); err != nil {
+			return fmt.Errorf("failed to add namespace column for %T: %w", m, err)
+		}
+		if err := tx.Migrator().CreateIndex(m, "Namespace"); err != nil {
+			return fmt.Errorf("failed to create namespace index for %T: %w", m, err)
+		}
+	}
+	return nil
+}
+
+func addNamespacesDown(tx *gorm.DB) error {
+	for _, m := range namespacedModels {
+		if tx.Migrator().HasIndex(m, "Namespace") {
+			if err := tx.Migrator().DropIndex(m, "Namespace"); err != nil {
+				return fmt.Errorf("failed to drop namespace index for %T: %w", m, err)
+			}
+		}
+		if err := tx.Migrator().DropColumn(m, "Namespace"); err != nil {
+			return fmt.Errorf("failed to drop namespace column for %T: %w", m, err)
+		}
+	}
+	return tx.Migrator().DropTable(&model.Namespace{})
+}
+
+// toolSelectorColumns are the tool group columns that select tools by rules.
+var toolSelectorColumns = []string{"IncludedToolSelectors", "ExcludedToolSelectors"}
+
+// addToolSelectorsAndServerLabelsUp adds the tool selector columns to tool groups and the labels column
+// to MCP servers, which tool selectors can match.
+func addToolSelectorsAndServerLabelsUp(tx *gorm.DB) error {
+	for _, col := range toolSelectorColumns {
+		if err := tx.Migrator().AddColumn(&model.ToolGroup{}, col); err != nil {
+			return fmt.Errorf("failed to add column %s to tool groups: %w", col, err)
+		}
+	}
+	if err := tx.Migrator().AddColumn(&model.McpServer{}, "Labels"); err != nil {
+		return fmt.Errorf("failed to add labels column to mcp servers: %w", err)
+	}
+	return nil
+}
+
+func addToolSelectorsAndServerLabelsDown(tx *gorm.DB) error {
+	for _, col := range toolSelectorColumns {
+		if err := tx.Migrator().DropColumn(&model.ToolGroup{}, col); err != nil {
+			return fmt.Errorf("failed to drop column %s from tool groups: %w", col, err)
+		}
+	}
+	if err := tx.Migrator().DropColumn(&model.McpServer{}, "Labels"); err != nil {
+		return fmt.Errorf("failed to drop labels column from mcp servers: %w", err)
+	}
+	return nil
+}
+
+// addToolGroupIncludedGroupsUp adds the column listing the other groups a tool group includes.
+func addToolGroupIncludedGroupsUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&model.ToolGroup{}, "IncludedGroups"); err != nil {
+		return fmt.Errorf("failed to add included groups column to tool groups: %w", err)
+	}
+	return nil
+}
+
+func addToolGroupIncludedGroupsDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&model.ToolGroup{}, "IncludedGroups"); err != nil {
+		return fmt.Errorf("failed to drop included groups column from tool groups: %w", err)
+	}
+	return nil
+}
+
+// addMcpClientAllowedGroupsUp adds the column listing the tool groups an MCP client is bound to.
+func addMcpClientAllowedGroupsUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&model.McpClient{}, "AllowedGroups"); err != nil {
+		return fmt.Errorf("failed to add allowed groups column to mcp clients: %w", err)
+	}
+	return nil
+}
+
+func addMcpClientAllowedGroupsDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&model.McpClient{}, "AllowedGroups"); err != nil {
+		return fmt.Errorf("failed to drop allowed groups column from mcp clients: %w", err)
+	}
+	return nil
+}
+
+// addToolOverridesUp adds the tool overrides columns to MCP servers and tool groups.
+func addToolOverridesUp(tx *gorm.DB) error {
+	for _, table := range []any{&model.McpServer{}, &model.ToolGroup{}} {
+		if err := tx.Migrator().AddColumn(table, "ToolOverrides"); err != nil {
+			return fmt.Errorf("failed to add tool overrides column: %w", err)
+		}
+	}
+	return nil
+}
+
+func addToolOverridesDown(tx *gorm.DB) error {
+	for _, table := range []any{&model.McpServer{}, &model.ToolGroup{}} {
+		if err := tx.Migrator().DropColumn(table, "ToolOverrides"); err != nil {
+			return fmt.Errorf("failed to drop tool overrides column: %w", err)
+		}
+	}
+	return nil
+}
+
+// addMcpServerAllowedRootsUp adds the column of the roots that MCP clients may share with an MCP server.
+func addMcpServerAllowedRootsUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&model.McpServer{}, "AllowedRoots"); err != nil {
+		return fmt.Errorf("failed to add allowed roots column: %w", err)
+	}
+	return nil
+}
+
+func addMcpServerAllowedRootsDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&model.McpServer{}, "AllowedRoots"); err != nil {
+		return fmt.Errorf("failed to drop allowed roots column: %w", err)
+	}
+	return nil
+}
+
+// resourceTemplateV12 is a snapshot of the resource template model as it was introduced.
+// AutoMigrate also migrates the tables a model belongs to, so migrating the current model would
+// add the columns of later migrations to the mcp_servers table.
+type resourceTemplateV12 struct {
+	gorm.Model
+
+	URITemplate         string         `gorm:"not null"`
+	OriginalURITemplate string         `gorm:"not null"`
+	Name                string         `gorm:"not null"`
+	Description         string         ``
+	MIMEType            string         ``
+	Annotations         datatypes.JSON `gorm:"type:jsonb"`
+	Meta                datatypes.JSON `gorm:"type:jsonb"`
+
+	ServerID uint              `gorm:"not null"`
+	Server   baselineMcpServer `gorm:"foreignKey:ServerID;references:ID"`
+}
+
+func (resourceTemplateV12) TableName() string { return "resource_templates" }
+
+// addResourceTemplatesUp creates the table of the resource templates provided by MCP servers.
+// Templates of the servers registered before are discovered when the servers are updated or registered again.
+func addResourceTemplatesUp(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&resourceTemplateV12{}); err != nil {
+		return fmt.Errorf("auto-migration failed for ResourceTemplate model: %v", err)
+	}
+	return nil
+}
+
+func addResourceTemplatesDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable(&model.ResourceTemplate{})
+}
+
+// addMcpServerLogLevelUp adds the column of the log level that mcpjungle sets on an MCP server.
+func addMcpServerLogLevelUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&model.McpServer{}, "LogLevel"); err != nil {
+		return fmt.Errorf("failed to add log level column: %w", err)
+	}
+	return nil
+}
+
+func addMcpServerLogLevelDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&model.McpServer{}, "LogLevel"); err != nil {
+		return fmt.Errorf("failed to drop log level column: %w", err)
+	}
+	return nil
+}
+
+// addMcpServerInfoUp adds the column of the information that MCP servers report about themselves
+// during initialization. Existing servers have none until they are updated.
+func addMcpServerInfoUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&model.McpServer{}, "ServerInfo"); err != nil {
+		return fmt.Errorf("failed to add server info column: %w", err)
+	}
+	return nil
+}
+
+func addMcpServerInfoDown(tx *gorm.DB) error {
+	if err := tx.Migrator().DropColumn(&model.McpServer{}, "ServerInfo"); err != nil {
+		return fmt.Errorf("failed to drop server info column: %w", err)
+	}
+	return nil
+}
+
+// normalizeMcpClientAllowListUp rewrites MCP client allow lists that were stored as JSON null
+// (or are otherwise not a JSON array of strings) into an empty JSON array.
+// Older versions of mcpjungle stored null when a client was created without an allow list.
+func normalizeMcpClientAllowListUp(tx *gorm.DB) error {
+	var clients []model.McpClient
+	if err := tx.Find(&clients).Error; err != nil {
+		return fmt.Errorf("failed to read mcp clients: %w", err)
+	}
+	for _, c := range clients {
+		var allowList []string
+		if err := json.Unmarshal(c.AllowList, &allowList); err == nil && allowList != nil {
+			continue
+		}
+		err := tx.Model(&model.McpClient{}).
+			Where("id = ?", c.ID).
+			Update("allow_list", datatypes.JSON("[]")).Error
+		if err != nil {
+			return fmt.Errorf("failed to normalize allow list of mcp client %s: %w", c.Name, err)
+		}
+	}
+	return nil
+}
@@ -12,20 +12,65 @@ import (
 type McpClient struct {
 	gorm.Model
 
+	// Name is the canonical name of the client, which includes its namespace (see QualifiedName).
 	Name        string `json:"name" gorm:"uniqueIndex;not null"`
+	Namespace   string `json:"namespace" gorm:"index;not null;default:''"`
 	Description string `json:"description"`
 
 	AccessToken string `json:"access_token" gorm:"unique; not null"`
 
-	// AllowList contains a list of MCP Server names that this client is allowed to view and call
+	// AllowList contains a list of MCP Server names that this client is allowed to view and call.
+	// The names are relative to the client's namespace.
 	// storing the list of server names as a JSON array is a convenient way for now.
 	// In the future, this will be removed in favor of a separate table for ACLs.
 	AllowList datatypes.JSON `json:"allow_list" gorm:"type:jsonb; not null"`
+
+	// AllowedGroups contains a list of tool group names, relative to the client's namespace.
+	// If it is not empty, the client is bound to these groups: it can only connect to their MCP endpoints
+	// and is denied access to the global MCP proxy.
+	AllowedGroups datatypes.JSON `json:"allowed_groups" gorm:"type:jsonb"`
+}
+
+// GetAllowedGroups unmarshals the AllowedGroups JSON array into a slice of strings.
+func (c *McpClient) GetAllowedGroups() ([]string, error) {
+	return unmarshalNames(c.AllowedGroups)
+}
+
+// IsBoundToGroups returns true if the client can only access the MCP endpoints of specific tool groups.
+// A client whose allowed groups cannot be read is considered bound, so that it fails closed.
+func (c *McpClient) IsBoundToGroups() bool {
+	groups, err := c.GetAllowedGroups()
+	return err != nil || len(groups) > 0
+}
+
+// CheckHasGroupAccess returns true if this client has access to the MCP endpoints of the specified tool group.
+// Clients that are not bound to groups can access the endpoints of all groups.
+func (c *McpClient) CheckHasGroupAccess(groupName string) bool {
+	if !InNamespace(groupName, c.Namespace) {
+		return false
+	}
+	groups, err := c.GetAllowedGroups()
+	if err != nil {
+		return false
+	}
+	if len(groups) == 0 {
+		return true
+	}
+	for _, allowed := range groups {
+		if QualifiedName(c.Namespace, allowed) == groupName {
+			return true
+		}
+	}
+	return false
 }
 
 // CheckHasServerAccess returns true if this client has access to the specified MCP server.
 // If not, it returns false.
 func (c *McpClient) CheckHasServerAccess(serverName string) bool {
+	// a client in a namespace can never access MCP servers outside of it, not even with the wildcard
+	if !InNamespace(serverName, c.Namespace) {
+		return false
+	}
 	if c.AllowList == nil {
 		return false
 	}
@@ -35,7 +80,7 @@ func (c *McpClient) CheckHasServerAccess(serverName string) bool {
 	}
 	for _, allowed := range allowedServers {
 		// If the client's allow list contains wildcard, then it is allowed to access all mcp servers
-		if allowed == types.AllowAllMcpServers || allowed == serverName {
+		if allowed == types.AllowAllMcpServers || QualifiedName(c.Namespace, allowed) == serverName {
 			return true
 		}
 	}
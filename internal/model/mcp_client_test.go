@@ -71,3 +71,77 @@ func TestMcpClient_CheckHasServerAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestMcpClient_CheckHasGroupAccess(t *testing.T) {
+	cases := []struct {
+		name      string
+		namespace string
+		groups    datatypes.JSON
+		group     string
+		wantBound bool
+		want      bool
+	}{
+		{
+			name:   "unbound client can access any group",
+			groups: datatypes.JSON(nil),
+			group:  "math",
+			want:   true,
+		},
+		{
+			name:   "empty array is unbound",
+			groups: datatypes.JSON("[]"),
+			group:  "math",
+			want:   true,
+		},
+		{
+			name:      "bound client can access its group",
+			groups:    datatypes.JSON(`["math","docs"]`),
+			group:     "docs",
+			wantBound: true,
+			want:      true,
+		},
+		{
+			name:      "bound client cannot access other groups",
+			groups:    datatypes.JSON(`["math"]`),
+			group:     "docs",
+			wantBound: true,
+			want:      false,
+		},
+		{
+			name:      "group names are relative to the client's namespace",
+			namespace: "team-a",
+			groups:    datatypes.JSON(`["math"]`),
+			group:     "team-a.math",
+			wantBound: true,
+			want:      true,
+		},
+		{
+			name:      "unbound client cannot access groups outside its namespace",
+			namespace: "team-a",
+			groups:    datatypes.JSON(nil),
+			group:     "team-b.math",
+			want:      false,
+		},
+		{
+			name:      "malformed json is bound to nothing",
+			groups:    datatypes.JSON("not-json"),
+			group:     "math",
+			wantBound: true,
+			want:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			client := &McpClient{Namespace: tc.namespace, AllowedGroups: tc.groups}
+			if got := client.IsBoundToGroups(); got != tc.wantBound {
+				t.Fatalf("case %q: IsBoundToGroups() = %v, want %v", tc.name, got, tc.wantBound)
+			}
+			if got := client.CheckHasGroupAccess(tc.group); got != tc.want {
+				t.Fatalf("case %q: CheckHasGroupAccess(%q) = %v, want %v", tc.name, tc.group, got, tc.want)
+			}
+		})
+	}
+}
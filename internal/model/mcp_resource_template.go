@@ -0,0 +1,34 @@
+package model
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ResourceTemplate represents a resource template provided by an MCP server.
+// Resource templates describe parameterized resources, which clients read by expanding the URI template.
+type ResourceTemplate struct {
+	gorm.Model
+
+	// URITemplate is the MCPJungle-assigned public URI template for this resource template.
+	URITemplate string `json:"uri_template" gorm:"not null"`
+
+	// OriginalURITemplate is the template's original URI template as advertised by the upstream MCP server
+	OriginalURITemplate string `json:"-" gorm:"not null"`
+
+	// Name is the upstream display name of the resource template, without the server name prefix.
+	Name string `json:"name" gorm:"not null"`
+
+	Description string `json:"description"`
+	MIMEType    string `json:"mime_type"`
+
+	// Annotations stores upstream MCP resource template annotations.
+	Annotations datatypes.JSON `json:"annotations" gorm:"type:jsonb"`
+
+	// Meta stores upstream MCP resource template metadata.
+	Meta datatypes.JSON `json:"meta" gorm:"type:jsonb"`
+
+	// ServerID is the ID of the MCP server that provides this resource template.
+	ServerID uint      `json:"-" gorm:"not null"`
+	Server   McpServer `json:"-" gorm:"foreignKey:ServerID;references:ID"`
+}
@@ -3,6 +3,9 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
@@ -44,7 +47,9 @@ type SSEConfig struct {
 type McpServer struct {
 	gorm.Model
 
+	// Name is the canonical name of the server, which includes its namespace (see QualifiedName).
 	Name      string                   `json:"name" gorm:"uniqueIndex;not null"`
+	Namespace string                   `json:"namespace" gorm:"index;not null;default:''"`
 	Transport types.McpServerTransport `json:"transport" gorm:"type:varchar(30);not null"`
 	Enabled   bool                     `json:"enabled" gorm:"default:true"`
 
@@ -58,6 +63,139 @@ type McpServer struct {
 	// "stateless" (default): Creates a new connection for each tool call.
 	// "stateful": Maintains a persistent connection across tool calls.
 	SessionMode types.SessionMode `json:"session_mode" gorm:"type:varchar(20);default:'stateless'"`
+
+	// Labels contains a JSON array of the labels attached to the server.
+	// Tool groups can select tools by the labels of their servers.
+	Labels datatypes.JSON `json:"labels" gorm:"type:jsonb"`
+
+	// ToolOverrides contains a JSON object of types.ToolOverride keyed by the names of the server's tools
+	// (without the server name prefix).
+	// They change how the MCP proxy and, unless overridden there, tool groups expose the tools.
+	ToolOverrides datatypes.JSON `json:"tool_overrides" gorm:"type:jsonb"`
+
+	// AllowedRoots contains a JSON array of the root URIs that MCP clients may share with the server.
+	// The roots of a client are only passed on to the server if they are within these roots, and
+	// none are passed on if there are no allowed roots.
+	AllowedRoots datatypes.JSON `json:"allowed_roots" gorm:"type:jsonb"`
+
+	// LogLevel is the minimum level of the log messages that mcpjungle asks the server to send (logging/setLevel).
+	// It is only applied to stateful sessions. If empty, the server sends the messages of its default level.
+	LogLevel string `json:"log_level" gorm:"type:varchar(20)"`
+
+	// ServerInfo contains a JSON object of types.UpstreamServerInfo, which describes the server as it reported
+	// itself during initialization, including its usage instructions.
+	// It is captured when the server is registered or updated.
+	ServerInfo datatypes.JSON `json:"server_info" gorm:"type:jsonb"`
+}
+
+// GetServerInfo unmarshals the ServerInfo JSON object.
+// It returns nil if no information was captured from the server.
+func (s *McpServer) GetServerInfo() (*types.UpstreamServerInfo, error) {
+	if s.ServerInfo == nil {
+		return nil, nil
+	}
+	var info types.UpstreamServerInfo
+	if err := json.Unmarshal(s.ServerInfo, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// SetServerInfo stores the given information reported by the server as its server info.
+func (s *McpServer) SetServerInfo(info *types.UpstreamServerInfo) error {
+	if info == nil {
+		s.ServerInfo = nil
+		return nil
+	}
+	infoJSON, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	s.ServerInfo = infoJSON
+	return nil
+}
+
+// SetLogLevel validates the given MCP log level and stores it as the server's log level.
+func (s *McpServer) SetLogLevel(level string) error {
+	if err := types.ValidateLogLevel(level); err != nil {
+		return err
+	}
+	s.LogLevel = level
+	return nil
+}
+
+// GetAllowedRoots unmarshals the AllowedRoots JSON array into a slice of URIs.
+func (s *McpServer) GetAllowedRoots() ([]string, error) {
+	if s.AllowedRoots == nil {
+		return []string{}, nil
+	}
+	var roots []string
+	err := json.Unmarshal(s.AllowedRoots, &roots)
+	return roots, err
+}
+
+// SetAllowedRoots validates the given root URIs and stores them as the server's allowed roots.
+func (s *McpServer) SetAllowedRoots(roots []string) error {
+	for _, root := range roots {
+		u, err := url.Parse(root)
+		if err != nil || u.Scheme == "" {
+			return fmt.Errorf("allowed root %q must be an absolute URI, eg- file:///home/user/projects", root)
+		}
+	}
+	if len(roots) == 0 {
+		s.AllowedRoots = nil
+		return nil
+	}
+	rootsJSON, err := json.Marshal(roots)
+	if err != nil {
+		return err
+	}
+	s.AllowedRoots = rootsJSON
+	return nil
+}
+
+// GetToolOverrides unmarshals the ToolOverrides JSON object.
+func (s *McpServer) GetToolOverrides() (map[string]types.ToolOverride, error) {
+	return unmarshalToolOverrides(s.ToolOverrides)
+}
+
+// SetToolOverrides validates the given tool overrides and stores them as the server's tool overrides.
+func (s *McpServer) SetToolOverrides(overrides map[string]types.ToolOverride) error {
+	j, err := marshalToolOverrides(overrides)
+	if err != nil {
+		return err
+	}
+	s.ToolOverrides = j
+	return nil
+}
+
+// GetLabels unmarshals the Labels JSON array into a slice of strings.
+func (s *McpServer) GetLabels() ([]string, error) {
+	if s.Labels == nil {
+		return []string{}, nil
+	}
+	var labels []string
+	err := json.Unmarshal(s.Labels, &labels)
+	return labels, err
+}
+
+// SetLabels validates the given labels and stores them as the server's labels.
+func (s *McpServer) SetLabels(labels []string) error {
+	for _, label := range labels {
+		if strings.TrimSpace(label) == "" {
+			return errors.New("server labels must not be empty")
+		}
+	}
+	if len(labels) == 0 {
+		s.Labels = nil
+		return nil
+	}
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return err
+	}
+	s.Labels = labelsJSON
+	return nil
 }
 
 // NewStreamableHTTPServer creates a new MCP server with streamable HTTP transport configuration.
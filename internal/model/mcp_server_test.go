@@ -462,3 +462,77 @@ func TestNewSSEServerWithSessionMode(t *testing.T) {
 		})
 	}
 }
+
+func TestMcpServer_SetLabels(t *testing.T) {
+	server := &McpServer{Name: "test-server"}
+
+	if err := server.SetLabels([]string{"prod", " "}); err == nil {
+		t.Error("expected blank label to be rejected")
+	}
+
+	if err := server.SetLabels([]string{"prod", "team-a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	labels, err := server.GetLabels()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != "prod" || labels[1] != "team-a" {
+		t.Errorf("expected [prod team-a], got %v", labels)
+	}
+
+	if err := server.SetLabels(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.Labels != nil {
+		t.Errorf("expected labels to be cleared, got %s", server.Labels)
+	}
+}
+
+func TestMcpServer_SetAllowedRoots(t *testing.T) {
+	server := &McpServer{Name: "test-server"}
+
+	if err := server.SetAllowedRoots([]string{"/home/user/projects"}); err == nil {
+		t.Error("expected a root without a scheme to be rejected")
+	}
+
+	if err := server.SetAllowedRoots([]string{"file:///srv/data"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	roots, err := server.GetAllowedRoots()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(roots) != 1 || roots[0] != "file:///srv/data" {
+		t.Errorf("expected [file:///srv/data], got %v", roots)
+	}
+
+	if err := server.SetAllowedRoots(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.AllowedRoots != nil {
+		t.Errorf("expected allowed roots to be cleared, got %s", server.AllowedRoots)
+	}
+}
+
+func TestMcpServer_SetLogLevel(t *testing.T) {
+	server := &McpServer{Name: "test-server"}
+
+	if err := server.SetLogLevel("verbose"); err == nil {
+		t.Error("expected an unknown log level to be rejected")
+	}
+
+	if err := server.SetLogLevel("warning"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.LogLevel != "warning" {
+		t.Errorf("expected log level warning, got %s", server.LogLevel)
+	}
+
+	if err := server.SetLogLevel(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server.LogLevel != "" {
+		t.Errorf("expected log level to be cleared, got %s", server.LogLevel)
+	}
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// NamespaceSep is the separator used to combine a namespace and the name of an entity in it.
+// This combination produces the canonical name that uniquely identifies the entity across MCPJungle,
+// eg- the MCP server `github` in the namespace `team-a` is identified by `team-a.github`.
+// Entities outside any namespace are identified by their name alone.
+const NamespaceSep = "."
+
+// ValidNamespaceName matches valid namespace names.
+// Namespace names must not contain underscores or dots, because they are part of the canonical names
+// of MCP servers, which are in turn part of the canonical names of tools (`<server>__<tool>`).
+var ValidNamespaceName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+
+// Namespace isolates MCP servers, tool groups, MCP clients and users of one tenant (eg- a product team)
+// from those of other tenants sharing the same mcpjungle deployment.
+type Namespace struct {
+	gorm.Model
+
+	Name        string `json:"name" gorm:"uniqueIndex;not null"`
+	Description string `json:"description"`
+}
+
+// QualifiedName returns the canonical name of an entity with the given name in the given namespace.
+// If namespace is empty, the name is returned as-is.
+func QualifiedName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + NamespaceSep + name
+}
+
+// SplitQualifiedName splits the canonical name of an MCP server, tool group or MCP client into its namespace
+// and its name within the namespace. The namespace is empty if the entity does not belong to any namespace.
+// It must not be used for tool or prompt names, since the names of upstream tools and prompts may contain dots.
+func SplitQualifiedName(name string) (string, string) {
+	namespace, local, ok := strings.Cut(name, NamespaceSep)
+	if !ok {
+		return "", name
+	}
+	return namespace, local
+}
+
+// LocalName returns the name of an entity within the given namespace, given its canonical name.
+// Unlike SplitQualifiedName, it can be used for the canonical names of tools and prompts as well.
+func LocalName(name, namespace string) string {
+	if namespace == "" {
+		return name
+	}
+	return strings.TrimPrefix(name, namespace+NamespaceSep)
+}
+
+// InNamespace returns true if the canonical name belongs to an entity in the given namespace.
+// Every entity is considered to be in the empty namespace, which is used for unrestricted access.
+func InNamespace(name, namespace string) bool {
+	if namespace == "" {
+		return true
+	}
+	return strings.HasPrefix(name, namespace+NamespaceSep)
+}
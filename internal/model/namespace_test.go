@@ -0,0 +1,88 @@
+package model
+
+import "testing"
+
+func TestValidNamespaceName(t *testing.T) {
+	cases := map[string]bool{
+		"team-a":  true,
+		"TeamB":   true,
+		"42":      true,
+		"":        false,
+		"-team":   false,
+		"team_a":  false,
+		"team.a":  false,
+		"team a":  false,
+		"team/a":  false,
+		"team-a-": true,
+	}
+	for name, want := range cases {
+		if got := ValidNamespaceName.MatchString(name); got != want {
+			t.Errorf("ValidNamespaceName.MatchString(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestQualifiedName(t *testing.T) {
+	if got := QualifiedName("", "github"); got != "github" {
+		t.Errorf("QualifiedName without namespace = %q, want %q", got, "github")
+	}
+	if got := QualifiedName("team-a", "github"); got != "team-a.github" {
+		t.Errorf("QualifiedName with namespace = %q, want %q", got, "team-a.github")
+	}
+}
+
+func TestSplitQualifiedName(t *testing.T) {
+	cases := []struct {
+		name      string
+		wantNs    string
+		wantLocal string
+	}{
+		{"github", "", "github"},
+		{"team-a.github", "team-a", "github"},
+		{"team-a.my.server", "team-a", "my.server"},
+	}
+	for _, tc := range cases {
+		ns, local := SplitQualifiedName(tc.name)
+		if ns != tc.wantNs || local != tc.wantLocal {
+			t.Errorf("SplitQualifiedName(%q) = (%q, %q), want (%q, %q)", tc.name, ns, local, tc.wantNs, tc.wantLocal)
+		}
+	}
+}
+
+func TestLocalName(t *testing.T) {
+	cases := []struct {
+		name string
+		ns   string
+		want string
+	}{
+		{"github__create_issue", "", "github__create_issue"},
+		{"team-a.github__create_issue", "team-a", "github__create_issue"},
+		{"team-a.github__create_issue", "", "team-a.github__create_issue"},
+		{"team-b.github__create_issue", "team-a", "team-b.github__create_issue"},
+	}
+	for _, tc := range cases {
+		if got := LocalName(tc.name, tc.ns); got != tc.want {
+			t.Errorf("LocalName(%q, %q) = %q, want %q", tc.name, tc.ns, got, tc.want)
+		}
+	}
+}
+
+func TestInNamespace(t *testing.T) {
+	cases := []struct {
+		name string
+		ns   string
+		want bool
+	}{
+		{"github", "", true},
+		{"team-a.github", "", true},
+		{"team-a.github", "team-a", true},
+		{"github", "team-a", false},
+		{"team-ab.github", "team-a", false},
+		{"team-b.github", "team-a", false},
+	}
+	for _, tc := range cases {
+		if got := InNamespace(tc.name, tc.ns); got != tc.want {
+			t.Errorf("InNamespace(%q, %q) = %v, want %v", tc.name, tc.ns, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// RegistryChangeEntityType is the kind of entity affected by a registry change.
+type RegistryChangeEntityType string
+
+const (
+	RegistryChangeServer    RegistryChangeEntityType = "server"
+	RegistryChangeToolGroup RegistryChangeEntityType = "tool_group"
+)
+
+// RegistryChange records that an MCP server or a tool group was changed by one of the mcpjungle replicas
+// sharing the registry database.
+// The other replicas pick up the change and reload the affected entity into their in-memory proxy servers.
+// Changes are only kept for a short while, they are not part of the registry's configuration.
+type RegistryChange struct {
+	// ID increases monotonically, replicas use it to keep track of the changes they have already applied.
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+
+	EntityType RegistryChangeEntityType `json:"entity_type" gorm:"type:varchar(30);not null"`
+	EntityName string                   `json:"entity_name" gorm:"not null"`
+
+	// Origin is the ID of the replica that made the change, so it does not apply its own changes again.
+	Origin string `json:"origin" gorm:"not null"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// RevisionEntityType is the kind of entity whose configuration history is recorded.
+type RevisionEntityType string
+
+const (
+	RevisionEntityServer    RevisionEntityType = "server"
+	RevisionEntityToolGroup RevisionEntityType = "tool_group"
+)
+
+// RevisionAction describes the change that produced a revision.
+type RevisionAction string
+
+const (
+	// RevisionActionBaseline records the configuration of an entity that existed before its
+	// history was recorded, eg- a server registered with an older version of mcpjungle.
+	RevisionActionBaseline   RevisionAction = "baseline"
+	RevisionActionCreated    RevisionAction = "created"
+	RevisionActionUpdated    RevisionAction = "updated"
+	RevisionActionDeleted    RevisionAction = "deleted"
+	RevisionActionRolledBack RevisionAction = "rolled_back"
+)
+
+// Revision is a numbered snapshot of the configuration of an MCP server or a tool group.
+// A new revision is recorded every time the entity is created, changed or deleted.
+// Revisions are kept after the entity is deleted, so it can be restored later.
+type Revision struct {
+	gorm.Model
+
+	EntityType RevisionEntityType `json:"entity_type" gorm:"type:varchar(30);not null;uniqueIndex:idx_revision_entity_number"`
+	EntityName string             `json:"entity_name" gorm:"not null;uniqueIndex:idx_revision_entity_number"`
+
+	// Number is the revision number, starting at 1 for every entity.
+	Number int `json:"number" gorm:"not null;uniqueIndex:idx_revision_entity_number"`
+
+	Action RevisionAction `json:"action" gorm:"type:varchar(30);not null"`
+
+	// Author is the username of the user who made the change.
+	// It is empty if the change was made without authentication, eg- in development mode.
+	Author string `json:"author"`
+
+	// Note is an optional human-readable remark, eg- which revision was restored by a rollback.
+	Note string `json:"note"`
+
+	// Snapshot is the full configuration of the entity after the change.
+	// For servers, it is a types.RegisterServerInput, for tool groups a types.ToolGroup.
+	// It is JSON null for deletions.
+	Snapshot datatypes.JSON `json:"snapshot" gorm:"type:jsonb"`
+}
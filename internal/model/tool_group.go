@@ -2,35 +2,95 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+// ErrToolGroupCycle is returned when a tool group includes itself, directly or through other groups.
+var ErrToolGroupCycle = errors.New("tool group includes itself")
+
+// GroupResolver defines the interface needed to resolve the groups included by a tool group.
+type GroupResolver interface {
+	// GetToolGroup returns the tool group with the given canonical name.
+	GetToolGroup(name string) (*ToolGroup, error)
+}
+
 // ToolResolver defines the interface needed to resolve tools by server.
 type ToolResolver interface {
+	GroupResolver
 	// ListToolsByServer returns a list of tools for the given MCP server name.
 	ListToolsByServer(serverName string) ([]Tool, error)
+	// ListTools returns all tools in mcpjungle, along with their MCP servers.
+	// It is used to evaluate tool selectors.
+	ListTools() ([]Tool, error)
+}
+
+// PromptResolver defines the interface needed to resolve prompts by server.
+type PromptResolver interface {
+	GroupResolver
+	// ListPromptsByServer returns a list of prompts for the given MCP server name.
+	ListPromptsByServer(serverName string) ([]Prompt, error)
+}
+
+// ResourceResolver defines the interface needed to resolve resources by server.
+type ResourceResolver interface {
+	GroupResolver
+	// ListResourcesByServer returns a list of resources for the given MCP server name.
+	ListResourcesByServer(serverName string) ([]Resource, error)
 }
 
-// ToolGroup represents a group of tools.
+// ToolGroup represents a group of tools, prompts and resources.
 // It is useful when the user wants to expose only a subset of all tools to MCP clients.
 type ToolGroup struct {
 	gorm.Model
 
+	// Name is the canonical name of the group, which includes its namespace (see QualifiedName).
 	Name        string `json:"name" gorm:"unique; not null"`
+	Namespace   string `json:"namespace" gorm:"index;not null;default:''"`
 	Description string `json:"description"`
 
+	// The tool and server names below are relative to the group's namespace,
+	// so a group can only contain the tools of MCP servers in the same namespace.
+
 	// IncludedTools contains a list of tool names that are included in this group.
 	// storing the list of tool names as a JSON array is a convenient way for now.
 	IncludedTools datatypes.JSON `json:"included_tools" gorm:"type:jsonb"`
 
-	// IncludedServers contains a list of MCP server names. All tools from these servers will be included.
+	// IncludedServers contains a list of MCP server names.
+	// All tools, prompts and resources from these servers will be included.
 	IncludedServers datatypes.JSON `json:"included_servers" gorm:"type:jsonb"`
 
 	// ExcludedTools contains a list of tool names to exclude from the group.
 	ExcludedTools datatypes.JSON `json:"excluded_tools" gorm:"type:jsonb"`
+
+	// IncludedGroups contains a list of other tool group names.
+	// All effective tools, prompts and resources of these groups will be included.
+	IncludedGroups datatypes.JSON `json:"included_groups" gorm:"type:jsonb"`
+
+	// IncludedToolSelectors and ExcludedToolSelectors contain JSON arrays of types.ToolSelector.
+	// Tools matching any of the included selectors are included, unless they match any of the excluded ones.
+	IncludedToolSelectors datatypes.JSON `json:"included_tool_selectors" gorm:"type:jsonb"`
+	ExcludedToolSelectors datatypes.JSON `json:"excluded_tool_selectors" gorm:"type:jsonb"`
+
+	// IncludedPrompts and ExcludedPrompts contain the prompt names included in and excluded from this group.
+	IncludedPrompts datatypes.JSON `json:"included_prompts" gorm:"type:jsonb"`
+	ExcludedPrompts datatypes.JSON `json:"excluded_prompts" gorm:"type:jsonb"`
+
+	// IncludedResources and ExcludedResources contain the mcpjungle URIs of the resources
+	// included in and excluded from this group.
+	// Unlike tool and prompt names, resource URIs are not relative to the group's namespace.
+	IncludedResources datatypes.JSON `json:"included_resources" gorm:"type:jsonb"`
+	ExcludedResources datatypes.JSON `json:"excluded_resources" gorm:"type:jsonb"`
+
+	// ToolOverrides contains a JSON object of types.ToolOverride keyed by tool name.
+	// They change how the group exposes its tools and take precedence over the tool overrides of MCP servers.
+	ToolOverrides datatypes.JSON `json:"tool_overrides" gorm:"type:jsonb"`
 }
 
 // GetTools unmarshals the IncludedTools JSON array into a slice of strings.
@@ -63,12 +123,155 @@ func (g *ToolGroup) GetExcludedTools() ([]string, error) {
 	return tools, err
 }
 
+// GetToolSelectors unmarshals the IncludedToolSelectors JSON array.
+func (g *ToolGroup) GetToolSelectors() ([]types.ToolSelector, error) {
+	return unmarshalToolSelectors(g.IncludedToolSelectors)
+}
+
+// GetExcludedToolSelectors unmarshals the ExcludedToolSelectors JSON array.
+func (g *ToolGroup) GetExcludedToolSelectors() ([]types.ToolSelector, error) {
+	return unmarshalToolSelectors(g.ExcludedToolSelectors)
+}
+
+func unmarshalToolSelectors(j datatypes.JSON) ([]types.ToolSelector, error) {
+	if j == nil {
+		return []types.ToolSelector{}, nil
+	}
+	var selectors []types.ToolSelector
+	err := json.Unmarshal(j, &selectors)
+	return selectors, err
+}
+
+// ValidateToolSelectors returns an error if any of the group's tool selectors is invalid.
+func (g *ToolGroup) ValidateToolSelectors() error {
+	_, _, err := g.compileToolSelectors()
+	return err
+}
+
+// compileToolSelectors compiles the included and excluded tool selectors of the group.
+func (g *ToolGroup) compileToolSelectors() (included, excluded []*ToolSelectorMatcher, err error) {
+	includedSelectors, err := g.GetToolSelectors()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get included tool selectors: %w", err)
+	}
+	excludedSelectors, err := g.GetExcludedToolSelectors()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get excluded tool selectors: %w", err)
+	}
+	if included, err = CompileToolSelectors(includedSelectors); err != nil {
+		return nil, nil, err
+	}
+	if excluded, err = CompileToolSelectors(excludedSelectors); err != nil {
+		return nil, nil, err
+	}
+	return included, excluded, nil
+}
+
+// GetGroups unmarshals the IncludedGroups JSON array into a slice of strings.
+func (g *ToolGroup) GetGroups() ([]string, error) {
+	return unmarshalNames(g.IncludedGroups)
+}
+
+// ValidateIncludedGroups returns an error if a group included by this group, directly or
+// through other groups, does not exist, or if the group includes itself (ErrToolGroupCycle).
+func (g *ToolGroup) ValidateIncludedGroups(groups GroupResolver) error {
+	return g.forEachIncludedGroup(groups, nil, nil)
+}
+
+// forEachIncludedGroup looks up the groups included by this group and calls fn for each of them.
+// path contains the canonical names of the groups being resolved that (transitively) include this group,
+// it is used to detect cycles. A nil fn only checks the included groups recursively.
+func (g *ToolGroup) forEachIncludedGroup(
+	groups GroupResolver,
+	path []string,
+	fn func(included *ToolGroup, path []string) error,
+) error {
+	names, err := g.GetGroups()
+	if err != nil {
+		return fmt.Errorf("failed to get included groups: %w", err)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	path = append(slices.Clip(path), g.Name)
+	for _, name := range names {
+		name = QualifiedName(g.Namespace, name)
+		if slices.Contains(path, name) {
+			return fmt.Errorf("%w: %s", ErrToolGroupCycle, strings.Join(append(path, name), " -> "))
+		}
+		included, err := groups.GetToolGroup(name)
+		if err != nil {
+			return fmt.Errorf("failed to get included group %s: %w", name, err)
+		}
+		if fn == nil {
+			err = included.forEachIncludedGroup(groups, path, nil)
+		} else {
+			err = fn(included, path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetToolOverrides unmarshals the ToolOverrides JSON object.
+// The overrides are keyed by tool names relative to the group's namespace.
+func (g *ToolGroup) GetToolOverrides() (map[string]types.ToolOverride, error) {
+	return unmarshalToolOverrides(g.ToolOverrides)
+}
+
+// ValidateToolOverrides returns an error if any of the group's tool overrides is invalid.
+func (g *ToolGroup) ValidateToolOverrides() error {
+	overrides, err := g.GetToolOverrides()
+	if err != nil {
+		return fmt.Errorf("failed to get tool overrides: %w", err)
+	}
+	return ValidateToolOverrides(overrides)
+}
+
+// GetPrompts unmarshals the IncludedPrompts JSON array into a slice of strings.
+func (g *ToolGroup) GetPrompts() ([]string, error) {
+	return unmarshalNames(g.IncludedPrompts)
+}
+
+// GetExcludedPrompts unmarshals the ExcludedPrompts JSON array into a slice of strings.
+func (g *ToolGroup) GetExcludedPrompts() ([]string, error) {
+	return unmarshalNames(g.ExcludedPrompts)
+}
+
+// GetResources unmarshals the IncludedResources JSON array into a slice of strings.
+func (g *ToolGroup) GetResources() ([]string, error) {
+	return unmarshalNames(g.IncludedResources)
+}
+
+// GetExcludedResources unmarshals the ExcludedResources JSON array into a slice of strings.
+func (g *ToolGroup) GetExcludedResources() ([]string, error) {
+	return unmarshalNames(g.ExcludedResources)
+}
+
+// unmarshalNames unmarshals a JSON array of names into a slice of strings.
+func unmarshalNames(j datatypes.JSON) ([]string, error) {
+	if j == nil {
+		return []string{}, nil
+	}
+	var names []string
+	err := json.Unmarshal(j, &names)
+	return names, err
+}
+
 // ResolveEffectiveTools resolves all effective tools for this group by combining
-// included_tools, included_servers, and applying excluded_tools.
+// included_tools, included_servers, included_tool_selectors and the effective tools of included_groups,
+// and applying excluded_tools and excluded_tool_selectors.
 // Note that tool exclusions are applied at last, so if a tool is both included and excluded,
 // it will be excluded.
+// The tool and server names of the group are qualified with its namespace, so the resolved names are canonical.
 // It requires an MCP service to lookup tools by server.
 func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, error) {
+	return g.resolveEffectiveTools(mcpService, nil)
+}
+
+func (g *ToolGroup) resolveEffectiveTools(mcpService ToolResolver, path []string) ([]string, error) {
 	effectiveTools := make(map[string]bool)
 
 	// Add tools from included_tools
@@ -77,7 +280,7 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 		return nil, fmt.Errorf("failed to get included tools: %w", err)
 	}
 	for _, tool := range includedTools {
-		effectiveTools[tool] = true
+		effectiveTools[QualifiedName(g.Namespace, tool)] = true
 	}
 
 	// Add tools from included_servers
@@ -86,7 +289,7 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 		return nil, fmt.Errorf("failed to get included servers: %w", err)
 	}
 	for _, serverName := range includedServers {
-		serverTools, err := mcpService.ListToolsByServer(serverName)
+		serverTools, err := mcpService.ListToolsByServer(QualifiedName(g.Namespace, serverName))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get tools for server %s: %w", serverName, err)
 		}
@@ -95,13 +298,58 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 		}
 	}
 
+	// Add the effective tools of included_groups
+	err = g.forEachIncludedGroup(mcpService, path, func(included *ToolGroup, path []string) error {
+		groupTools, err := included.resolveEffectiveTools(mcpService, path)
+		if err != nil {
+			return err
+		}
+		for _, tool := range groupTools {
+			effectiveTools[tool] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add tools matching included_tool_selectors, and remove the ones matching excluded_tool_selectors
+	includedSelectors, excludedSelectors, err := g.compileToolSelectors()
+	if err != nil {
+		return nil, err
+	}
+	if len(includedSelectors) > 0 || len(excludedSelectors) > 0 {
+		allTools, err := mcpService.ListTools()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools for tool selectors: %w", err)
+		}
+		for i := range allTools {
+			// selectors only apply to the tools in the group's namespace
+			if !InNamespace(allTools[i].Server.Name, g.Namespace) {
+				continue
+			}
+			// disabled tools are not served, so selectors don't include them until they are enabled again
+			if !allTools[i].Enabled || !allTools[i].Server.Enabled {
+				continue
+			}
+			if matchesAnyToolSelector(includedSelectors, g.Namespace, &allTools[i]) {
+				effectiveTools[allTools[i].Name] = true
+			}
+		}
+		for i := range allTools {
+			if matchesAnyToolSelector(excludedSelectors, g.Namespace, &allTools[i]) {
+				delete(effectiveTools, allTools[i].Name)
+			}
+		}
+	}
+
 	// Remove tools from excluded_tools
 	excludedTools, err := g.GetExcludedTools()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get excluded tools: %w", err)
 	}
 	for _, tool := range excludedTools {
-		delete(effectiveTools, tool)
+		delete(effectiveTools, QualifiedName(g.Namespace, tool))
 	}
 
 	// Convert map to slice
@@ -112,3 +360,230 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 
 	return result, nil
 }
+
+// IncludesTool reports whether the given tool is one of the effective tools of this group.
+// It is equivalent to checking whether ResolveEffectiveTools returns the tool, but only evaluates the group's
+// rules against this tool instead of listing all the tools in mcpjungle.
+// The tool name must be canonical and its MCP server must be loaded, the selectors need its labels.
+func (g *ToolGroup) IncludesTool(groups GroupResolver, tool *Tool) (bool, error) {
+	return g.includesTool(groups, tool, nil)
+}
+
+func (g *ToolGroup) includesTool(groups GroupResolver, tool *Tool, path []string) (bool, error) {
+	// exclusions win, so they are checked first
+	excludedTools, err := g.GetExcludedTools()
+	if err != nil {
+		return false, fmt.Errorf("failed to get excluded tools: %w", err)
+	}
+	for _, name := range excludedTools {
+		if QualifiedName(g.Namespace, name) == tool.Name {
+			return false, nil
+		}
+	}
+	includedSelectors, excludedSelectors, err := g.compileToolSelectors()
+	if err != nil {
+		return false, err
+	}
+	if matchesAnyToolSelector(excludedSelectors, g.Namespace, tool) {
+		return false, nil
+	}
+
+	includedTools, err := g.GetTools()
+	if err != nil {
+		return false, fmt.Errorf("failed to get included tools: %w", err)
+	}
+	for _, name := range includedTools {
+		if QualifiedName(g.Namespace, name) == tool.Name {
+			return true, nil
+		}
+	}
+	includedServers, err := g.GetServers()
+	if err != nil {
+		return false, fmt.Errorf("failed to get included servers: %w", err)
+	}
+	for _, name := range includedServers {
+		if QualifiedName(g.Namespace, name) == tool.Server.Name {
+			return true, nil
+		}
+	}
+	if InNamespace(tool.Server.Name, g.Namespace) && tool.Enabled && tool.Server.Enabled &&
+		matchesAnyToolSelector(includedSelectors, g.Namespace, tool) {
+		return true, nil
+	}
+
+	included := false
+	err = g.forEachIncludedGroup(groups, path, func(group *ToolGroup, path []string) error {
+		if included {
+			return nil
+		}
+		included, err = group.includesTool(groups, tool, path)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return included, nil
+}
+
+// ResolveEffectivePrompts resolves all effective prompts for this group by combining
+// included_prompts, the prompts of included_servers and the effective prompts of included_groups,
+// and applying excluded_prompts.
+// Like for tools, exclusions win and the resolved prompt names are canonical.
+func (g *ToolGroup) ResolveEffectivePrompts(mcpService PromptResolver) ([]string, error) {
+	return g.resolveEffectivePrompts(mcpService, nil)
+}
+
+func (g *ToolGroup) resolveEffectivePrompts(mcpService PromptResolver, path []string) ([]string, error) {
+	effectivePrompts := make(map[string]bool)
+
+	includedPrompts, err := g.GetPrompts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get included prompts: %w", err)
+	}
+	for _, prompt := range includedPrompts {
+		effectivePrompts[QualifiedName(g.Namespace, prompt)] = true
+	}
+
+	includedServers, err := g.GetServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get included servers: %w", err)
+	}
+	for _, serverName := range includedServers {
+		serverPrompts, err := mcpService.ListPromptsByServer(QualifiedName(g.Namespace, serverName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prompts for server %s: %w", serverName, err)
+		}
+		for _, prompt := range serverPrompts {
+			effectivePrompts[prompt.Name] = true
+		}
+	}
+
+	err = g.forEachIncludedGroup(mcpService, path, func(included *ToolGroup, path []string) error {
+		groupPrompts, err := included.resolveEffectivePrompts(mcpService, path)
+		if err != nil {
+			return err
+		}
+		for _, prompt := range groupPrompts {
+			effectivePrompts[prompt] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	excludedPrompts, err := g.GetExcludedPrompts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded prompts: %w", err)
+	}
+	for _, prompt := range excludedPrompts {
+		delete(effectivePrompts, QualifiedName(g.Namespace, prompt))
+	}
+
+	result := make([]string, 0, len(effectivePrompts))
+	for prompt := range effectivePrompts {
+		result = append(result, prompt)
+	}
+	return result, nil
+}
+
+// ResolveEffectiveResources resolves the URIs of all effective resources for this group by combining
+// included_resources, the resources of included_servers and the effective resources of included_groups,
+// and applying excluded_resources.
+// Like for tools, exclusions win.
+func (g *ToolGroup) ResolveEffectiveResources(mcpService ResourceResolver) ([]string, error) {
+	return g.resolveEffectiveResources(mcpService, nil)
+}
+
+func (g *ToolGroup) resolveEffectiveResources(mcpService ResourceResolver, path []string) ([]string, error) {
+	effectiveResources := make(map[string]bool)
+
+	includedResources, err := g.GetResources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get included resources: %w", err)
+	}
+	for _, uri := range includedResources {
+		effectiveResources[uri] = true
+	}
+
+	includedServers, err := g.GetServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get included servers: %w", err)
+	}
+	for _, serverName := range includedServers {
+		serverResources, err := mcpService.ListResourcesByServer(QualifiedName(g.Namespace, serverName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resources for server %s: %w", serverName, err)
+		}
+		for _, resource := range serverResources {
+			effectiveResources[resource.URI] = true
+		}
+	}
+
+	err = g.forEachIncludedGroup(mcpService, path, func(included *ToolGroup, path []string) error {
+		groupResources, err := included.resolveEffectiveResources(mcpService, path)
+		if err != nil {
+			return err
+		}
+		for _, uri := range groupResources {
+			effectiveResources[uri] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	excludedResources, err := g.GetExcludedResources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excluded resources: %w", err)
+	}
+	for _, uri := range excludedResources {
+		delete(effectiveResources, uri)
+	}
+
+	result := make([]string, 0, len(effectiveResources))
+	for uri := range effectiveResources {
+		result = append(result, uri)
+	}
+	return result, nil
+}
+
+// ResolveIncludedServers resolves the canonical names of the MCP servers included by this group
+// through included_servers, directly or through included_groups.
+// The group serves the resource templates of these servers, since templates cannot be selected individually.
+func (g *ToolGroup) ResolveIncludedServers(groups GroupResolver) ([]string, error) {
+	return g.resolveIncludedServers(groups, nil)
+}
+
+func (g *ToolGroup) resolveIncludedServers(groups GroupResolver, path []string) ([]string, error) {
+	includedServers, err := g.GetServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get included servers: %w", err)
+	}
+	servers := make(map[string]bool, len(includedServers))
+	for _, serverName := range includedServers {
+		servers[QualifiedName(g.Namespace, serverName)] = true
+	}
+
+	err = g.forEachIncludedGroup(groups, path, func(included *ToolGroup, path []string) error {
+		groupServers, err := included.resolveIncludedServers(groups, path)
+		if err != nil {
+			return err
+		}
+		for _, serverName := range groupServers {
+			servers[serverName] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(servers))
+	for serverName := range servers {
+		result = append(result, serverName)
+	}
+	slices.Sort(result)
+	return result, nil
+}
@@ -2,13 +2,30 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 
 	"gorm.io/datatypes"
 )
 
+// mockGroupResolver implements GroupResolver for testing
+type mockGroupResolver struct {
+	groups map[string]*ToolGroup
+}
+
+func (m *mockGroupResolver) GetToolGroup(name string) (*ToolGroup, error) {
+	if group, exists := m.groups[name]; exists {
+		return group, nil
+	}
+	return nil, fmt.Errorf("tool group %s not found", name)
+}
+
 // mockToolResolver implements ToolResolver for testing
 type mockToolResolver struct {
+	mockGroupResolver
 	serverTools map[string][]Tool
 }
 
@@ -19,6 +36,19 @@ func (m *mockToolResolver) ListToolsByServer(serverName string) ([]Tool, error)
 	return []Tool{}, nil
 }
 
+func (m *mockToolResolver) ListTools() ([]Tool, error) {
+	var all []Tool
+	for serverName, tools := range m.serverTools {
+		for _, tool := range tools {
+			if tool.Server.Name == "" {
+				tool.Server = McpServer{Name: serverName, Enabled: true}
+			}
+			all = append(all, tool)
+		}
+	}
+	return all, nil
+}
+
 func TestToolGroup_GetTools(t *testing.T) {
 	tools := []string{"tool1", "tool2"}
 	toolsJSON, _ := json.Marshal(tools)
@@ -285,3 +315,170 @@ func TestToolGroup_ResolveEffectiveTools_EmptyGroup(t *testing.T) {
 		t.Errorf("Expected 0 tools for empty group, got %d", len(result))
 	}
 }
+
+// mockPromptAndResourceResolver implements PromptResolver and ResourceResolver for testing
+type mockPromptAndResourceResolver struct {
+	mockGroupResolver
+	serverPrompts   map[string][]Prompt
+	serverResources map[string][]Resource
+}
+
+func (m *mockPromptAndResourceResolver) ListPromptsByServer(serverName string) ([]Prompt, error) {
+	return m.serverPrompts[serverName], nil
+}
+
+func (m *mockPromptAndResourceResolver) ListResourcesByServer(serverName string) ([]Resource, error) {
+	return m.serverResources[serverName], nil
+}
+
+func TestToolGroup_ResolveEffectivePromptsAndResources(t *testing.T) {
+	resolver := &mockPromptAndResourceResolver{
+		serverPrompts: map[string][]Prompt{
+			"team-a.docs": {{Name: "team-a.docs__explain"}, {Name: "team-a.docs__summarize"}},
+		},
+		serverResources: map[string][]Resource{
+			"team-a.docs": {{URI: "mcpj://res/team-a.docs/a"}, {URI: "mcpj://res/team-a.docs/b"}},
+		},
+	}
+
+	group := &ToolGroup{
+		Namespace:         "team-a",
+		IncludedServers:   datatypes.JSON(`["docs"]`),
+		IncludedPrompts:   datatypes.JSON(`["calc__greet"]`),
+		ExcludedPrompts:   datatypes.JSON(`["docs__summarize"]`),
+		ExcludedResources: datatypes.JSON(`["mcpj://res/team-a.docs/b"]`),
+	}
+
+	prompts, err := group.ResolveEffectivePrompts(resolver)
+	if err != nil {
+		t.Fatalf("ResolveEffectivePrompts() failed: %v", err)
+	}
+	sort.Strings(prompts)
+	if !reflect.DeepEqual(prompts, []string{"team-a.calc__greet", "team-a.docs__explain"}) {
+		t.Errorf("Unexpected effective prompts: %v", prompts)
+	}
+
+	resources, err := group.ResolveEffectiveResources(resolver)
+	if err != nil {
+		t.Fatalf("ResolveEffectiveResources() failed: %v", err)
+	}
+	if !reflect.DeepEqual(resources, []string{"mcpj://res/team-a.docs/a"}) {
+		t.Errorf("Unexpected effective resources: %v", resources)
+	}
+}
+
+func TestToolGroup_IncludedGroups(t *testing.T) {
+	base := &ToolGroup{
+		Name:            "base-readonly",
+		IncludedTools:   datatypes.JSON(`["time__get_current_time"]`),
+		IncludedPrompts: datatypes.JSON(`["time__explain"]`),
+	}
+	jira := &ToolGroup{
+		Name:           "base-jira",
+		IncludedGroups: datatypes.JSON(`["base-readonly"]`),
+		IncludedTools:  datatypes.JSON(`["jira__search"]`),
+	}
+	groups := mockGroupResolver{groups: map[string]*ToolGroup{base.Name: base, jira.Name: jira}}
+	tools := &mockToolResolver{mockGroupResolver: groups}
+	prompts := &mockPromptAndResourceResolver{mockGroupResolver: groups}
+
+	t.Run("union of included groups, exclusions applied last", func(t *testing.T) {
+		group := &ToolGroup{
+			Name:           "composed",
+			IncludedGroups: datatypes.JSON(`["base-jira"]`),
+			IncludedTools:  datatypes.JSON(`["github__list_repos"]`),
+			ExcludedTools:  datatypes.JSON(`["jira__search"]`),
+		}
+		result, err := group.ResolveEffectiveTools(tools)
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		sort.Strings(result)
+		expected := []string{"github__list_repos", "time__get_current_time"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+
+		promptNames, err := group.ResolveEffectivePrompts(prompts)
+		if err != nil {
+			t.Fatalf("ResolveEffectivePrompts() failed: %v", err)
+		}
+		if !reflect.DeepEqual(promptNames, []string{"time__explain"}) {
+			t.Errorf("Expected [time__explain], got %v", promptNames)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		cyclic := mockGroupResolver{groups: map[string]*ToolGroup{
+			"a": {Name: "a", IncludedGroups: datatypes.JSON(`["b"]`)},
+			"b": {Name: "b", IncludedGroups: datatypes.JSON(`["a"]`)},
+		}}
+		group := cyclic.groups["a"]
+		if err := group.ValidateIncludedGroups(&cyclic); !errors.Is(err, ErrToolGroupCycle) {
+			t.Errorf("Expected ErrToolGroupCycle, got %v", err)
+		}
+		if _, err := group.ResolveEffectiveTools(&mockToolResolver{mockGroupResolver: cyclic}); !errors.Is(err, ErrToolGroupCycle) {
+			t.Errorf("Expected ErrToolGroupCycle, got %v", err)
+		}
+
+		self := &ToolGroup{Name: "self", IncludedGroups: datatypes.JSON(`["self"]`)}
+		if err := self.ValidateIncludedGroups(&groups); !errors.Is(err, ErrToolGroupCycle) {
+			t.Errorf("Expected ErrToolGroupCycle for a group including itself, got %v", err)
+		}
+	})
+
+	t.Run("diamond is not a cycle", func(t *testing.T) {
+		group := &ToolGroup{Name: "diamond", IncludedGroups: datatypes.JSON(`["base-readonly","base-jira"]`)}
+		if err := group.ValidateIncludedGroups(&groups); err != nil {
+			t.Errorf("ValidateIncludedGroups() failed: %v", err)
+		}
+	})
+
+	t.Run("missing group", func(t *testing.T) {
+		group := &ToolGroup{Name: "broken", IncludedGroups: datatypes.JSON(`["missing"]`)}
+		if err := group.ValidateIncludedGroups(&groups); err == nil {
+			t.Error("Expected an error for a missing included group")
+		}
+	})
+
+	t.Run("names are relative to the namespace", func(t *testing.T) {
+		nsGroups := mockGroupResolver{groups: map[string]*ToolGroup{
+			"team.base": {Name: "team.base", Namespace: "team", IncludedTools: datatypes.JSON(`["jira__search"]`)},
+		}}
+		group := &ToolGroup{Name: "team.composed", Namespace: "team", IncludedGroups: datatypes.JSON(`["base"]`)}
+		result, err := group.ResolveEffectiveTools(&mockToolResolver{mockGroupResolver: nsGroups})
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		if !reflect.DeepEqual(result, []string{"team.jira__search"}) {
+			t.Errorf("Expected [team.jira__search], got %v", result)
+		}
+	})
+}
+
+func TestToolGroup_ResolveIncludedServers(t *testing.T) {
+	groups := mockGroupResolver{groups: map[string]*ToolGroup{
+		"team.base": {
+			Name:            "team.base",
+			Namespace:       "team",
+			IncludedServers: datatypes.JSON(`["jira"]`),
+			IncludedTools:   datatypes.JSON(`["time__get_current_time"]`),
+		},
+	}}
+	group := &ToolGroup{
+		Name:            "team.composed",
+		Namespace:       "team",
+		IncludedGroups:  datatypes.JSON(`["base"]`),
+		IncludedServers: datatypes.JSON(`["github","jira"]`),
+	}
+
+	// only the servers included as a whole count, not the ones of individually included tools
+	result, err := group.ResolveIncludedServers(&groups)
+	if err != nil {
+		t.Fatalf("ResolveIncludedServers() failed: %v", err)
+	}
+	expected := []string{"team.github", "team.jira"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
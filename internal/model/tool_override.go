@@ -0,0 +1,95 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+)
+
+// validToolAlias matches the aliases tools can be exposed under.
+// An alias must not contain "__", the separator of canonical tool names, so that it can never be
+// mistaken for the canonical name of another tool.
+var validToolAlias = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// ValidateToolOverrides returns an error if any of the given tool overrides, keyed by tool name, is invalid
+// or if two tools are exposed under the same alias.
+func ValidateToolOverrides(overrides map[string]types.ToolOverride) error {
+	tools := make([]string, 0, len(overrides))
+	for tool := range overrides {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+
+	aliases := make(map[string]string)
+	for _, tool := range tools {
+		o := overrides[tool]
+		if strings.TrimSpace(tool) == "" {
+			return fmt.Errorf("tool overrides must be keyed by tool names")
+		}
+		if o.Name != "" {
+			if !validToolAlias.MatchString(o.Name) || strings.Contains(o.Name, "__") {
+				return fmt.Errorf(
+					"invalid alias %q for tool %s: alias must start with an alphanumeric character, "+
+						"can only contain alphanumeric characters, underscores and hyphens and must not contain '__'",
+					o.Name, tool,
+				)
+			}
+			if other, ok := aliases[o.Name]; ok {
+				return fmt.Errorf("tools %s and %s cannot be exposed under the same alias %s", other, tool, o.Name)
+			}
+			aliases[o.Name] = tool
+		}
+		for _, param := range o.HiddenParams {
+			if strings.TrimSpace(param) == "" {
+				return fmt.Errorf("hidden parameters of tool %s must not be empty", tool)
+			}
+		}
+		for param := range o.PinnedParams {
+			if strings.TrimSpace(param) == "" {
+				return fmt.Errorf("pinned parameters of tool %s must not be empty", tool)
+			}
+			if slices.Contains(o.HiddenParams, param) {
+				return fmt.Errorf("parameter %s of tool %s cannot be both hidden and pinned", param, tool)
+			}
+		}
+		for param := range o.DefaultParams {
+			if strings.TrimSpace(param) == "" {
+				return fmt.Errorf("default parameters of tool %s must not be empty", tool)
+			}
+			if _, pinned := o.PinnedParams[param]; pinned || slices.Contains(o.HiddenParams, param) {
+				return fmt.Errorf("parameter %s of tool %s is hidden or pinned and cannot have a default value", param, tool)
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalToolOverrides unmarshals a JSON object of tool overrides keyed by tool name.
+func unmarshalToolOverrides(j datatypes.JSON) (map[string]types.ToolOverride, error) {
+	overrides := make(map[string]types.ToolOverride)
+	if j == nil {
+		return overrides, nil
+	}
+	if err := json.Unmarshal(j, &overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// marshalToolOverrides validates the given tool overrides and marshals them into a JSON object.
+// It returns nil if there are no overrides.
+func marshalToolOverrides(overrides map[string]types.ToolOverride) (datatypes.JSON, error) {
+	if err := ValidateToolOverrides(overrides); err != nil {
+		return nil, err
+	}
+	if len(overrides) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(overrides)
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestValidateToolOverrides(t *testing.T) {
+	valid := map[string]types.ToolOverride{
+		"github__create_pull_request": {Name: "create_pr", AppendDescription: "Always open drafts."},
+		"github__list_issues": {
+			HiddenParams:  []string{"page"},
+			PinnedParams:  map[string]any{"owner": "acme"},
+			DefaultParams: map[string]any{"per_page": 20},
+		},
+	}
+	if err := ValidateToolOverrides(valid); err != nil {
+		t.Fatalf("expected overrides to be valid, got %v", err)
+	}
+
+	cases := map[string]map[string]types.ToolOverride{
+		"alias with separator": {"github__list_issues": {Name: "list__issues"}},
+		"alias with dot":       {"github__list_issues": {Name: "acme.issues"}},
+		"duplicate alias": {
+			"github__list_issues": {Name: "issues"},
+			"gitlab__list_issues": {Name: "issues"},
+		},
+		"empty tool name":            {" ": {Name: "issues"}},
+		"empty hidden param":         {"github__list_issues": {HiddenParams: []string{""}}},
+		"empty pinned param":         {"github__list_issues": {PinnedParams: map[string]any{"": 1}}},
+		"alias starting with hyphen": {"github__list_issues": {Name: "-issues"}},
+		"hidden and pinned param": {
+			"github__list_issues": {HiddenParams: []string{"owner"}, PinnedParams: map[string]any{"owner": "acme"}},
+		},
+		"pinned param with default": {
+			"github__list_issues": {PinnedParams: map[string]any{"owner": "acme"}, DefaultParams: map[string]any{"owner": "x"}},
+		},
+		"empty default param": {"github__list_issues": {DefaultParams: map[string]any{"": 1}}},
+	}
+	for name, overrides := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := ValidateToolOverrides(overrides); err == nil {
+				t.Errorf("expected overrides %+v to be rejected", overrides)
+			}
+		})
+	}
+}
+
+func TestMcpServer_SetToolOverrides(t *testing.T) {
+	s := &McpServer{Name: "github"}
+	if err := s.SetToolOverrides(map[string]types.ToolOverride{"list_issues": {Name: "issues"}}); err != nil {
+		t.Fatalf("failed to set tool overrides: %v", err)
+	}
+	overrides, err := s.GetToolOverrides()
+	if err != nil {
+		t.Fatalf("failed to get tool overrides: %v", err)
+	}
+	if overrides["list_issues"].Name != "issues" {
+		t.Fatalf("expected alias issues, got %+v", overrides)
+	}
+
+	if err := s.SetToolOverrides(nil); err != nil {
+		t.Fatalf("failed to clear tool overrides: %v", err)
+	}
+	if s.ToolOverrides != nil {
+		t.Fatalf("expected tool overrides to be cleared, got %s", s.ToolOverrides)
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// defaultToolAnnotationHints are the values the MCP specification assumes for the hints a tool does not declare.
+var defaultToolAnnotationHints = map[string]bool{
+	"readOnlyHint":    false,
+	"destructiveHint": true,
+	"idempotentHint":  false,
+	"openWorldHint":   true,
+}
+
+// ToolSelectorMatcher is a validated types.ToolSelector, ready to be matched against tools.
+type ToolSelectorMatcher struct {
+	selector types.ToolSelector
+	regex    *regexp.Regexp
+}
+
+// CompileToolSelectors validates the given selectors and compiles them into matchers.
+func CompileToolSelectors(selectors []types.ToolSelector) ([]*ToolSelectorMatcher, error) {
+	matchers := make([]*ToolSelectorMatcher, 0, len(selectors))
+	for i, sel := range selectors {
+		m, err := CompileToolSelector(sel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool selector #%d: %w", i+1, err)
+		}
+		matchers = append(matchers, m)
+	}
+	return matchers, nil
+}
+
+// CompileToolSelector validates a selector and compiles it into a matcher.
+// A selector must contain at least one rule, so that it does not match all tools by accident.
+func CompileToolSelector(sel types.ToolSelector) (*ToolSelectorMatcher, error) {
+	if sel.Pattern == "" && sel.Regex == "" && len(sel.Annotations) == 0 && len(sel.ServerLabels) == 0 {
+		return nil, errors.New("selector must contain at least one rule")
+	}
+	m := &ToolSelectorMatcher{selector: sel}
+	if sel.Pattern != "" {
+		if _, err := path.Match(sel.Pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", sel.Pattern, err)
+		}
+	}
+	if sel.Regex != "" {
+		re, err := regexp.Compile(sel.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", sel.Regex, err)
+		}
+		m.regex = re
+	}
+	return m, nil
+}
+
+// Matches returns true if the tool satisfies all the rules of the selector.
+// The tool's name must be canonical and its Server must be populated.
+// Name rules are matched against the tool's name relative to the given namespace.
+func (m *ToolSelectorMatcher) Matches(namespace string, tool *Tool) bool {
+	name := LocalName(tool.Name, namespace)
+	if m.selector.Pattern != "" {
+		if ok, _ := path.Match(m.selector.Pattern, name); !ok {
+			return false
+		}
+	}
+	if m.regex != nil && !m.regex.MatchString(name) {
+		return false
+	}
+	if len(m.selector.Annotations) > 0 && !matchToolAnnotations(tool, m.selector.Annotations) {
+		return false
+	}
+	if len(m.selector.ServerLabels) > 0 {
+		labels, err := tool.Server.GetLabels()
+		if err != nil {
+			return false
+		}
+		for _, label := range m.selector.ServerLabels {
+			if !slices.Contains(labels, label) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchToolAnnotations returns true if the annotation hints of the tool have the required values.
+// The destructive hint is only meaningful for tools that are not read-only,
+// so a read-only tool is never considered destructive unless it says so.
+func matchToolAnnotations(tool *Tool, required map[string]bool) bool {
+	hints := make(map[string]any)
+	if len(tool.Annotations) > 0 {
+		if err := json.Unmarshal(tool.Annotations, &hints); err != nil {
+			return false
+		}
+	}
+	readOnly, _ := hints["readOnlyHint"].(bool)
+	for hint, want := range required {
+		got, declared := hints[hint].(bool)
+		if !declared && hint == "destructiveHint" && readOnly {
+			got, declared = false, true
+		}
+		if !declared {
+			got, declared = defaultToolAnnotationHints[hint]
+		}
+		if !declared || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyToolSelector returns true if the tool matches at least one of the selectors.
+func matchesAnyToolSelector(selectors []*ToolSelectorMatcher, namespace string, tool *Tool) bool {
+	for _, m := range selectors {
+		if m.Matches(namespace, tool) {
+			return true
+		}
+	}
+	return false
+}
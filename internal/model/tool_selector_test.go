@@ -0,0 +1,255 @@
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+)
+
+func TestCompileToolSelector_Invalid(t *testing.T) {
+	cases := map[string]types.ToolSelector{
+		"empty selector": {},
+		"bad pattern":    {Pattern: "github__[list"},
+		"bad regex":      {Regex: "github__(list"},
+	}
+	for name, sel := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := CompileToolSelector(sel); err == nil {
+				t.Errorf("expected selector %+v to be rejected", sel)
+			}
+		})
+	}
+}
+
+func TestToolSelectorMatcher_Matches(t *testing.T) {
+	prod := McpServer{Name: "github", Labels: datatypes.JSON(`["prod","team-a"]`)}
+	listIssues := &Tool{
+		Name:        "github__list_issues",
+		Annotations: datatypes.JSON(`{"readOnlyHint":true}`),
+		Server:      prod,
+	}
+	createIssue := &Tool{Name: "github__create_issue", Server: prod}
+	staging := &Tool{
+		Name:        "staging.github__list_issues",
+		Annotations: datatypes.JSON(`{"readOnlyHint":true}`),
+		Server:      McpServer{Name: "staging.github", Labels: datatypes.JSON(`["staging"]`)},
+	}
+
+	cases := []struct {
+		name      string
+		selector  types.ToolSelector
+		namespace string
+		tool      *Tool
+		want      bool
+	}{
+		{"pattern matches", types.ToolSelector{Pattern: "github__list_*"}, "", listIssues, true},
+		{"pattern does not match", types.ToolSelector{Pattern: "github__list_*"}, "", createIssue, false},
+		{"regex matches anywhere", types.ToolSelector{Regex: "issue"}, "", createIssue, true},
+		{"anchored regex", types.ToolSelector{Regex: "^issue"}, "", createIssue, false},
+		{"declared annotation", types.ToolSelector{Annotations: map[string]bool{"readOnlyHint": true}}, "", listIssues, true},
+		{"default annotation", types.ToolSelector{Annotations: map[string]bool{"readOnlyHint": true}}, "", createIssue, false},
+		{"default destructive hint", types.ToolSelector{Annotations: map[string]bool{"destructiveHint": true}}, "", createIssue, true},
+		{"read-only tools are not destructive", types.ToolSelector{Annotations: map[string]bool{"destructiveHint": false}}, "", listIssues, true},
+		{"unknown annotation", types.ToolSelector{Annotations: map[string]bool{"fooHint": false}}, "", createIssue, false},
+		{"server labels", types.ToolSelector{ServerLabels: []string{"prod", "team-a"}}, "", createIssue, true},
+		{"missing server label", types.ToolSelector{ServerLabels: []string{"prod", "team-b"}}, "", createIssue, false},
+		{
+			"all rules must match",
+			types.ToolSelector{Pattern: "github__*", Annotations: map[string]bool{"readOnlyHint": true}, ServerLabels: []string{"prod"}},
+			"", listIssues, true,
+		},
+		{"name relative to namespace", types.ToolSelector{Pattern: "github__list_*"}, "staging", staging, true},
+		{"canonical name outside namespace", types.ToolSelector{Pattern: "github__list_*"}, "", staging, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := CompileToolSelector(tc.selector)
+			if err != nil {
+				t.Fatalf("CompileToolSelector() failed: %v", err)
+			}
+			if got := m.Matches(tc.namespace, tc.tool); got != tc.want {
+				t.Errorf("Matches(%q, %s) = %v, want %v", tc.namespace, tc.tool.Name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestToolGroup_ResolveEffectiveTools_Selectors(t *testing.T) {
+	prod := McpServer{Name: "github", Enabled: true, Labels: datatypes.JSON(`["prod"]`)}
+	resolver := &mockToolResolver{
+		serverTools: map[string][]Tool{
+			"github": {
+				{Name: "github__list_issues", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`), Server: prod},
+				{Name: "github__list_repos", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`), Server: prod},
+				{Name: "github__delete_repo", Enabled: true, Annotations: datatypes.JSON(`{"destructiveHint":true}`), Server: prod},
+			},
+			"time": {
+				{Name: "time__get_current_time", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+			},
+			"staging.github": {
+				{Name: "staging.github__list_issues", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+			},
+		},
+	}
+	selectorsJSON := func(selectors ...types.ToolSelector) datatypes.JSON {
+		j, _ := json.Marshal(selectors)
+		return j
+	}
+	resolve := func(t *testing.T, group *ToolGroup) []string {
+		t.Helper()
+		result, err := group.ResolveEffectiveTools(resolver)
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	t.Run("read-only tools of prod servers", func(t *testing.T) {
+		group := &ToolGroup{
+			IncludedToolSelectors: selectorsJSON(types.ToolSelector{
+				Annotations:  map[string]bool{"readOnlyHint": true},
+				ServerLabels: []string{"prod"},
+			}),
+		}
+		got := resolve(t, group)
+		want := []string{"github__list_issues", "github__list_repos"}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("excluded selectors win over inclusions", func(t *testing.T) {
+		group := &ToolGroup{
+			IncludedServers:       datatypes.JSON(`["github"]`),
+			ExcludedToolSelectors: selectorsJSON(types.ToolSelector{Annotations: map[string]bool{"destructiveHint": true}}),
+			ExcludedTools:         datatypes.JSON(`["github__list_repos"]`),
+		}
+		got := resolve(t, group)
+		if len(got) != 1 || got[0] != "github__list_issues" {
+			t.Errorf("expected [github__list_issues], got %v", got)
+		}
+	})
+
+	t.Run("selectors only apply within the group's namespace", func(t *testing.T) {
+		group := &ToolGroup{
+			Namespace:             "staging",
+			IncludedToolSelectors: selectorsJSON(types.ToolSelector{Pattern: "*__list_issues"}),
+		}
+		got := resolve(t, group)
+		if len(got) != 1 || got[0] != "staging.github__list_issues" {
+			t.Errorf("expected [staging.github__list_issues], got %v", got)
+		}
+	})
+
+	t.Run("disabled tools are not selected", func(t *testing.T) {
+		disabledServer := McpServer{Name: "time"}
+		resolver := &mockToolResolver{
+			serverTools: map[string][]Tool{
+				"github": {
+					{Name: "github__list_issues", Enabled: true, Server: prod},
+					{Name: "github__list_repos", Server: prod},
+				},
+				"time": {
+					{Name: "time__get_current_time", Enabled: true, Server: disabledServer},
+				},
+			},
+		}
+		group := &ToolGroup{IncludedToolSelectors: selectorsJSON(types.ToolSelector{Regex: "list_|time"})}
+		got, err := group.ResolveEffectiveTools(resolver)
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		if len(got) != 1 || got[0] != "github__list_issues" {
+			t.Errorf("expected [github__list_issues], got %v", got)
+		}
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		group := &ToolGroup{IncludedToolSelectors: selectorsJSON(types.ToolSelector{Regex: "("})}
+		if err := group.ValidateToolSelectors(); err == nil {
+			t.Error("expected an invalid regex to be rejected")
+		}
+		if _, err := group.ResolveEffectiveTools(resolver); err == nil {
+			t.Error("expected ResolveEffectiveTools() to fail for an invalid selector")
+		}
+	})
+}
+
+func TestToolGroup_IncludesTool_MatchesResolveEffectiveTools(t *testing.T) {
+	prod := McpServer{Name: "github", Enabled: true, Labels: datatypes.JSON(`["prod"]`)}
+	staging := McpServer{Name: "staging.github", Enabled: true}
+	resolver := &mockToolResolver{
+		serverTools: map[string][]Tool{
+			"github": {
+				{Name: "github__list_issues", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`), Server: prod},
+				{Name: "github__list_repos", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`), Server: prod},
+				{Name: "github__list_commits", Annotations: datatypes.JSON(`{"readOnlyHint":true}`), Server: prod},
+				{Name: "github__delete_repo", Enabled: true, Annotations: datatypes.JSON(`{"destructiveHint":true}`), Server: prod},
+			},
+			"time": {
+				{Name: "time__get_current_time", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+			},
+			"staging.github": {
+				{Name: "staging.github__list_issues", Enabled: true, Server: staging},
+				{Name: "staging.github__delete_repo", Enabled: true, Server: staging},
+			},
+		},
+	}
+	selectorsJSON := func(selectors ...types.ToolSelector) datatypes.JSON {
+		j, _ := json.Marshal(selectors)
+		return j
+	}
+	resolver.groups = map[string]*ToolGroup{
+		"readonly": {
+			Name:                  "readonly",
+			IncludedToolSelectors: selectorsJSON(types.ToolSelector{Annotations: map[string]bool{"readOnlyHint": true}}),
+			ExcludedTools:         datatypes.JSON(`["time__get_current_time"]`),
+		},
+		"github": {
+			Name:                  "github",
+			IncludedServers:       datatypes.JSON(`["github"]`),
+			ExcludedToolSelectors: selectorsJSON(types.ToolSelector{Pattern: "*__delete_*"}),
+		},
+		"combined": {
+			Name:           "combined",
+			IncludedTools:  datatypes.JSON(`["github__delete_repo"]`),
+			IncludedGroups: datatypes.JSON(`["readonly"]`),
+			ExcludedTools:  datatypes.JSON(`["github__list_repos"]`),
+		},
+		"staging.all": {
+			Name:                  "staging.all",
+			Namespace:             "staging",
+			IncludedToolSelectors: selectorsJSON(types.ToolSelector{Pattern: "github__*"}),
+			ExcludedTools:         datatypes.JSON(`["github__delete_repo"]`),
+		},
+	}
+
+	tools, err := resolver.ListTools()
+	if err != nil {
+		t.Fatalf("ListTools() failed: %v", err)
+	}
+	for name, group := range resolver.groups {
+		effective, err := group.ResolveEffectiveTools(resolver)
+		if err != nil {
+			t.Fatalf("%s: ResolveEffectiveTools() failed: %v", name, err)
+		}
+		for i := range tools {
+			includes, err := group.IncludesTool(resolver, &tools[i])
+			if err != nil {
+				t.Fatalf("%s: IncludesTool(%s) failed: %v", name, tools[i].Name, err)
+			}
+			want := false
+			for _, e := range effective {
+				want = want || e == tools[i].Name
+			}
+			if includes != want {
+				t.Errorf("%s: IncludesTool(%s) = %v, but ResolveEffectiveTools() returned %v",
+					name, tools[i].Name, includes, effective)
+			}
+		}
+	}
+}
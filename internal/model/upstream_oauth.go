@@ -55,3 +55,20 @@ type UpstreamOAuthToken struct {
 	Scope        string    `json:"scope"`
 	ExpiresAt    time.Time `json:"expires_at"`
 }
+
+// UpstreamOAuthSessionResult stores the final outcome of an upstream OAuth authorization
+// started from the dashboard (completed, failed or expired).
+// The dashboard polls for it after opening the authorization URL. Storing it in the DB
+// lets any mcpjungle replica serve the poll, regardless of which one received the OAuth callback.
+type UpstreamOAuthSessionResult struct {
+	gorm.Model
+
+	SessionID string `json:"session_id" gorm:"uniqueIndex;not null"`
+
+	Status     string `json:"status" gorm:"type:varchar(30);not null"`
+	Error      string `json:"error"`
+	ServerName string `json:"server_name"`
+
+	// ExpiresAt is the expiry of the pending OAuth session the result belongs to.
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+}
@@ -8,11 +8,14 @@ import (
 
 // User represents an authenticated, human user in enterprise mode.
 // A user can be an admin or a regular user.
+// A user in a namespace can only manage and use the entities in that namespace, so an admin user
+// in a namespace is the admin of that namespace only.
 // There are no users if mcpjungle is running in development mode.
 type User struct {
 	gorm.Model
 
 	Username    string         `json:"username" gorm:"unique; not null"`
 	Role        types.UserRole `json:"role" gorm:"not null"`
+	Namespace   string         `json:"namespace" gorm:"index;not null;default:''"`
 	AccessToken string         `json:"access_token" gorm:"unique; not null"`
 }
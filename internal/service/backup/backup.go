@@ -0,0 +1,633 @@
+// Package backup provides functionality to export the entire mcpjungle registry into a portable
+// archive and to restore such an archive into an empty mcpjungle instance.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BackupService creates and restores backup archives of the mcpjungle registry.
+type BackupService struct {
+	db *gorm.DB
+}
+
+func NewBackupService(db *gorm.DB) *BackupService {
+	return &BackupService{db: db}
+}
+
+// CreateBackup reads every entity in the registry and returns it as a backup archive.
+// Pending upstream OAuth sessions are short-lived and intentionally not included.
+func (b *BackupService) CreateBackup() (*types.BackupArchive, error) {
+	archive := &types.BackupArchive{
+		Version:   types.BackupArchiveVersion,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var cfg model.ServerConfig
+	err := b.db.First(&cfg).Error
+	if err == nil {
+		archive.ServerConfig = &types.BackupServerConfig{
+			Mode:        string(cfg.Mode),
+			Initialized: cfg.Initialized,
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to read server config: %w", err)
+	}
+
+	var namespaces []model.Namespace
+	if err := b.db.Order("id").Find(&namespaces).Error; err != nil {
+		return nil, fmt.Errorf("failed to read namespaces: %w", err)
+	}
+	archive.Namespaces = make([]types.BackupNamespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		archive.Namespaces = append(archive.Namespaces, types.BackupNamespace{
+			Name:        ns.Name,
+			Description: ns.Description,
+		})
+	}
+
+	var servers []model.McpServer
+	if err := b.db.Order("id").Find(&servers).Error; err != nil {
+		return nil, fmt.Errorf("failed to read mcp servers: %w", err)
+	}
+	// tools, prompts and resources reference their server by name in the archive
+	serverNames := make(map[uint]string, len(servers))
+	archive.McpServers = make([]types.BackupMcpServer, 0, len(servers))
+	for _, s := range servers {
+		serverNames[s.ID] = s.Name
+		archive.McpServers = append(archive.McpServers, types.BackupMcpServer{
+			Name:        s.Name,
+			Transport:   string(s.Transport),
+			Enabled:     s.Enabled,
+			Description: s.Description,
+			Config:      rawJSON(s.Config),
+			SessionMode: string(s.SessionMode),
+			Labels:      rawJSON(s.Labels),
+
+			ToolOverrides: rawJSON(s.ToolOverrides),
+			AllowedRoots:  rawJSON(s.AllowedRoots),
+			LogLevel:      s.LogLevel,
+			ServerInfo:    rawJSON(s.ServerInfo),
+		})
+	}
+
+	var tools []model.Tool
+	if err := b.db.Order("id").Find(&tools).Error; err != nil {
+		return nil, fmt.Errorf("failed to read tools: %w", err)
+	}
+	archive.Tools = make([]types.BackupTool, 0, len(tools))
+	for _, t := range tools {
+		archive.Tools = append(archive.Tools, types.BackupTool{
+			Server:      serverNames[t.ServerID],
+			Name:        t.Name,
+			Enabled:     t.Enabled,
+			Description: t.Description,
+			InputSchema: rawJSON(t.InputSchema),
+			Annotations: rawJSON(t.Annotations),
+		})
+	}
+
+	var prompts []model.Prompt
+	if err := b.db.Order("id").Find(&prompts).Error; err != nil {
+		return nil, fmt.Errorf("failed to read prompts: %w", err)
+	}
+	archive.Prompts = make([]types.BackupPrompt, 0, len(prompts))
+	for _, p := range prompts {
+		archive.Prompts = append(archive.Prompts, types.BackupPrompt{
+			Server:      serverNames[p.ServerID],
+			Name:        p.Name,
+			Enabled:     p.Enabled,
+			Description: p.Description,
+			Arguments:   rawJSON(p.Arguments),
+		})
+	}
+
+	var resources []model.Resource
+	if err := b.db.Order("id").Find(&resources).Error; err != nil {
+		return nil, fmt.Errorf("failed to read resources: %w", err)
+	}
+	archive.Resources = make([]types.BackupResource, 0, len(resources))
+	for _, r := range resources {
+		archive.Resources = append(archive.Resources, types.BackupResource{
+			Server:      serverNames[r.ServerID],
+			URI:         r.URI,
+			OriginalURI: r.OriginalURI,
+			Name:        r.Name,
+			Enabled:     r.Enabled,
+			Description: r.Description,
+			MIMEType:    r.MIMEType,
+			Annotations: rawJSON(r.Annotations),
+			Meta:        rawJSON(r.Meta),
+		})
+	}
+
+	var templates []model.ResourceTemplate
+	if err := b.db.Order("id").Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to read resource templates: %w", err)
+	}
+	archive.ResourceTemplates = make([]types.BackupResourceTemplate, 0, len(templates))
+	for _, rt := range templates {
+		archive.ResourceTemplates = append(archive.ResourceTemplates, types.BackupResourceTemplate{
+			Server:              serverNames[rt.ServerID],
+			URITemplate:         rt.URITemplate,
+			OriginalURITemplate: rt.OriginalURITemplate,
+			Name:                rt.Name,
+			Description:         rt.Description,
+			MIMEType:            rt.MIMEType,
+			Annotations:         rawJSON(rt.Annotations),
+			Meta:                rawJSON(rt.Meta),
+		})
+	}
+
+	var groups []model.ToolGroup
+	if err := b.db.Order("id").Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to read tool groups: %w", err)
+	}
+	archive.ToolGroups = make([]types.BackupToolGroup, 0, len(groups))
+	for _, g := range groups {
+		archive.ToolGroups = append(archive.ToolGroups, types.BackupToolGroup{
+			Name:            g.Name,
+			Description:     g.Description,
+			IncludedTools:   rawJSON(g.IncludedTools),
+			IncludedServers: rawJSON(g.IncludedServers),
+			ExcludedTools:   rawJSON(g.ExcludedTools),
+			IncludedGroups:  rawJSON(g.IncludedGroups),
+
+			IncludedToolSelectors: rawJSON(g.IncludedToolSelectors),
+			ExcludedToolSelectors: rawJSON(g.ExcludedToolSelectors),
+
+			IncludedPrompts:   rawJSON(g.IncludedPrompts),
+			ExcludedPrompts:   rawJSON(g.ExcludedPrompts),
+			IncludedResources: rawJSON(g.IncludedResources),
+			ExcludedResources: rawJSON(g.ExcludedResources),
+
+			ToolOverrides: rawJSON(g.ToolOverrides),
+		})
+	}
+
+	var clients []model.McpClient
+	if err := b.db.Order("id").Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("failed to read mcp clients: %w", err)
+	}
+	archive.McpClients = make([]types.BackupMcpClient, 0, len(clients))
+	for _, c := range clients {
+		archive.McpClients = append(archive.McpClients, types.BackupMcpClient{
+			Name:          c.Name,
+			Description:   c.Description,
+			AccessToken:   c.AccessToken,
+			AllowList:     rawJSON(c.AllowList),
+			AllowedGroups: rawJSON(c.AllowedGroups),
+		})
+	}
+
+	var users []model.User
+	if err := b.db.Order("id").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+	archive.Users = make([]types.BackupUser, 0, len(users))
+	for _, u := range users {
+		archive.Users = append(archive.Users, types.BackupUser{
+			Username:    u.Username,
+			Role:        string(u.Role),
+			Namespace:   u.Namespace,
+			AccessToken: u.AccessToken,
+		})
+	}
+
+	var tokens []model.UpstreamOAuthToken
+	if err := b.db.Order("id").Find(&tokens).Error; err != nil {
+		return nil, fmt.Errorf("failed to read upstream oauth tokens: %w", err)
+	}
+	archive.UpstreamOAuthTokens = make([]types.BackupUpstreamOAuthToken, 0, len(tokens))
+	for _, t := range tokens {
+		archive.UpstreamOAuthTokens = append(archive.UpstreamOAuthTokens, types.BackupUpstreamOAuthToken{
+			ServerName:   t.ServerName,
+			Transport:    string(t.Transport),
+			ClientID:     t.ClientID,
+			ClientSecret: t.ClientSecret,
+			RedirectURI:  t.RedirectURI,
+			Scopes:       rawJSON(t.Scopes),
+			AccessToken:  t.AccessToken,
+			TokenType:    t.TokenType,
+			RefreshToken: t.RefreshToken,
+			Scope:        t.Scope,
+			ExpiresAt:    t.ExpiresAt,
+		})
+	}
+
+	var revisions []model.Revision
+	if err := b.db.Order("id").Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to read revisions: %w", err)
+	}
+	archive.Revisions = make([]types.BackupRevision, 0, len(revisions))
+	for _, r := range revisions {
+		archive.Revisions = append(archive.Revisions, types.BackupRevision{
+			EntityType: string(r.EntityType),
+			EntityName: r.EntityName,
+			Number:     r.Number,
+			Action:     string(r.Action),
+			Author:     r.Author,
+			Note:       r.Note,
+			Snapshot:   rawJSON(r.Snapshot),
+			CreatedAt:  r.CreatedAt,
+		})
+	}
+
+	return archive, nil
+}
+
+// RestoreBackup writes the contents of a backup archive into the registry.
+// The registry must not contain any mcp servers, tool groups or mcp clients, so that restoring
+// never silently merges with or overwrites existing state.
+// Users that already exist (eg- the admin created by init-server) are kept and the archived
+// user with the same name is skipped. The server config of an already initialized instance is
+// kept as well, since its mode is decided when the server is started.
+// The whole restore runs in a single transaction, so a failure leaves the registry untouched.
+func (b *BackupService) RestoreBackup(archive *types.BackupArchive) (*types.RestoreResult, error) {
+	if archive == nil {
+		return nil, fmt.Errorf("backup archive is empty: %w", apierrors.ErrInvalidInput)
+	}
+	if archive.Version < 1 {
+		return nil, fmt.Errorf("backup archive does not specify a valid version: %w", apierrors.ErrInvalidInput)
+	}
+	if archive.Version > types.BackupArchiveVersion {
+		return nil, fmt.Errorf(
+			"backup archive version %d is newer than the version supported by this mcpjungle (%d), upgrade mcpjungle first: %w",
+			archive.Version, types.BackupArchiveVersion, apierrors.ErrInvalidInput,
+		)
+	}
+
+	result := &types.RestoreResult{}
+	err := b.db.Transaction(func(tx *gorm.DB) error {
+		if err := ensureRegistryIsEmpty(tx); err != nil {
+			return err
+		}
+		if err := restoreServerConfig(tx, archive.ServerConfig, result); err != nil {
+			return err
+		}
+		if err := restoreNamespaces(tx, archive.Namespaces, result); err != nil {
+			return err
+		}
+
+		serverIDs, err := restoreServers(tx, archive, result)
+		if err != nil {
+			return err
+		}
+		if err := restoreServerEntities(tx, archive, serverIDs, result); err != nil {
+			return err
+		}
+
+		for _, g := range archive.ToolGroups {
+			ns, _ := model.SplitQualifiedName(g.Name)
+			group := model.ToolGroup{
+				Name:            g.Name,
+				Namespace:       ns,
+				Description:     g.Description,
+				IncludedTools:   datatypes.JSON(g.IncludedTools),
+				IncludedServers: datatypes.JSON(g.IncludedServers),
+				ExcludedTools:   datatypes.JSON(g.ExcludedTools),
+				IncludedGroups:  datatypes.JSON(g.IncludedGroups),
+
+				IncludedToolSelectors: datatypes.JSON(g.IncludedToolSelectors),
+				ExcludedToolSelectors: datatypes.JSON(g.ExcludedToolSelectors),
+
+				IncludedPrompts:   datatypes.JSON(g.IncludedPrompts),
+				ExcludedPrompts:   datatypes.JSON(g.ExcludedPrompts),
+				IncludedResources: datatypes.JSON(g.IncludedResources),
+				ExcludedResources: datatypes.JSON(g.ExcludedResources),
+
+				ToolOverrides: datatypes.JSON(g.ToolOverrides),
+			}
+			if err := tx.Create(&group).Error; err != nil {
+				return fmt.Errorf("failed to restore tool group %s: %w", g.Name, err)
+			}
+			result.ToolGroups++
+		}
+
+		for _, c := range archive.McpClients {
+			allowList := datatypes.JSON(c.AllowList)
+			if len(allowList) == 0 {
+				allowList = datatypes.JSON("[]")
+			}
+			ns, _ := model.SplitQualifiedName(c.Name)
+			client := model.McpClient{
+				Name:          c.Name,
+				Namespace:     ns,
+				Description:   c.Description,
+				AccessToken:   c.AccessToken,
+				AllowList:     allowList,
+				AllowedGroups: datatypes.JSON(c.AllowedGroups),
+			}
+			if err := tx.Create(&client).Error; err != nil {
+				return fmt.Errorf("failed to restore mcp client %s: %w", c.Name, err)
+			}
+			result.McpClients++
+		}
+
+		for _, r := range archive.Revisions {
+			rev := model.Revision{
+				EntityType: model.RevisionEntityType(r.EntityType),
+				EntityName: r.EntityName,
+				Number:     r.Number,
+				Action:     model.RevisionAction(r.Action),
+				Author:     r.Author,
+				Note:       r.Note,
+				Snapshot:   datatypes.JSON(r.Snapshot),
+			}
+			// keep the original time of the change instead of the time of the restore
+			rev.CreatedAt = r.CreatedAt
+			if err := tx.Create(&rev).Error; err != nil {
+				return fmt.Errorf("failed to restore revision %d of %s %s: %w", r.Number, r.EntityType, r.EntityName, err)
+			}
+			result.Revisions++
+		}
+
+		return restoreUsers(tx, archive.Users, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func ensureRegistryIsEmpty(tx *gorm.DB) error {
+	checks := []struct {
+		model any
+		name  string
+	}{
+		{&model.McpServer{}, "mcp servers"},
+		{&model.ToolGroup{}, "tool groups"},
+		{&model.McpClient{}, "mcp clients"},
+		{&model.Revision{}, "revision history"},
+	}
+	for _, c := range checks {
+		var count int64
+		if err := tx.Model(c.model).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count %s: %w", c.name, err)
+		}
+		if count > 0 {
+			return fmt.Errorf(
+				"cannot restore a backup into a registry that already contains %s, restore into an empty instance: %w",
+				c.name, apierrors.ErrInvalidInput,
+			)
+		}
+	}
+	return nil
+}
+
+func restoreServerConfig(tx *gorm.DB, archived *types.BackupServerConfig, result *types.RestoreResult) error {
+	if archived == nil {
+		return nil
+	}
+
+	var current model.ServerConfig
+	err := tx.First(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		cfg := model.ServerConfig{
+			Mode:        model.ServerMode(archived.Mode),
+			Initialized: archived.Initialized,
+		}
+		if err := tx.Create(&cfg).Error; err != nil {
+			return fmt.Errorf("failed to restore server config: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read server config: %w", err)
+	}
+
+	if string(current.Mode) != archived.Mode {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"backup was taken from a server in %s mode, but this server runs in %s mode; keeping the current mode",
+			archived.Mode, current.Mode,
+		))
+	}
+	return nil
+}
+
+// restoreNamespaces creates all archived namespaces.
+// Namespaces that already exist are kept, so that the archived entities can be restored into them.
+func restoreNamespaces(tx *gorm.DB, namespaces []types.BackupNamespace, result *types.RestoreResult) error {
+	for _, n := range namespaces {
+		var existing int64
+		if err := tx.Model(&model.Namespace{}).Where("name = ?", n.Name).Count(&existing).Error; err != nil {
+			return fmt.Errorf("failed to check for existing namespace %s: %w", n.Name, err)
+		}
+		if existing > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped namespace %s: it already exists", n.Name))
+			continue
+		}
+
+		ns := model.Namespace{Name: n.Name, Description: n.Description}
+		if err := tx.Create(&ns).Error; err != nil {
+			return fmt.Errorf("failed to restore namespace %s: %w", n.Name, err)
+		}
+		result.Namespaces++
+	}
+	return nil
+}
+
+// restoreServers creates all archived mcp servers and returns their new IDs keyed by server name.
+func restoreServers(tx *gorm.DB, archive *types.BackupArchive, result *types.RestoreResult) (map[string]uint, error) {
+	serverIDs := make(map[string]uint, len(archive.McpServers))
+	for _, s := range archive.McpServers {
+		ns, _ := model.SplitQualifiedName(s.Name)
+		server := model.McpServer{
+			Name:        s.Name,
+			Namespace:   ns,
+			Transport:   types.McpServerTransport(s.Transport),
+			Enabled:     s.Enabled,
+			Description: s.Description,
+			Config:      datatypes.JSON(s.Config),
+			SessionMode: types.SessionMode(s.SessionMode),
+			Labels:      datatypes.JSON(s.Labels),
+
+			ToolOverrides: datatypes.JSON(s.ToolOverrides),
+			AllowedRoots:  datatypes.JSON(s.AllowedRoots),
+			LogLevel:      s.LogLevel,
+			ServerInfo:    datatypes.JSON(s.ServerInfo),
+		}
+		if err := createPreservingEnabled(tx, &server, s.Enabled); err != nil {
+			return nil, fmt.Errorf("failed to restore mcp server %s: %w", s.Name, err)
+		}
+		serverIDs[s.Name] = server.ID
+		result.McpServers++
+	}
+	return serverIDs, nil
+}
+
+// restoreServerEntities creates the tools, prompts, resources and upstream oauth tokens
+// that belong to the restored mcp servers.
+func restoreServerEntities(
+	tx *gorm.DB, archive *types.BackupArchive, serverIDs map[string]uint, result *types.RestoreResult,
+) error {
+	for _, t := range archive.Tools {
+		serverID, ok := serverIDs[t.Server]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped tool %s: server %q is not in the backup", t.Name, t.Server))
+			continue
+		}
+		tool := model.Tool{
+			Name:        t.Name,
+			Enabled:     t.Enabled,
+			Description: t.Description,
+			InputSchema: datatypes.JSON(t.InputSchema),
+			Annotations: datatypes.JSON(t.Annotations),
+			ServerID:    serverID,
+		}
+		if err := createPreservingEnabled(tx, &tool, t.Enabled); err != nil {
+			return fmt.Errorf("failed to restore tool %s of server %s: %w", t.Name, t.Server, err)
+		}
+		result.Tools++
+	}
+
+	for _, p := range archive.Prompts {
+		serverID, ok := serverIDs[p.Server]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped prompt %s: server %q is not in the backup", p.Name, p.Server))
+			continue
+		}
+		prompt := model.Prompt{
+			Name:        p.Name,
+			Enabled:     p.Enabled,
+			Description: p.Description,
+			Arguments:   datatypes.JSON(p.Arguments),
+			ServerID:    serverID,
+		}
+		if err := createPreservingEnabled(tx, &prompt, p.Enabled); err != nil {
+			return fmt.Errorf("failed to restore prompt %s of server %s: %w", p.Name, p.Server, err)
+		}
+		result.Prompts++
+	}
+
+	for _, r := range archive.Resources {
+		serverID, ok := serverIDs[r.Server]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped resource %s: server %q is not in the backup", r.URI, r.Server))
+			continue
+		}
+		resource := model.Resource{
+			URI:         r.URI,
+			OriginalURI: r.OriginalURI,
+			Name:        r.Name,
+			Enabled:     r.Enabled,
+			Description: r.Description,
+			MIMEType:    r.MIMEType,
+			Annotations: datatypes.JSON(r.Annotations),
+			Meta:        datatypes.JSON(r.Meta),
+			ServerID:    serverID,
+		}
+		if err := createPreservingEnabled(tx, &resource, r.Enabled); err != nil {
+			return fmt.Errorf("failed to restore resource %s of server %s: %w", r.URI, r.Server, err)
+		}
+		result.Resources++
+	}
+
+	for _, rt := range archive.ResourceTemplates {
+		serverID, ok := serverIDs[rt.Server]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped resource template %s: server %q is not in the backup", rt.URITemplate, rt.Server))
+			continue
+		}
+		template := model.ResourceTemplate{
+			URITemplate:         rt.URITemplate,
+			OriginalURITemplate: rt.OriginalURITemplate,
+			Name:                rt.Name,
+			Description:         rt.Description,
+			MIMEType:            rt.MIMEType,
+			Annotations:         datatypes.JSON(rt.Annotations),
+			Meta:                datatypes.JSON(rt.Meta),
+			ServerID:            serverID,
+		}
+		if err := tx.Create(&template).Error; err != nil {
+			return fmt.Errorf("failed to restore resource template %s of server %s: %w", rt.URITemplate, rt.Server, err)
+		}
+		result.ResourceTemplates++
+	}
+
+	for _, t := range archive.UpstreamOAuthTokens {
+		if _, ok := serverIDs[t.ServerName]; !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped upstream oauth token: server %q is not in the backup", t.ServerName))
+			continue
+		}
+		token := model.UpstreamOAuthToken{
+			ServerName:   t.ServerName,
+			Transport:    types.McpServerTransport(t.Transport),
+			ClientID:     t.ClientID,
+			ClientSecret: t.ClientSecret,
+			RedirectURI:  t.RedirectURI,
+			Scopes:       datatypes.JSON(t.Scopes),
+			AccessToken:  t.AccessToken,
+			TokenType:    t.TokenType,
+			RefreshToken: t.RefreshToken,
+			Scope:        t.Scope,
+			ExpiresAt:    t.ExpiresAt,
+		}
+		if err := tx.Create(&token).Error; err != nil {
+			return fmt.Errorf("failed to restore upstream oauth token of server %s: %w", t.ServerName, err)
+		}
+		result.UpstreamOAuthTokens++
+	}
+
+	return nil
+}
+
+func restoreUsers(tx *gorm.DB, users []types.BackupUser, result *types.RestoreResult) error {
+	for _, u := range users {
+		var existing int64
+		err := tx.Model(&model.User{}).
+			Where("username = ? OR access_token = ?", u.Username, u.AccessToken).
+			Count(&existing).Error
+		if err != nil {
+			return fmt.Errorf("failed to check for existing user %s: %w", u.Username, err)
+		}
+		if existing > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipped user %s: a user with the same name or access token already exists", u.Username))
+			continue
+		}
+
+		user := model.User{
+			Username:    u.Username,
+			Role:        types.UserRole(u.Role),
+			Namespace:   u.Namespace,
+			AccessToken: u.AccessToken,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("failed to restore user %s: %w", u.Username, err)
+		}
+		result.Users++
+	}
+	return nil
+}
+
+// createPreservingEnabled inserts a record whose "enabled" column has a database default of true.
+// GORM omits zero values for columns with defaults on insert, so disabled records are
+// explicitly updated after being created.
+func createPreservingEnabled(tx *gorm.DB, record any, enabled bool) error {
+	if err := tx.Omit(clause.Associations).Create(record).Error; err != nil {
+		return err
+	}
+	if enabled {
+		return nil
+	}
+	return tx.Model(record).Update("enabled", false).Error
+}
+
+// rawJSON converts a JSON column into a raw message for the archive.
+// Empty columns are omitted from the archive instead of being written as invalid JSON.
+func rawJSON(v datatypes.JSON) json.RawMessage {
+	if len(v) == 0 {
+		return nil
+	}
+	return json.RawMessage(v)
+}
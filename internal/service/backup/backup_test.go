@@ -0,0 +1,287 @@
+package backup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+	return db
+}
+
+// seedRegistry populates a registry with one entity of every kind, including disabled ones.
+func seedRegistry(t *testing.T, db *gorm.DB) {
+	t.Helper()
+
+	must := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("failed to seed registry: %v", err)
+		}
+	}
+
+	must(db.Create(&model.ServerConfig{Mode: model.ModeEnterprise, Initialized: true}).Error)
+
+	server := model.McpServer{
+		Name:      "calc",
+		Transport: types.TransportStreamableHTTP,
+		Enabled:   true,
+		Config:    datatypes.JSON(`{"url":"http://localhost:8000/mcp"}`),
+		Labels:    datatypes.JSON(`["prod"]`),
+
+		ToolOverrides: datatypes.JSON(`{"add":{"name":"sum"}}`),
+		AllowedRoots:  datatypes.JSON(`["file:///srv/data"]`),
+		LogLevel:      "warning",
+		ServerInfo:    datatypes.JSON(`{"name":"calc-server","version":"1.2.0","instructions":"Use add for sums."}`),
+	}
+	must(db.Create(&server).Error)
+
+	add := model.Tool{Name: "add", Enabled: true, ServerID: server.ID, InputSchema: datatypes.JSON(`{"type":"object"}`)}
+	sub := model.Tool{Name: "subtract", Enabled: true, ServerID: server.ID, InputSchema: datatypes.JSON(`{"type":"object"}`)}
+	must(db.Create(&add).Error)
+	must(db.Create(&sub).Error)
+	must(db.Model(&sub).Update("enabled", false).Error)
+
+	must(db.Create(&model.Prompt{Name: "explain", Enabled: true, ServerID: server.ID}).Error)
+	must(db.Create(&model.Resource{
+		URI: "mcpj://res/calc/abc", OriginalURI: "file:///abc", Name: "calc__abc", Enabled: true, ServerID: server.ID,
+	}).Error)
+	must(db.Create(&model.ResourceTemplate{
+		URITemplate: "mcpj://tmpl/calc/calc://history/{day}", OriginalURITemplate: "calc://history/{day}",
+		Name: "history", ServerID: server.ID,
+	}).Error)
+
+	must(db.Create(&model.ToolGroup{
+		Name:          "math",
+		IncludedTools: datatypes.JSON(`["calc__add"]`),
+		ExcludedToolSelectors: datatypes.JSON(
+			`[{"annotations":{"destructiveHint":true}}]`,
+		),
+		IncludedPrompts:   datatypes.JSON(`["calc__explain"]`),
+		IncludedResources: datatypes.JSON(`["mcpj://res/calc/abc"]`),
+		ToolOverrides:     datatypes.JSON(`{"calc__add":{"pinned_params":{"precision":2}}}`),
+	}).Error)
+	must(db.Create(&model.McpClient{Name: "cursor", AccessToken: "client-token", AllowList: datatypes.JSON(`["calc"]`), AllowedGroups: datatypes.JSON(`["math"]`)}).Error)
+	must(db.Create(&model.User{Username: "admin", Role: types.UserRoleAdmin, AccessToken: "admin-token"}).Error)
+	must(db.Create(&model.User{Username: "alice", Role: types.UserRoleUser, AccessToken: "alice-token"}).Error)
+	must(db.Create(&model.UpstreamOAuthToken{ServerName: "calc", Transport: types.TransportStreamableHTTP, AccessToken: "up"}).Error)
+	must(db.Create(&model.Revision{
+		EntityType: model.RevisionEntityToolGroup,
+		EntityName: "math",
+		Number:     1,
+		Action:     model.RevisionActionCreated,
+		Author:     "alice",
+		Snapshot:   datatypes.JSON(`{"name":"math","included_tools":["calc__add"]}`),
+	}).Error)
+}
+
+func TestCreateBackup(t *testing.T) {
+	db := newTestDB(t)
+	seedRegistry(t, db)
+
+	archive, err := NewBackupService(db).CreateBackup()
+	testhelpers.AssertNoError(t, err)
+
+	testhelpers.AssertEqual(t, types.BackupArchiveVersion, archive.Version)
+	testhelpers.AssertEqual(t, "enterprise", archive.ServerConfig.Mode)
+	testhelpers.AssertEqual(t, 1, len(archive.McpServers))
+	testhelpers.AssertEqual(t, 2, len(archive.Tools))
+	testhelpers.AssertEqual(t, "calc", archive.Tools[0].Server)
+	testhelpers.AssertEqual(t, false, archive.Tools[1].Enabled)
+	testhelpers.AssertEqual(t, 1, len(archive.Prompts))
+	testhelpers.AssertEqual(t, "file:///abc", archive.Resources[0].OriginalURI)
+	testhelpers.AssertEqual(t, "calc://history/{day}", archive.ResourceTemplates[0].OriginalURITemplate)
+	testhelpers.AssertEqual(t, 1, len(archive.ToolGroups))
+	testhelpers.AssertEqual(t, "client-token", archive.McpClients[0].AccessToken)
+	testhelpers.AssertEqual(t, 2, len(archive.Users))
+	testhelpers.AssertEqual(t, 1, len(archive.UpstreamOAuthTokens))
+	testhelpers.AssertEqual(t, 1, len(archive.Revisions))
+	testhelpers.AssertEqual(t, "alice", archive.Revisions[0].Author)
+}
+
+func TestRestoreBackup_RoundTrip(t *testing.T) {
+	source := newTestDB(t)
+	seedRegistry(t, source)
+	archive, err := NewBackupService(source).CreateBackup()
+	testhelpers.AssertNoError(t, err)
+
+	// the target has already been initialized in enterprise mode and has its own admin user
+	target := newTestDB(t)
+	testhelpers.AssertNoError(t, target.Create(&model.ServerConfig{Mode: model.ModeEnterprise, Initialized: true}).Error)
+	testhelpers.AssertNoError(t, target.Create(&model.User{Username: "admin", Role: types.UserRoleAdmin, AccessToken: "new-admin"}).Error)
+
+	result, err := NewBackupService(target).RestoreBackup(archive)
+	testhelpers.AssertNoError(t, err)
+
+	testhelpers.AssertEqual(t, 1, result.McpServers)
+	testhelpers.AssertEqual(t, 2, result.Tools)
+	testhelpers.AssertEqual(t, 1, result.Prompts)
+	testhelpers.AssertEqual(t, 1, result.Resources)
+	testhelpers.AssertEqual(t, 1, result.ResourceTemplates)
+	testhelpers.AssertEqual(t, 1, result.ToolGroups)
+	testhelpers.AssertEqual(t, 1, result.McpClients)
+	testhelpers.AssertEqual(t, 1, result.Users)
+	testhelpers.AssertEqual(t, 1, result.UpstreamOAuthTokens)
+	testhelpers.AssertEqual(t, 1, result.Revisions)
+	testhelpers.AssertEqual(t, 1, len(result.Warnings))
+
+	var sub model.Tool
+	testhelpers.AssertNoError(t, target.Where("name = ?", "subtract").First(&sub).Error)
+	testhelpers.AssertEqual(t, false, sub.Enabled)
+
+	var server model.McpServer
+	testhelpers.AssertNoError(t, target.Where("name = ?", "calc").First(&server).Error)
+	testhelpers.AssertEqual(t, server.ID, sub.ServerID)
+	labels, err := server.GetLabels()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(labels))
+	testhelpers.AssertEqual(t, "prod", labels[0])
+	serverOverrides, err := server.GetToolOverrides()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "sum", serverOverrides["add"].Name)
+	allowedRoots, err := server.GetAllowedRoots()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(allowedRoots))
+	testhelpers.AssertEqual(t, "file:///srv/data", allowedRoots[0])
+	testhelpers.AssertEqual(t, "warning", server.LogLevel)
+	serverInfo, err := server.GetServerInfo()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "calc-server", serverInfo.Name)
+	testhelpers.AssertEqual(t, "Use add for sums.", serverInfo.Instructions)
+
+	var group model.ToolGroup
+	testhelpers.AssertNoError(t, target.Where("name = ?", "math").First(&group).Error)
+	resources, err := group.GetResources()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(resources))
+	testhelpers.AssertEqual(t, "mcpj://res/calc/abc", resources[0])
+	excludedSelectors, err := group.GetExcludedToolSelectors()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(excludedSelectors))
+	testhelpers.AssertEqual(t, true, excludedSelectors[0].Annotations["destructiveHint"])
+	groupOverrides, err := group.GetToolOverrides()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, float64(2), groupOverrides["calc__add"].PinnedParams["precision"])
+
+	var client model.McpClient
+	testhelpers.AssertNoError(t, target.Where("name = ?", "cursor").First(&client).Error)
+	allowedGroups, err := client.GetAllowedGroups()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(allowedGroups))
+	testhelpers.AssertEqual(t, "math", allowedGroups[0])
+
+	var admin model.User
+	testhelpers.AssertNoError(t, target.Where("username = ?", "admin").First(&admin).Error)
+	testhelpers.AssertEqual(t, "new-admin", admin.AccessToken)
+
+	// a second backup of the target must match the original one
+	again, err := NewBackupService(target).CreateBackup()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, len(archive.Tools), len(again.Tools))
+	testhelpers.AssertEqual(t, string(archive.McpServers[0].Config), string(again.McpServers[0].Config))
+	testhelpers.AssertTrue(t, archive.Revisions[0].CreatedAt.Equal(again.Revisions[0].CreatedAt), "expected revision time to be preserved")
+}
+
+func TestRestoreBackup_Namespaces(t *testing.T) {
+	source := newTestDB(t)
+	testhelpers.AssertNoError(t, source.Create(&model.ServerConfig{Mode: model.ModeEnterprise, Initialized: true}).Error)
+	testhelpers.AssertNoError(t, source.Create(&model.Namespace{Name: "team-a", Description: "Team A"}).Error)
+	testhelpers.AssertNoError(t, source.Create(&model.McpServer{
+		Name:      "team-a.calc",
+		Namespace: "team-a",
+		Transport: types.TransportStreamableHTTP,
+		Enabled:   true,
+		Config:    datatypes.JSON(`{"url":"http://localhost:8000/mcp"}`),
+	}).Error)
+	testhelpers.AssertNoError(t, source.Create(&model.User{
+		Username: "bob", Role: types.UserRoleAdmin, Namespace: "team-a", AccessToken: "bob-token",
+	}).Error)
+
+	archive, err := NewBackupService(source).CreateBackup()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(archive.Namespaces))
+
+	target := newTestDB(t)
+	result, err := NewBackupService(target).RestoreBackup(archive)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, result.Namespaces)
+
+	var ns model.Namespace
+	testhelpers.AssertNoError(t, target.Where("name = ?", "team-a").First(&ns).Error)
+	testhelpers.AssertEqual(t, "Team A", ns.Description)
+
+	var server model.McpServer
+	testhelpers.AssertNoError(t, target.Where("name = ?", "team-a.calc").First(&server).Error)
+	testhelpers.AssertEqual(t, "team-a", server.Namespace)
+
+	var bob model.User
+	testhelpers.AssertNoError(t, target.Where("username = ?", "bob").First(&bob).Error)
+	testhelpers.AssertEqual(t, "team-a", bob.Namespace)
+	testhelpers.AssertEqual(t, types.UserRoleAdmin, bob.Role)
+}
+
+func TestRestoreBackup_IntoUninitializedDB(t *testing.T) {
+	source := newTestDB(t)
+	seedRegistry(t, source)
+	archive, err := NewBackupService(source).CreateBackup()
+	testhelpers.AssertNoError(t, err)
+
+	target := newTestDB(t)
+	result, err := NewBackupService(target).RestoreBackup(archive)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, result.Users)
+
+	var cfg model.ServerConfig
+	testhelpers.AssertNoError(t, target.First(&cfg).Error)
+	testhelpers.AssertEqual(t, model.ModeEnterprise, cfg.Mode)
+	testhelpers.AssertTrue(t, cfg.Initialized, "expected restored server config to be initialized")
+}
+
+func TestRestoreBackup_Rejections(t *testing.T) {
+	source := newTestDB(t)
+	seedRegistry(t, source)
+	archive, err := NewBackupService(source).CreateBackup()
+	testhelpers.AssertNoError(t, err)
+
+	t.Run("non-empty registry", func(t *testing.T) {
+		_, err := NewBackupService(source).RestoreBackup(archive)
+		testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected invalid input error")
+	})
+
+	t.Run("newer archive version", func(t *testing.T) {
+		newer := *archive
+		newer.Version = types.BackupArchiveVersion + 1
+		_, err := NewBackupService(newTestDB(t)).RestoreBackup(&newer)
+		testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected invalid input error")
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		_, err := NewBackupService(newTestDB(t)).RestoreBackup(&types.BackupArchive{})
+		testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected invalid input error")
+	})
+
+	t.Run("failure rolls back", func(t *testing.T) {
+		broken := *archive
+		// duplicate server names violate the unique index halfway through the restore
+		broken.McpServers = append(broken.McpServers, broken.McpServers[0])
+		target := newTestDB(t)
+		_, err := NewBackupService(target).RestoreBackup(&broken)
+		testhelpers.AssertError(t, err)
+
+		var count int64
+		testhelpers.AssertNoError(t, target.Model(&model.McpServer{}).Count(&count).Error)
+		testhelpers.AssertEqual(t, int64(0), count)
+	})
+}
@@ -88,6 +88,7 @@ func (s *Service) Servers() (*types.DashboardServersResponse, error) {
 	}
 	for _, inv := range inventory {
 		summary := summarizeServerConfig(inv.McpServer)
+		serverInfo, _ := inv.GetServerInfo()
 		resp.Servers = append(resp.Servers, types.DashboardServer{
 			Name:              inv.Name,
 			Transport:         string(inv.Transport),
@@ -100,6 +101,7 @@ func (s *Service) Servers() (*types.DashboardServersResponse, error) {
 			UpdatedAt:         formatTime(inv.UpdatedAt),
 			ConnectionSummary: summary.SanitizedSummary,
 			ConfigSummary:     summary,
+			ServerInfo:        serverInfo,
 		})
 	}
 
@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// cancelledNotificationMethod is the method of the notifications that tell the receiver
+// that a request it is working on was cancelled.
+const cancelledNotificationMethod = "notifications/cancelled"
+
+// cancelNotificationTimeout is how long mcpjungle waits for an upstream MCP server to accept
+// the notification that a tool call was cancelled.
+const cancelNotificationTimeout = 5 * time.Second
+
+// callUpstreamTool forwards a tool call to an upstream MCP server through the given client.
+//
+// The MCP proxy server cancels the context of a request when the MCP client that made it sends
+// notifications/cancelled for it or disconnects. mcpjungle assigns its own ID to every call it forwards,
+// so when that happens, it can tell the upstream server to stop working on the call as well.
+func (m *MCPService) callUpstreamTool(
+	ctx context.Context, s *model.McpServer, c *client.Client, request mcp.CallToolRequest,
+) (*mcp.CallToolResult, error) {
+	// the upstream server may ask the MCP client to sample an LLM, elicit information or list its roots
+	// while it works on the call
+	defer m.clientRequests.track(ctx, s, c)()
+
+	id := mcp.NewRequestId(fmt.Sprintf("mcpjungle-call-%d", m.upstreamCallIDs.Add(1)))
+	response, err := c.GetTransport().SendRequest(ctx, transport.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Method:  string(mcp.MethodToolsCall),
+		Params:  request.Params,
+		Header:  request.Header,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			cancelUpstreamRequest(ctx, c, id)
+			return nil, fmt.Errorf("call of tool %s was cancelled: %w", request.Params.Name, ctx.Err())
+		}
+		return nil, transport.NewError(err)
+	}
+	if response.Error != nil {
+		return nil, response.Error.AsError()
+	}
+	return mcp.ParseCallToolResult(&response.Result)
+}
+
+// cancelUpstreamRequest tells an upstream MCP server that the request with the given ID was cancelled.
+// The notification is best-effort, so failures are only logged.
+func cancelUpstreamRequest(ctx context.Context, c *client.Client, id mcp.RequestId) {
+	reason := "the MCP client cancelled the request"
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		reason = "the request timed out"
+	}
+
+	// the request's context is done, so the notification is sent with a context of its own
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelNotificationTimeout)
+	defer cancel()
+
+	err := c.GetTransport().SendNotification(notifyCtx, mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: cancelledNotificationMethod,
+			Params: mcp.NotificationParams{
+				AdditionalFields: map[string]any{"requestId": id, "reason": reason},
+			},
+		},
+	})
+	if err != nil {
+		log.Printf("[WARN] failed to notify upstream MCP server that request %s was cancelled: %v", id.String(), err)
+	}
+}
+
+// isCancelled returns true if the error of a call is due to the caller cancelling the call.
+// Calls that time out are not considered cancelled.
+func isCancelled(ctx context.Context, err error) bool {
+	return err != nil && errors.Is(ctx.Err(), context.Canceled)
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outcomeRecordingMetrics records the outcomes of tool calls.
+type outcomeRecordingMetrics struct {
+	telemetry.NoopCustomMetrics
+
+	mu       sync.Mutex
+	outcomes []telemetry.ToolCallOutcome
+}
+
+func (m *outcomeRecordingMetrics) RecordToolCall(
+	_ context.Context, _, _ string, outcome telemetry.ToolCallOutcome, _ time.Duration,
+) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.outcomes = append(m.outcomes, outcome)
+}
+
+func TestMCPProxyToolCallHandler_ForwardsCancellation(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	started := make(chan struct{})
+	upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", mcpserver.WithToolCapabilities(true))
+	upstream.AddTool(
+		mcp.NewTool("import"),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	)
+
+	// capture the IDs of the upstream tool calls and of the requests cancelled upstream
+	var mu sync.Mutex
+	var callIDs, cancelledIDs []any
+	streamable := mcpserver.NewStreamableHTTPServer(upstream)
+	upstreamHTTP := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var message struct {
+			ID     any            `json:"id"`
+			Method string         `json:"method"`
+			Params map[string]any `json:"params"`
+		}
+		if json.Unmarshal(body, &message) == nil {
+			mu.Lock()
+			switch message.Method {
+			case string(mcp.MethodToolsCall):
+				callIDs = append(callIDs, message.ID)
+			case cancelledNotificationMethod:
+				cancelledIDs = append(cancelledIDs, message.Params["requestId"])
+			}
+			mu.Unlock()
+		}
+		streamable.ServeHTTP(w, r)
+	}))
+	defer upstreamHTTP.Close()
+
+	srv := createStreamableHTTPTestServer(t, "importer", upstreamHTTP.URL)
+	srv.SessionMode = types.SessionModeStateful
+	require.NoError(t, db.Create(srv).Error)
+
+	metrics := &outcomeRecordingMetrics{}
+	service := &MCPService{
+		db:                         db,
+		metrics:                    metrics,
+		mcpServerInitReqTimeoutSec: 5,
+		sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+	}
+	defer service.sessionManager.Shutdown()
+
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), "mode", model.ModeDev))
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "importer__import"
+	_, err := service.MCPProxyToolCallHandler(ctx, req)
+	require.ErrorIs(t, err, context.Canceled)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, callIDs, 1)
+	assert.Equal(t, callIDs, cancelledIDs)
+
+	assert.Equal(t, []telemetry.ToolCallOutcome{telemetry.ToolCallOutcomeCancelled}, metrics.outcomes)
+	// cancelling a call does not tear down the stateful session
+	assert.True(t, service.sessionManager.HasSession("importer"))
+}
@@ -0,0 +1,310 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// upstreamClientCapabilities returns the capabilities mcpjungle advertises when it connects to an upstream MCP server.
+//
+// mcpjungle relays the sampling, elicitation and roots requests of upstream servers to the MCP client whose tool call
+// they belong to, so a stateless session advertises them if the MCP client it is created for supports them.
+// A stateful session is shared by all MCP clients, so it always advertises them. Requests that belong to the call
+// of a client that doesn't support them are rejected when they arrive.
+// Roots are only advertised to servers that MCP clients are allowed to share roots with.
+func upstreamClientCapabilities(ctx context.Context, s *model.McpServer) mcp.ClientCapabilities {
+	var capabilities mcp.ClientCapabilities
+	if s.SessionMode == types.SessionModeStateful {
+		capabilities = mcp.ClientCapabilities{
+			Sampling:    &struct{}{},
+			Elicitation: &mcp.ElicitationCapability{},
+			Roots: &struct {
+				ListChanged bool `json:"listChanged,omitempty"`
+			}{},
+		}
+	} else if downstream, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo); ok {
+		supported := downstream.GetClientCapabilities()
+		capabilities = mcp.ClientCapabilities{
+			Sampling:    supported.Sampling,
+			Elicitation: supported.Elicitation,
+			Roots:       supported.Roots,
+		}
+	}
+
+	if capabilities.Roots != nil {
+		if allowed, _ := s.GetAllowedRoots(); len(allowed) == 0 {
+			capabilities.Roots = nil
+		} else {
+			// mcpjungle relays the changes to the roots of MCP clients, see relayRootsListChanged
+			capabilities.Roots.ListChanged = true
+		}
+	}
+	return capabilities
+}
+
+// routeClientRequests relays the requests that the upstream MCP server of a new stateless session sends
+// to the MCP client whose tool call the session is created for, if any.
+// A stateless session only serves one call, so its requests are relayed even before the call is made,
+// eg- the roots requests that servers send right after they are initialized.
+// It must be called before the session is initialized.
+func routeClientRequests(ctx context.Context, c *client.Client, s *model.McpServer) {
+	bidirectional, ok := c.GetTransport().(transport.BidirectionalInterface)
+	if !ok || s.SessionMode == types.SessionModeStateful || server.ServerFromContext(ctx) == nil {
+		return
+	}
+	bidirectional.SetRequestHandler(func(_ context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+		return relayClientRequest(ctx, s, request)
+	})
+}
+
+// clientRequestRouter routes the requests that upstream MCP servers send to mcpjungle while working on
+// tool calls, ie, sampling, elicitation and roots requests, to the MCP clients that made the calls.
+//
+// The requests of streamable HTTP servers may arrive on the response stream of the call they belong to,
+// in which case they carry the context of the call. Other requests are routed to the only call in progress
+// on their session, if there is exactly one.
+type clientRequestRouter struct {
+	mu sync.Mutex
+	// calls contains the tool calls in progress on every upstream client
+	calls map[*client.Client][]upstreamCall
+	// lastSessions contains the MCP client session that made the latest call to every stateful MCP server
+	lastSessions map[string]server.ClientSession
+}
+
+// upstreamCall is a tool call that an MCP client made through an upstream MCP server.
+type upstreamCall struct {
+	// ctx is the context of the downstream request, which identifies the client session
+	ctx    context.Context
+	server *model.McpServer
+}
+
+// track routes the requests sent by the upstream server s through the given client to the MCP client
+// of the given tool call, until the returned function is called once the call is over.
+func (r *clientRequestRouter) track(ctx context.Context, s *model.McpServer, c *client.Client) func() {
+	bidirectional, ok := c.GetTransport().(transport.BidirectionalInterface)
+	if !ok || server.ServerFromContext(ctx) == nil {
+		// SSE servers cannot send requests, and calls made through the HTTP API have no client to relay them to
+		return func() {}
+	}
+	call := upstreamCall{ctx: ctx, server: s}
+
+	r.mu.Lock()
+	if r.calls == nil {
+		r.calls = make(map[*client.Client][]upstreamCall)
+		r.lastSessions = make(map[string]server.ClientSession)
+	}
+	r.calls[c] = append(r.calls[c], call)
+
+	rootsChanged := false
+	if s.SessionMode == types.SessionModeStateful {
+		// stateless sessions relay the requests of their server since they were created, see routeClientRequests
+		bidirectional.SetRequestHandler(func(reqCtx context.Context, request transport.JSONRPCRequest) (*transport.JSONRPCResponse, error) {
+			return r.handle(reqCtx, c, request)
+		})
+		session := server.ClientSessionFromContext(ctx)
+		previous, ok := r.lastSessions[s.Name]
+		rootsChanged = ok && previous != session
+		r.lastSessions[s.Name] = session
+	}
+	r.mu.Unlock()
+
+	if rootsChanged {
+		// the session was used on behalf of another client before, whose roots the server may still be using
+		notifyRootsListChanged(ctx, s, c)
+	}
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		calls := r.calls[c]
+		for i := range calls {
+			if calls[i].ctx == ctx {
+				calls = append(calls[:i:i], calls[i+1:]...)
+				break
+			}
+		}
+		if len(calls) == 0 {
+			delete(r.calls, c)
+			return
+		}
+		r.calls[c] = calls
+	}
+}
+
+// target returns the tool call that a request received by the given upstream client belongs to.
+func (r *clientRequestRouter) target(reqCtx context.Context, c *client.Client) (*upstreamCall, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := r.calls[c]
+	if session := server.ClientSessionFromContext(reqCtx); session != nil {
+		for i := range calls {
+			if server.ClientSessionFromContext(calls[i].ctx) == session {
+				return &upstreamCall{ctx: reqCtx, server: calls[i].server}, nil
+			}
+		}
+	}
+	switch len(calls) {
+	case 0:
+		return nil, fmt.Errorf("no tool call is in progress")
+	case 1:
+		return &calls[0], nil
+	default:
+		return nil, fmt.Errorf("cannot tell which of the %d tool calls in progress the request belongs to", len(calls))
+	}
+}
+
+// handle relays a request received from an upstream MCP server to the MCP client whose tool call it belongs to
+// and returns the client's response.
+func (r *clientRequestRouter) handle(
+	reqCtx context.Context, c *client.Client, request transport.JSONRPCRequest,
+) (*transport.JSONRPCResponse, error) {
+	call, err := r.target(reqCtx, c)
+	if err != nil {
+		switch request.Method {
+		case string(mcp.MethodPing):
+			return transport.NewJSONRPCResultResponse(request.ID, json.RawMessage(`{}`)), nil
+		case string(mcp.MethodListRoots):
+			// servers ask for roots whenever they are told that the roots changed, even between calls
+			return transport.NewJSONRPCResultResponse(request.ID, json.RawMessage(`{"roots":[]}`)), nil
+		}
+		return nil, fmt.Errorf("failed to relay %s request to MCP client: %w", request.Method, err)
+	}
+	return relayClientRequest(call.ctx, call.server, request)
+}
+
+// relayClientRequest relays a request received from the upstream MCP server s to the MCP client of the given
+// tool call context and returns the client's response.
+func relayClientRequest(
+	ctx context.Context, s *model.McpServer, request transport.JSONRPCRequest,
+) (*transport.JSONRPCResponse, error) {
+	proxy := server.ServerFromContext(ctx)
+	var capabilities mcp.ClientCapabilities
+	if downstream, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo); ok {
+		capabilities = downstream.GetClientCapabilities()
+	}
+
+	var (
+		result any
+		err    error
+	)
+	switch request.Method {
+	case string(mcp.MethodPing):
+		return transport.NewJSONRPCResultResponse(request.ID, json.RawMessage(`{}`)), nil
+
+	case string(mcp.MethodSamplingCreateMessage):
+		if capabilities.Sampling == nil {
+			return nil, fmt.Errorf("the MCP client does not support sampling")
+		}
+		req := mcp.CreateMessageRequest{Request: mcp.Request{Method: request.Method}}
+		if err := remarshal(request.Params, &req.CreateMessageParams); err != nil {
+			return nil, fmt.Errorf("invalid sampling request: %w", err)
+		}
+		result, err = proxy.RequestSampling(ctx, req)
+
+	case string(mcp.MethodElicitationCreate):
+		if capabilities.Elicitation == nil {
+			return nil, fmt.Errorf("the MCP client does not support elicitation")
+		}
+		req := mcp.ElicitationRequest{Request: mcp.Request{Method: request.Method}}
+		if err := remarshal(request.Params, &req.Params); err != nil {
+			return nil, fmt.Errorf("invalid elicitation request: %w", err)
+		}
+		result, err = proxy.RequestElicitation(ctx, req)
+
+	case string(mcp.MethodListRoots):
+		allowed, err := s.GetAllowedRoots()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allowed roots of MCP server %s: %w", s.Name, err)
+		}
+		if len(allowed) == 0 {
+			return nil, fmt.Errorf("MCP clients are not allowed to share roots with MCP server %s", s.Name)
+		}
+		roots := []mcp.Root{}
+		if capabilities.Roots != nil {
+			res, err := proxy.RequestRoots(ctx, mcp.ListRootsRequest{Request: mcp.Request{Method: request.Method}})
+			if err != nil {
+				return nil, err
+			}
+			roots = restrictRoots(res.Roots, allowed)
+		}
+		result = &mcp.ListRootsResult{Roots: roots}
+
+	default:
+		return nil, fmt.Errorf("unsupported request method: %s", request.Method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return transport.NewJSONRPCResultResponse(request.ID, resultBytes), nil
+}
+
+// relayRootsListChanged tells the upstream MCP servers that are working on tool calls of an MCP client
+// that the client's roots changed, so they can ask for them again.
+func (r *clientRequestRouter) relayRootsListChanged(ctx context.Context, _ mcp.JSONRPCNotification) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+
+	type target struct {
+		c *client.Client
+		s *model.McpServer
+	}
+	var targets []target
+	r.mu.Lock()
+	for c, calls := range r.calls {
+		for _, call := range calls {
+			if server.ClientSessionFromContext(call.ctx) == session {
+				targets = append(targets, target{c: c, s: call.server})
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	for _, t := range targets {
+		notifyRootsListChanged(ctx, t.s, t.c)
+	}
+}
+
+// notifyRootsListChanged tells an upstream MCP server that the roots it was given may have changed,
+// if MCP clients are allowed to share roots with it.
+func notifyRootsListChanged(ctx context.Context, s *model.McpServer, c *client.Client) {
+	if allowed, _ := s.GetAllowedRoots(); len(allowed) == 0 {
+		return
+	}
+	err := c.GetTransport().SendNotification(ctx, mcp.JSONRPCNotification{
+		JSONRPC:      mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{Method: mcp.MethodNotificationRootsListChanged},
+	})
+	if err != nil {
+		log.Printf("[WARN] failed to notify MCP server %s that the roots changed: %v", s.Name, err)
+	}
+}
+
+// remarshal converts the params of a JSON-RPC request into the given struct.
+func remarshal(params any, v any) error {
+	if params == nil {
+		return nil
+	}
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assistant answers the sampling and elicitation requests relayed to a downstream MCP client.
+type assistant struct{}
+
+func (assistant) CreateMessage(_ context.Context, request mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+	return &mcp.CreateMessageResult{
+		SamplingMessage: mcp.SamplingMessage{
+			Role:    mcp.RoleAssistant,
+			Content: mcp.NewTextContent(fmt.Sprintf("summary of %d messages", len(request.Messages))),
+		},
+		Model: "test-model",
+	}, nil
+}
+
+func (assistant) Elicit(_ context.Context, request mcp.ElicitationRequest) (*mcp.ElicitationResult, error) {
+	return &mcp.ElicitationResult{
+		ElicitationResponse: mcp.ElicitationResponse{
+			Action:  mcp.ElicitationResponseActionAccept,
+			Content: map[string]any{"confirm": request.Params.Message == "Proceed?"},
+		},
+	}, nil
+}
+
+// newAskingUpstreamServer returns an upstream MCP server whose tool samples an LLM and elicits a confirmation
+// from the user before it answers.
+func newAskingUpstreamServer() *mcpserver.MCPServer {
+	upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", mcpserver.WithToolCapabilities(true))
+	upstream.AddTool(
+		mcp.NewTool("summarize"),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			s := mcpserver.ServerFromContext(ctx)
+
+			sampling := mcp.CreateMessageRequest{}
+			sampling.Messages = []mcp.SamplingMessage{{Role: mcp.RoleUser, Content: mcp.NewTextContent("hello")}}
+			sampling.MaxTokens = 10
+			sampled, err := s.RequestSampling(ctx, sampling)
+			if err != nil {
+				return mcp.NewToolResultError("sampling failed: " + err.Error()), nil
+			}
+
+			elicitation := mcp.ElicitationRequest{}
+			elicitation.Params.Message = "Proceed?"
+			elicitation.Params.RequestedSchema = map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"confirm": map[string]any{"type": "boolean"}},
+			}
+			elicited, err := s.RequestElicitation(ctx, elicitation)
+			if err != nil {
+				return mcp.NewToolResultError("elicitation failed: " + err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf(
+				"%s, %s, %v", sampled.Content.(mcp.TextContent).Text, elicited.Action, elicited.Content,
+			)), nil
+		},
+	)
+	return upstream
+}
+
+func TestMCPProxyToolCallHandler_RelaysSamplingAndElicitation(t *testing.T) {
+	cases := []struct {
+		sessionMode types.SessionMode
+		handlers    bool
+		want        string
+		wantError   string
+	}{
+		{sessionMode: types.SessionModeStateless, handlers: true, want: "summary of 1 messages, accept, map[confirm:true]"},
+		{sessionMode: types.SessionModeStateful, handlers: true, want: "summary of 1 messages, accept, map[confirm:true]"},
+		{sessionMode: types.SessionModeStateful, handlers: false, wantError: "the MCP client does not support sampling"},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s handlers=%v", tc.sessionMode, tc.handlers), func(t *testing.T) {
+			db := setupTestDBForProxyAdditional(t)
+
+			upstreamHTTP := newUpstreamStreamableHTTPServer(t, newAskingUpstreamServer())
+			defer upstreamHTTP.Close()
+
+			srv := createStreamableHTTPTestServer(t, "summarizer", upstreamHTTP.URL)
+			srv.SessionMode = tc.sessionMode
+			require.NoError(t, db.Create(srv).Error)
+
+			service := &MCPService{
+				db:                         db,
+				metrics:                    telemetry.NewNoopCustomMetrics(),
+				mcpServerInitReqTimeoutSec: 5,
+				sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+			}
+			defer service.sessionManager.Shutdown()
+
+			proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithToolCapabilities(true))
+			proxy.AddTool(mcp.NewTool("summarizer__summarize"), service.MCPProxyToolCallHandler)
+			proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(
+				proxy,
+				mcpserver.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+					return context.WithValue(ctx, "mode", model.ModeDev)
+				}),
+			))
+			defer proxyHTTP.Close()
+
+			trans, err := transport.NewStreamableHTTP(proxyHTTP.URL, transport.WithContinuousListening())
+			require.NoError(t, err)
+			var opts []mcpclient.ClientOption
+			if tc.handlers {
+				opts = append(opts, mcpclient.WithSamplingHandler(assistant{}), mcpclient.WithElicitationHandler(assistant{}))
+			}
+			client := mcpclient.NewClient(trans, opts...)
+			defer client.Close()
+
+			ctx := context.Background()
+			require.NoError(t, client.Start(ctx))
+			initReq := mcp.InitializeRequest{}
+			initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+			initReq.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+			_, err = client.Initialize(ctx, initReq)
+			require.NoError(t, err)
+
+			req := mcp.CallToolRequest{}
+			req.Params.Name = "summarizer__summarize"
+			res, err := client.CallTool(ctx, req)
+			require.NoError(t, err)
+			require.Len(t, res.Content, 1)
+			text := res.Content[0].(mcp.TextContent).Text
+
+			if tc.wantError != "" {
+				assert.True(t, res.IsError)
+				assert.Contains(t, text, tc.wantError)
+				return
+			}
+			assert.False(t, res.IsError, text)
+			assert.Equal(t, tc.want, text)
+			assert.Empty(t, service.clientRequests.calls)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+)
+
+// CompletePromptArgument returns the completions suggested by the upstream MCP server of a prompt
+// for the value of one of the prompt's arguments.
+// The input name must be the canonical prompt name, ie, it must contain the server name prefix.
+func (m *MCPService) CompletePromptArgument(
+	ctx context.Context, name string, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	serverName, promptName, ok := splitServerPromptName(name)
+	if !ok {
+		return nil, fmt.Errorf("prompt name does not contain a %s separator: %w", serverPromptNameSep, apierrors.ErrInvalidInput)
+	}
+	if _, exists := m.GetPromptInstance(name); !exists {
+		return nil, fmt.Errorf("prompt %s not found or is disabled: %w", name, apierrors.ErrNotFound)
+	}
+
+	ref := mcp.PromptReference{Type: "ref/prompt", Name: promptName}
+	return m.completeArgument(ctx, serverName, ref, argument, completeCtx)
+}
+
+// CompleteResourceTemplateArgument returns the completions suggested by the upstream MCP server of a resource
+// template for the value of one of the variables of its URI template.
+// The input URI must be the URI template of the resource template in mcpjungle, ie, mcpj://tmpl/...
+func (m *MCPService) CompleteResourceTemplateArgument(
+	ctx context.Context, uri string, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	serverName, originalURITemplate, err := parseResourceTemplateURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !m.servesResourceTemplate(serverName, uri) {
+		return nil, fmt.Errorf("resource template %s not found: %w", uri, apierrors.ErrNotFound)
+	}
+
+	ref := mcp.ResourceReference{Type: "ref/resource", URI: originalURITemplate}
+	return m.completeArgument(ctx, serverName, ref, argument, completeCtx)
+}
+
+// servesResourceTemplate returns true if the resource template with the given mcpjungle URI template is
+// currently served for an MCP server.
+func (m *MCPService) servesResourceTemplate(serverName, uri string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, template := range m.resourceTemplateInstances[serverName] {
+		if template.URITemplate != nil && template.URITemplate.Raw() == uri {
+			return true
+		}
+	}
+	return false
+}
+
+// completeArgument forwards a completion request for the given prompt or resource template reference
+// to an upstream MCP server.
+// Upstream servers that don't support completions have no suggestions for any argument.
+func (m *MCPService) completeArgument(
+	ctx context.Context, serverName string, ref any, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	serverModel, err := m.GetMcpServer(serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details about MCP server %s from DB: %w", serverName, err)
+	}
+
+	session, err := m.getSession(ctx, serverModel)
+	if err != nil {
+		return nil, err
+	}
+	defer session.closeIfApplicable()
+
+	if session.client.GetServerCapabilities().Completions == nil {
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+
+	request := mcp.CompleteRequest{}
+	request.Params.Ref = ref
+	request.Params.Argument = argument
+	request.Params.Context = completeCtx
+
+	result, err := session.client.Complete(ctx, request)
+	if err != nil {
+		session.invalidateOnError(err) // Invalidate unhealthy stateful sessions
+		return nil, fmt.Errorf("failed to get completions from MCP server %s: %w", serverName, err)
+	}
+	if result.Completion.Values == nil {
+		result.Completion.Values = []string{}
+	}
+	return &result.Completion, nil
+}
+
+// ProxyCompletionProvider serves the argument completion requests (completion/complete) of an MCP proxy server
+// by forwarding them to the upstream MCP server of the prompt or resource template.
+// It implements both server.PromptCompletionProvider and server.ResourceCompletionProvider.
+type ProxyCompletionProvider struct {
+	// service is set once the MCPService is created, since the gateway's proxy servers are created before it.
+	service atomic.Pointer[MCPService]
+
+	// servesPrompt reports whether the proxy server serves the prompt with the given canonical name.
+	// It is nil for the gateway's proxy servers, which serve all enabled prompts.
+	servesPrompt func(name string) bool
+	// servesResourceTemplates reports whether the proxy server serves the resource templates of the MCP server
+	// with the given name. It is nil for the gateway's proxy servers, which serve the templates of all enabled servers.
+	servesResourceTemplates func(serverName string) bool
+}
+
+// NewProxyCompletionProvider creates the completion provider of the gateway's MCP proxy servers.
+// It must be bound to the MCPService before the proxy servers serve any MCP clients.
+func NewProxyCompletionProvider() *ProxyCompletionProvider {
+	return &ProxyCompletionProvider{}
+}
+
+// NewGroupCompletionProvider creates the completion provider of a tool group's MCP proxy server,
+// which only completes the arguments of the prompts and resource templates it serves.
+func (m *MCPService) NewGroupCompletionProvider(
+	servesPrompt func(name string) bool, servesResourceTemplates func(serverName string) bool,
+) *ProxyCompletionProvider {
+	p := &ProxyCompletionProvider{servesPrompt: servesPrompt, servesResourceTemplates: servesResourceTemplates}
+	p.Bind(m)
+	return p
+}
+
+// Bind sets the MCPService that the provider forwards completion requests through.
+func (p *ProxyCompletionProvider) Bind(m *MCPService) {
+	p.service.Store(m)
+}
+
+// CompletePromptArgument implements server.PromptCompletionProvider.
+func (p *ProxyCompletionProvider) CompletePromptArgument(
+	ctx context.Context, promptName string, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	m := p.service.Load()
+	if m == nil {
+		return nil, errors.New("completions are not available yet")
+	}
+	serverName, _, ok := splitServerPromptName(promptName)
+	if !ok {
+		return nil, fmt.Errorf("prompt name does not contain a %s separator: %w", serverPromptNameSep, apierrors.ErrInvalidInput)
+	}
+	if err := authorizeProxyServerAccess(ctx, serverName); err != nil {
+		return nil, err
+	}
+	if p.servesPrompt != nil && !p.servesPrompt(promptName) {
+		return nil, fmt.Errorf("prompt %s not found: %w", promptName, apierrors.ErrNotFound)
+	}
+	return m.CompletePromptArgument(ctx, promptName, argument, completeCtx)
+}
+
+// CompleteResourceArgument implements server.ResourceCompletionProvider.
+func (p *ProxyCompletionProvider) CompleteResourceArgument(
+	ctx context.Context, uri string, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	m := p.service.Load()
+	if m == nil {
+		return nil, errors.New("completions are not available yet")
+	}
+	serverName, _, err := parseResourceTemplateURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeProxyServerAccess(ctx, serverName); err != nil {
+		return nil, err
+	}
+	if p.servesResourceTemplates != nil && !p.servesResourceTemplates(serverName) {
+		return nil, fmt.Errorf("resource template %s not found: %w", uri, apierrors.ErrNotFound)
+	}
+	return m.CompleteResourceTemplateArgument(ctx, uri, argument, completeCtx)
+}
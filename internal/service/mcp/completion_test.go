@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upstreamCompletionProvider suggests values that identify the reference and argument they complete.
+type upstreamCompletionProvider struct{}
+
+func (upstreamCompletionProvider) CompletePromptArgument(
+	_ context.Context, promptName string, argument mcp.CompleteArgument, completeCtx mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	return &mcp.Completion{
+		Values: []string{promptName + ":" + argument.Name + ":" + argument.Value + ":" + completeCtx.Arguments["owner"]},
+	}, nil
+}
+
+func (upstreamCompletionProvider) CompleteResourceArgument(
+	_ context.Context, uri string, argument mcp.CompleteArgument, _ mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	return &mcp.Completion{Values: []string{uri + ":" + argument.Name + ":" + argument.Value}, Total: 10, HasMore: true}, nil
+}
+
+func TestCompletions_ForwardedToUpstream(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	upstream := mcpserver.NewMCPServer(
+		"Upstream", "0.1.0",
+		mcpserver.WithToolCapabilities(false),
+		mcpserver.WithPromptCapabilities(false),
+		mcpserver.WithResourceCapabilities(false, false),
+		mcpserver.WithCompletions(),
+		mcpserver.WithPromptCompletionProvider(upstreamCompletionProvider{}),
+		mcpserver.WithResourceCompletionProvider(upstreamCompletionProvider{}),
+	)
+	upstream.AddPrompt(
+		mcp.NewPrompt("review", mcp.WithArgument("repo")),
+		func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return mcp.NewGetPromptResult("review", nil), nil
+		},
+	)
+	upstream.AddResourceTemplate(
+		mcp.NewResourceTemplate("repo://{owner}/{name}/readme", "readme"),
+		func(context.Context, mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		},
+	)
+	upstreamHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(upstream))
+	defer upstreamHTTP.Close()
+
+	completions := NewProxyCompletionProvider()
+	proxy := mcpserver.NewMCPServer(
+		"Proxy", "0.1.0",
+		mcpserver.WithCompletions(),
+		mcpserver.WithPromptCompletionProvider(completions),
+		mcpserver.WithResourceCompletionProvider(completions),
+	)
+	service, err := NewMCPService(&ServiceConfig{
+		DB:                      db,
+		McpProxyServer:          proxy,
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 5,
+	})
+	require.NoError(t, err)
+	defer service.Shutdown()
+	completions.Bind(service)
+
+	ctx := context.Background()
+	require.NoError(t, service.registerMcpServer(ctx, createStreamableHTTPTestServer(t, "github", upstreamHTTP.URL), false))
+
+	// MCP clients get the completions through the proxy server, which forwards the upstream prompt name
+	client, err := mcpclient.NewInProcessClient(proxy)
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Start(ctx))
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initResult, err := client.Initialize(ctx, initReq)
+	require.NoError(t, err)
+	assert.NotNil(t, initResult.Capabilities.Completions)
+
+	requestCtx := context.WithValue(ctx, "mode", model.ModeDev)
+	req := mcp.CompleteRequest{}
+	req.Params.Ref = mcp.PromptReference{Type: "ref/prompt", Name: "github__review"}
+	req.Params.Argument = mcp.CompleteArgument{Name: "repo", Value: "mcp"}
+	req.Params.Context = mcp.CompleteContext{Arguments: map[string]string{"owner": "octo"}}
+	result, err := client.Complete(requestCtx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"review:repo:mcp:octo"}, result.Completion.Values)
+
+	// resource templates are completed upstream with their original URI template
+	req.Params.Ref = mcp.ResourceReference{Type: "ref/resource", URI: "mcpj://tmpl/github/repo://{owner}/{name}/readme"}
+	req.Params.Argument = mcp.CompleteArgument{Name: "owner", Value: "oc"}
+	result, err = client.Complete(requestCtx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repo://{owner}/{name}/readme:owner:oc"}, result.Completion.Values)
+	assert.Equal(t, 10, result.Completion.Total)
+	assert.True(t, result.Completion.HasMore)
+
+	// unknown prompts and resource templates are not completed
+	_, err = service.CompletePromptArgument(ctx, "github__missing", mcp.CompleteArgument{Name: "repo"}, mcp.CompleteContext{})
+	require.ErrorIs(t, err, apierrors.ErrNotFound)
+	_, err = service.CompleteResourceTemplateArgument(
+		ctx, "mcpj://tmpl/github/repo://{owner}", mcp.CompleteArgument{Name: "owner"}, mcp.CompleteContext{},
+	)
+	require.ErrorIs(t, err, apierrors.ErrNotFound)
+
+	// group proxy servers only complete the arguments of their own prompts and resource templates
+	groupCompletions := service.NewGroupCompletionProvider(
+		func(name string) bool { return name == "github__other" },
+		func(serverName string) bool { return serverName == "other" },
+	)
+	_, err = groupCompletions.CompletePromptArgument(
+		requestCtx, "github__review", mcp.CompleteArgument{Name: "repo"}, mcp.CompleteContext{},
+	)
+	require.ErrorIs(t, err, apierrors.ErrNotFound)
+	_, err = groupCompletions.CompleteResourceArgument(
+		requestCtx, "mcpj://tmpl/github/repo://{owner}/{name}/readme", mcp.CompleteArgument{Name: "owner"}, mcp.CompleteContext{},
+	)
+	require.ErrorIs(t, err, apierrors.ErrNotFound)
+
+	groupCompletions = service.NewGroupCompletionProvider(
+		func(name string) bool { return false },
+		func(serverName string) bool { return serverName == "github" },
+	)
+	completion, err := groupCompletions.CompleteResourceArgument(
+		requestCtx, "mcpj://tmpl/github/repo://{owner}/{name}/readme", mcp.CompleteArgument{Name: "owner", Value: "oc"}, mcp.CompleteContext{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"repo://{owner}/{name}/readme:owner:oc"}, completion.Values)
+}
+
+func TestCompletions_UpstreamWithoutCompletionsSuggestsNothing(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	upstream := mcpserver.NewMCPServer(
+		"Upstream", "0.1.0", mcpserver.WithToolCapabilities(false), mcpserver.WithPromptCapabilities(false),
+	)
+	upstream.AddPrompt(
+		mcp.NewPrompt("review", mcp.WithArgument("repo")),
+		func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+			return mcp.NewGetPromptResult("review", nil), nil
+		},
+	)
+	upstreamHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(upstream))
+	defer upstreamHTTP.Close()
+
+	service, err := NewMCPService(&ServiceConfig{
+		DB:                      db,
+		McpProxyServer:          mcpserver.NewMCPServer("Proxy", "0.1.0"),
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 5,
+	})
+	require.NoError(t, err)
+	defer service.Shutdown()
+
+	ctx := context.Background()
+	require.NoError(t, service.registerMcpServer(ctx, createStreamableHTTPTestServer(t, "github", upstreamHTTP.URL), false))
+
+	completion, err := service.CompletePromptArgument(
+		ctx, "github__review", mcp.CompleteArgument{Name: "repo"}, mcp.CompleteContext{},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, completion.Values)
+	assert.NotNil(t, completion.Values)
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// instructionsPreamble introduces the instructions of the upstream MCP servers in the instructions of a proxy server.
+const instructionsPreamble = "The tools and prompts of the following MCP servers are named <server>" +
+	serverToolNameSep + "<name>. Their servers provide these usage instructions:"
+
+// ServeServerInstructions composes the instructions that upstream MCP servers reported during initialization
+// into the instructions that the given proxy server returns to the MCP clients that initialize with it.
+// Clients only get the instructions of the enabled servers they can access.
+// servers returns the names of the servers whose entities the proxy serves; if it is nil, the proxy
+// serves all the servers in the registry.
+// It does nothing unless composing server instructions is enabled, and must be called before the proxy
+// server serves any MCP clients.
+func (m *MCPService) ServeServerInstructions(proxy *server.MCPServer, servers func() ([]string, error)) {
+	if m == nil || !m.composeServerInstructions {
+		return
+	}
+	hooks := proxy.GetHooks()
+	if hooks == nil {
+		return
+	}
+	hooks.AddAfterInitialize(func(ctx context.Context, _ any, _ *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		instructions, err := m.serverInstructions(ctx, servers)
+		if err != nil {
+			log.Printf("[WARN] failed to compose instructions of MCP servers: %v", err)
+			return
+		}
+		result.Instructions = instructions
+	})
+}
+
+// serverInstructions returns the composed instructions of the enabled MCP servers, among the given ones,
+// that the MCP client of the request in ctx can access.
+// It returns an empty string if none of these servers provide instructions.
+func (m *MCPService) serverInstructions(ctx context.Context, servers func() ([]string, error)) (string, error) {
+	hasAccess, ok := proxyServerAccessChecker(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	query := m.db.Where("enabled = ?", true).Order("name")
+	if servers != nil {
+		names, err := servers()
+		if err != nil {
+			return "", err
+		}
+		if len(names) == 0 {
+			return "", nil
+		}
+		query = query.Where("name IN ?", names)
+	}
+	var records []model.McpServer
+	if err := query.Find(&records).Error; err != nil {
+		return "", fmt.Errorf("failed to read MCP servers: %w", err)
+	}
+
+	var sections []string
+	for _, s := range records {
+		if !hasAccess(s.Name) {
+			continue
+		}
+		info, err := s.GetServerInfo()
+		if err != nil {
+			log.Printf("[WARN] failed to read server info of MCP server %s: %v", s.Name, err)
+			continue
+		}
+		if info == nil || strings.TrimSpace(info.Instructions) == "" {
+			continue
+		}
+		sections = append(sections, fmt.Sprintf("## %s\n\n%s", s.Name, strings.TrimSpace(info.Instructions)))
+	}
+	if len(sections) == 0 {
+		return "", nil
+	}
+	return instructionsPreamble + "\n\n" + strings.Join(sections, "\n\n"), nil
+}
+
+// EntityServers returns the sorted names of the MCP servers that provide the given tools, prompts and resources,
+// given their canonical names and mcpjungle URIs respectively.
+func EntityServers(tools, prompts, resourceURIs []string) []string {
+	servers := make(map[string]bool)
+	for _, name := range tools {
+		if serverName, _, ok := splitServerToolName(name); ok {
+			servers[serverName] = true
+		}
+	}
+	for _, name := range prompts {
+		if serverName, _, ok := splitServerPromptName(name); ok {
+			servers[serverName] = true
+		}
+	}
+	for _, uri := range resourceURIs {
+		if serverName, _, err := parseResourceURI(uri); err == nil {
+			servers[serverName] = true
+		}
+	}
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
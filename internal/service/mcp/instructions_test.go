@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerInfo_CapturedOnRegistrationAndUpdate(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	newUpstream := func(version, instructions string) *httptest.Server {
+		upstream := mcpserver.NewMCPServer(
+			"Calculator", version,
+			mcpserver.WithToolCapabilities(true),
+			mcpserver.WithInstructions(instructions),
+		)
+		upstream.AddTool(mcp.NewTool("add"), func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		})
+		return newUpstreamStreamableHTTPServer(t, upstream)
+	}
+
+	oldUpstream := newUpstream("1.0.0", "Use add for sums.")
+	defer oldUpstream.Close()
+	srv := createStreamableHTTPTestServer(t, "calc", oldUpstream.URL)
+	require.NoError(t, service.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+
+	registered, err := service.GetMcpServer("calc")
+	require.NoError(t, err)
+	info, err := registered.GetServerInfo()
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "Calculator", info.Name)
+	assert.Equal(t, "1.0.0", info.Version)
+	assert.Equal(t, "Use add for sums.", info.Instructions)
+	assert.Contains(t, info.Capabilities, "tools")
+
+	newUpstreamHTTP := newUpstream("2.0.0", "Use add for sums of any length.")
+	defer newUpstreamHTTP.Close()
+	_, err = service.UpdateMcpServer(context.Background(), createStreamableHTTPTestServer(t, "calc", newUpstreamHTTP.URL))
+	require.NoError(t, err)
+
+	updated, err := service.GetMcpServer("calc")
+	require.NoError(t, err)
+	info, err = updated.GetServerInfo()
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, "2.0.0", info.Version)
+	assert.Equal(t, "Use add for sums of any length.", info.Instructions)
+}
+
+func TestServeServerInstructions_ComposesInstructionsOfVisibleServers(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	createServer := func(name, instructions string, enabled bool) {
+		t.Helper()
+		srv := createStreamableHTTPTestServer(t, name, "http://127.0.0.1:1/mcp")
+		ns, _ := model.SplitQualifiedName(name)
+		srv.Namespace = ns
+		if instructions != "" {
+			require.NoError(t, srv.SetServerInfo(&types.UpstreamServerInfo{Name: name, Instructions: instructions}))
+		}
+		require.NoError(t, db.Create(srv).Error)
+		if !enabled {
+			require.NoError(t, db.Model(srv).Update("enabled", false).Error)
+		}
+	}
+	createServer("calc", "Use add for sums.", true)
+	createServer("team.docs", "Search before reading documents.", true)
+	createServer("weather", "", true)
+	createServer("legacy", "Do not use.", false)
+
+	initialize := func(t *testing.T, service *MCPService, servers func() ([]string, error), namespace string) string {
+		t.Helper()
+		proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithHooks(&mcpserver.Hooks{}))
+		service.ServeServerInstructions(proxy, servers)
+		proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(
+			proxy,
+			mcpserver.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+				ctx = context.WithValue(ctx, "mode", model.ModeDev)
+				return context.WithValue(ctx, "namespace", namespace)
+			}),
+		))
+		defer proxyHTTP.Close()
+
+		client, err := mcpclient.NewStreamableHttpClient(proxyHTTP.URL)
+		require.NoError(t, err)
+		defer client.Close()
+		require.NoError(t, client.Start(context.Background()))
+		initReq := mcp.InitializeRequest{}
+		initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+		initReq.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+		result, err := client.Initialize(context.Background(), initReq)
+		require.NoError(t, err)
+		return result.Instructions
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		service := &MCPService{db: db}
+		assert.Empty(t, initialize(t, service, nil, ""))
+	})
+
+	service := &MCPService{db: db, composeServerInstructions: true}
+
+	t.Run("gateway serves the instructions of all enabled servers", func(t *testing.T) {
+		instructions := initialize(t, service, nil, "")
+		assert.Equal(
+			t,
+			instructionsPreamble+"\n\n## calc\n\nUse add for sums.\n\n## team.docs\n\nSearch before reading documents.",
+			instructions,
+		)
+	})
+
+	t.Run("namespaced clients only get the instructions of their namespace", func(t *testing.T) {
+		instructions := initialize(t, service, nil, "team")
+		assert.Contains(t, instructions, "## team.docs")
+		assert.NotContains(t, instructions, "calc")
+	})
+
+	t.Run("proxies of some servers only serve their instructions", func(t *testing.T) {
+		servers := func() ([]string, error) {
+			return EntityServers([]string{"calc__add"}, []string{"weather__forecast"}, nil), nil
+		}
+		instructions := initialize(t, service, servers, "")
+		assert.Contains(t, instructions, "## calc")
+		assert.NotContains(t, instructions, "team.docs")
+
+		noServers := func() ([]string, error) { return nil, nil }
+		assert.Empty(t, initialize(t, service, noServers, ""))
+	})
+}
+
+func TestEntityServers(t *testing.T) {
+	servers := EntityServers(
+		[]string{"calc__add", "calc__sub", "invalid"},
+		[]string{"team.docs__summarize"},
+		[]string{buildResourceURI("weather", "file:///forecast.json"), "not-a-uri"},
+	)
+	assert.Equal(t, []string{"calc", "team.docs", "weather"}, servers)
+}
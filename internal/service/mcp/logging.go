@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+)
+
+// logMessageNotificationMethod is the method of the notifications that MCP servers send their log messages with.
+const logMessageNotificationMethod = "notifications/message"
+
+// captureUpstreamLogs writes the log messages that an upstream MCP server sends through the given client
+// to the mcpjungle server logs, along with the name of the server.
+func captureUpstreamLogs(name string, c *client.Client) {
+	c.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != logMessageNotificationMethod {
+			return
+		}
+		params, err := parseLogMessageParams(notification)
+		if err != nil {
+			log.Printf("['%s' MCP Server] [WARN] received an invalid log message: %v", name, err)
+			return
+		}
+
+		logger := ""
+		if params.Logger != "" {
+			logger = params.Logger + ": "
+		}
+		log.Printf("['%s' MCP Server] [%s] %s%s", name, strings.ToUpper(string(params.Level)), logger, logMessageData(params.Data))
+	})
+}
+
+// parseLogMessageParams parses the params of a log message notification.
+func parseLogMessageParams(notification mcp.JSONRPCNotification) (*mcp.LoggingMessageNotificationParams, error) {
+	var params mcp.LoggingMessageNotificationParams
+	if err := remarshal(notification.Params.AdditionalFields, &params); err != nil {
+		return nil, err
+	}
+	if params.Level == "" {
+		return nil, fmt.Errorf("the log message has no level")
+	}
+	return &params, nil
+}
+
+// logMessageData returns the printable form of the data of a log message, which can be any JSON value.
+func logMessageData(data any) string {
+	if text, ok := data.(string); ok {
+		return text
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Sprintf("%v", data)
+	}
+	return string(b)
+}
+
+// setUpstreamLogLevel asks an upstream MCP server to only send the log messages of the server's configured
+// log level and above (logging/setLevel), if a level is configured and the server supports logging.
+func setUpstreamLogLevel(ctx context.Context, s *model.McpServer, c *client.Client) error {
+	if s.LogLevel == "" {
+		return nil
+	}
+	if capabilities := c.GetServerCapabilities(); capabilities.Logging == nil {
+		log.Printf("[WARN] MCP server %s does not support logging, its log level %s is not set", s.Name, s.LogLevel)
+		return nil
+	}
+
+	request := mcp.SetLevelRequest{}
+	request.Params.Level = mcp.LoggingLevel(s.LogLevel)
+	if err := c.SetLevel(ctx, request); err != nil {
+		return fmt.Errorf("failed to set log level of MCP server %s to %s: %w", s.Name, s.LogLevel, err)
+	}
+	return nil
+}
+
+// SetMcpServerLogLevel changes the minimum level of the log messages that an MCP server sends to mcpjungle.
+// An empty level unsets it. Like the level configured at registration, the new level is applied upstream
+// with logging/setLevel the next time the server's stateful session is used.
+func (m *MCPService) SetMcpServerLogLevel(name, level string) error {
+	if err := validateServerName(name); err != nil {
+		return err
+	}
+	server, err := m.GetMcpServer(name)
+	if err != nil {
+		return err
+	}
+	if err := server.SetLogLevel(level); err != nil {
+		return fmt.Errorf("%v: %w", err, apierrors.ErrInvalidInput)
+	}
+	if err := m.db.Model(server).Update("log_level", server.LogLevel).Error; err != nil {
+		return fmt.Errorf("failed to set log level of server %s: %w", name, err)
+	}
+	m.notifyServerChange(name)
+	return nil
+}
+
+// logRelay forwards the log messages that upstream MCP servers send during tool calls to the MCP clients
+// that made the calls, if they asked for log messages with logging/setLevel.
+// Messages are only sent to a client if they are at or above the level it asked for.
+//
+// Log messages carry nothing that tells which request they belong to, and a stateful session is shared by
+// all MCP clients. So like the requests of upstream servers (see clientRequestRouter), a message is only relayed
+// if exactly one call is in progress on its session, since the message must belong to that call.
+type logRelay struct {
+	mu sync.Mutex
+
+	// calls contains the contexts of the tool calls in progress on every upstream client.
+	// The context of a downstream request identifies the client session and proxy server it was made to.
+	calls map[*client.Client][]context.Context
+
+	// relaying contains the upstream clients whose log messages are relayed.
+	// Clients of stateless sessions are forgotten once their call is over, while the client of a stateful
+	// session is remembered in statefulClients until the session of its MCP server is replaced.
+	relaying        map[*client.Client]bool
+	statefulClients map[string]*client.Client
+
+	// listeners contains the client sessions that asked for log messages.
+	listeners map[server.ClientSession]bool
+}
+
+// listen records that the MCP client session of the request in ctx asked for log messages.
+func (r *logRelay) listen(ctx context.Context) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.listeners == nil {
+		r.listeners = make(map[server.ClientSession]bool)
+	}
+	r.listeners[session] = true
+}
+
+// forget drops an MCP client session that ended.
+func (r *logRelay) forget(session server.ClientSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.listeners, session)
+}
+
+// track relays the log messages that the given MCP server sends through the session to the MCP client
+// of the tool call in ctx, until the returned function is called once the call is over.
+// The messages of a stateful session are not relayed while other calls are in progress on it.
+func (r *logRelay) track(ctx context.Context, serverName string, session *sessionResult) func() {
+	downstream := server.ClientSessionFromContext(ctx)
+	if downstream == nil || server.ServerFromContext(ctx) == nil {
+		// calls made through the HTTP API have no client to relay the messages to
+		return func() {}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.listeners[downstream] {
+		return func() {}
+	}
+	if r.calls == nil {
+		r.calls = make(map[*client.Client][]context.Context)
+		r.relaying = make(map[*client.Client]bool)
+		r.statefulClients = make(map[string]*client.Client)
+	}
+
+	c := session.client
+	if !session.shouldClose {
+		if previous, ok := r.statefulClients[serverName]; ok && previous != c {
+			delete(r.relaying, previous)
+		}
+		r.statefulClients[serverName] = c
+	}
+	if !r.relaying[c] {
+		r.relaying[c] = true
+		c.OnNotification(func(notification mcp.JSONRPCNotification) {
+			r.relay(serverName, c, notification)
+		})
+	}
+	r.calls[c] = append(r.calls[c], ctx)
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		calls := r.calls[c]
+		for i := range calls {
+			if calls[i] == ctx {
+				calls = append(calls[:i:i], calls[i+1:]...)
+				break
+			}
+		}
+		if len(calls) == 0 {
+			delete(r.calls, c)
+		} else {
+			r.calls[c] = calls
+		}
+		if session.shouldClose {
+			delete(r.relaying, c)
+		}
+	}
+}
+
+// relay forwards a log message received from an upstream MCP server through the given client to the MCP client
+// of the call in progress on it. The name of the server is prepended to the logger of the message.
+// Messages received while no call or several calls are in progress are only written to the mcpjungle server logs.
+func (r *logRelay) relay(serverName string, c *client.Client, notification mcp.JSONRPCNotification) {
+	if notification.Method != logMessageNotificationMethod {
+		return
+	}
+
+	r.mu.Lock()
+	calls := r.calls[c]
+	if !r.relaying[c] || len(calls) != 1 || !r.listeners[server.ClientSessionFromContext(calls[0])] {
+		r.mu.Unlock()
+		return
+	}
+	ctx := calls[0]
+	r.mu.Unlock()
+
+	params, err := parseLogMessageParams(notification)
+	if err != nil {
+		return
+	}
+	logger := serverName
+	if params.Logger != "" {
+		logger += "/" + params.Logger
+	}
+	message := mcp.NewLoggingMessageNotification(params.Level, logger, params.Data)
+
+	if err := server.ServerFromContext(ctx).SendLogMessageToClient(ctx, message); err != nil {
+		log.Printf("[WARN] failed to relay log message of MCP server %s to MCP client: %v", serverName, err)
+	}
+}
@@ -0,0 +1,356 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockedBuffer is a bytes.Buffer that can be written to by the goroutines of upstream clients.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestUpstreamLogs_CapturedAndRelayedToListeningClients(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	var levelMu sync.Mutex
+	var upstreamLevels []mcp.LoggingLevel
+	upstreamHooks := &mcpserver.Hooks{}
+	upstreamHooks.AddAfterSetLevel(func(_ context.Context, _ any, message *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+		levelMu.Lock()
+		upstreamLevels = append(upstreamLevels, message.Params.Level)
+		levelMu.Unlock()
+	})
+	upstream := mcpserver.NewMCPServer(
+		"Upstream", "0.1.0",
+		mcpserver.WithToolCapabilities(true),
+		mcpserver.WithLogging(),
+		mcpserver.WithHooks(upstreamHooks),
+	)
+	upstream.AddTool(
+		mcp.NewTool("import"),
+		func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			srv := mcpserver.ServerFromContext(ctx)
+			for _, message := range []mcp.LoggingMessageNotification{
+				mcp.NewLoggingMessageNotification(mcp.LoggingLevelDebug, "db", "connecting"),
+				mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, "db", "imported 3 rows"),
+				mcp.NewLoggingMessageNotification(mcp.LoggingLevelWarning, "", map[string]any{"skipped": 1}),
+			} {
+				if err := srv.SendLogMessageToClient(ctx, message); err != nil {
+					return nil, err
+				}
+			}
+			// give the proxy time to stream the notifications to the client before the response is ready
+			time.Sleep(100 * time.Millisecond)
+			return mcp.NewToolResultText("done"), nil
+		},
+	)
+	upstreamHTTP := newUpstreamStreamableHTTPServer(t, upstream)
+	defer upstreamHTTP.Close()
+
+	srv := createStreamableHTTPTestServer(t, "importer", upstreamHTTP.URL)
+	srv.SessionMode = types.SessionModeStateful
+	require.NoError(t, srv.SetLogLevel("info"))
+	require.NoError(t, db.Create(srv).Error)
+
+	service := &MCPService{
+		db:                         db,
+		metrics:                    telemetry.NewNoopCustomMetrics(),
+		mcpServerInitReqTimeoutSec: 5,
+		sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+	}
+	defer service.sessionManager.Shutdown()
+
+	proxy := mcpserver.NewMCPServer(
+		"Proxy", "0.1.0",
+		mcpserver.WithToolCapabilities(true),
+		mcpserver.WithLogging(),
+		mcpserver.WithHooks(&mcpserver.Hooks{}),
+	)
+	service.AttachProxyServer(proxy)
+	proxy.AddTool(mcp.NewTool("importer__import"), service.MCPProxyToolCallHandler)
+	proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(
+		proxy,
+		mcpserver.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			return context.WithValue(ctx, "mode", model.ModeDev)
+		}),
+	))
+	defer proxyHTTP.Close()
+
+	var serverLogs lockedBuffer
+	old := log.Writer()
+	log.SetOutput(&serverLogs)
+	defer log.SetOutput(old)
+
+	client, err := mcpclient.NewStreamableHttpClient(proxyHTTP.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	var mu sync.Mutex
+	var relayed []mcp.LoggingMessageNotificationParams
+	client.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method != logMessageNotificationMethod {
+			return
+		}
+		params, err := parseLogMessageParams(notification)
+		if assert.NoError(t, err) {
+			mu.Lock()
+			relayed = append(relayed, *params)
+			mu.Unlock()
+		}
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.Start(ctx))
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initReq.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	initResult, err := client.Initialize(ctx, initReq)
+	require.NoError(t, err)
+	assert.NotNil(t, initResult.Capabilities.Logging)
+
+	callReq := mcp.CallToolRequest{}
+	callReq.Params.Name = "importer__import"
+
+	// clients that didn't ask for log messages don't get any
+	res, err := client.CallTool(ctx, callReq)
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	mu.Lock()
+	assert.Empty(t, relayed)
+	mu.Unlock()
+
+	// the server's log level is applied once to its stateful session
+	levelMu.Lock()
+	assert.Equal(t, []mcp.LoggingLevel{mcp.LoggingLevelInfo}, upstreamLevels)
+	levelMu.Unlock()
+
+	// the upstream log messages are captured into the server logs with the server name
+	logs := serverLogs.String()
+	assert.Contains(t, logs, "['importer' MCP Server] [INFO] db: imported 3 rows")
+	assert.Contains(t, logs, `['importer' MCP Server] [WARNING] {"skipped":1}`)
+	assert.NotContains(t, logs, "connecting")
+
+	// clients that asked for log messages get the ones at or above their level
+	setLevelReq := mcp.SetLevelRequest{}
+	setLevelReq.Params.Level = mcp.LoggingLevelWarning
+	require.NoError(t, client.SetLevel(ctx, setLevelReq))
+
+	res, err = client.CallTool(ctx, callReq)
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, relayed, 1)
+	assert.Equal(t, mcp.LoggingLevelWarning, relayed[0].Level)
+	assert.Equal(t, "importer", relayed[0].Logger)
+	assert.Equal(t, map[string]any{"skipped": float64(1)}, relayed[0].Data)
+
+	service.logs.mu.Lock()
+	defer service.logs.mu.Unlock()
+	assert.Empty(t, service.logs.calls)
+}
+
+func TestSessionManager_AppliesChangedLogLevel(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	var mu sync.Mutex
+	var upstreamLevels []mcp.LoggingLevel
+	hooks := &mcpserver.Hooks{}
+	hooks.AddAfterSetLevel(func(_ context.Context, _ any, message *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+		mu.Lock()
+		upstreamLevels = append(upstreamLevels, message.Params.Level)
+		mu.Unlock()
+	})
+	upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", mcpserver.WithLogging(), mcpserver.WithHooks(hooks))
+	upstreamHTTP := newUpstreamStreamableHTTPServer(t, upstream)
+	defer upstreamHTTP.Close()
+
+	srv := createStreamableHTTPTestServer(t, "importer", upstreamHTTP.URL)
+	srv.SessionMode = types.SessionModeStateful
+	require.NoError(t, db.Create(srv).Error)
+
+	sm := NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5})
+	defer sm.Shutdown()
+
+	ctx := context.Background()
+	_, err := sm.GetOrCreateSession(ctx, srv)
+	require.NoError(t, err)
+
+	require.NoError(t, srv.SetLogLevel("error"))
+	_, err = sm.GetOrCreateSession(ctx, srv)
+	require.NoError(t, err)
+	_, err = sm.GetOrCreateSession(ctx, srv)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []mcp.LoggingLevel{mcp.LoggingLevelError}, upstreamLevels)
+}
+
+func TestUpstreamLogs_OnlyRelayedToTheCallOfTheirSession(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	// arrived is signalled by every call that waits for the other one before logging
+	arrived := make(chan struct{}, 2)
+	upstream := mcpserver.NewMCPServer(
+		"Upstream", "0.1.0", mcpserver.WithToolCapabilities(true), mcpserver.WithLogging(),
+	)
+	upstream.AddTool(
+		mcp.NewTool("import", mcp.WithBoolean("concurrent")),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if request.GetBool("concurrent", false) {
+				arrived <- struct{}{}
+				for len(arrived) < 2 {
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+			message := mcp.NewLoggingMessageNotification(mcp.LoggingLevelInfo, "", "importing")
+			if err := mcpserver.ServerFromContext(ctx).SendLogMessageToClient(ctx, message); err != nil {
+				return nil, err
+			}
+			time.Sleep(100 * time.Millisecond)
+			return mcp.NewToolResultText("done"), nil
+		},
+	)
+	upstreamHTTP := newUpstreamStreamableHTTPServer(t, upstream)
+	defer upstreamHTTP.Close()
+
+	srv := createStreamableHTTPTestServer(t, "importer", upstreamHTTP.URL)
+	srv.SessionMode = types.SessionModeStateful
+	require.NoError(t, srv.SetLogLevel("debug"))
+	require.NoError(t, db.Create(srv).Error)
+
+	service := &MCPService{
+		db:                         db,
+		metrics:                    telemetry.NewNoopCustomMetrics(),
+		mcpServerInitReqTimeoutSec: 5,
+		sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+	}
+	defer service.sessionManager.Shutdown()
+
+	proxy := mcpserver.NewMCPServer(
+		"Proxy", "0.1.0",
+		mcpserver.WithToolCapabilities(true),
+		mcpserver.WithLogging(),
+		mcpserver.WithHooks(&mcpserver.Hooks{}),
+	)
+	service.AttachProxyServer(proxy)
+	proxy.AddTool(mcp.NewTool("importer__import"), service.MCPProxyToolCallHandler)
+	proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(
+		proxy,
+		mcpserver.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+			return context.WithValue(ctx, "mode", model.ModeDev)
+		}),
+	))
+	defer proxyHTTP.Close()
+
+	// newListeningClient connects an MCP client that asked for all log messages and counts the ones it gets
+	ctx := context.Background()
+	var mu sync.Mutex
+	relayed := make(map[string]int)
+	newListeningClient := func(name string) *mcpclient.Client {
+		client, err := mcpclient.NewStreamableHttpClient(proxyHTTP.URL)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = client.Close() })
+		client.OnNotification(func(notification mcp.JSONRPCNotification) {
+			if notification.Method == logMessageNotificationMethod {
+				mu.Lock()
+				relayed[name]++
+				mu.Unlock()
+			}
+		})
+		require.NoError(t, client.Start(ctx))
+		initReq := mcp.InitializeRequest{}
+		initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+		initReq.Params.ClientInfo = mcp.Implementation{Name: name, Version: "1.0.0"}
+		_, err = client.Initialize(ctx, initReq)
+		require.NoError(t, err)
+		setLevelReq := mcp.SetLevelRequest{}
+		setLevelReq.Params.Level = mcp.LoggingLevelDebug
+		require.NoError(t, client.SetLevel(ctx, setLevelReq))
+		return client
+	}
+	alice := newListeningClient("alice")
+	bob := newListeningClient("bob")
+
+	callTool := func(client *mcpclient.Client, concurrent bool) {
+		callReq := mcp.CallToolRequest{}
+		callReq.Params.Name = "importer__import"
+		callReq.Params.Arguments = map[string]any{"concurrent": concurrent}
+		res, err := client.CallTool(ctx, callReq)
+		if assert.NoError(t, err) {
+			assert.False(t, res.IsError)
+		}
+	}
+
+	// the messages sent while both clients' calls share the stateful session cannot be attributed to either call
+	var wg sync.WaitGroup
+	for _, client := range []*mcpclient.Client{alice, bob} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			callTool(client, true)
+		}()
+	}
+	wg.Wait()
+	mu.Lock()
+	assert.Empty(t, relayed)
+	mu.Unlock()
+
+	// the messages of a single call only go to the client that made it
+	callTool(alice, false)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"alice": 1}, relayed)
+}
+
+func TestSetMcpServerLogLevel(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+	srv := createStreamableHTTPTestServer(t, "importer", "http://localhost:9000/mcp")
+	require.NoError(t, db.Create(srv).Error)
+	service := &MCPService{db: db}
+
+	require.NoError(t, service.SetMcpServerLogLevel("importer", "warning"))
+	record, err := service.GetMcpServer("importer")
+	require.NoError(t, err)
+	assert.Equal(t, "warning", record.LogLevel)
+
+	require.NoError(t, service.SetMcpServerLogLevel("importer", ""))
+	record, err = service.GetMcpServer("importer")
+	require.NoError(t, err)
+	assert.Empty(t, record.LogLevel)
+
+	assert.ErrorIs(t, service.SetMcpServerLogLevel("importer", "verbose"), apierrors.ErrInvalidInput)
+	assert.ErrorIs(t, service.SetMcpServerLogLevel("missing", "info"), apierrors.ErrNotFound)
+}
@@ -4,10 +4,12 @@ package mcp
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
 )
 
@@ -15,8 +17,9 @@ import (
 type ServiceConfig struct {
 	DB *gorm.DB
 
-	McpProxyServer    *server.MCPServer
-	SseMcpProxyServer *server.MCPServer
+	// McpProxyServer is the MCP proxy server that serves all the tools, prompts and resources,
+	// over both the streamable HTTP and SSE transports.
+	McpProxyServer *server.MCPServer
 
 	Metrics telemetry.CustomMetrics
 
@@ -25,6 +28,10 @@ type ServiceConfig struct {
 	// SessionManager manages persistent connections for MCP servers configured in stateful mode.
 	// If nil, a default SessionManager will be created.
 	SessionManager *SessionManager
+
+	// ComposeServerInstructions enables composing the instructions of the upstream MCP servers into
+	// the instructions that the proxy servers return to MCP clients during initialization.
+	ComposeServerInstructions bool
 }
 
 // MCPService coordinates operations amongst the registry database, mcp proxy server and upstream MCP servers.
@@ -32,12 +39,49 @@ type ServiceConfig struct {
 type MCPService struct {
 	db *gorm.DB
 
-	mcpProxyServer    *server.MCPServer
-	sseMcpProxyServer *server.MCPServer
+	mcpProxyServer *server.MCPServer
 
 	// toolInstances keeps track of all the in-memory mcp.Tool instances, keyed by their unique names.
 	toolInstances map[string]mcp.Tool
-	mu            sync.RWMutex
+	// promptInstances and resourceInstances keep track of the prompts and resources served by the proxy servers,
+	// keyed by their canonical names and mcpjungle URIs respectively.
+	promptInstances   map[string]mcp.Prompt
+	resourceInstances map[string]mcp.Resource
+	// resourceTemplateInstances keeps track of the resource templates served by the proxy servers,
+	// keyed by the name of the MCP server that provides them.
+	resourceTemplateInstances map[string][]mcp.ResourceTemplate
+	// serverLabels keeps track of the labels of every MCP server known to the proxy servers,
+	// so a reload can tell when the labels, which tool groups can select tools by, changed.
+	serverLabels map[string][]string
+	// serverToolOverrides keeps track of the tool overrides of every MCP server known to the proxy servers,
+	// keyed by server name and tool name (without the server name prefix).
+	serverToolOverrides map[string]map[string]types.ToolOverride
+	mu                  sync.RWMutex
+
+	// exposedToolNames keeps track of the tools that MCP proxy servers, including the ones of tool groups,
+	// expose under an alias. It maps each proxy server to the aliases keyed by canonical tool name.
+	exposedToolNames   map[*server.MCPServer]map[string]string
+	exposedToolNamesMu sync.Mutex
+
+	// resourceTemplatesMu serializes the updates of the resource templates served by the proxy servers.
+	resourceTemplatesMu sync.Mutex
+	// groupTemplateServers keeps track of the proxy servers, eg- the ones of tool groups, that only serve the
+	// resource templates of some MCP servers. It maps each proxy server to a function returning their names.
+	// It is guarded by resourceTemplatesMu.
+	groupTemplateServers map[*server.MCPServer]func() ([]string, error)
+
+	// progress relays the progress notifications of upstream tool calls to the MCP clients that made them.
+	progress progressRelay
+	// logs relays the log messages of upstream tool calls to the MCP clients that made them and asked for logs.
+	logs logRelay
+	// clientRequests routes the sampling, elicitation and roots requests of upstream MCP servers to the MCP clients
+	// whose tool calls they belong to.
+	clientRequests clientRequestRouter
+	// subscriptions keeps track of the resources that MCP clients are subscribed to.
+	subscriptions subscriptionRegistry
+	// upstreamCallIDs generates the IDs of the tool calls forwarded to upstream MCP servers,
+	// so that calls can be cancelled upstream when the MCP clients that made them cancel them.
+	upstreamCallIDs atomic.Uint64
 
 	// toolDeletionCallback is a callback that gets invoked when one or more tools is removed
 	// (deregistered or disabled) from mcpjungle.
@@ -45,6 +89,16 @@ type MCPService struct {
 	// toolAdditionCallback is a callback that gets invoked when one or more tools is added
 	// (registered or (re)enabled) in mcpjungle.
 	toolAdditionCallback ToolAdditionCallback
+	// promptDeletionCallback and promptAdditionCallback are the counterparts of the tool callbacks for prompts.
+	promptDeletionCallback PromptDeletionCallback
+	promptAdditionCallback PromptAdditionCallback
+	// resourceDeletionCallback and resourceAdditionCallback are the counterparts of the tool callbacks for resources.
+	resourceDeletionCallback ResourceDeletionCallback
+	resourceAdditionCallback ResourceAdditionCallback
+	// serverChangeCallback is a callback that gets invoked when an MCP server or any of its
+	// tools, prompts or resources is changed in the registry by this instance.
+	// It is nil unless set, see notifyServerChange.
+	serverChangeCallback ServerChangeCallback
 
 	metrics telemetry.CustomMetrics
 
@@ -52,6 +106,10 @@ type MCPService struct {
 
 	// sessionManager manages persistent connections for MCP servers configured in stateful mode.
 	sessionManager *SessionManager
+
+	// composeServerInstructions enables composing the instructions of the upstream MCP servers into
+	// the instructions of the proxy servers (see ServeServerInstructions).
+	composeServerInstructions bool
 }
 
 // NewMCPService creates a new instance of MCPService.
@@ -63,8 +121,8 @@ func NewMCPService(c *ServiceConfig) (*MCPService, error) {
 	if c.DB == nil {
 		return nil, fmt.Errorf("database connection is nil")
 	}
-	if c.McpProxyServer == nil || c.SseMcpProxyServer == nil {
-		return nil, fmt.Errorf("mcp proxy servers must not be nil")
+	if c.McpProxyServer == nil {
+		return nil, fmt.Errorf("mcp proxy server must not be nil")
 	}
 
 	// Use the provided session manager, or create a default one if not provided
@@ -80,21 +138,34 @@ func NewMCPService(c *ServiceConfig) (*MCPService, error) {
 	s := &MCPService{
 		db: c.DB,
 
-		mcpProxyServer:    c.McpProxyServer,
-		sseMcpProxyServer: c.SseMcpProxyServer,
+		mcpProxyServer: c.McpProxyServer,
 
-		toolInstances: make(map[string]mcp.Tool),
-		mu:            sync.RWMutex{},
+		toolInstances:     make(map[string]mcp.Tool),
+		promptInstances:   make(map[string]mcp.Prompt),
+		resourceInstances: make(map[string]mcp.Resource),
+		serverLabels:      make(map[string][]string),
+		mu:                sync.RWMutex{},
+
+		resourceTemplateInstances: make(map[string][]mcp.ResourceTemplate),
+		serverToolOverrides:       make(map[string]map[string]types.ToolOverride),
+		exposedToolNames:          make(map[*server.MCPServer]map[string]string),
+		groupTemplateServers:      make(map[*server.MCPServer]func() ([]string, error)),
 
 		// initialize the callbacks to NOOP functions
-		toolDeletionCallback: func(toolNames ...string) {},
-		toolAdditionCallback: func(toolName string) error { return nil },
+		toolDeletionCallback:     func(toolNames ...string) {},
+		toolAdditionCallback:     func(toolName string) error { return nil },
+		promptDeletionCallback:   func(promptNames ...string) {},
+		promptAdditionCallback:   func(promptName string) error { return nil },
+		resourceDeletionCallback: func(uris ...string) {},
+		resourceAdditionCallback: func(uri string) error { return nil },
 
 		metrics: c.Metrics,
 
 		mcpServerInitReqTimeoutSec: c.McpServerInitReqTimeout,
 
 		sessionManager: sessionManager,
+
+		composeServerInstructions: c.ComposeServerInstructions,
 	}
 	if err := s.initMCPProxyServer(); err != nil {
 		return nil, fmt.Errorf("failed to initialize MCP proxy server: %w", err)
@@ -104,6 +175,7 @@ func NewMCPService(c *ServiceConfig) (*MCPService, error) {
 
 // Shutdown gracefully shuts down the MCP service, closing all stateful sessions.
 func (m *MCPService) Shutdown() {
+	m.subscriptions.closeAll()
 	if m.sessionManager != nil {
 		m.sessionManager.Shutdown()
 	}
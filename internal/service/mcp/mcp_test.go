@@ -33,7 +33,7 @@ func TestNewMCPService(t *testing.T) {
 				var err error
 				db, err = testhelpers.CreateTestDB()
 				testhelpers.AssertNoError(t, err)
-				err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{})
+				err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{})
 				testhelpers.AssertNoError(t, err)
 			} else {
 				db = tt.db
@@ -42,7 +42,6 @@ func TestNewMCPService(t *testing.T) {
 			conf := &ServiceConfig{
 				DB:                      db,
 				McpProxyServer:          tt.mcpProxyServer,
-				SseMcpProxyServer:       tt.mcpProxyServer,
 				Metrics:                 telemetry.NewNoopCustomMetrics(),
 				McpServerInitReqTimeout: 10,
 			}
@@ -79,7 +78,6 @@ func TestMCPServiceInitialization(t *testing.T) {
 	conf := &ServiceConfig{
 		DB:                      setup.DB,
 		McpProxyServer:          proxyServer,
-		SseMcpProxyServer:       proxyServer,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 10,
 	}
@@ -110,7 +108,7 @@ func TestMCPServiceCallbacks(t *testing.T) {
 	testhelpers.AssertNoError(t, err)
 
 	// Auto-migrate the required models
-	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{})
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{})
 	testhelpers.AssertNoError(t, err)
 
 	proxyServer := &server.MCPServer{}
@@ -118,7 +116,6 @@ func TestMCPServiceCallbacks(t *testing.T) {
 	conf := &ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          proxyServer,
-		SseMcpProxyServer:       proxyServer,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 10,
 	}
@@ -145,7 +142,7 @@ func TestMCPServiceConcurrency(t *testing.T) {
 	testhelpers.AssertNoError(t, err)
 
 	// Auto-migrate the required models
-	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{})
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{})
 	testhelpers.AssertNoError(t, err)
 
 	proxyServer := &server.MCPServer{}
@@ -153,7 +150,6 @@ func TestMCPServiceConcurrency(t *testing.T) {
 	conf := &ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          proxyServer,
-		SseMcpProxyServer:       proxyServer,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 10,
 	}
@@ -188,7 +184,7 @@ func TestMCPServiceToolInstances(t *testing.T) {
 	testhelpers.AssertNoError(t, err)
 
 	// Auto-migrate the required models
-	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{})
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{})
 	testhelpers.AssertNoError(t, err)
 
 	proxyServer := &server.MCPServer{}
@@ -196,7 +192,6 @@ func TestMCPServiceToolInstances(t *testing.T) {
 	conf := &ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          proxyServer,
-		SseMcpProxyServer:       proxyServer,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 10,
 	}
@@ -230,7 +225,7 @@ func TestMCPServiceErrorHandling(t *testing.T) {
 	testhelpers.AssertNoError(t, err)
 
 	// Auto-migrate the required models
-	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{})
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{})
 	testhelpers.AssertNoError(t, err)
 
 	proxyServer := &server.MCPServer{}
@@ -238,7 +233,6 @@ func TestMCPServiceErrorHandling(t *testing.T) {
 	conf := &ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          proxyServer,
-		SseMcpProxyServer:       proxyServer,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 10,
 	}
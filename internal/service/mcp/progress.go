@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressNotificationMethod is the method of the notifications MCP servers send about the progress of requests.
+const progressNotificationMethod = "notifications/progress"
+
+// progressRelay forwards the progress notifications that upstream MCP servers send during tool calls
+// to the MCP clients that made the calls.
+// Every call is given its own progress token upstream, because a stateful session is shared by all clients
+// and their tokens may collide.
+type progressRelay struct {
+	mu   sync.Mutex
+	next uint64
+
+	// calls maps the progress tokens sent upstream to the calls that are in progress
+	calls map[string]progressTarget
+
+	// relaying contains the upstream clients whose notifications are relayed.
+	// Clients of stateless sessions are forgotten once their call is over, while the client of a stateful
+	// session is remembered in statefulClients until the session of its MCP server is replaced.
+	relaying        map[*client.Client]bool
+	statefulClients map[string]*client.Client
+}
+
+// progressTarget is a tool call made by a downstream MCP client that asked for progress notifications.
+type progressTarget struct {
+	// ctx is the context of the downstream request, which identifies the client session
+	ctx   context.Context
+	token mcp.ProgressToken
+}
+
+// track prepares a tool call forwarded upstream through the given session to relay its progress notifications.
+// It returns the request to send upstream and a function to call once the call is over.
+// Requests that don't ask for progress notifications are returned as they are.
+func (r *progressRelay) track(
+	ctx context.Context, session *sessionResult, request mcp.CallToolRequest,
+) (mcp.CallToolRequest, func()) {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil || server.ServerFromContext(ctx) == nil {
+		return request, func() {}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.calls == nil {
+		r.calls = make(map[string]progressTarget)
+		r.relaying = make(map[*client.Client]bool)
+		r.statefulClients = make(map[string]*client.Client)
+	}
+
+	if !session.shouldClose {
+		if previous, ok := r.statefulClients[session.serverName]; ok && previous != session.client {
+			delete(r.relaying, previous)
+		}
+		r.statefulClients[session.serverName] = session.client
+	}
+	if !r.relaying[session.client] {
+		r.relaying[session.client] = true
+		session.client.OnNotification(r.relay)
+	}
+
+	r.next++
+	token := fmt.Sprintf("mcpjungle-progress-%d", r.next)
+	r.calls[token] = progressTarget{ctx: ctx, token: request.Params.Meta.ProgressToken}
+
+	// the request's meta is shared with the caller, so it is copied before the token is replaced
+	meta := *request.Params.Meta
+	meta.ProgressToken = token
+	request.Params.Meta = &meta
+
+	return request, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.calls, token)
+		if session.shouldClose {
+			delete(r.relaying, session.client)
+		}
+	}
+}
+
+// relay forwards a progress notification received from an upstream MCP server to the client
+// that made the call it belongs to. Notifications of calls that are over are dropped.
+func (r *progressRelay) relay(notification mcp.JSONRPCNotification) {
+	if notification.Method != progressNotificationMethod {
+		return
+	}
+	token, ok := notification.Params.AdditionalFields["progressToken"].(string)
+	if !ok {
+		return
+	}
+
+	r.mu.Lock()
+	target, ok := r.calls[token]
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	params := maps.Clone(notification.Params.AdditionalFields)
+	params["progressToken"] = target.token
+	err := server.ServerFromContext(target.ctx).SendNotificationToClient(target.ctx, progressNotificationMethod, params)
+	if err != nil {
+		log.Printf("[WARN] failed to relay progress notification to MCP client: %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPProxyToolCallHandler_RelaysProgressNotifications(t *testing.T) {
+	for _, sessionMode := range []types.SessionMode{types.SessionModeStateless, types.SessionModeStateful} {
+		t.Run(string(sessionMode), func(t *testing.T) {
+			db := setupTestDBForProxyAdditional(t)
+
+			var seenToken mcp.ProgressToken
+			upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", mcpserver.WithToolCapabilities(true))
+			upstream.AddTool(
+				mcp.NewTool("import"),
+				func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					seenToken = request.Params.Meta.ProgressToken
+					for i := 1; i <= 2; i++ {
+						err := mcpserver.ServerFromContext(ctx).SendNotificationToClient(ctx, progressNotificationMethod, map[string]any{
+							"progressToken": seenToken,
+							"progress":      i,
+							"total":         2,
+						})
+						if err != nil {
+							return nil, err
+						}
+					}
+					// mcp-go's streamable HTTP server may drop notifications that are still being written
+					// when the response is ready, so give the proxy time to stream them to the client
+					time.Sleep(100 * time.Millisecond)
+					return mcp.NewToolResultText("done"), nil
+				},
+			)
+			upstreamHTTP := newUpstreamStreamableHTTPServer(t, upstream)
+			defer upstreamHTTP.Close()
+
+			srv := createStreamableHTTPTestServer(t, "importer", upstreamHTTP.URL)
+			srv.SessionMode = sessionMode
+			require.NoError(t, db.Create(srv).Error)
+
+			service := &MCPService{
+				db:                         db,
+				metrics:                    telemetry.NewNoopCustomMetrics(),
+				mcpServerInitReqTimeoutSec: 5,
+				sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+			}
+			defer service.sessionManager.Shutdown()
+
+			proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithToolCapabilities(true))
+			proxy.AddTool(mcp.NewTool("importer__import"), service.MCPProxyToolCallHandler)
+			proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(
+				proxy,
+				mcpserver.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+					return context.WithValue(ctx, "mode", model.ModeDev)
+				}),
+			))
+			defer proxyHTTP.Close()
+
+			client, err := mcpclient.NewStreamableHttpClient(proxyHTTP.URL)
+			require.NoError(t, err)
+			defer client.Close()
+
+			var mu sync.Mutex
+			var progress []map[string]any
+			client.OnNotification(func(notification mcp.JSONRPCNotification) {
+				if notification.Method == progressNotificationMethod {
+					mu.Lock()
+					progress = append(progress, notification.Params.AdditionalFields)
+					mu.Unlock()
+				}
+			})
+
+			ctx := context.Background()
+			require.NoError(t, client.Start(ctx))
+			initReq := mcp.InitializeRequest{}
+			initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+			initReq.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+			_, err = client.Initialize(ctx, initReq)
+			require.NoError(t, err)
+
+			req := mcp.CallToolRequest{}
+			req.Params.Name = "importer__import"
+			req.Params.Meta = &mcp.Meta{ProgressToken: "client-token"}
+			res, err := client.CallTool(ctx, req)
+			require.NoError(t, err)
+			require.False(t, res.IsError)
+
+			// the upstream server never sees the client's own token
+			assert.NotEqual(t, "client-token", seenToken)
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Len(t, progress, 2)
+			for i, p := range progress {
+				assert.Equal(t, "client-token", p["progressToken"])
+				assert.Equal(t, float64(i+1), p["progress"])
+				assert.Equal(t, float64(2), p["total"])
+			}
+			assert.Empty(t, service.progress.calls)
+		})
+	}
+}
@@ -15,6 +15,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// PromptDeletionCallback is a function type that can be registered to be called
+// whenever one or more prompts are deleted (deregistered) or disabled.
+// The callback receives the canonical names of the deleted prompts as arguments.
+type PromptDeletionCallback func(promptNames ...string)
+
+// PromptAdditionCallback is a function type that can be registered to be called
+// whenever a prompt is added (registered or re-enabled) or its definition changes.
+// The callback receives the canonical name of the added prompt as argument.
+type PromptAdditionCallback func(promptName string) error
+
 // ListPrompts returns all prompts registered in the registry.
 func (m *MCPService) ListPrompts() ([]model.Prompt, error) {
 	var prompts []model.Prompt
@@ -80,6 +90,37 @@ func (m *MCPService) GetPrompt(name string) (*model.Prompt, error) {
 	return &prompt, nil
 }
 
+// GetPromptInstance returns the in-memory mcp.Prompt instance for the given canonical prompt name.
+// Returns the prompt instance and a boolean indicating if it was found, ie, the prompt exists and is enabled.
+func (m *MCPService) GetPromptInstance(name string) (mcp.Prompt, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	prompt, exists := m.promptInstances[name]
+	return prompt, exists
+}
+
+// GetPromptParentServer returns the MCP server that provides the given prompt.
+// The input name must be the canonical prompt name, ie, it must contain the server name prefix.
+func (m *MCPService) GetPromptParentServer(name string) (*model.McpServer, error) {
+	serverName, _, ok := splitServerPromptName(name)
+	if !ok {
+		return nil, fmt.Errorf("prompt name does not contain a %s separator: %w", serverPromptNameSep, apierrors.ErrInvalidInput)
+	}
+	return m.GetMcpServer(serverName)
+}
+
+// SetPromptDeletionCallback registers a callback function to be called
+// whenever one or more prompts are deleted or disabled.
+func (m *MCPService) SetPromptDeletionCallback(callback PromptDeletionCallback) {
+	m.promptDeletionCallback = callback
+}
+
+// SetPromptAdditionCallback registers a callback function to be called
+// whenever a prompt is added or re-enabled.
+func (m *MCPService) SetPromptAdditionCallback(callback PromptAdditionCallback) {
+	m.promptAdditionCallback = callback
+}
+
 // GetPromptWithArgs retrieves a prompt with provided arguments and returns the rendered template.
 func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args map[string]any) (*types.PromptResult, error) {
 	serverName, promptName, ok := splitServerPromptName(name)
@@ -203,19 +244,16 @@ func (m *MCPService) setPromptsEnabled(entity string, enabled bool) ([]string, e
 			// set the prompt name to its canonical form in the proxy
 			mcpPrompt.Name = entity
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddPrompt(mcpPrompt, m.mcpProxyPromptHandler)
-			} else {
-				m.mcpProxyServer.AddPrompt(mcpPrompt, m.mcpProxyPromptHandler)
-			}
+			m.mcpProxyServer.AddPrompt(mcpPrompt, m.MCPProxyPromptHandler)
+			m.addPromptInstance(mcpPrompt)
+			m.notifyPromptAddition(mcpPrompt.Name)
 		} else {
 			// if the prompt was disabled, remove it from the MCP proxy server
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.DeletePrompts(entity)
-			} else {
-				m.mcpProxyServer.DeletePrompts(entity)
-			}
+			m.mcpProxyServer.DeletePrompts(entity)
+			m.deletePromptInstances(entity)
+			m.notifyPromptDeletion(entity)
 		}
+		m.notifyServerChange(s.Name)
 
 		return []string{entity}, nil
 	}
@@ -251,21 +289,20 @@ func (m *MCPService) setPromptsEnabled(entity string, enabled bool) ([]string, e
 			// set the prompt name to its canonical form in the proxy
 			mcpPrompt.Name = canonicalPromptName
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddPrompt(mcpPrompt, m.mcpProxyPromptHandler)
-			} else {
-				m.mcpProxyServer.AddPrompt(mcpPrompt, m.mcpProxyPromptHandler)
-			}
+			m.mcpProxyServer.AddPrompt(mcpPrompt, m.MCPProxyPromptHandler)
+			m.addPromptInstance(mcpPrompt)
+			m.notifyPromptAddition(mcpPrompt.Name)
 		} else {
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.DeletePrompts(canonicalPromptName)
-			} else {
-				m.mcpProxyServer.DeletePrompts(canonicalPromptName)
-			}
+			m.mcpProxyServer.DeletePrompts(canonicalPromptName)
+			m.deletePromptInstances(canonicalPromptName)
+			m.notifyPromptDeletion(canonicalPromptName)
 		}
 
 		changedPromptNames = append(changedPromptNames, canonicalPromptName)
 	}
+	if len(changedPromptNames) > 0 {
+		m.notifyServerChange(s.Name)
+	}
 
 	return changedPromptNames, nil
 }
@@ -280,16 +317,7 @@ func (m *MCPService) registerServerPrompts(ctx context.Context, s *model.McpServ
 	for _, prompt := range resp.Prompts {
 		canonicalPromptName := mergeServerPromptNames(s.Name, prompt.GetName())
 
-		// extracting json schema is currently on best-effort basis
-		// if it fails, we log the error and continue with the next prompt
-		jsonArguments, _ := json.Marshal(prompt.Arguments)
-
-		p := &model.Prompt{
-			ServerID:    s.ID,
-			Name:        prompt.GetName(),
-			Description: prompt.Description,
-			Arguments:   jsonArguments,
-		}
+		p := newPromptModel(s, prompt)
 		if err := m.db.Create(p).Error; err != nil {
 			// If registration of a prompt fails, we should not fail the entire server registration.
 			// Instead, continue with the next prompt.
@@ -299,16 +327,27 @@ func (m *MCPService) registerServerPrompts(ctx context.Context, s *model.McpServ
 			// then add the prompt to the MCP proxy server
 			prompt.Name = canonicalPromptName
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddPrompt(prompt, m.mcpProxyPromptHandler)
-			} else {
-				m.mcpProxyServer.AddPrompt(prompt, m.mcpProxyPromptHandler)
-			}
+			m.mcpProxyServer.AddPrompt(prompt, m.MCPProxyPromptHandler)
+			m.addPromptInstance(prompt)
+			m.notifyPromptAddition(prompt.Name)
 		}
 	}
 	return nil
 }
 
+// newPromptModel creates the DB record of a prompt provided by an MCP server.
+func newPromptModel(s *model.McpServer, prompt mcp.Prompt) *model.Prompt {
+	// extracting json schema is currently on best-effort basis
+	jsonArguments, _ := json.Marshal(prompt.Arguments)
+
+	return &model.Prompt{
+		ServerID:    s.ID,
+		Name:        prompt.GetName(),
+		Description: prompt.Description,
+		Arguments:   jsonArguments,
+	}
+}
+
 // deregisterServerPrompts deletes all prompts that belong to an MCP server from the DB.
 // It also removes the prompts from the MCP proxy server.
 func (m *MCPService) deregisterServerPrompts(s *model.McpServer) error {
@@ -330,11 +369,48 @@ func (m *MCPService) deregisterServerPrompts(s *model.McpServer) error {
 		promptNames[i] = prompt.Name
 	}
 
-	if s.Transport == types.TransportSSE {
-		m.sseMcpProxyServer.DeletePrompts(promptNames...)
-	} else {
-		m.mcpProxyServer.DeletePrompts(promptNames...)
-	}
+	m.mcpProxyServer.DeletePrompts(promptNames...)
+	m.deletePromptInstances(promptNames...)
+	m.notifyPromptDeletion(promptNames...)
 
 	return nil
 }
+
+// addPromptInstance adds a prompt instance to the in-memory prompt instance tracker.
+// If a prompt with the same name already exists, it is overwritten.
+func (m *MCPService) addPromptInstance(prompt mcp.Prompt) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.promptInstances == nil {
+		m.promptInstances = make(map[string]mcp.Prompt)
+	}
+	m.promptInstances[prompt.GetName()] = prompt
+}
+
+// deletePromptInstances deletes one or more prompt instances from the in-memory prompt instance tracker.
+func (m *MCPService) deletePromptInstances(promptNames ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, name := range promptNames {
+		delete(m.promptInstances, name)
+	}
+}
+
+// notifyPromptDeletion calls the registered prompt deletion callback with the given prompt names.
+func (m *MCPService) notifyPromptDeletion(promptNames ...string) {
+	if m.promptDeletionCallback == nil {
+		return
+	}
+	m.promptDeletionCallback(promptNames...)
+}
+
+// notifyPromptAddition calls the registered prompt addition callback with the given prompt name.
+// Like notifyToolAddition, it works on best-effort basis and only logs callback failures.
+func (m *MCPService) notifyPromptAddition(promptName string) {
+	if m.promptAdditionCallback == nil {
+		return
+	}
+	if err := m.promptAdditionCallback(promptName); err != nil {
+		log.Printf("[ERROR] prompt addition callback failed for prompt %s: %v", promptName, err)
+	}
+}
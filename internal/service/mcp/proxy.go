@@ -6,26 +6,58 @@ import (
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
-	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func authorizeProxyServerAccess(ctx context.Context, serverName string) error {
+	// servers outside the namespace the request is scoped to don't exist as far as the client is concerned
+	namespace, _ := ctx.Value("namespace").(string)
+	if !model.InNamespace(serverName, namespace) {
+		return fmt.Errorf("MCP server %s not found in namespace %s: %w", serverName, namespace, apierrors.ErrNotFound)
+	}
+
 	serverMode := ctx.Value("mode").(model.ServerMode)
 	if !model.IsEnterpriseMode(serverMode) {
 		return nil
 	}
 
 	c := ctx.Value("client").(*model.McpClient)
-	if !c.CheckHasServerAccess(serverName) {
+	if !groupGrantsServerAccess(ctx, c) && !c.CheckHasServerAccess(serverName) {
 		return fmt.Errorf("client %s is not authorized to access MCP server %s", c.Name, serverName)
 	}
 
 	return nil
 }
 
+// AttachProxyServer sets up a proxy MCP server to relay the notifications of its MCP clients to the upstream
+// MCP servers, to relay upstream log messages to the clients that ask for them, and to keep track of
+// its client sessions, which can subscribe to resources, and release their subscriptions when they end.
+// It must be called before the proxy server serves any MCP clients.
+func (m *MCPService) AttachProxyServer(proxy *server.MCPServer) {
+	proxy.AddNotificationHandler(
+		mcp.MethodNotificationRootsListChanged,
+		func(ctx context.Context, notification mcp.JSONRPCNotification) {
+			m.clientRequests.relayRootsListChanged(ctx, notification)
+		},
+	)
+	if hooks := proxy.GetHooks(); hooks != nil {
+		hooks.AddOnRegisterSession(func(_ context.Context, session server.ClientSession) {
+			m.subscriptions.trackSession(proxy, session.SessionID(), true)
+		})
+		hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+			m.subscriptions.trackSession(proxy, session.SessionID(), false)
+			m.dropResourceSubscriber(proxy, session.SessionID())
+			m.logs.forget(session)
+		})
+		hooks.AddAfterSetLevel(func(ctx context.Context, _ any, _ *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+			m.logs.listen(ctx)
+		})
+	}
+}
+
 // MCPProxyToolCallHandler handles tool calls for the MCP proxy server
 // by forwarding the request to the appropriate upstream MCP server and
 // relaying the response back.
@@ -73,8 +105,18 @@ func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.Ca
 	// See https://github.com/mcpjungle/MCPJungle/issues/252
 	request.Header = nil
 
-	res, err := session.client.CallTool(ctx, request)
-	if err != nil {
+	// relay the upstream server's progress notifications, if the client asked for them
+	request, stopProgressRelay := m.progress.track(ctx, session, request)
+	defer stopProgressRelay()
+	// relay the upstream server's log messages, if the client asked for them
+	stopLogRelay := m.logs.track(ctx, serverName, session)
+	defer stopLogRelay()
+
+	res, err := m.callUpstreamTool(ctx, server, session.client, request)
+	if isCancelled(ctx, err) {
+		// the client cancelled the call or went away, which says nothing about the health of the session
+		outcome = telemetry.ToolCallOutcomeCancelled
+	} else if err != nil {
 		outcome = telemetry.ToolCallOutcomeError
 		session.invalidateOnError(err) // Invalidate unhealthy stateful sessions
 	}
@@ -83,10 +125,10 @@ func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.Ca
 	return res, err
 }
 
-// mcpProxyResourceHandler handles resource reads for the MCP proxy server
+// MCPProxyResourceHandler handles resource reads for the MCP proxy server
 // by forwarding the request to the appropriate upstream MCP server and
 // relaying the response back.
-func (m *MCPService) mcpProxyResourceHandler(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+func (m *MCPService) MCPProxyResourceHandler(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	// get the upstream mcp server and original resource uri for the requested resource uri
 	resource, err := m.GetResource(request.Params.URI)
 	if err != nil {
@@ -117,10 +159,54 @@ func (m *MCPService) mcpProxyResourceHandler(ctx context.Context, request mcp.Re
 	return rewriteResourceContentsURI(res.Contents, resource.URI), nil
 }
 
-// mcpProxyPromptHandler handles prompt requests for the MCP proxy server
+// MCPProxyResourceTemplateHandler handles reads of the resources expanded from resource templates for the
+// MCP proxy server by forwarding the request with the upstream resource URI to the MCP server that provides
+// the template, and relaying the response back.
+func (m *MCPService) MCPProxyResourceTemplateHandler(
+	ctx context.Context,
+	request mcp.ReadResourceRequest,
+) ([]mcp.ResourceContents, error) {
+	uri := request.Params.URI
+	serverName, originalURI, err := parseResourceTemplateURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeProxyServerAccess(ctx, serverName); err != nil {
+		return nil, err
+	}
+
+	server, err := m.GetMcpServer(serverName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details about MCP server %s from DB: %w", serverName, err)
+	}
+
+	session, err := m.getSession(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	defer session.closeIfApplicable()
+
+	request.Params.URI = originalURI
+	// the arguments were matched against mcpjungle's template, the upstream server matches its own template
+	request.Params.Arguments = nil
+
+	// Do not let any client-sent headers get forwarded to the upstream MCP server.
+	request.Header = nil
+
+	res, err := session.client.ReadResource(ctx, request)
+	if err != nil {
+		session.invalidateOnError(err)
+		return nil, err
+	}
+
+	return rewriteResourceContentsURI(res.Contents, uri), nil
+}
+
+// MCPProxyPromptHandler handles prompt requests for the MCP proxy server
 // by forwarding the request to the appropriate upstream MCP server and
 // relaying the response back.
-func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+func (m *MCPService) MCPProxyPromptHandler(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 	started := time.Now()
 	outcome := telemetry.PromptCallOutcomeSuccess
 
@@ -173,10 +259,35 @@ func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetP
 	return res, err
 }
 
+// LoadProxyFromDB adds all registered MCP tools, prompts and resources from the database to the proxy servers.
+// It is meant to be used when the database was populated outside of this service while the proxy is empty,
+// eg- after restoring a backup into a fresh instance.
+func (m *MCPService) LoadProxyFromDB() error {
+	if err := m.initMCPProxyServer(); err != nil {
+		return err
+	}
+	// other mcpjungle instances sharing the database must load the new servers as well
+	servers, err := m.ListMcpServers()
+	if err != nil {
+		return fmt.Errorf("failed to list MCP servers from DB: %w", err)
+	}
+	for _, s := range servers {
+		m.notifyServerChange(s.Name)
+	}
+	return nil
+}
+
 // initMCPProxyServer initializes the MCP proxy server.
 // It loads all the registered MCP tools, prompts and resources from the database into the proxy server.
 func (m *MCPService) initMCPProxyServer() error {
-	mcpServerModelsCache := make(map[string]*model.McpServer)
+	servers, err := m.ListMcpServers()
+	if err != nil {
+		return fmt.Errorf("failed to list MCP servers from DB: %w", err)
+	}
+	for i := range servers {
+		m.trackServer(&servers[i])
+	}
+
 	// Load Tools
 	tools, err := m.ListTools()
 	if err != nil {
@@ -195,30 +306,7 @@ func (m *MCPService) initMCPProxyServer() error {
 			return fmt.Errorf("failed to convert tool model to MCP object for tool %s: %w", tm.Name, err)
 		}
 
-		// get the tool's MCP server so we can determine the transport type
-		// use a cache to avoid querying the DB multiple times for the same server
-		// since multiple tools can belong to the same server
-		var server *model.McpServer
-		serverName, _, _ := splitServerToolName(tool.Name)
-
-		server, exists := mcpServerModelsCache[serverName]
-		if !exists {
-			server, err = m.GetMcpServer(serverName)
-			if err != nil {
-				return fmt.Errorf(
-					"init mcp proxy server: failed to get MCP server %s for tool %s from DB: %w", serverName, tool.Name, err,
-				)
-			}
-			// store the server model in cache so we don't have to query the DB again for the same server
-			mcpServerModelsCache[serverName] = server
-		}
-
-		if server.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
-		} else {
-			m.mcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
-		}
-
+		m.serveProxyTool(m.mcpProxyServer, tool)
 		m.addToolInstance(tool)
 	}
 
@@ -240,26 +328,8 @@ func (m *MCPService) initMCPProxyServer() error {
 			return fmt.Errorf("failed to convert prompt model to MCP object for prompt %s: %w", pm.Name, err)
 		}
 
-		// get the prompt's MCP server from cache so we can determine the transport type
-		var server *model.McpServer
-		serverName, _, _ := splitServerPromptName(prompt.Name)
-
-		server, exists := mcpServerModelsCache[serverName]
-		if !exists {
-			server, err = m.GetMcpServer(serverName)
-			if err != nil {
-				return fmt.Errorf(
-					"init mcp proxy server: failed to get MCP server %s for tool %s from DB: %w", serverName, prompt.Name, err,
-				)
-			}
-			mcpServerModelsCache[serverName] = server
-		}
-
-		if server.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddPrompt(prompt, m.mcpProxyPromptHandler)
-		} else {
-			m.mcpProxyServer.AddPrompt(prompt, m.mcpProxyPromptHandler)
-		}
+		m.mcpProxyServer.AddPrompt(prompt, m.MCPProxyPromptHandler)
+		m.addPromptInstance(prompt)
 	}
 
 	// Load resources
@@ -273,18 +343,20 @@ func (m *MCPService) initMCPProxyServer() error {
 			continue
 		}
 
-		// no need to use mcp servers model cache because resources come pre-loaded with server, ie, rm.Server
-
 		resource, err := convertResourceModelToMcpObject(&rm)
 		if err != nil {
 			return fmt.Errorf("failed to convert resource model to MCP object for resource %s: %w", rm.URI, err)
 		}
 		resource.Name = rm.Name
 
-		if rm.Server.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddResource(resource, m.mcpProxyResourceHandler)
-		} else {
-			m.mcpProxyServer.AddResource(resource, m.mcpProxyResourceHandler)
+		m.mcpProxyServer.AddResource(resource, m.MCPProxyResourceHandler)
+		m.addResourceInstance(resource)
+	}
+
+	// Load resource templates
+	for i := range servers {
+		if err := m.reloadServerResourceTemplates(servers[i].Name, &servers[i]); err != nil {
+			return fmt.Errorf("failed to load resource templates of MCP server %s: %w", servers[i].Name, err)
 		}
 	}
 
@@ -4,44 +4,164 @@ import (
 	"context"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 )
 
-// ProxyToolFilter filters tools exposed by MCP proxy for enterprise mode based on client allow-list.
-func ProxyToolFilter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+// proxyServerAccessChecker returns a function that reports whether the MCP proxy request in ctx
+// may access the given MCP server.
+// The request may only access the servers in the namespace it is scoped to (if any) and, in enterprise mode,
+// the servers in the allow-list of its client, unless the client is bound to the tool group it is accessing.
+// It returns false if the request cannot access any server at all.
+func proxyServerAccessChecker(ctx context.Context) (func(serverName string) bool, bool) {
 	serverMode, ok := ctx.Value("mode").(model.ServerMode)
 	if !ok {
 		// Missing/invalid mode in context: fail closed.
-		return nil
+		return nil, false
 	}
+	namespace, _ := ctx.Value("namespace").(string)
+
 	if !model.IsEnterpriseMode(serverMode) {
-		// In non-enterprise mode, there are no access restrictions, so return all tools
-		return tools
+		// In non-enterprise mode, there are no access restrictions beyond the namespace
+		return func(serverName string) bool {
+			return model.InNamespace(serverName, namespace)
+		}, true
 	}
 
 	c, ok := ctx.Value("client").(*model.McpClient)
 	if !ok || c == nil {
 		// Enterprise mode requires authenticated client context; fail closed if absent.
-		return nil
+		return nil, false
 	}
 
-	var filteredTools []mcp.Tool
-	allowedServers := make(map[string]bool)
-
-	for _, tool := range tools {
-		serverName, _, _ := splitServerToolName(tool.Name)
+	if groupGrantsServerAccess(ctx, c) {
+		return func(serverName string) bool {
+			return model.InNamespace(serverName, namespace)
+		}, true
+	}
 
+	allowedServers := make(map[string]bool)
+	return func(serverName string) bool {
 		allowed, cached := allowedServers[serverName]
 		if !cached {
 			// check whether the client has access to this server and cache the result for faster future checks
-			allowed = c.CheckHasServerAccess(serverName)
+			allowed = model.InNamespace(serverName, namespace) && c.CheckHasServerAccess(serverName)
 			allowedServers[serverName] = allowed
 		}
-		if allowed {
+		return allowed
+	}, true
+}
+
+// groupGrantsServerAccess returns true if the MCP proxy request in ctx is made by a client bound to tool groups
+// through the endpoint of one of its groups.
+// A group grants such clients access to the MCP servers of its entities, regardless of their server allow-list.
+// Group proxy servers only serve the entities of their group, so the client cannot reach anything else.
+func groupGrantsServerAccess(ctx context.Context, c *model.McpClient) bool {
+	group, ok := ctx.Value("group").(string)
+	return ok && group != "" && c.IsBoundToGroups() && c.CheckHasGroupAccess(group)
+}
+
+// proxyRequestIsUnrestricted returns true if the MCP proxy request in ctx can access all MCP servers.
+func proxyRequestIsUnrestricted(ctx context.Context) bool {
+	serverMode, ok := ctx.Value("mode").(model.ServerMode)
+	if !ok || model.IsEnterpriseMode(serverMode) {
+		return false
+	}
+	namespace, _ := ctx.Value("namespace").(string)
+	return namespace == ""
+}
+
+// ProxyToolFilter filters tools exposed by MCP proxy based on the request's namespace and,
+// in enterprise mode, the client allow-list.
+func ProxyToolFilter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	if proxyRequestIsUnrestricted(ctx) {
+		return tools
+	}
+	hasAccess, ok := proxyServerAccessChecker(ctx)
+	if !ok {
+		return nil
+	}
+
+	var filteredTools []mcp.Tool
+	for _, tool := range tools {
+		serverName, _, _ := splitServerToolName(CanonicalToolName(tool))
+		if hasAccess(serverName) {
 			// client has access to this tool's server, so include it in the filtered list
 			filteredTools = append(filteredTools, tool)
 		}
 	}
-
 	return filteredTools
 }
+
+// ProxyPromptFilter filters prompts exposed by MCP proxy the same way ProxyToolFilter filters tools.
+func ProxyPromptFilter(ctx context.Context, prompts []mcp.Prompt) []mcp.Prompt {
+	if proxyRequestIsUnrestricted(ctx) {
+		return prompts
+	}
+	hasAccess, ok := proxyServerAccessChecker(ctx)
+	if !ok {
+		return nil
+	}
+
+	var filteredPrompts []mcp.Prompt
+	for _, prompt := range prompts {
+		serverName, _, _ := splitServerPromptName(prompt.Name)
+		if hasAccess(serverName) {
+			filteredPrompts = append(filteredPrompts, prompt)
+		}
+	}
+	return filteredPrompts
+}
+
+// ProxyResourceFilterHooks returns MCP server hooks that filter the resources and resource templates listed
+// by MCP proxy the same way ProxyToolFilter filters tools.
+// Unlike for tools and prompts, mcp-go does not support filters for resources, so a hook is used instead.
+func ProxyResourceFilterHooks() *server.Hooks {
+	hooks := &server.Hooks{}
+	hooks.AddAfterListResources(
+		func(ctx context.Context, _ any, _ *mcp.ListResourcesRequest, result *mcp.ListResourcesResult) {
+			if result == nil || proxyRequestIsUnrestricted(ctx) {
+				return
+			}
+			hasAccess, ok := proxyServerAccessChecker(ctx)
+			if !ok {
+				result.Resources = []mcp.Resource{}
+				return
+			}
+
+			filteredResources := make([]mcp.Resource, 0, len(result.Resources))
+			for _, resource := range result.Resources {
+				serverName, _, err := parseResourceURI(resource.URI)
+				if err == nil && hasAccess(serverName) {
+					filteredResources = append(filteredResources, resource)
+				}
+			}
+			result.Resources = filteredResources
+		},
+	)
+	hooks.AddAfterListResourceTemplates(
+		func(ctx context.Context, _ any, _ *mcp.ListResourceTemplatesRequest, result *mcp.ListResourceTemplatesResult) {
+			if result == nil || proxyRequestIsUnrestricted(ctx) {
+				return
+			}
+			hasAccess, ok := proxyServerAccessChecker(ctx)
+			if !ok {
+				result.ResourceTemplates = []mcp.ResourceTemplate{}
+				return
+			}
+
+			filteredTemplates := make([]mcp.ResourceTemplate, 0, len(result.ResourceTemplates))
+			for _, template := range result.ResourceTemplates {
+				if template.URITemplate == nil || template.URITemplate.Template == nil {
+					continue
+				}
+				serverName, _, err := parseResourceTemplateURI(template.URITemplate.Raw())
+				if err == nil && hasAccess(serverName) {
+					filteredTemplates = append(filteredTemplates, template)
+				}
+			}
+			result.ResourceTemplates = filteredTemplates
+		},
+	)
+	return hooks
+}
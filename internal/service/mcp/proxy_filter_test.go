@@ -147,6 +147,178 @@ func TestMcpProxyToolFilter_EnterpriseMalformedToolNamesAreDenied(t *testing.T)
 	assert.Equal(t, []string{"time__get_current_time"}, toolNames(got))
 }
 
+func TestMcpProxyToolFilter_Namespace(t *testing.T) {
+	t.Parallel()
+
+	tools := []mcp.Tool{
+		{Name: "time__get_current_time"},
+		{Name: "team-a.time__get_current_time"},
+		{Name: "team-a.deepwiki__search_wiki"},
+		{Name: "team-b.time__get_current_time"},
+	}
+
+	t.Run("development mode only returns tools of the namespace", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+		ctx = context.WithValue(ctx, "namespace", "team-a")
+
+		got := ProxyToolFilter(ctx, tools)
+		assert.Equal(t, []string{"team-a.time__get_current_time", "team-a.deepwiki__search_wiki"}, toolNames(got))
+	})
+
+	t.Run("enterprise mode applies both the namespace and the allow list", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), "mode", model.ModeEnterprise)
+		ctx = context.WithValue(ctx, "namespace", "team-a")
+		ctx = context.WithValue(ctx, "client", &model.McpClient{
+			Name:      "team-a.claude",
+			Namespace: "team-a",
+			AllowList: datatypes.JSON(`["time"]`),
+		})
+
+		got := ProxyToolFilter(ctx, tools)
+		assert.Equal(t, []string{"team-a.time__get_current_time"}, toolNames(got))
+	})
+}
+
+func TestMcpProxyToolFilter_GroupBoundClient(t *testing.T) {
+	t.Parallel()
+
+	tools := []mcp.Tool{
+		{Name: "time__get_current_time"},
+		{Name: "deepwiki__search_wiki"},
+	}
+	client := &model.McpClient{
+		Name:          "claude",
+		AllowList:     datatypes.JSON(`[]`),
+		AllowedGroups: datatypes.JSON(`["readonly"]`),
+	}
+
+	t.Run("the client's group grants access to its servers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), "mode", model.ModeEnterprise)
+		ctx = context.WithValue(ctx, "client", client)
+		ctx = context.WithValue(ctx, "group", "readonly")
+
+		got := ProxyToolFilter(ctx, tools)
+		assert.Equal(t, []string{"time__get_current_time", "deepwiki__search_wiki"}, toolNames(got))
+		assert.NoError(t, authorizeProxyServerAccess(ctx, "time"))
+	})
+
+	t.Run("other groups and the global proxy grant nothing", func(t *testing.T) {
+		t.Parallel()
+
+		for _, group := range []string{"", "admin"} {
+			ctx := context.WithValue(context.Background(), "mode", model.ModeEnterprise)
+			ctx = context.WithValue(ctx, "client", client)
+			if group != "" {
+				ctx = context.WithValue(ctx, "group", group)
+			}
+
+			assert.Empty(t, toolNames(ProxyToolFilter(ctx, tools)))
+			assert.Error(t, authorizeProxyServerAccess(ctx, "time"))
+		}
+	})
+}
+
+func TestMcpProxyPromptFilter(t *testing.T) {
+	t.Parallel()
+
+	prompts := []mcp.Prompt{
+		{Name: "time__summarize"},
+		{Name: "team-a.time__summarize"},
+		{Name: "team-a.deepwiki__explain"},
+	}
+
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	assert.Len(t, ProxyPromptFilter(ctx, prompts), 3)
+
+	ctx = context.WithValue(ctx, "namespace", "team-a")
+	got := ProxyPromptFilter(ctx, prompts)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "team-a.time__summarize", got[0].Name)
+	assert.Equal(t, "team-a.deepwiki__explain", got[1].Name)
+
+	ctx = context.WithValue(context.Background(), "mode", model.ModeEnterprise)
+	ctx = context.WithValue(ctx, "client", &model.McpClient{
+		Name:      "claude",
+		AllowList: datatypes.JSON(`["time"]`),
+	})
+	got = ProxyPromptFilter(ctx, prompts)
+	assert.Len(t, got, 1)
+	assert.Equal(t, "time__summarize", got[0].Name)
+
+	assert.Empty(t, ProxyPromptFilter(context.Background(), prompts))
+}
+
+func TestMcpProxyResourceFilterHooks(t *testing.T) {
+	t.Parallel()
+
+	hooks := ProxyResourceFilterHooks()
+	assert.Len(t, hooks.OnAfterListResources, 1)
+	filter := hooks.OnAfterListResources[0]
+
+	newResult := func() *mcp.ListResourcesResult {
+		return &mcp.ListResourcesResult{
+			Resources: []mcp.Resource{
+				{URI: buildResourceURI("time", "file:///zones.json")},
+				{URI: buildResourceURI("team-a.time", "file:///zones.json")},
+				{URI: "not-a-proxy-uri"},
+			},
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	result := newResult()
+	filter(ctx, nil, nil, result)
+	assert.Len(t, result.Resources, 3)
+
+	ctx = context.WithValue(ctx, "namespace", "team-a")
+	result = newResult()
+	filter(ctx, nil, nil, result)
+	assert.Len(t, result.Resources, 1)
+	assert.Equal(t, buildResourceURI("team-a.time", "file:///zones.json"), result.Resources[0].URI)
+
+	result = newResult()
+	filter(context.Background(), nil, nil, result)
+	assert.Empty(t, result.Resources)
+}
+
+func TestMcpProxyResourceFilterHooks_ResourceTemplates(t *testing.T) {
+	t.Parallel()
+
+	hooks := ProxyResourceFilterHooks()
+	assert.Len(t, hooks.OnAfterListResourceTemplates, 1)
+	filter := hooks.OnAfterListResourceTemplates[0]
+
+	newResult := func() *mcp.ListResourceTemplatesResult {
+		return &mcp.ListResourceTemplatesResult{
+			ResourceTemplates: []mcp.ResourceTemplate{
+				mcp.NewResourceTemplate(buildResourceTemplateURI("time", "tz://{zone}"), "time__zone"),
+				mcp.NewResourceTemplate(buildResourceTemplateURI("team-a.time", "tz://{zone}"), "team-a.time__zone"),
+			},
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	result := newResult()
+	filter(ctx, nil, nil, result)
+	assert.Len(t, result.ResourceTemplates, 2)
+
+	ctx = context.WithValue(ctx, "namespace", "team-a")
+	result = newResult()
+	filter(ctx, nil, nil, result)
+	assert.Len(t, result.ResourceTemplates, 1)
+	assert.Equal(t, "team-a.time__zone", result.ResourceTemplates[0].Name)
+
+	result = newResult()
+	filter(context.Background(), nil, nil, result)
+	assert.Empty(t, result.ResourceTemplates)
+}
+
 func toolNames(tools []mcp.Tool) []string {
 	names := make([]string, len(tools))
 	for i, tool := range tools {
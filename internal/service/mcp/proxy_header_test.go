@@ -28,6 +28,7 @@ func setupProxyHeaderTestDB(t *testing.T) *gorm.DB {
 		&model.Tool{},
 		&model.Prompt{},
 		&model.Resource{},
+		&model.ResourceTemplate{},
 		&model.UpstreamOAuthToken{},
 		&model.UpstreamOAuthPendingSession{},
 	)
@@ -136,7 +137,7 @@ func TestMCPProxyPromptHandlerStripsInboundHeaders(t *testing.T) {
 		"X-Test-Forward": []string{"should-not-forward"},
 	}
 
-	res, err := service.mcpProxyPromptHandler(context.WithValue(context.Background(), "mode", model.ModeDev), req)
+	res, err := service.MCPProxyPromptHandler(context.WithValue(context.Background(), "mode", model.ModeDev), req)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	assert.Empty(t, seenAuthorization)
@@ -190,7 +191,7 @@ func TestMCPProxyResourceHandlerStripsInboundHeaders(t *testing.T) {
 		"X-Test-Forward": []string{"should-not-forward"},
 	}
 
-	res, err := service.mcpProxyResourceHandler(context.WithValue(context.Background(), "mode", model.ModeDev), req)
+	res, err := service.MCPProxyResourceHandler(context.WithValue(context.Background(), "mode", model.ModeDev), req)
 	require.NoError(t, err)
 	require.Len(t, res, 1)
 	assert.Empty(t, seenAuthorization)
@@ -27,6 +27,7 @@ func setupTestDBForProxyAdditional(t *testing.T) *gorm.DB {
 		&model.Tool{},
 		&model.Prompt{},
 		&model.Resource{},
+		&model.ResourceTemplate{},
 		&model.UpstreamOAuthToken{},
 		&model.UpstreamOAuthPendingSession{},
 	)
@@ -118,14 +119,14 @@ func TestMCPProxyPromptHandler_RewritesCanonicalNameAndForwardsArguments(t *test
 	req.Params.Name = "prompt-server__review"
 	req.Params.Arguments = map[string]string{"topic": "security"}
 
-	res, err := service.mcpProxyPromptHandler(context.WithValue(context.Background(), "mode", model.ModeDev), req)
+	res, err := service.MCPProxyPromptHandler(context.WithValue(context.Background(), "mode", model.ModeDev), req)
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	assert.Equal(t, "review", seenPromptName)
 	assert.Equal(t, "security", seenArgument)
 }
 
-func TestInitMCPProxyServer_LoadsEnabledEntitiesIntoCorrectProxyServers(t *testing.T) {
+func TestInitMCPProxyServer_LoadsEnabledEntitiesOfAllTransports(t *testing.T) {
 	db := setupTestDBForProxyAdditional(t)
 
 	stdioServer := createTestServer(t, db)
@@ -146,34 +147,30 @@ func TestInitMCPProxyServer_LoadsEnabledEntitiesIntoCorrectProxyServers(t *testi
 
 	service := newTestLifecycleService(t, db)
 
-	stdioTools := service.mcpProxyServer.ListTools()
-	require.Contains(t, stdioTools, "test-server__stdio-tool")
-	assert.NotContains(t, stdioTools, "test-server__disabled-tool")
-	assert.NotContains(t, stdioTools, "sse-server__sse-tool")
+	// the upstream transport is a connection detail, the proxy server serves the entities of all servers
+	tools := service.mcpProxyServer.ListTools()
+	require.Contains(t, tools, "test-server__stdio-tool")
+	require.Contains(t, tools, "sse-server__sse-tool")
+	assert.NotContains(t, tools, "test-server__disabled-tool")
 
-	sseTools := service.sseMcpProxyServer.ListTools()
-	require.Contains(t, sseTools, "sse-server__sse-tool")
-	assert.NotContains(t, sseTools, "test-server__stdio-tool")
-
-	stdioClient := newInitializedInProcessClient(t, service.mcpProxyServer)
-	stdioPromptList, err := stdioClient.ListPrompts(context.Background(), mcp.ListPromptsRequest{})
-	require.NoError(t, err)
-	require.Len(t, stdioPromptList.Prompts, 1)
-	assert.Equal(t, []string{"test-server__stdio-prompt"}, []string{stdioPromptList.Prompts[0].Name})
-
-	stdioResourceList, err := stdioClient.ListResources(context.Background(), mcp.ListResourcesRequest{})
-	require.NoError(t, err)
-	require.Len(t, stdioResourceList.Resources, 1)
-	assert.Equal(t, []string{buildResourceURI("test-server", "resource://stdio/status")}, []string{stdioResourceList.Resources[0].URI})
-
-	sseClient := newInitializedInProcessClient(t, service.sseMcpProxyServer)
-	ssePromptList, err := sseClient.ListPrompts(context.Background(), mcp.ListPromptsRequest{})
+	client := newInitializedInProcessClient(t, service.mcpProxyServer)
+	promptList, err := client.ListPrompts(context.Background(), mcp.ListPromptsRequest{})
 	require.NoError(t, err)
-	require.Len(t, ssePromptList.Prompts, 1)
-	assert.Equal(t, []string{"sse-server__sse-prompt"}, []string{ssePromptList.Prompts[0].Name})
+	var promptNames []string
+	for _, prompt := range promptList.Prompts {
+		promptNames = append(promptNames, prompt.Name)
+	}
+	assert.ElementsMatch(t, []string{"test-server__stdio-prompt", "sse-server__sse-prompt"}, promptNames)
 
-	sseResourceList, err := sseClient.ListResources(context.Background(), mcp.ListResourcesRequest{})
+	resourceList, err := client.ListResources(context.Background(), mcp.ListResourcesRequest{})
 	require.NoError(t, err)
-	require.Len(t, sseResourceList.Resources, 1)
-	assert.Equal(t, []string{buildResourceURI("sse-server", "resource://sse/status")}, []string{sseResourceList.Resources[0].URI})
+	var resourceURIs []string
+	for _, resource := range resourceList.Resources {
+		resourceURIs = append(resourceURIs, resource.URI)
+	}
+	assert.ElementsMatch(
+		t,
+		[]string{buildResourceURI("test-server", "resource://stdio/status"), buildResourceURI("sse-server", "resource://sse/status")},
+		resourceURIs,
+	)
 }
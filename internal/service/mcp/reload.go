@@ -0,0 +1,320 @@
+package mcp
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ServerChangeCallback is invoked after this mcpjungle instance changed an MCP server in the registry,
+// eg- registered, updated or deregistered it, or enabled or disabled some of its tools, prompts or resources.
+// The callback receives the name of the changed server.
+type ServerChangeCallback func(serverName string)
+
+// SetServerChangeCallback registers a callback function to be called whenever an MCP server is changed.
+// It is used to let other mcpjungle instances sharing the same database know about the change.
+func (m *MCPService) SetServerChangeCallback(callback ServerChangeCallback) {
+	m.serverChangeCallback = callback
+}
+
+// notifyServerChange calls the registered server change callback, if any, with the given server name.
+func (m *MCPService) notifyServerChange(serverName string) {
+	if m.serverChangeCallback == nil {
+		return
+	}
+	m.serverChangeCallback(serverName)
+}
+
+// trackServer records the transport, labels and tool overrides of an MCP server whose entities are served
+// by the proxy servers.
+// It reports whether the server was tracked before with different labels or tool overrides.
+func (m *MCPService) trackServer(s *model.McpServer) (labelsChanged, overridesChanged bool) {
+	labels, err := s.GetLabels()
+	if err != nil {
+		log.Printf("[WARN] failed to read labels of MCP server %s: %v", s.Name, err)
+	}
+	overrides, err := s.GetToolOverrides()
+	if err != nil {
+		log.Printf("[WARN] failed to read tool overrides of MCP server %s: %v", s.Name, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.serverLabels == nil {
+		m.serverLabels = make(map[string][]string)
+	}
+	if m.serverToolOverrides == nil {
+		m.serverToolOverrides = make(map[string]map[string]types.ToolOverride)
+	}
+	previousLabels, known := m.serverLabels[s.Name]
+	m.serverLabels[s.Name] = labels
+	previousOverrides := m.serverToolOverrides[s.Name]
+	m.serverToolOverrides[s.Name] = overrides
+	labelsChanged = known && !slices.Equal(previousLabels, labels)
+	overridesChanged = known && len(previousOverrides)+len(overrides) > 0 && !reflect.DeepEqual(previousOverrides, overrides)
+	return labelsChanged, overridesChanged
+}
+
+// untrackServer forgets the labels and tool overrides of an MCP server that is no longer registered.
+func (m *MCPService) untrackServer(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.serverLabels, name)
+	delete(m.serverToolOverrides, name)
+}
+
+// reevaluateServerTools notifies the tool addition callback of all the served tools of an MCP server,
+// so that tool groups selecting tools by the server's labels pick up a change of the labels.
+func (m *MCPService) reevaluateServerTools(serverName string) {
+	m.mu.RLock()
+	var names []string
+	for name := range m.toolInstances {
+		if s, _, ok := splitServerToolName(name); ok && s == serverName {
+			names = append(names, name)
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		m.notifyToolAddition(name)
+	}
+}
+
+// ReloadMcpServer brings the tools, prompts and resources served by the proxy servers for an MCP server
+// in line with the registry database.
+// It is used when the server was changed in the database by another mcpjungle instance.
+//
+// Only the entities that were added, removed or changed are touched, so MCP clients and tool groups
+// are not disrupted by a reload. If the server no longer exists, all of its entities are removed.
+// A stateful session that was created with an outdated configuration of the server is closed.
+// Reloading a server does not invoke the server change callback.
+func (m *MCPService) ReloadMcpServer(name string) error {
+	s, err := m.GetMcpServer(name)
+	if err != nil {
+		if !errors.Is(err, apierrors.ErrNotFound) {
+			return fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
+		}
+		s = nil
+	}
+
+	var (
+		tools     []mcp.Tool
+		prompts   []mcp.Prompt
+		resources []mcp.Resource
+	)
+	if s != nil {
+		if tools, prompts, resources, err = m.loadEnabledServerEntities(s); err != nil {
+			return err
+		}
+	}
+
+	labelsChanged, overridesChanged := false, false
+	if s != nil {
+		labelsChanged, overridesChanged = m.trackServer(s)
+	} else {
+		m.untrackServer(name)
+	}
+
+	m.reloadServerTools(name, tools)
+	m.reloadServerPrompts(name, prompts)
+	m.reloadServerResources(name, resources)
+	if err := m.reloadServerResourceTemplates(name, s); err != nil {
+		return err
+	}
+	if overridesChanged {
+		m.reserveServerTools(s)
+	} else if labelsChanged {
+		m.reevaluateServerTools(name)
+	}
+
+	if s == nil || s.SessionMode != types.SessionModeStateful {
+		m.sessionManager.CloseSession(name)
+	} else {
+		m.sessionManager.CloseStaleSession(s)
+	}
+	return nil
+}
+
+// loadEnabledServerEntities loads the enabled tools, prompts and resources of an MCP server from the DB,
+// in the form they are served by the proxy servers.
+func (m *MCPService) loadEnabledServerEntities(s *model.McpServer) ([]mcp.Tool, []mcp.Prompt, []mcp.Resource, error) {
+	toolModels, err := m.ListToolsByServer(s.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var tools []mcp.Tool
+	for i := range toolModels {
+		if !toolModels[i].Enabled {
+			continue
+		}
+		tool, err := convertToolModelToMcpObject(&toolModels[i])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to convert tool model to MCP object for tool %s: %w", toolModels[i].Name, err)
+		}
+		tools = append(tools, tool)
+	}
+
+	promptModels, err := m.ListPromptsByServer(s.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var prompts []mcp.Prompt
+	for i := range promptModels {
+		if !promptModels[i].Enabled {
+			continue
+		}
+		prompt, err := convertPromptModelToMcpObject(&promptModels[i])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to convert prompt model to MCP object for prompt %s: %w", promptModels[i].Name, err)
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	resourceModels, err := m.ListResourcesByServer(s.Name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var resources []mcp.Resource
+	for i := range resourceModels {
+		if !resourceModels[i].Enabled {
+			continue
+		}
+		resource, err := convertResourceModelToMcpObject(&resourceModels[i])
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to convert resource model to MCP object for resource %s: %w", resourceModels[i].URI, err)
+		}
+		resources = append(resources, resource)
+	}
+
+	return tools, prompts, resources, nil
+}
+
+// reloadServerTools replaces the tools served for an MCP server with the given ones.
+// Tool groups are notified about the tools that were removed, added or changed.
+func (m *MCPService) reloadServerTools(name string, tools []mcp.Tool) {
+	prefix := name + serverToolNameSep
+	current := make(map[string]mcp.Tool)
+	m.mu.RLock()
+	for toolName, tool := range m.toolInstances {
+		if strings.HasPrefix(toolName, prefix) {
+			current[toolName] = tool
+		}
+	}
+	m.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		wanted[tool.Name] = true
+	}
+	var removed []string
+	for toolName := range current {
+		if !wanted[toolName] {
+			removed = append(removed, toolName)
+		}
+	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		m.DeleteProxyTools(m.mcpProxyServer, removed...)
+		m.deleteToolInstances(removed...)
+		m.notifyToolDeletion(removed...)
+	}
+
+	for _, tool := range tools {
+		if existing, ok := current[tool.Name]; ok && reflect.DeepEqual(existing, tool) {
+			continue
+		}
+		m.serveProxyTool(m.mcpProxyServer, tool)
+		m.addToolInstance(tool)
+		m.notifyToolAddition(tool.Name)
+	}
+}
+
+// reloadServerPrompts replaces the prompts served for an MCP server with the given ones.
+// Like for tools, tool groups are notified about the prompts that were removed, added or changed.
+func (m *MCPService) reloadServerPrompts(name string, prompts []mcp.Prompt) {
+	prefix := name + serverPromptNameSep
+	current := make(map[string]mcp.Prompt)
+	m.mu.RLock()
+	for promptName, prompt := range m.promptInstances {
+		if strings.HasPrefix(promptName, prefix) {
+			current[promptName] = prompt
+		}
+	}
+	m.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(prompts))
+	for _, prompt := range prompts {
+		wanted[prompt.Name] = true
+	}
+	var removed []string
+	for promptName := range current {
+		if !wanted[promptName] {
+			removed = append(removed, promptName)
+		}
+	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		m.mcpProxyServer.DeletePrompts(removed...)
+		m.deletePromptInstances(removed...)
+		m.notifyPromptDeletion(removed...)
+	}
+
+	for _, prompt := range prompts {
+		if existing, ok := current[prompt.Name]; ok && reflect.DeepEqual(existing, prompt) {
+			continue
+		}
+		m.mcpProxyServer.AddPrompt(prompt, m.MCPProxyPromptHandler)
+		m.addPromptInstance(prompt)
+		m.notifyPromptAddition(prompt.Name)
+	}
+}
+
+// reloadServerResources replaces the resources served for an MCP server with the given ones.
+// Like for tools, tool groups are notified about the resources that were removed, added or changed.
+func (m *MCPService) reloadServerResources(name string, resources []mcp.Resource) {
+	current := make(map[string]mcp.Resource)
+	m.mu.RLock()
+	for uri, resource := range m.resourceInstances {
+		serverName, _, err := parseResourceURI(uri)
+		if err == nil && serverName == name {
+			current[uri] = resource
+		}
+	}
+	m.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		wanted[resource.URI] = true
+	}
+	var removed []string
+	for uri := range current {
+		if !wanted[uri] {
+			removed = append(removed, uri)
+		}
+	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		m.mcpProxyServer.DeleteResources(removed...)
+		m.deleteResourceInstances(removed...)
+		m.notifyResourceDeletion(removed...)
+	}
+
+	for _, resource := range resources {
+		if existing, ok := current[resource.URI]; ok && reflect.DeepEqual(existing, resource) {
+			continue
+		}
+		m.mcpProxyServer.AddResource(resource, m.MCPProxyResourceHandler)
+		m.addResourceInstance(resource)
+		m.notifyResourceAddition(resource.URI)
+	}
+}
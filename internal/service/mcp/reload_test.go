@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listProxiedToolNames(t *testing.T, service *MCPService) []string {
+	t.Helper()
+
+	proxyClient := newInitializedInProcessClient(t, service.mcpProxyServer)
+	toolList, err := proxyClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, tool := range toolList.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestReloadMcpServer_AppliesChangesMadeByAnotherInstance(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	// both services share the same database, like two mcpjungle replicas
+	origin := newTestLifecycleService(t, db)
+	replica := newTestLifecycleService(t, db)
+
+	var changed []string
+	origin.SetServerChangeCallback(func(serverName string) {
+		changed = append(changed, serverName)
+	})
+	var added, deleted []string
+	replica.SetToolAdditionCallback(func(name string) error {
+		added = append(added, name)
+		return nil
+	})
+	replica.SetToolDeletionCallback(func(names ...string) {
+		deleted = append(deleted, names...)
+	})
+
+	upstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{
+		"echo": "Echo a message",
+		"sum":  "Add numbers",
+	}, true))
+	defer upstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", upstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	require.NoError(t, origin.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+	assert.Equal(t, []string{"calc"}, changed)
+	assert.Empty(t, listProxiedToolNames(t, replica))
+
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+	assert.ElementsMatch(t, []string{"calc__echo", "calc__sum"}, listProxiedToolNames(t, replica))
+	assert.ElementsMatch(t, []string{"calc__echo", "calc__sum"}, added)
+	_, ok := replica.promptInstances["calc__review"]
+	assert.True(t, ok)
+
+	// reloading an unchanged server does not touch its tools
+	added = nil
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+	assert.Empty(t, added)
+
+	changed = nil
+	_, err = origin.DisableTools("calc__echo")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"calc"}, changed)
+
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+	assert.Equal(t, []string{"calc__sum"}, listProxiedToolNames(t, replica))
+	assert.Equal(t, []string{"calc__echo"}, deleted)
+
+	require.NoError(t, origin.DeregisterMcpServer("calc"))
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+	assert.Empty(t, listProxiedToolNames(t, replica))
+	assert.Equal(t, []string{"calc__echo", "calc__sum"}, deleted)
+	assert.Empty(t, replica.promptInstances)
+}
+
+func TestReloadMcpServer_ClosesStaleStatefulSession(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	origin := newTestLifecycleService(t, db)
+	replica := newTestLifecycleService(t, db)
+
+	upstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{"echo": "Echo"}, false))
+	defer upstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", upstream.URL, "", nil, types.SessionModeStateful)
+	require.NoError(t, err)
+	require.NoError(t, origin.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+
+	registered, err := replica.GetMcpServer("calc")
+	require.NoError(t, err)
+	_, err = replica.sessionManager.GetOrCreateSession(context.Background(), registered)
+	require.NoError(t, err)
+
+	// a description-only change keeps the session
+	sameConn, err := model.NewStreamableHTTPServer("calc", "new description", upstream.URL, "", nil, types.SessionModeStateful)
+	require.NoError(t, err)
+	_, err = origin.UpdateMcpServer(context.Background(), sameConn)
+	require.NoError(t, err)
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+	assert.True(t, replica.sessionManager.HasSession("calc"))
+
+	newConn, err := model.NewStreamableHTTPServer(
+		"calc", "new description", upstream.URL, "", map[string]string{"X-Team": "a"}, types.SessionModeStateful,
+	)
+	require.NoError(t, err)
+	_, err = origin.UpdateMcpServer(context.Background(), newConn)
+	require.NoError(t, err)
+	require.NoError(t, replica.ReloadMcpServer("calc"))
+	assert.False(t, replica.sessionManager.HasSession("calc"))
+}
@@ -18,6 +18,16 @@ import (
 
 const resourceURIPrefix = "mcpj://res/"
 
+// ResourceDeletionCallback is a function type that can be registered to be called
+// whenever one or more resources are deleted (deregistered) or disabled.
+// The callback receives the mcpjungle URIs of the deleted resources as arguments.
+type ResourceDeletionCallback func(uris ...string)
+
+// ResourceAdditionCallback is a function type that can be registered to be called
+// whenever a resource is added (registered or re-enabled) or its definition changes.
+// The callback receives the mcpjungle URI of the added resource as argument.
+type ResourceAdditionCallback func(uri string) error
+
 // buildResourceURI constructs a new URI for a mcp resource which is unique across all resources registered in mcpjungle.
 // It is of the form:
 // mcpj://res/{upstream mcp server name}/{base64-encoded original URI}
@@ -150,6 +160,36 @@ func (m *MCPService) GetResource(uri string) (*model.Resource, error) {
 	return &resource, nil
 }
 
+// GetResourceInstance returns the in-memory mcp.Resource instance for the given mcpjungle resource URI.
+// Returns the resource instance and a boolean indicating if it was found, ie, the resource exists and is enabled.
+func (m *MCPService) GetResourceInstance(uri string) (mcp.Resource, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	resource, exists := m.resourceInstances[uri]
+	return resource, exists
+}
+
+// GetResourceParentServer returns the MCP server that provides the resource with the given mcpjungle URI.
+func (m *MCPService) GetResourceParentServer(uri string) (*model.McpServer, error) {
+	serverName, _, err := parseResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetMcpServer(serverName)
+}
+
+// SetResourceDeletionCallback registers a callback function to be called
+// whenever one or more resources are deleted or disabled.
+func (m *MCPService) SetResourceDeletionCallback(callback ResourceDeletionCallback) {
+	m.resourceDeletionCallback = callback
+}
+
+// SetResourceAdditionCallback registers a callback function to be called
+// whenever a resource is added or re-enabled.
+func (m *MCPService) SetResourceAdditionCallback(callback ResourceAdditionCallback) {
+	m.resourceAdditionCallback = callback
+}
+
 // ReadResource reads live resource content by URI.
 func (m *MCPService) ReadResource(ctx context.Context, uri string) (*types.ResourceReadResult, error) {
 	resource, err := m.GetResource(uri)
@@ -240,18 +280,15 @@ func (m *MCPService) setResourcesEnabled(entity string, enabled bool) ([]string,
 		}
 		mcpResource.Name = mergeServerResourceNames(resource.Server.Name, mcpResource.Name)
 
-		if resource.Server.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddResource(mcpResource, m.mcpProxyResourceHandler)
-		} else {
-			m.mcpProxyServer.AddResource(mcpResource, m.mcpProxyResourceHandler)
-		}
+		m.mcpProxyServer.AddResource(mcpResource, m.MCPProxyResourceHandler)
+		m.addResourceInstance(mcpResource)
+		m.notifyResourceAddition(mcpResource.URI)
 	} else {
-		if resource.Server.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.DeleteResources(resource.URI)
-		} else {
-			m.mcpProxyServer.DeleteResources(resource.URI)
-		}
+		m.mcpProxyServer.DeleteResources(resource.URI)
+		m.deleteResourceInstances(resource.URI)
+		m.notifyResourceDeletion(resource.URI)
 	}
+	m.notifyServerChange(resource.Server.Name)
 
 	return []string{resource.URI}, nil
 }
@@ -279,21 +316,20 @@ func (m *MCPService) setServerResourcesEnabled(s *model.McpServer, enabled bool)
 			}
 			mcpResource.Name = mergeServerResourceNames(s.Name, mcpResource.Name)
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddResource(mcpResource, m.mcpProxyResourceHandler)
-			} else {
-				m.mcpProxyServer.AddResource(mcpResource, m.mcpProxyResourceHandler)
-			}
+			m.mcpProxyServer.AddResource(mcpResource, m.MCPProxyResourceHandler)
+			m.addResourceInstance(mcpResource)
+			m.notifyResourceAddition(mcpResource.URI)
 		} else {
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.DeleteResources(resources[i].URI)
-			} else {
-				m.mcpProxyServer.DeleteResources(resources[i].URI)
-			}
+			m.mcpProxyServer.DeleteResources(resources[i].URI)
+			m.deleteResourceInstances(resources[i].URI)
+			m.notifyResourceDeletion(resources[i].URI)
 		}
 
 		changedURIs = append(changedURIs, resources[i].URI)
 	}
+	if len(changedURIs) > 0 {
+		m.notifyServerChange(s.Name)
+	}
 
 	return changedURIs, nil
 }
@@ -308,19 +344,7 @@ func (m *MCPService) registerServerResources(ctx context.Context, s *model.McpSe
 	for _, resource := range resp.Resources {
 		canonicalResourceName := mergeServerResourceNames(s.Name, resource.GetName())
 
-		annotationsJSON, _ := json.Marshal(resource.Annotations)
-		metaJSON, _ := json.Marshal(resource.Meta)
-
-		r := &model.Resource{
-			ServerID:    s.ID,
-			URI:         buildResourceURI(s.Name, resource.URI),
-			OriginalURI: resource.URI,
-			Name:        resource.GetName(),
-			Description: resource.Description,
-			MIMEType:    resource.MIMEType,
-			Annotations: annotationsJSON,
-			Meta:        metaJSON,
-		}
+		r := newResourceModel(s, resource)
 		if err := m.db.Create(r).Error; err != nil {
 			log.Printf("[ERROR] failed to register resource %s (%s) in DB: %v", canonicalResourceName, resource.URI, err)
 			continue
@@ -328,16 +352,31 @@ func (m *MCPService) registerServerResources(ctx context.Context, s *model.McpSe
 
 		resource.URI = r.URI
 		resource.Name = canonicalResourceName
-		if s.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddResource(resource, m.mcpProxyResourceHandler)
-		} else {
-			m.mcpProxyServer.AddResource(resource, m.mcpProxyResourceHandler)
-		}
+		m.mcpProxyServer.AddResource(resource, m.MCPProxyResourceHandler)
+		m.addResourceInstance(resource)
+		m.notifyResourceAddition(resource.URI)
 	}
 
 	return nil
 }
 
+// newResourceModel creates the DB record of a resource provided by an MCP server.
+func newResourceModel(s *model.McpServer, resource mcp.Resource) *model.Resource {
+	annotationsJSON, _ := json.Marshal(resource.Annotations)
+	metaJSON, _ := json.Marshal(resource.Meta)
+
+	return &model.Resource{
+		ServerID:    s.ID,
+		URI:         buildResourceURI(s.Name, resource.URI),
+		OriginalURI: resource.URI,
+		Name:        resource.GetName(),
+		Description: resource.Description,
+		MIMEType:    resource.MIMEType,
+		Annotations: annotationsJSON,
+		Meta:        metaJSON,
+	}
+}
+
 // deregisterServerResources deletes all resources that belong to an MCP server from the DB.
 // It also removes the resources from the MCP proxy server.
 func (m *MCPService) deregisterServerResources(s *model.McpServer) error {
@@ -356,11 +395,51 @@ func (m *MCPService) deregisterServerResources(s *model.McpServer) error {
 		resourceURIs[i] = resource.URI
 	}
 
-	if s.Transport == types.TransportSSE {
-		m.sseMcpProxyServer.DeleteResources(resourceURIs...)
-	} else {
-		m.mcpProxyServer.DeleteResources(resourceURIs...)
-	}
+	m.mcpProxyServer.DeleteResources(resourceURIs...)
+	m.deleteResourceInstances(resourceURIs...)
+	m.notifyResourceDeletion(resourceURIs...)
 
 	return nil
 }
+
+// addResourceInstance adds a resource instance to the in-memory resource instance tracker.
+// If a resource with the same URI already exists, it is overwritten.
+func (m *MCPService) addResourceInstance(resource mcp.Resource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.resourceInstances == nil {
+		m.resourceInstances = make(map[string]mcp.Resource)
+	}
+	m.resourceInstances[resource.URI] = resource
+}
+
+// deleteResourceInstances deletes one or more resource instances from the in-memory resource instance tracker.
+func (m *MCPService) deleteResourceInstances(uris ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, uri := range uris {
+		delete(m.resourceInstances, uri)
+	}
+}
+
+// notifyResourceDeletion calls the registered resource deletion callback with the given resource URIs.
+func (m *MCPService) notifyResourceDeletion(uris ...string) {
+	// clients can no longer access the resources, so they cannot stay subscribed to them
+	m.dropResourceSubscriptions(uris...)
+
+	if m.resourceDeletionCallback == nil {
+		return
+	}
+	m.resourceDeletionCallback(uris...)
+}
+
+// notifyResourceAddition calls the registered resource addition callback with the given resource URI.
+// Like notifyToolAddition, it works on best-effort basis and only logs callback failures.
+func (m *MCPService) notifyResourceAddition(uri string) {
+	if m.resourceAdditionCallback == nil {
+		return
+	}
+	if err := m.resourceAdditionCallback(uri); err != nil {
+		log.Printf("[ERROR] resource addition callback failed for resource %s: %v", uri, err)
+	}
+}
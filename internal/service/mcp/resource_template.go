@@ -0,0 +1,350 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/yosida95/uritemplate/v3"
+	"gorm.io/gorm"
+)
+
+const resourceTemplateURIPrefix = "mcpj://tmpl/"
+
+// buildResourceTemplateURI constructs the URI template of a resource template which is unique across all resource
+// templates registered in mcpjungle. It is of the form:
+// mcpj://tmpl/{upstream mcp server name}/{original URI template}
+// Unlike resource URIs, the original URI template is not encoded, so that every URI expanded from the template
+// contains the URI that the upstream MCP server expands its own template to.
+func buildResourceTemplateURI(serverName string, originalURITemplate string) string {
+	return resourceTemplateURIPrefix + serverName + "/" + originalURITemplate
+}
+
+// parseResourceTemplateURI parses the server name and original URI from a URI expanded from a resource template
+// registered in mcpjungle (or from such a URI template).
+func parseResourceTemplateURI(uri string) (string, string, error) {
+	rest, ok := strings.CutPrefix(uri, resourceTemplateURIPrefix)
+	serverName, originalURI, found := strings.Cut(rest, "/")
+	if !ok || !found || serverName == "" || originalURI == "" {
+		return "", "", fmt.Errorf(
+			"URI %s is not a valid MCPJungle resource template URI: %w", uri, apierrors.ErrInvalidInput,
+		)
+	}
+	if err := validateServerName(serverName); err != nil {
+		return "", "", err
+	}
+	return serverName, originalURI, nil
+}
+
+// ListResourceTemplates returns all resource templates registered in the registry.
+// It sets each template's name to its canonical display form by prepending its server name.
+func (m *MCPService) ListResourceTemplates() ([]model.ResourceTemplate, error) {
+	var templates []model.ResourceTemplate
+	if err := m.db.Preload("Server").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		templates[i].Name = mergeServerResourceNames(templates[i].Server.Name, templates[i].Name)
+	}
+
+	return templates, nil
+}
+
+// ListResourceTemplatesByServer fetches the resource templates provided by an MCP server from the registry.
+func (m *MCPService) ListResourceTemplatesByServer(name string) ([]model.ResourceTemplate, error) {
+	if err := validateServerName(name); err != nil {
+		return nil, err
+	}
+
+	s, err := m.GetMcpServer(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MCP server %s from DB: %w", name, err)
+	}
+
+	var templates []model.ResourceTemplate
+	if err := m.db.Where("server_id = ?", s.ID).Find(&templates).Error; err != nil {
+		return nil, fmt.Errorf("failed to get resource templates for server %s from DB: %w", name, err)
+	}
+
+	for i := range templates {
+		templates[i].Server = *s
+		templates[i].Name = mergeServerResourceNames(s.Name, templates[i].Name)
+	}
+
+	return templates, nil
+}
+
+// registerServerResourceTemplates fetches all resource templates from an MCP server and registers them in the DB.
+func (m *MCPService) registerServerResourceTemplates(ctx context.Context, s *model.McpServer, c *client.Client) error {
+	resp, err := c.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch resource templates from MCP server %s: %w", s.Name, err)
+	}
+
+	for _, template := range resp.ResourceTemplates {
+		rt := newResourceTemplateModel(s, template)
+		if rt.OriginalURITemplate == "" {
+			log.Printf("[WARN] skipping resource template %s of MCP server %s without a URI template", rt.Name, s.Name)
+			continue
+		}
+		if err := m.db.Create(rt).Error; err != nil {
+			log.Printf(
+				"[ERROR] failed to register resource template %s (%s) in DB: %v",
+				mergeServerResourceNames(s.Name, rt.Name), rt.OriginalURITemplate, err,
+			)
+		}
+	}
+
+	return m.reloadServerResourceTemplates(s.Name, s)
+}
+
+// newResourceTemplateModel creates the DB record of a resource template provided by an MCP server.
+func newResourceTemplateModel(s *model.McpServer, template mcp.ResourceTemplate) *model.ResourceTemplate {
+	annotationsJSON, _ := json.Marshal(template.Annotations)
+	metaJSON, _ := json.Marshal(template.Meta)
+
+	var originalURITemplate string
+	if template.URITemplate != nil && template.URITemplate.Template != nil {
+		originalURITemplate = template.URITemplate.Raw()
+	}
+
+	return &model.ResourceTemplate{
+		ServerID:            s.ID,
+		URITemplate:         buildResourceTemplateURI(s.Name, originalURITemplate),
+		OriginalURITemplate: originalURITemplate,
+		Name:                template.GetName(),
+		Description:         template.Description,
+		MIMEType:            template.MIMEType,
+		Annotations:         annotationsJSON,
+		Meta:                metaJSON,
+	}
+}
+
+// convertResourceTemplateModelToMcpObject converts the DB record of a resource template into the mcp object
+// served by the proxy servers.
+func convertResourceTemplateModelToMcpObject(rt *model.ResourceTemplate) (mcp.ResourceTemplate, error) {
+	uriTemplate, err := uritemplate.New(rt.URITemplate)
+	if err != nil {
+		return mcp.ResourceTemplate{}, fmt.Errorf("resource template %s has an invalid URI template: %w", rt.URITemplate, err)
+	}
+	template := mcp.ResourceTemplate{
+		URITemplate: &mcp.URITemplate{Template: uriTemplate},
+		Name:        rt.Name,
+		Description: rt.Description,
+		MIMEType:    rt.MIMEType,
+	}
+
+	if len(rt.Annotations) > 0 {
+		var annotations mcp.Annotations
+		if err := json.Unmarshal(rt.Annotations, &annotations); err != nil {
+			log.Printf("[WARN] failed to unmarshal annotations for resource template %s: %v", rt.URITemplate, err)
+		} else {
+			template.Annotations = &annotations
+		}
+	}
+
+	if len(rt.Meta) > 0 {
+		var meta mcp.Meta
+		if err := json.Unmarshal(rt.Meta, &meta); err != nil {
+			log.Printf("[WARN] failed to unmarshal meta for resource template %s: %v", rt.URITemplate, err)
+		} else {
+			template.Meta = &meta
+		}
+	}
+
+	return template, nil
+}
+
+// deregisterServerResourceTemplates deletes all resource templates that belong to an MCP server from the DB.
+// It also removes the templates from the MCP proxy server.
+func (m *MCPService) deregisterServerResourceTemplates(s *model.McpServer) error {
+	result := m.db.Unscoped().Where("server_id = ?", s.ID).Delete(&model.ResourceTemplate{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete resource templates for server %s: %w", s.Name, result.Error)
+	}
+	m.serveServerResourceTemplates(s.Name, nil)
+	return nil
+}
+
+// reconcileServerResourceTemplates makes the resource templates of a server in the DB match the templates
+// provided upstream. Templates are identified by their original URI template.
+func reconcileServerResourceTemplates(tx *gorm.DB, s *model.McpServer, upstream []mcp.ResourceTemplate) error {
+	var current []model.ResourceTemplate
+	if err := tx.Where("server_id = ?", s.ID).Find(&current).Error; err != nil {
+		return fmt.Errorf("failed to list resource templates for server %s: %w", s.Name, err)
+	}
+	byURITemplate := make(map[string]*model.ResourceTemplate, len(current))
+	for i := range current {
+		byURITemplate[current[i].OriginalURITemplate] = &current[i]
+	}
+
+	seen := make(map[string]bool, len(upstream))
+	for _, template := range upstream {
+		rt := newResourceTemplateModel(s, template)
+		if rt.OriginalURITemplate == "" || seen[rt.OriginalURITemplate] {
+			continue
+		}
+		seen[rt.OriginalURITemplate] = true
+
+		old, exists := byURITemplate[rt.OriginalURITemplate]
+		if !exists {
+			if err := tx.Create(rt).Error; err != nil {
+				return fmt.Errorf("failed to register resource template %s: %w", rt.URITemplate, err)
+			}
+			continue
+		}
+		err := tx.Model(old).Select("Name", "Description", "MIMEType", "Annotations", "Meta").Updates(rt).Error
+		if err != nil {
+			return fmt.Errorf("failed to update resource template %s: %w", rt.URITemplate, err)
+		}
+	}
+
+	for i := range current {
+		if seen[current[i].OriginalURITemplate] {
+			continue
+		}
+		if err := tx.Unscoped().Delete(&current[i]).Error; err != nil {
+			return fmt.Errorf("failed to remove resource template %s: %w", current[i].URITemplate, err)
+		}
+	}
+	return nil
+}
+
+// reloadServerResourceTemplates replaces the resource templates served for an MCP server with the ones in the DB.
+// The templates of a disabled or deregistered (nil) server are not served.
+// Unlike resources, resource templates cannot be enabled or disabled individually, so tool groups serve the
+// templates of the servers they include as a whole (see ServeResourceTemplates).
+func (m *MCPService) reloadServerResourceTemplates(name string, s *model.McpServer) error {
+	var templates []mcp.ResourceTemplate
+	if s != nil && s.Enabled {
+		templateModels, err := m.ListResourceTemplatesByServer(s.Name)
+		if err != nil {
+			return err
+		}
+		for i := range templateModels {
+			template, err := convertResourceTemplateModelToMcpObject(&templateModels[i])
+			if err != nil {
+				log.Printf("[ERROR] failed to convert resource template model to MCP object: %v", err)
+				continue
+			}
+			templates = append(templates, template)
+		}
+	}
+	m.serveServerResourceTemplates(name, templates)
+	return nil
+}
+
+// serveServerResourceTemplates replaces the resource templates served for an MCP server with the given ones.
+// mcp-go cannot delete individual resource templates from an MCP server, so the templates of the proxy servers
+// are replaced as a whole whenever the templates of a server change.
+func (m *MCPService) serveServerResourceTemplates(name string, templates []mcp.ResourceTemplate) {
+	m.resourceTemplatesMu.Lock()
+	defer m.resourceTemplatesMu.Unlock()
+
+	m.mu.Lock()
+	if m.resourceTemplateInstances == nil {
+		m.resourceTemplateInstances = make(map[string][]mcp.ResourceTemplate)
+	}
+	unchanged := reflect.DeepEqual(m.resourceTemplateInstances[name], templates)
+	if len(templates) > 0 {
+		m.resourceTemplateInstances[name] = templates
+	} else {
+		delete(m.resourceTemplateInstances, name)
+	}
+	m.mu.Unlock()
+
+	if unchanged {
+		return
+	}
+	m.mcpProxyServer.SetResourceTemplates(m.servedResourceTemplates(nil)...)
+	// the other proxy servers only need to be updated if they serve the templates of this server
+	for proxy, servers := range m.groupTemplateServers {
+		names, err := servers()
+		if err != nil {
+			log.Printf("[ERROR] failed to resolve the MCP servers whose resource templates a proxy server serves: %v", err)
+			continue
+		}
+		if slices.Contains(names, name) {
+			proxy.SetResourceTemplates(m.servedResourceTemplates(names)...)
+		}
+	}
+}
+
+// ServeResourceTemplates makes a proxy server, eg- the one of a tool group, serve the resource templates
+// of the MCP servers whose names are returned by servers, and keeps them in sync as the templates of
+// these servers change. RefreshResourceTemplates must be called whenever the names returned by servers change.
+// The templates are no longer kept in sync once the proxy server is forgotten (see ForgetProxyServer).
+func (m *MCPService) ServeResourceTemplates(proxy *server.MCPServer, servers func() ([]string, error)) {
+	m.resourceTemplatesMu.Lock()
+	defer m.resourceTemplatesMu.Unlock()
+
+	if m.groupTemplateServers == nil {
+		m.groupTemplateServers = make(map[*server.MCPServer]func() ([]string, error))
+	}
+	m.groupTemplateServers[proxy] = servers
+	m.setGroupResourceTemplates(proxy, servers)
+}
+
+// RefreshResourceTemplates replaces the resource templates served by a proxy server registered with
+// ServeResourceTemplates, eg- after the MCP servers included by its tool group changed.
+func (m *MCPService) RefreshResourceTemplates(proxy *server.MCPServer) {
+	m.resourceTemplatesMu.Lock()
+	defer m.resourceTemplatesMu.Unlock()
+
+	if servers, ok := m.groupTemplateServers[proxy]; ok {
+		m.setGroupResourceTemplates(proxy, servers)
+	}
+}
+
+// forgetResourceTemplateProxy stops keeping the resource templates of a discarded proxy server in sync.
+func (m *MCPService) forgetResourceTemplateProxy(proxy *server.MCPServer) {
+	m.resourceTemplatesMu.Lock()
+	defer m.resourceTemplatesMu.Unlock()
+	delete(m.groupTemplateServers, proxy)
+}
+
+// setGroupResourceTemplates replaces the resource templates served by a proxy server with the templates of
+// the MCP servers returned by servers. It must be called with resourceTemplatesMu held.
+// If the servers cannot be resolved, the proxy keeps serving its current templates.
+func (m *MCPService) setGroupResourceTemplates(proxy *server.MCPServer, servers func() ([]string, error)) {
+	names, err := servers()
+	if err != nil {
+		log.Printf("[ERROR] failed to resolve the MCP servers whose resource templates a proxy server serves: %v", err)
+		return
+	}
+	proxy.SetResourceTemplates(m.servedResourceTemplates(names)...)
+}
+
+// servedResourceTemplates returns the resource templates currently served for the given MCP servers,
+// sorted by server name. A nil names returns the templates of all servers.
+func (m *MCPService) servedResourceTemplates(names []string) []server.ServerResourceTemplate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	serverNames := names
+	if serverNames == nil {
+		serverNames = make([]string, 0, len(m.resourceTemplateInstances))
+		for serverName := range m.resourceTemplateInstances {
+			serverNames = append(serverNames, serverName)
+		}
+	}
+	serverNames = slices.Sorted(slices.Values(serverNames))
+
+	var served []server.ServerResourceTemplate
+	for _, serverName := range serverNames {
+		for _, template := range m.resourceTemplateInstances[serverName] {
+			served = append(served, server.ServerResourceTemplate{Template: template, Handler: m.MCPProxyResourceTemplateHandler})
+		}
+	}
+	return served
+}
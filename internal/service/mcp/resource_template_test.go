@@ -0,0 +1,206 @@
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseResourceTemplateURI(t *testing.T) {
+	cases := []struct {
+		uri          string
+		wantServer   string
+		wantOriginal string
+		wantErr      bool
+	}{
+		{uri: "mcpj://tmpl/github/repo://octo/hello/readme", wantServer: "github", wantOriginal: "repo://octo/hello/readme"},
+		{uri: "mcpj://tmpl/team.github/repo://{owner}/{name}", wantServer: "team.github", wantOriginal: "repo://{owner}/{name}"},
+		{uri: "mcpj://res/github/cmVwbw", wantErr: true},
+		{uri: "mcpj://tmpl/github", wantErr: true},
+		{uri: "mcpj://tmpl/github/", wantErr: true},
+		{uri: "mcpj://tmpl//repo://octo", wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.uri, func(t *testing.T) {
+			serverName, originalURI, err := parseResourceTemplateURI(tc.uri)
+			if tc.wantErr {
+				require.ErrorIs(t, err, apierrors.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantServer, serverName)
+			assert.Equal(t, tc.wantOriginal, originalURI)
+		})
+	}
+}
+
+func TestResourceTemplates_RegisteredAndRoutedToUpstream(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	upstream := mcpserver.NewMCPServer(
+		"Upstream", "0.1.0", mcpserver.WithToolCapabilities(false), mcpserver.WithResourceCapabilities(false, false),
+	)
+	upstream.AddResourceTemplate(
+		mcp.NewResourceTemplate("repo://{owner}/{name}/readme", "readme", mcp.WithTemplateMIMEType("text/markdown")),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/markdown", Text: request.Params.URI},
+			}, nil
+		},
+	)
+	upstreamHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(upstream))
+	defer upstreamHTTP.Close()
+
+	proxy := mcpserver.NewMCPServer("Proxy", "0.1.0")
+	service, err := NewMCPService(&ServiceConfig{
+		DB:                      db,
+		McpProxyServer:          proxy,
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 5,
+	})
+	require.NoError(t, err)
+	defer service.Shutdown()
+
+	ctx := context.Background()
+	require.NoError(t, service.registerMcpServer(ctx, createStreamableHTTPTestServer(t, "github", upstreamHTTP.URL), false))
+
+	templates, err := service.ListResourceTemplatesByServer("github")
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	assert.Equal(t, "mcpj://tmpl/github/repo://{owner}/{name}/readme", templates[0].URITemplate)
+	assert.Equal(t, "repo://{owner}/{name}/readme", templates[0].OriginalURITemplate)
+	assert.Equal(t, "github__readme", templates[0].Name)
+
+	// MCP clients discover the template through the proxy server
+	client, err := mcpclient.NewInProcessClient(proxy)
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Start(ctx))
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = client.Initialize(ctx, initReq)
+	require.NoError(t, err)
+	listed, err := client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listed.ResourceTemplates, 1)
+	assert.Equal(t, "github__readme", listed.ResourceTemplates[0].Name)
+	assert.Equal(t, "text/markdown", listed.ResourceTemplates[0].MIMEType)
+
+	// reads of the URIs expanded from the template are routed to the upstream server with the upstream URI
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = "mcpj://tmpl/github/repo://octo/hello/readme"
+	contents, err := service.MCPProxyResourceTemplateHandler(context.WithValue(ctx, "mode", model.ModeDev), req)
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	text, ok := contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, "repo://octo/hello/readme", text.Text)
+	assert.Equal(t, req.Params.URI, text.URI)
+
+	// the templates of a disabled server are not served
+	_, _, err = service.DisableMcpServer("github")
+	require.NoError(t, err)
+	listed, err = client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, listed.ResourceTemplates)
+
+	_, _, err = service.EnableMcpServer("github")
+	require.NoError(t, err)
+	listed, err = client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, listed.ResourceTemplates, 1)
+
+	require.NoError(t, service.DeregisterMcpServer("github"))
+	var count int64
+	require.NoError(t, db.Model(&model.ResourceTemplate{}).Count(&count).Error)
+	assert.Zero(t, count)
+	listed, err = client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, listed.ResourceTemplates)
+}
+
+func TestResourceTemplates_ServedOnGroupProxies(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	upstream := mcpserver.NewMCPServer(
+		"Upstream", "0.1.0", mcpserver.WithToolCapabilities(false), mcpserver.WithResourceCapabilities(false, false),
+	)
+	upstream.AddResourceTemplate(
+		mcp.NewResourceTemplate("repo://{owner}/{name}/readme", "readme"),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return nil, nil
+		},
+	)
+	upstreamHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(upstream))
+	defer upstreamHTTP.Close()
+
+	service, err := NewMCPService(&ServiceConfig{
+		DB:                      db,
+		McpProxyServer:          mcpserver.NewMCPServer("Proxy", "0.1.0"),
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 5,
+	})
+	require.NoError(t, err)
+	defer service.Shutdown()
+
+	ctx := context.Background()
+
+	// listTemplates returns the names of the resource templates an MCP client discovers through a proxy server
+	listTemplates := func(proxy *mcpserver.MCPServer) []string {
+		client, err := mcpclient.NewInProcessClient(proxy)
+		require.NoError(t, err)
+		defer client.Close()
+		require.NoError(t, client.Start(ctx))
+		initReq := mcp.InitializeRequest{}
+		initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+		_, err = client.Initialize(ctx, initReq)
+		require.NoError(t, err)
+		listed, err := client.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+		require.NoError(t, err)
+		names := []string{}
+		for _, template := range listed.ResourceTemplates {
+			names = append(names, template.Name)
+		}
+		return names
+	}
+
+	// one group includes the server before it is registered, the other one doesn't include it
+	githubGroup := mcpserver.NewMCPServer("github group", "0.1.0", mcpserver.WithResourceCapabilities(false, false))
+	service.ServeResourceTemplates(githubGroup, func() ([]string, error) { return []string{"github"}, nil })
+	otherServers := []string{"other"}
+	otherGroup := mcpserver.NewMCPServer("other group", "0.1.0", mcpserver.WithResourceCapabilities(false, false))
+	service.ServeResourceTemplates(otherGroup, func() ([]string, error) { return otherServers, nil })
+
+	require.NoError(t, service.registerMcpServer(ctx, createStreamableHTTPTestServer(t, "github", upstreamHTTP.URL), false))
+	assert.Equal(t, []string{"github__readme"}, listTemplates(githubGroup))
+	assert.Empty(t, listTemplates(otherGroup))
+
+	// the templates of a disabled server are removed from the groups as well
+	_, _, err = service.DisableMcpServer("github")
+	require.NoError(t, err)
+	assert.Empty(t, listTemplates(githubGroup))
+
+	_, _, err = service.EnableMcpServer("github")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github__readme"}, listTemplates(githubGroup))
+
+	// a group that starts including the server serves its templates once refreshed
+	otherServers = []string{"github", "other"}
+	service.RefreshResourceTemplates(otherGroup)
+	assert.Equal(t, []string{"github__readme"}, listTemplates(otherGroup))
+
+	// forgotten proxy servers are no longer kept in sync
+	service.ForgetProxyServer(githubGroup)
+	require.NoError(t, service.DeregisterMcpServer("github"))
+	assert.Equal(t, []string{"github__readme"}, listTemplates(githubGroup))
+	assert.Empty(t, listTemplates(otherGroup))
+}
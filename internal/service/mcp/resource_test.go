@@ -23,7 +23,7 @@ func setupTestDBWithResources(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{})
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{})
 	require.NoError(t, err)
 
 	return db
@@ -275,7 +275,6 @@ func TestMCPProxyResourceHandlerRoutesReadByURI(t *testing.T) {
 	service := &MCPService{
 		db:                         db,
 		mcpProxyServer:             server.NewMCPServer("Test Proxy", "0.1.0"),
-		sseMcpProxyServer:          server.NewMCPServer("Test Proxy SSE", "0.1.0"),
 		metrics:                    telemetry.NewNoopCustomMetrics(),
 		mcpServerInitReqTimeoutSec: 10,
 		sessionManager:             sessionManager,
@@ -285,7 +284,7 @@ func TestMCPProxyResourceHandlerRoutesReadByURI(t *testing.T) {
 	req.Params.URI = buildResourceURI("test-server", "resource://test/status")
 	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
 
-	contents, err := service.mcpProxyResourceHandler(ctx, req)
+	contents, err := service.MCPProxyResourceHandler(ctx, req)
 	require.NoError(t, err)
 	require.Len(t, contents, 1)
 
@@ -314,7 +313,6 @@ func TestMCPProxyResourceHandlerEnterpriseRejectsUnauthorizedClient(t *testing.T
 	service := &MCPService{
 		db:                         db,
 		mcpProxyServer:             server.NewMCPServer("Test Proxy", "0.1.0"),
-		sseMcpProxyServer:          server.NewMCPServer("Test Proxy SSE", "0.1.0"),
 		metrics:                    telemetry.NewNoopCustomMetrics(),
 		mcpServerInitReqTimeoutSec: 10,
 		sessionManager:             sessionManager,
@@ -328,7 +326,7 @@ func TestMCPProxyResourceHandlerEnterpriseRejectsUnauthorizedClient(t *testing.T
 		AllowList: datatypes.JSON(`["other-server"]`),
 	})
 
-	_, err := service.mcpProxyResourceHandler(ctx, req)
+	_, err := service.MCPProxyResourceHandler(ctx, req)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not authorized to access MCP server test-server")
 	assert.False(t, sessionCreated)
@@ -386,7 +384,6 @@ func TestMCPProxyResourceHandlerEnterpriseAllowsAuthorizedClient(t *testing.T) {
 	service := &MCPService{
 		db:                         db,
 		mcpProxyServer:             server.NewMCPServer("Test Proxy", "0.1.0"),
-		sseMcpProxyServer:          server.NewMCPServer("Test Proxy SSE", "0.1.0"),
 		metrics:                    telemetry.NewNoopCustomMetrics(),
 		mcpServerInitReqTimeoutSec: 10,
 		sessionManager:             sessionManager,
@@ -400,7 +397,7 @@ func TestMCPProxyResourceHandlerEnterpriseAllowsAuthorizedClient(t *testing.T) {
 		AllowList: datatypes.JSON(`["test-server"]`),
 	})
 
-	contents, err := service.mcpProxyResourceHandler(ctx, req)
+	contents, err := service.MCPProxyResourceHandler(ctx, req)
 	require.NoError(t, err)
 	require.Len(t, contents, 1)
 
@@ -473,7 +470,6 @@ func TestMCPProxyResourceHandlerRoutesDuplicateUpstreamURIs(t *testing.T) {
 	service := &MCPService{
 		db:                         db,
 		mcpProxyServer:             proxyServer,
-		sseMcpProxyServer:          proxyServer,
 		metrics:                    telemetry.NewNoopCustomMetrics(),
 		mcpServerInitReqTimeoutSec: 10,
 		sessionManager:             sessionManager,
@@ -487,7 +483,7 @@ func TestMCPProxyResourceHandlerRoutesDuplicateUpstreamURIs(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, types.SessionModeStateful, resource.Server.SessionMode)
 
-	contents, err := service.mcpProxyResourceHandler(ctx, req)
+	contents, err := service.MCPProxyResourceHandler(ctx, req)
 	require.NoError(t, err)
 	require.Len(t, contents, 1)
 
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// restrictRoots returns the parts of the roots of an MCP client that lie within the roots an upstream MCP server
+// is allowed to be given.
+// A client root within an allowed root is kept as is, while a client root that contains allowed roots is narrowed
+// down to them. Client roots that are unrelated to all the allowed roots are dropped, so that clients can never give
+// a server access to more than the admin allowed.
+func restrictRoots(roots []mcp.Root, allowed []string) []mcp.Root {
+	restricted := []mcp.Root{}
+	seen := make(map[string]bool)
+	add := func(r mcp.Root) {
+		if !seen[r.URI] {
+			seen[r.URI] = true
+			restricted = append(restricted, r)
+		}
+	}
+
+	for _, root := range roots {
+		if slices.ContainsFunc(allowed, func(a string) bool { return withinRoot(root.URI, a) }) {
+			add(root)
+			continue
+		}
+		for _, a := range allowed {
+			if withinRoot(a, root.URI) {
+				add(mcp.Root{URI: a, Name: root.Name})
+			}
+		}
+	}
+	return restricted
+}
+
+// withinRoot returns true if the given URI is the root URI itself or lies beneath it.
+func withinRoot(uri, root string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	r, err := url.Parse(root)
+	if err != nil {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, r.Scheme) || !strings.EqualFold(u.Host, r.Host) {
+		return false
+	}
+	// "." and ".." segments would let a URI escape the root it appears to be under
+	if strings.Contains("/"+u.Path+"/", "/../") || strings.Contains("/"+u.Path+"/", "/./") {
+		return false
+	}
+	p := strings.TrimSuffix(u.Path, "/")
+	rp := strings.TrimSuffix(r.Path, "/")
+	return p == rp || strings.HasPrefix(p, rp+"/")
+}
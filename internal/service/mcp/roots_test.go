@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestrictRoots(t *testing.T) {
+	allowed := []string{"file:///srv/data", "file:///home/alice/projects/"}
+
+	cases := []struct {
+		name  string
+		roots []string
+		want  []string
+	}{
+		{name: "root equal to allowed root", roots: []string{"file:///srv/data/"}, want: []string{"file:///srv/data/"}},
+		{name: "root within allowed root", roots: []string{"file:///srv/data/reports"}, want: []string{"file:///srv/data/reports"}},
+		{name: "root containing allowed roots", roots: []string{"file:///"}, want: []string{"file:///srv/data", "file:///home/alice/projects/"}},
+		{name: "sibling with common prefix", roots: []string{"file:///srv/database"}, want: []string{}},
+		{name: "unrelated root", roots: []string{"file:///etc"}, want: []string{}},
+		{name: "escaping root", roots: []string{"file:///srv/data/../../etc"}, want: []string{}},
+		{name: "other scheme", roots: []string{"https:///srv/data"}, want: []string{}},
+		{
+			name:  "duplicates",
+			roots: []string{"file:///srv", "file:///srv/data", "file:///srv/data/reports"},
+			want:  []string{"file:///srv/data", "file:///srv/data/reports"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			roots := make([]mcp.Root, len(tc.roots))
+			for i, uri := range tc.roots {
+				roots[i] = mcp.Root{URI: uri}
+			}
+
+			got := []string{}
+			for _, r := range restrictRoots(roots, allowed) {
+				got = append(got, r.URI)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// workspace answers the roots requests relayed to a downstream MCP client.
+type workspace []string
+
+func (w workspace) ListRoots(context.Context, mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
+	res := &mcp.ListRootsResult{}
+	for _, uri := range w {
+		res.Roots = append(res.Roots, mcp.Root{URI: uri})
+	}
+	return res, nil
+}
+
+func TestMCPProxyToolCallHandler_RelaysRoots(t *testing.T) {
+	cases := []struct {
+		sessionMode  types.SessionMode
+		allowedRoots []string
+		want         string
+		wantError    string
+	}{
+		{sessionMode: types.SessionModeStateless, allowedRoots: []string{"file:///srv/data"}, want: "file:///srv/data/reports"},
+		{sessionMode: types.SessionModeStateful, allowedRoots: []string{"file:///srv/data"}, want: "file:///srv/data/reports"},
+		{sessionMode: types.SessionModeStateless, wantError: "the client does not support roots"},
+		{sessionMode: types.SessionModeStateful, wantError: "the client does not support roots"},
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s allowed=%v", tc.sessionMode, tc.allowedRoots), func(t *testing.T) {
+			db := setupTestDBForProxyAdditional(t)
+
+			upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", mcpserver.WithToolCapabilities(true))
+			upstream.AddTool(
+				mcp.NewTool("list_roots"),
+				func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					session := mcpserver.ClientSessionFromContext(ctx).(mcpserver.SessionWithClientInfo)
+					if session.GetClientCapabilities().Roots == nil {
+						return mcp.NewToolResultError("the client does not support roots"), nil
+					}
+					res, err := mcpserver.ServerFromContext(ctx).RequestRoots(ctx, mcp.ListRootsRequest{})
+					if err != nil {
+						return mcp.NewToolResultError("listing roots failed: " + err.Error()), nil
+					}
+					uris := make([]string, len(res.Roots))
+					for i, r := range res.Roots {
+						uris[i] = r.URI
+					}
+					return mcp.NewToolResultText(strings.Join(uris, ",")), nil
+				},
+			)
+			upstreamHTTP := newUpstreamStreamableHTTPServer(t, upstream)
+			defer upstreamHTTP.Close()
+
+			srv := createStreamableHTTPTestServer(t, "files", upstreamHTTP.URL)
+			srv.SessionMode = tc.sessionMode
+			require.NoError(t, srv.SetAllowedRoots(tc.allowedRoots))
+			require.NoError(t, db.Create(srv).Error)
+
+			service := &MCPService{
+				db:                         db,
+				metrics:                    telemetry.NewNoopCustomMetrics(),
+				mcpServerInitReqTimeoutSec: 5,
+				sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+			}
+			defer service.sessionManager.Shutdown()
+
+			proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithToolCapabilities(true))
+			proxy.AddTool(mcp.NewTool("files__list_roots"), service.MCPProxyToolCallHandler)
+			proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(
+				proxy,
+				mcpserver.WithHTTPContextFunc(func(ctx context.Context, r *http.Request) context.Context {
+					return context.WithValue(ctx, "mode", model.ModeDev)
+				}),
+			))
+			defer proxyHTTP.Close()
+
+			trans, err := transport.NewStreamableHTTP(proxyHTTP.URL, transport.WithContinuousListening())
+			require.NoError(t, err)
+			// the client works in a directory within the allowed root, and in one outside of it
+			client := mcpclient.NewClient(
+				trans, mcpclient.WithRootsHandler(workspace{"file:///srv/data/reports", "file:///etc"}),
+			)
+			defer client.Close()
+
+			ctx := context.Background()
+			require.NoError(t, client.Start(ctx))
+			initReq := mcp.InitializeRequest{}
+			initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+			initReq.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+			_, err = client.Initialize(ctx, initReq)
+			require.NoError(t, err)
+
+			req := mcp.CallToolRequest{}
+			req.Params.Name = "files__list_roots"
+			res, err := client.CallTool(ctx, req)
+			require.NoError(t, err)
+			require.Len(t, res.Content, 1)
+			text := res.Content[0].(mcp.TextContent).Text
+
+			if tc.wantError != "" {
+				assert.True(t, res.IsError)
+				assert.Contains(t, text, tc.wantError)
+				return
+			}
+			assert.False(t, res.IsError, text)
+			assert.Equal(t, tc.want, text)
+		})
+	}
+}
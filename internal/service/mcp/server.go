@@ -8,6 +8,7 @@ import (
 
 	mcpgotransport "github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
@@ -68,31 +69,19 @@ func (m *MCPService) registerMcpServer(ctx context.Context, s *model.McpServer,
 	if err := validateServerName(s.Name); err != nil {
 		return err
 	}
+	s.Namespace, _ = model.SplitQualifiedName(s.Name)
+	if err := namespace.CheckExists(m.db, s.Namespace); err != nil {
+		return err
+	}
 
 	// Upon registration, a server is always enabled. Admin can choose to disable it later.
 	s.Enabled = true
 
-	// Only validate URLs for transports that actually carry a URL in their config.
-	switch s.Transport {
-	case types.TransportStreamableHTTP:
-		conf, err := s.GetStreamableHTTPConfig()
-		if err != nil {
-			return err
-		}
-		if err := validateURL(conf.URL); err != nil {
-			return err
-		}
-	case types.TransportSSE:
-		conf, err := s.GetSSEConfig()
-		if err != nil {
-			return err
-		}
-		if err := validateURL(conf.URL); err != nil {
-			return err
-		}
+	if err := validateServerURL(s); err != nil {
+		return err
 	}
 
-	mcpClient, err := createMcpServerConnectionWithDB(
+	mcpClient, initResult, err := connectMcpServer(
 		ctx,
 		m.db,
 		s,
@@ -103,11 +92,21 @@ func (m *MCPService) registerMcpServer(ctx context.Context, s *model.McpServer,
 		return err
 	}
 	defer mcpClient.Close()
+	if err := s.SetServerInfo(newUpstreamServerInfo(initResult)); err != nil {
+		return fmt.Errorf("failed to store server info of MCP server %s: %w", s.Name, err)
+	}
+
+	if err := m.checkToolAliasesAvailable(s); err != nil {
+		return err
+	}
 
 	// register the server in the DB
 	if err := m.db.Create(s).Error; err != nil {
 		return fmt.Errorf("failed to register mcp server: %w", err)
 	}
+	m.trackServer(s)
+	// the server is in the registry from now on, even if registering some of its entities fails below
+	defer m.notifyServerChange(s.Name)
 
 	if err = m.registerServerTools(ctx, s, mcpClient); err != nil {
 		return fmt.Errorf("failed to register tools for MCP server %s: %w", s.Name, err)
@@ -123,6 +122,9 @@ func (m *MCPService) registerMcpServer(ctx context.Context, s *model.McpServer,
 		if err = m.registerServerResources(ctx, s, mcpClient); err != nil {
 			log.Printf("[WARN] failed to register resources for MCP server %s: %v", s.Name, err)
 		}
+		if err = m.registerServerResourceTemplates(ctx, s, mcpClient); err != nil {
+			log.Printf("[WARN] failed to register resource templates for MCP server %s: %v", s.Name, err)
+		}
 	}
 
 	return nil
@@ -159,6 +161,13 @@ func (m *MCPService) DeregisterMcpServer(name string) error {
 			err,
 		)
 	}
+	if err := m.deregisterServerResourceTemplates(s); err != nil {
+		return fmt.Errorf(
+			"failed to deregister resource templates for server %s, cannot proceed with server deregistration: %w",
+			name,
+			err,
+		)
+	}
 	if err := m.db.Unscoped().Delete(s).Error; err != nil {
 		return fmt.Errorf("failed to deregister server %s: %w", name, err)
 	}
@@ -172,6 +181,9 @@ func (m *MCPService) DeregisterMcpServer(name string) error {
 	// Close any stateful session associated with this server
 	m.sessionManager.CloseSession(name)
 
+	m.untrackServer(name)
+	m.notifyServerChange(name)
+
 	return nil
 }
 
@@ -269,5 +281,10 @@ func (m *MCPService) setMcpServerEnabled(name string, enabled bool) error {
 	if err := m.db.Save(server).Error; err != nil {
 		return fmt.Errorf("failed to set server %s enabled=%t: %w", name, enabled, err)
 	}
+	// resource templates cannot be enabled individually, they are served as long as their server is enabled
+	if err := m.reloadServerResourceTemplates(name, server); err != nil {
+		return err
+	}
+	m.notifyServerChange(name)
 	return nil
 }
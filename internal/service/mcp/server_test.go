@@ -29,6 +29,7 @@ func setupTestDBForServerLifecycle(t *testing.T) *gorm.DB {
 		&model.Tool{},
 		&model.Prompt{},
 		&model.Resource{},
+		&model.ResourceTemplate{},
 		&model.UpstreamOAuthToken{},
 		&model.UpstreamOAuthPendingSession{},
 	)
@@ -49,13 +50,6 @@ func newTestLifecycleService(t *testing.T, db *gorm.DB) *MCPService {
 			mcpserver.WithPromptCapabilities(true),
 			mcpserver.WithResourceCapabilities(true, true),
 		),
-		SseMcpProxyServer: mcpserver.NewMCPServer(
-			"Test Proxy SSE",
-			"0.1.0",
-			mcpserver.WithToolCapabilities(true),
-			mcpserver.WithPromptCapabilities(true),
-			mcpserver.WithResourceCapabilities(true, true),
-		),
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 5,
 	})
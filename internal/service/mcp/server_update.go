@@ -0,0 +1,524 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/mark3labs/mcp-go/client"
+	mcpgotransport "github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// entityChanges holds the DB records of the tools, prompts or resources of a server
+// that were affected by an update of the server's configuration.
+type entityChanges[T any] struct {
+	added, updated, removed []T
+}
+
+// upstreamEntities holds the entities currently provided by an upstream MCP server.
+// If listing prompts or resources failed, the corresponding sync flag is false
+// and the registered entities of that kind are left untouched.
+type upstreamEntities struct {
+	tools []mcp.Tool
+
+	prompts     []mcp.Prompt
+	syncPrompts bool
+
+	resources     []mcp.Resource
+	syncResources bool
+
+	resourceTemplates     []mcp.ResourceTemplate
+	syncResourceTemplates bool
+}
+
+// UpdateMcpServer updates the configuration of a registered MCP server in place.
+//
+// The new configuration is validated by connecting to the upstream server, then the tools, prompts and
+// resources it provides are reconciled with the registry.
+// Entities that are still provided keep their enabled state, so they also remain in the tool groups that include them.
+// New entities inherit the enabled state of the server, while entities no longer provided upstream are removed.
+// If the connection settings changed, any stateful session using the old configuration is closed.
+//
+// The name and transport of a server cannot be changed.
+// Upstream OAuth tokens stored for the server are reused, but a new OAuth authorization cannot be started by an update.
+func (m *MCPService) UpdateMcpServer(ctx context.Context, updated *model.McpServer) (*types.UpdateServerResult, error) {
+	existing, err := m.GetMcpServer(updated.Name)
+	if err != nil {
+		return nil, err
+	}
+	if updated.Transport != existing.Transport {
+		return nil, fmt.Errorf(
+			"cannot change the transport of server %s from %s to %s, deregister and register it again instead: %w",
+			existing.Name, existing.Transport, updated.Transport, apierrors.ErrInvalidInput,
+		)
+	}
+	if err := validateServerURL(updated); err != nil {
+		return nil, err
+	}
+	updated.Namespace = existing.Namespace
+	if err := m.checkToolAliasesAvailable(updated); err != nil {
+		return nil, err
+	}
+
+	mcpClient, initResult, err := connectMcpServer(ctx, m.db, updated, m.mcpServerInitReqTimeoutSec, true)
+	if err != nil {
+		if errors.Is(err, mcpgotransport.ErrUnauthorized) {
+			return nil, fmt.Errorf(
+				"upstream server %s rejected the new configuration as unauthorized, "+
+					"register it again with force to re-authorize with OAuth: %w",
+				updated.Name, errors.Join(err, apierrors.ErrInvalidInput),
+			)
+		}
+		return nil, fmt.Errorf("failed to connect to MCP server %s with the new configuration: %w", updated.Name, err)
+	}
+	defer mcpClient.Close()
+	// the server may describe itself differently with the new configuration, eg- when it was upgraded
+	if err := updated.SetServerInfo(newUpstreamServerInfo(initResult)); err != nil {
+		return nil, fmt.Errorf("failed to store server info of MCP server %s: %w", updated.Name, err)
+	}
+
+	upstream, err := fetchUpstreamEntities(ctx, updated.Name, mcpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// whether roots are shared with the server is negotiated when connecting to it
+	connectionChanged := !sameJSON(existing.Config, updated.Config) || existing.SessionMode != updated.SessionMode ||
+		!sameJSON(existing.AllowedRoots, updated.AllowedRoots)
+
+	var (
+		toolChanges     entityChanges[model.Tool]
+		promptChanges   entityChanges[model.Prompt]
+		resourceChanges entityChanges[model.Resource]
+	)
+	err = m.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(existing).Select("Description", "Config", "SessionMode", "Labels", "ToolOverrides", "AllowedRoots", "LogLevel", "ServerInfo").Updates(updated).Error
+		if err != nil {
+			return fmt.Errorf("failed to update configuration of server %s: %w", existing.Name, err)
+		}
+		if toolChanges, err = reconcileServerTools(tx, existing, upstream.tools); err != nil {
+			return err
+		}
+		if upstream.syncPrompts {
+			if promptChanges, err = reconcileServerPrompts(tx, existing, upstream.prompts); err != nil {
+				return err
+			}
+		}
+		if upstream.syncResources {
+			if resourceChanges, err = reconcileServerResources(tx, existing, upstream.resources); err != nil {
+				return err
+			}
+		}
+		if upstream.syncResourceTemplates {
+			return reconcileServerResourceTemplates(tx, existing, upstream.resourceTemplates)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &types.UpdateServerResult{
+		Tools:     m.applyToolChanges(existing, toolChanges),
+		Prompts:   m.applyPromptChanges(existing, promptChanges),
+		Resources: m.applyResourceChanges(existing, resourceChanges),
+	}
+	if err := m.reloadServerResourceTemplates(existing.Name, existing); err != nil {
+		log.Printf("[ERROR] failed to reload resource templates of MCP server %s: %v", existing.Name, err)
+	}
+
+	// tool groups may select the server's tools by its labels, and expose them with its tool overrides
+	existing.Labels = updated.Labels
+	existing.ToolOverrides = updated.ToolOverrides
+	if labelsChanged, overridesChanged := m.trackServer(existing); overridesChanged {
+		m.reserveServerTools(existing)
+	} else if labelsChanged {
+		m.reevaluateServerTools(existing.Name)
+	}
+
+	if connectionChanged && m.sessionManager.HasSession(existing.Name) {
+		m.sessionManager.CloseSession(existing.Name)
+		result.SessionClosed = true
+	}
+	if connectionChanged {
+		m.renewResourceSubscriptions(existing)
+	}
+
+	m.notifyServerChange(existing.Name)
+
+	return result, nil
+}
+
+// fetchUpstreamEntities lists the tools, prompts, resources and resource templates provided by an upstream MCP server.
+// Listing tools is required, while the other entities are best-effort, like during registration.
+func fetchUpstreamEntities(ctx context.Context, name string, c *client.Client) (*upstreamEntities, error) {
+	toolsResp, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tools from MCP server %s: %w", name, err)
+	}
+	entities := &upstreamEntities{
+		tools:                 toolsResp.Tools,
+		syncPrompts:           true,
+		syncResources:         true,
+		syncResourceTemplates: true,
+	}
+
+	if c.GetServerCapabilities().Prompts != nil {
+		resp, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+		if err != nil {
+			log.Printf("[WARN] failed to fetch prompts from MCP server %s, keeping the registered ones: %v", name, err)
+			entities.syncPrompts = false
+		} else {
+			entities.prompts = resp.Prompts
+		}
+	}
+	if c.GetServerCapabilities().Resources != nil {
+		resp, err := c.ListResources(ctx, mcp.ListResourcesRequest{})
+		if err != nil {
+			log.Printf("[WARN] failed to fetch resources from MCP server %s, keeping the registered ones: %v", name, err)
+			entities.syncResources = false
+		} else {
+			entities.resources = resp.Resources
+		}
+
+		templatesResp, err := c.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+		if err != nil {
+			log.Printf("[WARN] failed to fetch resource templates from MCP server %s, keeping the registered ones: %v", name, err)
+			entities.syncResourceTemplates = false
+		} else {
+			entities.resourceTemplates = templatesResp.ResourceTemplates
+		}
+	}
+	return entities, nil
+}
+
+// reconcileServerTools makes the tools of a server in the DB match the tools provided upstream.
+func reconcileServerTools(tx *gorm.DB, s *model.McpServer, upstream []mcp.Tool) (entityChanges[model.Tool], error) {
+	var changes entityChanges[model.Tool]
+
+	var current []model.Tool
+	if err := tx.Where("server_id = ?", s.ID).Find(&current).Error; err != nil {
+		return changes, fmt.Errorf("failed to list tools for server %s: %w", s.Name, err)
+	}
+	byName := make(map[string]*model.Tool, len(current))
+	for i := range current {
+		byName[current[i].Name] = &current[i]
+	}
+
+	seen := make(map[string]bool, len(upstream))
+	for _, tool := range upstream {
+		t := newToolModel(s, tool)
+		if seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+
+		old, exists := byName[t.Name]
+		if !exists {
+			t.Enabled = s.Enabled
+			if err := createWithEnabledState(tx, t, s.Enabled); err != nil {
+				return changes, fmt.Errorf("failed to register tool %s: %w", mergeServerToolNames(s.Name, t.Name), err)
+			}
+			changes.added = append(changes.added, *t)
+			continue
+		}
+		if old.Description == t.Description &&
+			sameJSON(old.InputSchema, t.InputSchema) &&
+			sameJSON(old.Annotations, t.Annotations) {
+			continue
+		}
+		err := tx.Model(old).Select("Description", "InputSchema", "Annotations").Updates(t).Error
+		if err != nil {
+			return changes, fmt.Errorf("failed to update tool %s: %w", mergeServerToolNames(s.Name, t.Name), err)
+		}
+		old.Description, old.InputSchema, old.Annotations = t.Description, t.InputSchema, t.Annotations
+		changes.updated = append(changes.updated, *old)
+	}
+
+	for i := range current {
+		if seen[current[i].Name] {
+			continue
+		}
+		if err := tx.Unscoped().Delete(&current[i]).Error; err != nil {
+			return changes, fmt.Errorf("failed to remove tool %s: %w", mergeServerToolNames(s.Name, current[i].Name), err)
+		}
+		changes.removed = append(changes.removed, current[i])
+	}
+	return changes, nil
+}
+
+// reconcileServerPrompts makes the prompts of a server in the DB match the prompts provided upstream.
+func reconcileServerPrompts(tx *gorm.DB, s *model.McpServer, upstream []mcp.Prompt) (entityChanges[model.Prompt], error) {
+	var changes entityChanges[model.Prompt]
+
+	var current []model.Prompt
+	if err := tx.Where("server_id = ?", s.ID).Find(&current).Error; err != nil {
+		return changes, fmt.Errorf("failed to list prompts for server %s: %w", s.Name, err)
+	}
+	byName := make(map[string]*model.Prompt, len(current))
+	for i := range current {
+		byName[current[i].Name] = &current[i]
+	}
+
+	seen := make(map[string]bool, len(upstream))
+	for _, prompt := range upstream {
+		p := newPromptModel(s, prompt)
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+
+		old, exists := byName[p.Name]
+		if !exists {
+			p.Enabled = s.Enabled
+			if err := createWithEnabledState(tx, p, s.Enabled); err != nil {
+				return changes, fmt.Errorf("failed to register prompt %s: %w", mergeServerPromptNames(s.Name, p.Name), err)
+			}
+			changes.added = append(changes.added, *p)
+			continue
+		}
+		if old.Description == p.Description && sameJSON(old.Arguments, p.Arguments) {
+			continue
+		}
+		if err := tx.Model(old).Select("Description", "Arguments").Updates(p).Error; err != nil {
+			return changes, fmt.Errorf("failed to update prompt %s: %w", mergeServerPromptNames(s.Name, p.Name), err)
+		}
+		old.Description, old.Arguments = p.Description, p.Arguments
+		changes.updated = append(changes.updated, *old)
+	}
+
+	for i := range current {
+		if seen[current[i].Name] {
+			continue
+		}
+		if err := tx.Unscoped().Delete(&current[i]).Error; err != nil {
+			return changes, fmt.Errorf("failed to remove prompt %s: %w", mergeServerPromptNames(s.Name, current[i].Name), err)
+		}
+		changes.removed = append(changes.removed, current[i])
+	}
+	return changes, nil
+}
+
+// reconcileServerResources makes the resources of a server in the DB match the resources provided upstream.
+// Resources are identified by their original URI.
+func reconcileServerResources(
+	tx *gorm.DB,
+	s *model.McpServer,
+	upstream []mcp.Resource,
+) (entityChanges[model.Resource], error) {
+	var changes entityChanges[model.Resource]
+
+	var current []model.Resource
+	if err := tx.Where("server_id = ?", s.ID).Find(&current).Error; err != nil {
+		return changes, fmt.Errorf("failed to list resources for server %s: %w", s.Name, err)
+	}
+	byURI := make(map[string]*model.Resource, len(current))
+	for i := range current {
+		byURI[current[i].OriginalURI] = &current[i]
+	}
+
+	seen := make(map[string]bool, len(upstream))
+	for _, resource := range upstream {
+		r := newResourceModel(s, resource)
+		if seen[r.OriginalURI] {
+			continue
+		}
+		seen[r.OriginalURI] = true
+
+		old, exists := byURI[r.OriginalURI]
+		if !exists {
+			r.Enabled = s.Enabled
+			if err := createWithEnabledState(tx, r, s.Enabled); err != nil {
+				return changes, fmt.Errorf("failed to register resource %s: %w", r.URI, err)
+			}
+			changes.added = append(changes.added, *r)
+			continue
+		}
+		if old.Name == r.Name &&
+			old.Description == r.Description &&
+			old.MIMEType == r.MIMEType &&
+			sameJSON(old.Annotations, r.Annotations) &&
+			sameJSON(old.Meta, r.Meta) {
+			continue
+		}
+		err := tx.Model(old).Select("Name", "Description", "MIMEType", "Annotations", "Meta").Updates(r).Error
+		if err != nil {
+			return changes, fmt.Errorf("failed to update resource %s: %w", r.URI, err)
+		}
+		old.Name, old.Description, old.MIMEType = r.Name, r.Description, r.MIMEType
+		old.Annotations, old.Meta = r.Annotations, r.Meta
+		changes.updated = append(changes.updated, *old)
+	}
+
+	for i := range current {
+		if seen[current[i].OriginalURI] {
+			continue
+		}
+		if err := tx.Unscoped().Delete(&current[i]).Error; err != nil {
+			return changes, fmt.Errorf("failed to remove resource %s: %w", current[i].URI, err)
+		}
+		changes.removed = append(changes.removed, current[i])
+	}
+	return changes, nil
+}
+
+// createWithEnabledState creates a tool, prompt or resource record with the given enabled state.
+// The enabled column defaults to true, so gorm skips a false value on create and it must be set afterwards.
+func createWithEnabledState(tx *gorm.DB, record any, enabled bool) error {
+	if err := tx.Create(record).Error; err != nil {
+		return err
+	}
+	if enabled {
+		return nil
+	}
+	return tx.Model(record).Update("enabled", false).Error
+}
+
+// applyToolChanges brings the MCP proxy server and tool groups in line with the tool changes of an update.
+// It returns the canonical names of the affected tools.
+func (m *MCPService) applyToolChanges(s *model.McpServer, changes entityChanges[model.Tool]) types.ServerEntityChanges {
+	proxy := m.mcpProxyServer
+	var result types.ServerEntityChanges
+
+	if len(changes.removed) > 0 {
+		names := make([]string, len(changes.removed))
+		for i, t := range changes.removed {
+			names[i] = mergeServerToolNames(s.Name, t.Name)
+		}
+		m.DeleteProxyTools(proxy, names...)
+		m.deleteToolInstances(names...)
+		m.notifyToolDeletion(names...)
+		result.Removed = names
+	}
+
+	publish := func(t *model.Tool) string {
+		name := mergeServerToolNames(s.Name, t.Name)
+		if !t.Enabled {
+			// disabled tools are not served by the proxy, their new definition is served once enabled
+			return name
+		}
+		tool, err := convertToolModelToMcpObject(t)
+		if err != nil {
+			log.Printf("[ERROR] failed to convert tool model to MCP object for tool %s: %v", name, err)
+			return name
+		}
+		tool.Name = name
+		m.serveProxyTool(proxy, tool)
+		m.addToolInstance(tool)
+		// groups that include the tool pick up the new definition as well
+		m.notifyToolAddition(name)
+		return name
+	}
+	for i := range changes.added {
+		result.Added = append(result.Added, publish(&changes.added[i]))
+	}
+	for i := range changes.updated {
+		result.Updated = append(result.Updated, publish(&changes.updated[i]))
+	}
+	return result
+}
+
+// applyPromptChanges brings the MCP proxy server and tool groups in line with the prompt changes of an update.
+// It returns the canonical names of the affected prompts.
+func (m *MCPService) applyPromptChanges(s *model.McpServer, changes entityChanges[model.Prompt]) types.ServerEntityChanges {
+	proxy := m.mcpProxyServer
+	var result types.ServerEntityChanges
+
+	if len(changes.removed) > 0 {
+		names := make([]string, len(changes.removed))
+		for i, p := range changes.removed {
+			names[i] = mergeServerPromptNames(s.Name, p.Name)
+		}
+		proxy.DeletePrompts(names...)
+		m.deletePromptInstances(names...)
+		m.notifyPromptDeletion(names...)
+		result.Removed = names
+	}
+
+	publish := func(p *model.Prompt) string {
+		name := mergeServerPromptNames(s.Name, p.Name)
+		if !p.Enabled {
+			return name
+		}
+		prompt, err := convertPromptModelToMcpObject(p)
+		if err != nil {
+			log.Printf("[ERROR] failed to convert prompt model to MCP object for prompt %s: %v", name, err)
+			return name
+		}
+		prompt.Name = name
+		proxy.AddPrompt(prompt, m.MCPProxyPromptHandler)
+		m.addPromptInstance(prompt)
+		m.notifyPromptAddition(prompt.Name)
+		return name
+	}
+	for i := range changes.added {
+		result.Added = append(result.Added, publish(&changes.added[i]))
+	}
+	for i := range changes.updated {
+		result.Updated = append(result.Updated, publish(&changes.updated[i]))
+	}
+	return result
+}
+
+// applyResourceChanges brings the MCP proxy server and tool groups in line with the resource changes of an update.
+// It returns the mcpjungle URIs of the affected resources.
+func (m *MCPService) applyResourceChanges(
+	s *model.McpServer,
+	changes entityChanges[model.Resource],
+) types.ServerEntityChanges {
+	proxy := m.mcpProxyServer
+	var result types.ServerEntityChanges
+
+	if len(changes.removed) > 0 {
+		uris := make([]string, len(changes.removed))
+		for i, r := range changes.removed {
+			uris[i] = r.URI
+		}
+		proxy.DeleteResources(uris...)
+		m.deleteResourceInstances(uris...)
+		m.notifyResourceDeletion(uris...)
+		result.Removed = uris
+	}
+
+	publish := func(r *model.Resource) string {
+		if !r.Enabled {
+			return r.URI
+		}
+		resource, err := convertResourceModelToMcpObject(r)
+		if err != nil {
+			log.Printf("[ERROR] failed to convert resource model to MCP object for resource %s: %v", r.URI, err)
+			return r.URI
+		}
+		resource.Name = mergeServerResourceNames(s.Name, resource.Name)
+		proxy.AddResource(resource, m.MCPProxyResourceHandler)
+		m.addResourceInstance(resource)
+		m.notifyResourceAddition(resource.URI)
+		return r.URI
+	}
+	for i := range changes.added {
+		result.Added = append(result.Added, publish(&changes.added[i]))
+	}
+	for i := range changes.updated {
+		result.Updated = append(result.Updated, publish(&changes.updated[i]))
+	}
+	return result
+}
+
+// sameJSON reports whether two JSON documents are semantically equal.
+// Stored JSON may be normalized by the database (eg- postgres jsonb), so the raw bytes cannot be compared.
+func sameJSON(a, b []byte) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,229 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUpdateTestUpstream(t *testing.T, tools map[string]string, withPrompt bool) *mcpserver.MCPServer {
+	t.Helper()
+
+	opts := []mcpserver.ServerOption{mcpserver.WithToolCapabilities(true)}
+	if withPrompt {
+		opts = append(opts, mcpserver.WithPromptCapabilities(true))
+	}
+	upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", opts...)
+	for name, description := range tools {
+		upstream.AddTool(
+			mcp.NewTool(name, mcp.WithDescription(description), mcp.WithString("msg")),
+			func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("ok"), nil
+			},
+		)
+	}
+	if withPrompt {
+		upstream.AddPrompt(
+			mcp.NewPrompt("review"),
+			func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+				return &mcp.GetPromptResult{}, nil
+			},
+		)
+	}
+	return upstream
+}
+
+func TestUpdateMcpServer_ReconcilesEntitiesAndPreservesState(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	var added []string
+	var deleted []string
+	service.SetToolAdditionCallback(func(name string) error {
+		added = append(added, name)
+		return nil
+	})
+	service.SetToolDeletionCallback(func(names ...string) {
+		deleted = append(deleted, names...)
+	})
+
+	oldUpstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{
+		"echo": "Echo a message",
+		"sum":  "Add numbers",
+		"ping": "Ping",
+	}, true))
+	defer oldUpstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "Calculator", oldUpstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	require.NoError(t, service.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+	registered, err := service.GetMcpServer("calc")
+	require.NoError(t, err)
+
+	_, err = service.DisableTools("calc__echo")
+	require.NoError(t, err)
+	added, deleted = nil, nil
+
+	newUpstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{
+		"echo": "Echo a message back",
+		"ping": "Ping",
+		"mul":  "Multiply numbers",
+	}, false))
+	defer newUpstream.Close()
+
+	updated, err := model.NewStreamableHTTPServer(
+		"calc", "Calculator v2", newUpstream.URL, "secret", nil, types.SessionModeStateless,
+	)
+	require.NoError(t, err)
+
+	result, err := service.UpdateMcpServer(context.Background(), updated)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"calc__mul"}, result.Tools.Added)
+	assert.Equal(t, []string{"calc__sum"}, result.Tools.Removed)
+	assert.Equal(t, []string{"calc__echo"}, result.Tools.Updated)
+	assert.Equal(t, []string{"calc__review"}, result.Prompts.Removed)
+	assert.True(t, result.Resources.IsEmpty())
+	assert.False(t, result.SessionClosed)
+
+	// the server keeps its identity and receives the new configuration
+	server, err := service.GetMcpServer("calc")
+	require.NoError(t, err)
+	assert.Equal(t, registered.ID, server.ID)
+	assert.Equal(t, "Calculator v2", server.Description)
+	conf, err := server.GetStreamableHTTPConfig()
+	require.NoError(t, err)
+	assert.Equal(t, newUpstream.URL, conf.URL)
+	assert.Equal(t, "secret", conf.BearerToken)
+
+	// the disabled tool stays disabled but receives its new definition
+	echo, err := service.GetTool("calc__echo")
+	require.NoError(t, err)
+	assert.False(t, echo.Enabled)
+	assert.Equal(t, "Echo a message back", echo.Description)
+
+	_, err = service.GetTool("calc__sum")
+	assert.True(t, errors.Is(err, apierrors.ErrNotFound))
+
+	proxyClient := newInitializedInProcessClient(t, service.mcpProxyServer)
+	toolList, err := proxyClient.ListTools(context.Background(), mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	var proxied []string
+	for _, tool := range toolList.Tools {
+		proxied = append(proxied, tool.Name)
+	}
+	assert.ElementsMatch(t, []string{"calc__ping", "calc__mul"}, proxied)
+
+	// unchanged tools are not re-announced to tool groups
+	assert.Equal(t, []string{"calc__mul"}, added)
+	assert.Equal(t, []string{"calc__sum"}, deleted)
+}
+
+func TestUpdateMcpServer_NewEntitiesOfDisabledServerAreDisabled(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	upstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{"echo": "Echo"}, false))
+	defer upstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", upstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	require.NoError(t, service.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+	_, _, err = service.DisableMcpServer("calc")
+	require.NoError(t, err)
+
+	newUpstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{
+		"echo": "Echo",
+		"mul":  "Multiply",
+	}, false))
+	defer newUpstream.Close()
+
+	updated, err := model.NewStreamableHTTPServer("calc", "", newUpstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	result, err := service.UpdateMcpServer(context.Background(), updated)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"calc__mul"}, result.Tools.Added)
+
+	mul, err := service.GetTool("calc__mul")
+	require.NoError(t, err)
+	assert.False(t, mul.Enabled)
+
+	server, err := service.GetMcpServer("calc")
+	require.NoError(t, err)
+	assert.False(t, server.Enabled)
+}
+
+func TestUpdateMcpServer_ClosesStaleStatefulSession(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	upstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{"echo": "Echo"}, false))
+	defer upstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", upstream.URL, "", nil, types.SessionModeStateful)
+	require.NoError(t, err)
+	require.NoError(t, service.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+
+	registered, err := service.GetMcpServer("calc")
+	require.NoError(t, err)
+	_, err = service.sessionManager.GetOrCreateSession(context.Background(), registered)
+	require.NoError(t, err)
+	require.True(t, service.sessionManager.HasSession("calc"))
+
+	// a description-only change keeps the session
+	sameConn, err := model.NewStreamableHTTPServer("calc", "new description", upstream.URL, "", nil, types.SessionModeStateful)
+	require.NoError(t, err)
+	result, err := service.UpdateMcpServer(context.Background(), sameConn)
+	require.NoError(t, err)
+	assert.False(t, result.SessionClosed)
+	assert.True(t, service.sessionManager.HasSession("calc"))
+
+	newConn, err := model.NewStreamableHTTPServer(
+		"calc", "new description", upstream.URL, "", map[string]string{"X-Team": "a"}, types.SessionModeStateful,
+	)
+	require.NoError(t, err)
+	result, err = service.UpdateMcpServer(context.Background(), newConn)
+	require.NoError(t, err)
+	assert.True(t, result.SessionClosed)
+	assert.False(t, service.sessionManager.HasSession("calc"))
+}
+
+func TestUpdateMcpServer_RejectsInvalidUpdates(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	upstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{"echo": "Echo"}, false))
+	defer upstream.Close()
+
+	missing, err := model.NewStreamableHTTPServer("missing", "", upstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	_, err = service.UpdateMcpServer(context.Background(), missing)
+	assert.True(t, errors.Is(err, apierrors.ErrNotFound))
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", upstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	require.NoError(t, service.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+
+	sse, err := model.NewSSEServer("calc", "", upstream.URL, "", types.SessionModeStateless)
+	require.NoError(t, err)
+	_, err = service.UpdateMcpServer(context.Background(), sse)
+	assert.True(t, errors.Is(err, apierrors.ErrInvalidInput))
+
+	badURL, err := model.NewStreamableHTTPServer("calc", "", "not-a-url", "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	_, err = service.UpdateMcpServer(context.Background(), badURL)
+	assert.True(t, errors.Is(err, apierrors.ErrInvalidInput))
+
+	// a failed update leaves the registered server untouched
+	tool, err := service.GetTool("calc__echo")
+	require.NoError(t, err)
+	assert.True(t, tool.Enabled)
+}
@@ -0,0 +1,255 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/client"
+	mcpgotransport "github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ValidateMcpServer performs a dry-run registration of an MCP server.
+//
+// It runs the same checks as a registration and connects to the upstream server to discover
+// the tools, prompts, resources and resource templates it provides, but nothing is written to the DB.
+// If force is true, an existing server with the same name is reported as being replaced instead of as an error.
+//
+// Problems that would make the registration fail are reported in the returned report,
+// an error is only returned if the registry itself could not be checked.
+func (m *MCPService) ValidateMcpServer(
+	ctx context.Context,
+	s *model.McpServer,
+	force bool,
+) (*types.ServerValidationReport, error) {
+	report := &types.ServerValidationReport{
+		Name:              s.Name,
+		Transport:         string(s.Transport),
+		Tools:             []types.ValidatedEntity{},
+		Prompts:           []types.ValidatedEntity{},
+		Resources:         []types.ValidatedResource{},
+		ResourceTemplates: []types.ValidatedResourceTemplate{},
+	}
+
+	if err := validateServerName(s.Name); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	} else {
+		ns, _ := model.SplitQualifiedName(s.Name)
+		if err := namespace.CheckExists(m.db, ns); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+		}
+	}
+	if err := validateServerURL(s); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	existing, err := m.GetMcpServer(s.Name)
+	switch {
+	case err == nil:
+		if force {
+			report.Warnings = append(report.Warnings, fmt.Sprintf(
+				"server %s is already registered and will be replaced, "+
+					"its tools, prompts and resources are registered again in the enabled state",
+				s.Name,
+			))
+		} else {
+			report.Errors = append(report.Errors, fmt.Sprintf(
+				"server %s is already registered, register it with force to replace it "+
+					"or use 'update server' to update it in place",
+				s.Name,
+			))
+		}
+	case errors.Is(err, apierrors.ErrNotFound):
+		existing = nil
+	default:
+		return nil, fmt.Errorf("failed to check for existing server %s: %w", s.Name, err)
+	}
+
+	if len(report.Errors) > 0 {
+		// the upstream server is not contacted if the registration would be rejected anyway,
+		// this also avoids starting the command of an stdio server with an invalid configuration.
+		return report, nil
+	}
+
+	mcpClient, initResult, err := connectMcpServer(ctx, m.db, s, m.mcpServerInitReqTimeoutSec, false)
+	if err != nil {
+		if errors.Is(err, mcpgotransport.ErrUnauthorized) &&
+			(s.Transport == types.TransportStreamableHTTP || s.Transport == types.TransportSSE) {
+			report.AuthorizationRequired = true
+			report.Warnings = append(report.Warnings, fmt.Sprintf(
+				"upstream server %s requires OAuth authorization, "+
+					"its capabilities can only be discovered once the authorization is completed during registration",
+				s.Name,
+			))
+			report.Valid = true
+			return report, nil
+		}
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to connect to MCP server %s: %v", s.Name, err))
+		return report, nil
+	}
+	defer mcpClient.Close()
+
+	report.ServerInfo = newUpstreamServerInfo(initResult)
+
+	if err := m.discoverServerEntities(ctx, s, mcpClient, report); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report, nil
+	}
+	if existing != nil {
+		if err := m.reportRemovedTools(existing, report); err != nil {
+			return nil, err
+		}
+	}
+
+	report.Valid = true
+	return report, nil
+}
+
+// discoverServerEntities lists the entities provided by an upstream MCP server into the validation report.
+// Like during registration, listing tools is required while the other entities are best-effort.
+func (m *MCPService) discoverServerEntities(
+	ctx context.Context,
+	s *model.McpServer,
+	c *client.Client,
+	report *types.ServerValidationReport,
+) error {
+	toolsResp, err := c.ListTools(ctx, mcp.ListToolsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch tools from MCP server %s: %w", s.Name, err)
+	}
+	seen := make(map[string]bool, len(toolsResp.Tools))
+	for _, tool := range toolsResp.Tools {
+		name := mergeServerToolNames(s.Name, tool.GetName())
+		if seen[name] {
+			report.Warnings = append(
+				report.Warnings, fmt.Sprintf("upstream server provides multiple tools named %s", tool.GetName()),
+			)
+			continue
+		}
+		seen[name] = true
+		report.Tools = append(report.Tools, types.ValidatedEntity{Name: name, Description: tool.Description})
+	}
+
+	capabilities := c.GetServerCapabilities()
+	if capabilities.Prompts != nil {
+		resp, err := c.ListPrompts(ctx, mcp.ListPromptsRequest{})
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("failed to fetch prompts, they will not be registered: %v", err))
+		} else {
+			seen := make(map[string]bool, len(resp.Prompts))
+			for _, prompt := range resp.Prompts {
+				name := mergeServerPromptNames(s.Name, prompt.GetName())
+				if seen[name] {
+					report.Warnings = append(
+						report.Warnings, fmt.Sprintf("upstream server provides multiple prompts named %s", prompt.GetName()),
+					)
+					continue
+				}
+				seen[name] = true
+				report.Prompts = append(report.Prompts, types.ValidatedEntity{Name: name, Description: prompt.Description})
+			}
+		}
+	}
+	if capabilities.Resources != nil {
+		resp, err := c.ListResources(ctx, mcp.ListResourcesRequest{})
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("failed to fetch resources, they will not be registered: %v", err))
+		} else {
+			seen := make(map[string]bool, len(resp.Resources))
+			for _, resource := range resp.Resources {
+				if seen[resource.URI] {
+					report.Warnings = append(
+						report.Warnings, fmt.Sprintf("upstream server provides multiple resources with URI %s", resource.URI),
+					)
+					continue
+				}
+				seen[resource.URI] = true
+				report.Resources = append(report.Resources, types.ValidatedResource{
+					URI:         buildResourceURI(s.Name, resource.URI),
+					OriginalURI: resource.URI,
+					Name:        resource.GetName(),
+					MIMEType:    resource.MIMEType,
+				})
+			}
+		}
+
+		templatesResp, err := c.ListResourceTemplates(ctx, mcp.ListResourceTemplatesRequest{})
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("failed to fetch resource templates: %v", err))
+		} else {
+			for _, template := range templatesResp.ResourceTemplates {
+				var uriTemplate string
+				if template.URITemplate != nil {
+					uriTemplate = template.URITemplate.Raw()
+				}
+				report.ResourceTemplates = append(report.ResourceTemplates, types.ValidatedResourceTemplate{
+					URITemplate: uriTemplate,
+					Name:        template.GetName(),
+					Description: template.Description,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// reportRemovedTools warns about the tools of an existing server that would be replaced
+// but are not provided by the upstream server anymore, so they would disappear from the registry.
+func (m *MCPService) reportRemovedTools(existing *model.McpServer, report *types.ServerValidationReport) error {
+	registered, err := m.ListToolsByServer(existing.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list tools of existing server %s: %w", existing.Name, err)
+	}
+	provided := make(map[string]bool, len(report.Tools))
+	for _, t := range report.Tools {
+		provided[t.Name] = true
+	}
+	for _, t := range registered {
+		// the listed tools already have their canonical names
+		if !provided[t.Name] {
+			report.Warnings = append(
+				report.Warnings, fmt.Sprintf("registered tool %s is not provided anymore and will be removed", t.Name),
+			)
+		}
+	}
+	return nil
+}
+
+// newUpstreamServerInfo converts the initialization result of an upstream MCP server into its API representation.
+func newUpstreamServerInfo(r *mcp.InitializeResult) *types.UpstreamServerInfo {
+	if r == nil {
+		return nil
+	}
+	info := &types.UpstreamServerInfo{
+		Name:            r.ServerInfo.Name,
+		Version:         r.ServerInfo.Version,
+		ProtocolVersion: r.ProtocolVersion,
+		Instructions:    r.Instructions,
+		Capabilities:    []string{},
+	}
+	c := r.Capabilities
+	for _, capability := range []struct {
+		name       string
+		advertised bool
+	}{
+		{"tools", c.Tools != nil},
+		{"prompts", c.Prompts != nil},
+		{"resources", c.Resources != nil},
+		{"logging", c.Logging != nil},
+		{"completions", c.Completions != nil},
+		{"sampling", c.Sampling != nil},
+		{"elicitation", c.Elicitation != nil},
+		{"roots", c.Roots != nil},
+		{"tasks", c.Tasks != nil},
+	} {
+		if capability.advertised {
+			info.Capabilities = append(info.Capabilities, capability.name)
+		}
+	}
+	return info
+}
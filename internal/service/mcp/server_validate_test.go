@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMcpServer_DiscoversCapabilitiesWithoutRegistering(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	upstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{"echo": "Echo a message"}, true))
+	defer upstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", upstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+
+	report, err := service.ValidateMcpServer(context.Background(), srv, false)
+	require.NoError(t, err)
+
+	assert.True(t, report.Valid)
+	assert.Empty(t, report.Errors)
+	require.NotNil(t, report.ServerInfo)
+	assert.Equal(t, "Upstream", report.ServerInfo.Name)
+	assert.Equal(t, "0.1.0", report.ServerInfo.Version)
+	assert.NotEmpty(t, report.ServerInfo.ProtocolVersion)
+	assert.Contains(t, report.ServerInfo.Capabilities, "tools")
+	assert.Contains(t, report.ServerInfo.Capabilities, "prompts")
+	assert.Equal(t, []types.ValidatedEntity{{Name: "calc__echo", Description: "Echo a message"}}, report.Tools)
+	assert.Equal(t, []types.ValidatedEntity{{Name: "calc__review"}}, report.Prompts)
+
+	// nothing is written to the registry
+	var servers, tools int64
+	require.NoError(t, db.Model(&model.McpServer{}).Count(&servers).Error)
+	require.NoError(t, db.Model(&model.Tool{}).Count(&tools).Error)
+	assert.Zero(t, servers)
+	assert.Zero(t, tools)
+}
+
+func TestValidateMcpServer_ReportsCollisionsWithExistingServer(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	oldUpstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{
+		"echo": "Echo",
+		"sum":  "Add numbers",
+	}, false))
+	defer oldUpstream.Close()
+
+	srv, err := model.NewStreamableHTTPServer("calc", "", oldUpstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	require.NoError(t, service.RegisterMcpServerWithOAuthSupport(context.Background(), &types.RegisterServerInput{}, srv, false, ""))
+
+	newUpstream := newUpstreamStreamableHTTPServer(t, newUpdateTestUpstream(t, map[string]string{"echo": "Echo"}, false))
+	defer newUpstream.Close()
+
+	replacement, err := model.NewStreamableHTTPServer("calc", "", newUpstream.URL, "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+
+	report, err := service.ValidateMcpServer(context.Background(), replacement, false)
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "already registered")
+	assert.Empty(t, report.Tools)
+
+	report, err = service.ValidateMcpServer(context.Background(), replacement, true)
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, []types.ValidatedEntity{{Name: "calc__echo", Description: "Echo"}}, report.Tools)
+	require.Len(t, report.Warnings, 2)
+	assert.Contains(t, report.Warnings[0], "will be replaced")
+	assert.Contains(t, report.Warnings[1], "calc__sum is not provided anymore")
+
+	// the registered server is left untouched
+	tools, err := service.ListToolsByServer("calc")
+	require.NoError(t, err)
+	assert.Len(t, tools, 2)
+}
+
+func TestValidateMcpServer_ReportsInvalidConfigurations(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	service := newTestLifecycleService(t, db)
+
+	badName, err := model.NewStreamableHTTPServer("calc__v2", "", "http://127.0.0.1:1/mcp", "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	report, err := service.ValidateMcpServer(context.Background(), badName, false)
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "invalid server name")
+	assert.Nil(t, report.ServerInfo)
+
+	unreachable, err := model.NewStreamableHTTPServer("calc", "", "http://127.0.0.1:1/mcp", "", nil, types.SessionModeStateless)
+	require.NoError(t, err)
+	report, err = service.ValidateMcpServer(context.Background(), unreachable, false)
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "failed to connect to MCP server calc")
+}
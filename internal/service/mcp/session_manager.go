@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -28,6 +30,14 @@ type ManagedSession struct {
 	Client     *client.Client
 	CreatedAt  time.Time
 	LastUsedAt time.Time
+
+	// config and sessionMode are the configuration of the server the session was created with,
+	// so the session can be recognized as stale once the configuration changes.
+	config      datatypes.JSON
+	sessionMode types.SessionMode
+
+	// logLevel is the log level last applied to the upstream server through the session, if any.
+	logLevel string
 }
 
 // SessionManager manages persistent connections to MCP servers configured in stateful mode.
@@ -91,6 +101,10 @@ func (sm *SessionManager) GetOrCreateSession(ctx context.Context, server *model.
 	// Check if we have an existing session
 	if session, exists := sm.sessions[server.Name]; exists {
 		session.LastUsedAt = time.Now()
+		if server.LogLevel != session.logLevel {
+			// the server's log level was changed since it was last applied
+			sm.applyLogLevel(ctx, session, server)
+		}
 		return session.Client, nil
 	}
 
@@ -100,11 +114,18 @@ func (sm *SessionManager) GetOrCreateSession(ctx context.Context, server *model.
 		return nil, fmt.Errorf("failed to create session for server '%s': %w", server.Name, err)
 	}
 
-	sm.sessions[server.Name] = &ManagedSession{
+	session := &ManagedSession{
 		ServerName: server.Name,
 		Client:     mcpClient,
 		CreatedAt:  time.Now(),
 		LastUsedAt: time.Now(),
+
+		config:      server.Config,
+		sessionMode: server.SessionMode,
+	}
+	sm.sessions[server.Name] = session
+	if server.LogLevel != "" {
+		sm.applyLogLevel(ctx, session, server)
 	}
 
 	log.Printf("[SessionManager] Created new stateful session for server '%s'", server.Name)
@@ -112,6 +133,16 @@ func (sm *SessionManager) GetOrCreateSession(ctx context.Context, server *model.
 	return mcpClient, nil
 }
 
+// applyLogLevel sets the log level of the upstream server of a session to the server's configured level.
+// Failures are only logged, since the session remains usable with the server's default level.
+// A level that is unset after being applied is left in place upstream, as MCP has no way to reset it.
+func (sm *SessionManager) applyLogLevel(ctx context.Context, session *ManagedSession, server *model.McpServer) {
+	session.logLevel = server.LogLevel
+	if err := setUpstreamLogLevel(ctx, server, session.Client); err != nil {
+		log.Printf("[SessionManager] [WARN] %v", err)
+	}
+}
+
 // CloseSession closes and removes the session for the given server.
 func (sm *SessionManager) CloseSession(serverName string) {
 	sm.mu.Lock()
@@ -128,6 +159,30 @@ func (sm *SessionManager) CloseSession(serverName string) {
 	}
 }
 
+// CloseStaleSession closes the session for the given server if it was created with a different
+// configuration than the server's current one, eg- after the server was updated by another replica.
+// It returns true if a session was closed.
+func (sm *SessionManager) CloseStaleSession(server *model.McpServer) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session, exists := sm.sessions[server.Name]
+	if !exists {
+		return false
+	}
+	if server.SessionMode == session.sessionMode && sameJSON(server.Config, session.config) {
+		return false
+	}
+	if session.Client != nil {
+		if err := session.Client.Close(); err != nil {
+			log.Printf("[SessionManager] Error closing stale session for server '%s': %v", server.Name, err)
+		}
+	}
+	delete(sm.sessions, server.Name)
+	log.Printf("[SessionManager] Closed stale session for server '%s'", server.Name)
+	return true
+}
+
 // InvalidateSession closes and removes a session due to a detected error.
 // This is called reactively when a connection error is detected during a tool call.
 // The next call to GetOrCreateSession will create a fresh session.
@@ -239,14 +294,38 @@ func createMcpServerConnectionWithDB(
 	initReqTimeoutSec int,
 	useStoredUpstreamAuth bool,
 ) (*client.Client, error) {
+	c, _, err := connectMcpServer(ctx, db, s, initReqTimeoutSec, useStoredUpstreamAuth)
+	return c, err
+}
+
+// connectMcpServer creates and initializes a connection to an upstream MCP server.
+// It also returns the server's response to the initialize request, which describes the server.
+func connectMcpServer(
+	ctx context.Context,
+	db *gorm.DB,
+	s *model.McpServer,
+	initReqTimeoutSec int,
+	useStoredUpstreamAuth bool,
+) (*client.Client, *mcp.InitializeResult, error) {
+	var (
+		c      *client.Client
+		result *mcp.InitializeResult
+		err    error
+	)
 	switch s.Transport {
 	case types.TransportStreamableHTTP:
-		return createHTTPMcpServerConn(ctx, db, s, initReqTimeoutSec, useStoredUpstreamAuth)
+		c, result, err = createHTTPMcpServerConn(ctx, db, s, initReqTimeoutSec, useStoredUpstreamAuth)
 	case types.TransportSSE:
-		return createSSEMcpServerConn(ctx, db, s, useStoredUpstreamAuth)
+		c, result, err = createSSEMcpServerConn(ctx, db, s, useStoredUpstreamAuth)
 	case types.TransportStdio:
-		return runStdioServer(ctx, s, initReqTimeoutSec)
+		c, result, err = runStdioServer(ctx, s, initReqTimeoutSec)
 	default:
-		return nil, fmt.Errorf("unsupported transport type: %s", s.Transport)
+		return nil, nil, fmt.Errorf("unsupported transport type: %s", s.Transport)
 	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	captureUpstreamLogs(s.Name, c)
+	return c, result, nil
 }
@@ -0,0 +1,442 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+const (
+	// MethodResourcesSubscribe is the method of the requests that MCP clients send to subscribe to a resource.
+	MethodResourcesSubscribe = "resources/subscribe"
+	// MethodResourcesUnsubscribe is the method of the requests that MCP clients send to unsubscribe from a resource.
+	MethodResourcesUnsubscribe = "resources/unsubscribe"
+
+	// unsubscribeTimeout is how long mcpjungle waits for an upstream MCP server to acknowledge that
+	// it unsubscribed from a resource nobody is subscribed to anymore.
+	unsubscribeTimeout = 5 * time.Second
+)
+
+// ErrInvalidSession is returned when an MCP client subscribes to a resource or unsubscribes from it
+// with the ID of a session that the proxy MCP server doesn't know about.
+var ErrInvalidSession = errors.New("invalid MCP session")
+
+// resourceSubscriber is an MCP client session subscribed to a resource through a proxy MCP server.
+type resourceSubscriber struct {
+	proxy     *server.MCPServer
+	sessionID string
+}
+
+// subscriptionRegistry keeps track of the resources that MCP clients are subscribed to.
+//
+// mcpjungle holds a single subscription to a resource on its upstream MCP server, no matter how many clients
+// are subscribed to it, and relays the server's updates about the resource to all of them.
+// The subscriptions to the resources of a server are held on a stateful session dedicated to them,
+// which is closed once nobody is subscribed to any of its resources anymore.
+type subscriptionRegistry struct {
+	mu sync.Mutex
+	// serverLocks serialize subscribing to the resources of every MCP server and unsubscribing from them,
+	// which involve requests to the server. A slow server only holds up the subscriptions to its own resources.
+	serverLocks map[string]*sync.Mutex
+	// sessions contains the MCP client sessions that are registered with the attached proxy servers.
+	// Only these sessions can receive notifications, so only they can subscribe to resources.
+	sessions map[resourceSubscriber]bool
+	// upstream contains the sessions holding the subscriptions to the resources of every MCP server
+	upstream map[string]*client.Client
+	// subscribers contains the MCP client sessions subscribed to every resource, keyed by mcpjungle resource URI
+	subscribers map[string]map[resourceSubscriber]bool
+	// originalURIs contains the original URIs of the resources that have subscribers
+	originalURIs map[string]string
+}
+
+// SubscribeResource subscribes the MCP client session with the given ID on the given proxy MCP server
+// to updates of the resource with the given mcpjungle URI.
+// The context must be the context of the MCP client's request, which grants it access to MCP servers.
+// The session must be registered with the proxy server to receive the updates, eg- a streamable HTTP session
+// must be listening for notifications, otherwise ErrInvalidSession is returned.
+func (m *MCPService) SubscribeResource(ctx context.Context, proxy *server.MCPServer, sessionID, uri string) error {
+	if sessionID == "" {
+		return fmt.Errorf("subscribing to resources requires an MCP session: %w", apierrors.ErrInvalidInput)
+	}
+	resource, err := m.GetResource(uri)
+	if err != nil {
+		return err
+	}
+	if _, enabled := m.GetResourceInstance(uri); !enabled {
+		return fmt.Errorf("resource %s not found: %w", uri, apierrors.ErrNotFound)
+	}
+	if err := authorizeProxyServerAccess(ctx, resource.Server.Name); err != nil {
+		return err
+	}
+
+	r := &m.subscriptions
+	unlock := r.lockServer(resource.Server.Name)
+	defer unlock()
+
+	subscriber := resourceSubscriber{proxy: proxy, sessionID: sessionID}
+	r.mu.Lock()
+	if !r.sessions[subscriber] {
+		r.mu.Unlock()
+		return fmt.Errorf("MCP session %s not found: %w", sessionID, ErrInvalidSession)
+	}
+	if subscribers, ok := r.subscribers[uri]; ok {
+		// mcpjungle is already subscribed to the resource upstream
+		subscribers[subscriber] = true
+		r.mu.Unlock()
+		return nil
+	}
+	c := r.upstream[resource.Server.Name]
+	r.mu.Unlock()
+
+	if c == nil {
+		c, err = m.openSubscriptionSession(&resource.Server)
+		if err != nil {
+			return err
+		}
+	}
+	if err := c.Subscribe(ctx, mcp.SubscribeRequest{Params: mcp.SubscribeParams{URI: resource.OriginalURI}}); err != nil {
+		r.closeIfUnused(resource.Server.Name)
+		return fmt.Errorf("failed to subscribe to resource %s on MCP server %s: %w", uri, resource.Server.Name, err)
+	}
+
+	r.mu.Lock()
+	if !r.sessions[subscriber] {
+		// the session ended while mcpjungle subscribed to the resource upstream
+		r.mu.Unlock()
+		r.unsubscribeUpstream(resource.Server.Name, resource.OriginalURI)
+		return fmt.Errorf("MCP session %s not found: %w", sessionID, ErrInvalidSession)
+	}
+	defer r.mu.Unlock()
+	if r.subscribers == nil {
+		r.subscribers = make(map[string]map[resourceSubscriber]bool)
+		r.originalURIs = make(map[string]string)
+	}
+	r.subscribers[uri] = map[resourceSubscriber]bool{subscriber: true}
+	r.originalURIs[uri] = resource.OriginalURI
+	return nil
+}
+
+// HasProxySession reports whether the MCP client session with the given ID is registered with
+// the given proxy MCP server.
+func (m *MCPService) HasProxySession(proxy *server.MCPServer, sessionID string) bool {
+	return m.subscriptions.hasSession(resourceSubscriber{proxy: proxy, sessionID: sessionID})
+}
+
+// UnsubscribeResource unsubscribes the MCP client session with the given ID on the given proxy MCP server
+// from updates of the resource with the given mcpjungle URI.
+// It returns ErrInvalidSession if the session is not registered with the proxy server.
+func (m *MCPService) UnsubscribeResource(proxy *server.MCPServer, sessionID, uri string) error {
+	r := &m.subscriptions
+	if !r.hasSession(resourceSubscriber{proxy: proxy, sessionID: sessionID}) {
+		return fmt.Errorf("MCP session %s not found: %w", sessionID, ErrInvalidSession)
+	}
+	serverName, _, err := parseResourceURI(uri)
+	if err != nil {
+		// nobody can be subscribed to an invalid URI
+		return nil
+	}
+	unlock := r.lockServer(serverName)
+	defer unlock()
+	r.remove(uri, func(s resourceSubscriber) bool { return s.proxy == proxy && s.sessionID == sessionID })
+	return nil
+}
+
+// dropResourceSubscriptions unsubscribes all MCP clients from the resources with the given mcpjungle URIs,
+// eg- because the resources were deleted or disabled.
+func (m *MCPService) dropResourceSubscriptions(uris ...string) {
+	r := &m.subscriptions
+	for _, uri := range uris {
+		r.removeLocked(uri, func(resourceSubscriber) bool { return true })
+	}
+}
+
+// dropResourceSubscriber unsubscribes an MCP client session from all the resources it is subscribed to,
+// eg- because the session ended.
+func (m *MCPService) dropResourceSubscriber(proxy *server.MCPServer, sessionID string) {
+	r := &m.subscriptions
+	var uris []string
+	r.mu.Lock()
+	for uri, subscribers := range r.subscribers {
+		if subscribers[resourceSubscriber{proxy: proxy, sessionID: sessionID}] {
+			uris = append(uris, uri)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, uri := range uris {
+		r.removeLocked(uri, func(s resourceSubscriber) bool { return s.proxy == proxy && s.sessionID == sessionID })
+	}
+}
+
+// renewResourceSubscriptions re-creates the session holding the subscriptions to the resources of an MCP server,
+// eg- because the server's connection configuration changed.
+func (m *MCPService) renewResourceSubscriptions(s *model.McpServer) {
+	r := &m.subscriptions
+	unlock := r.lockServer(s.Name)
+	defer unlock()
+
+	r.mu.Lock()
+	old, ok := r.upstream[s.Name]
+	delete(r.upstream, s.Name)
+	var originalURIs []string
+	for uri, originalURI := range r.originalURIs {
+		if serverName, _, err := parseResourceURI(uri); err == nil && serverName == s.Name {
+			originalURIs = append(originalURIs, originalURI)
+		}
+	}
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	if err := old.Close(); err != nil {
+		log.Printf("[WARN] failed to close resource subscription session of MCP server %s: %v", s.Name, err)
+	}
+
+	c, err := m.openSubscriptionSession(s)
+	if err != nil {
+		log.Printf("[ERROR] failed to renew resource subscriptions on MCP server %s: %v", s.Name, err)
+		return
+	}
+	for _, originalURI := range originalURIs {
+		err := c.Subscribe(context.Background(), mcp.SubscribeRequest{Params: mcp.SubscribeParams{URI: originalURI}})
+		if err != nil {
+			log.Printf("[ERROR] failed to renew subscription to resource %s on MCP server %s: %v", originalURI, s.Name, err)
+		}
+	}
+}
+
+// openSubscriptionSession opens the session that holds the subscriptions to the resources of an MCP server.
+// It must be called with the lock of the server held.
+func (m *MCPService) openSubscriptionSession(s *model.McpServer) (*client.Client, error) {
+	// the session outlives the request of the client that subscribes first,
+	// and has to receive the notifications that the server sends outside of any request
+	stateful := *s
+	stateful.SessionMode = types.SessionModeStateful
+	c, initResult, err := connectMcpServer(
+		context.Background(), m.db, &stateful, m.mcpServerInitReqTimeoutSec, true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MCP server %s: %w", s.Name, err)
+	}
+	if initResult.Capabilities.Resources == nil || !initResult.Capabilities.Resources.Subscribe {
+		_ = c.Close()
+		return nil, fmt.Errorf(
+			"MCP server %s does not support resource subscriptions: %w", s.Name, apierrors.ErrInvalidInput,
+		)
+	}
+
+	serverName := s.Name
+	c.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method == mcp.MethodNotificationResourceUpdated {
+			m.relayResourceUpdate(serverName, notification)
+		}
+	})
+
+	r := &m.subscriptions
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.upstream == nil {
+		r.upstream = make(map[string]*client.Client)
+	}
+	r.upstream[s.Name] = c
+	return c, nil
+}
+
+// relayResourceUpdate relays a notification that a resource of an MCP server was updated to the MCP clients
+// subscribed to it.
+func (m *MCPService) relayResourceUpdate(serverName string, notification mcp.JSONRPCNotification) {
+	originalURI, _ := notification.Params.AdditionalFields["uri"].(string)
+	if originalURI == "" {
+		return
+	}
+	uri := buildResourceURI(serverName, originalURI)
+
+	r := &m.subscriptions
+	type update struct {
+		uri        string
+		subscriber resourceSubscriber
+	}
+	var updates []update
+	r.mu.Lock()
+	for subscribedURI, subscribers := range r.subscribers {
+		// the updated resource may be a sub-resource of the one clients subscribed to,
+		// in which case clients are told about the update of the resource they subscribed to
+		if subscribedURI != uri {
+			name, subscribedOriginalURI, err := parseResourceURI(subscribedURI)
+			if err != nil || name != serverName || !isSubResource(originalURI, subscribedOriginalURI) {
+				continue
+			}
+		}
+		for subscriber := range subscribers {
+			updates = append(updates, update{uri: subscribedURI, subscriber: subscriber})
+		}
+	}
+	r.mu.Unlock()
+
+	for _, u := range updates {
+		err := u.subscriber.proxy.SendNotificationToSpecificClient(
+			u.subscriber.sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": u.uri},
+		)
+		if errors.Is(err, server.ErrSessionNotFound) {
+			// the session ended without unsubscribing.
+			// Unsubscribing may involve a request to the server, which cannot be made while handling its notification.
+			go m.dropResourceSubscriber(u.subscriber.proxy, u.subscriber.sessionID)
+		} else if err != nil {
+			log.Printf("[WARN] failed to notify MCP client session %s that resource %s was updated: %v",
+				u.subscriber.sessionID, u.uri, err)
+		}
+	}
+}
+
+// trackSession records whether an MCP client session is registered with a proxy MCP server.
+func (r *subscriptionRegistry) trackSession(proxy *server.MCPServer, sessionID string, registered bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := resourceSubscriber{proxy: proxy, sessionID: sessionID}
+	if !registered {
+		delete(r.sessions, session)
+		return
+	}
+	if r.sessions == nil {
+		r.sessions = make(map[resourceSubscriber]bool)
+	}
+	r.sessions[session] = true
+}
+
+// hasSession reports whether the MCP client session is registered with its proxy MCP server.
+func (r *subscriptionRegistry) hasSession(session resourceSubscriber) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[session]
+}
+
+// isSubResource reports whether the resource with the given URI is nested in the parent resource,
+// eg- file:///docs/readme.md in file:///docs, but not file:///docs.secret.
+func isSubResource(uri, parentURI string) bool {
+	if !strings.HasPrefix(uri, parentURI) || len(uri) == len(parentURI) {
+		return false
+	}
+	return strings.HasSuffix(parentURI, "/") || uri[len(parentURI)] == '/'
+}
+
+// remove unsubscribes the matching subscribers from the resource with the given mcpjungle URI.
+// When nobody is subscribed to the resource anymore, it unsubscribes from it upstream.
+// It must be called with the lock of the server held.
+func (r *subscriptionRegistry) remove(uri string, match func(resourceSubscriber) bool) {
+	r.mu.Lock()
+	subscribers, ok := r.subscribers[uri]
+	if !ok {
+		r.mu.Unlock()
+		return
+	}
+	for subscriber := range subscribers {
+		if match(subscriber) {
+			delete(subscribers, subscriber)
+		}
+	}
+	if len(subscribers) > 0 {
+		r.mu.Unlock()
+		return
+	}
+	originalURI := r.originalURIs[uri]
+	delete(r.subscribers, uri)
+	delete(r.originalURIs, uri)
+	serverName, _, _ := parseResourceURI(uri)
+	r.mu.Unlock()
+
+	r.unsubscribeUpstream(serverName, originalURI)
+}
+
+// removeLocked is like remove, but takes the lock of the resource's server itself.
+func (r *subscriptionRegistry) removeLocked(uri string, match func(resourceSubscriber) bool) {
+	serverName, _, err := parseResourceURI(uri)
+	if err != nil {
+		return
+	}
+	unlock := r.lockServer(serverName)
+	defer unlock()
+	r.remove(uri, match)
+}
+
+// unsubscribeUpstream unsubscribes from the resource with the given original URI on its MCP server,
+// and closes the session holding the subscriptions to the server's resources if it is no longer used.
+// It must be called with the lock of the server held.
+func (r *subscriptionRegistry) unsubscribeUpstream(serverName, originalURI string) {
+	r.mu.Lock()
+	c := r.upstream[serverName]
+	r.mu.Unlock()
+	if c == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+	defer cancel()
+	if err := c.Unsubscribe(ctx, mcp.UnsubscribeRequest{Params: mcp.UnsubscribeParams{URI: originalURI}}); err != nil {
+		log.Printf("[WARN] failed to unsubscribe from resource %s on MCP server %s: %v", originalURI, serverName, err)
+	}
+	r.closeIfUnused(serverName)
+}
+
+// lockServer locks the subscriptions to the resources of the MCP server with the given name
+// and returns the function that unlocks them.
+func (r *subscriptionRegistry) lockServer(serverName string) func() {
+	r.mu.Lock()
+	if r.serverLocks == nil {
+		r.serverLocks = make(map[string]*sync.Mutex)
+	}
+	l, ok := r.serverLocks[serverName]
+	if !ok {
+		l = &sync.Mutex{}
+		r.serverLocks[serverName] = l
+	}
+	r.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// closeIfUnused closes the session holding the subscriptions to the resources of an MCP server
+// if nobody is subscribed to any of them anymore.
+// It must be called with the lock of the server held.
+func (r *subscriptionRegistry) closeIfUnused(serverName string) {
+	r.mu.Lock()
+	for uri := range r.subscribers {
+		if name, _, err := parseResourceURI(uri); err == nil && name == serverName {
+			r.mu.Unlock()
+			return
+		}
+	}
+	c, ok := r.upstream[serverName]
+	delete(r.upstream, serverName)
+	r.mu.Unlock()
+
+	if ok {
+		if err := c.Close(); err != nil {
+			log.Printf("[WARN] failed to close resource subscription session of MCP server %s: %v", serverName, err)
+		}
+	}
+}
+
+// closeAll closes all the sessions holding resource subscriptions.
+func (r *subscriptionRegistry) closeAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, c := range r.upstream {
+		if err := c.Close(); err != nil {
+			log.Printf("[WARN] failed to close resource subscription session of MCP server %s: %v", name, err)
+		}
+	}
+	r.upstream = nil
+	r.subscribers = nil
+	r.originalURIs = nil
+}
@@ -0,0 +1,315 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	mcpclient "github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSubscribableUpstreamServer returns an upstream MCP server that supports resource subscriptions.
+// mcp-go servers don't serve subscription requests, so the returned HTTP server acknowledges them and
+// records the methods and URIs of the requests.
+func newSubscribableUpstreamServer(t *testing.T) (*mcpserver.MCPServer, *httptest.Server, func() []string) {
+	t.Helper()
+
+	upstream := mcpserver.NewMCPServer("Upstream", "0.1.0", mcpserver.WithResourceCapabilities(true, false))
+	streamable := mcpserver.NewStreamableHTTPServer(upstream)
+
+	var mu sync.Mutex
+	var requests []string
+	upstreamHTTP := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var request struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+			Params struct {
+				URI string `json:"uri"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(body, &request) == nil &&
+			(request.Method == MethodResourcesSubscribe || request.Method == MethodResourcesUnsubscribe) {
+			mu.Lock()
+			requests = append(requests, request.Method+" "+request.Params.URI)
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": map[string]any{}})
+			return
+		}
+		streamable.ServeHTTP(w, r)
+	}))
+
+	return upstream, upstreamHTTP, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requests...)
+	}
+}
+
+func TestSubscribeResource_RelaysUpdatesToSubscribers(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	upstream, upstreamHTTP, upstreamRequests := newSubscribableUpstreamServer(t)
+	defer upstreamHTTP.Close()
+
+	srv := createStreamableHTTPTestServer(t, "docs", upstreamHTTP.URL)
+	require.NoError(t, db.Create(srv).Error)
+	resource := createTestResource(t, db, srv, "file:///docs/readme.md", "readme")
+
+	service := &MCPService{
+		db:                         db,
+		metrics:                    telemetry.NewNoopCustomMetrics(),
+		mcpServerInitReqTimeoutSec: 5,
+		resourceInstances:          make(map[string]mcp.Resource),
+		sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+	}
+	defer service.Shutdown()
+	service.addResourceInstance(mcp.Resource{URI: resource.URI, Name: "docs__readme"})
+
+	proxy := mcpserver.NewMCPServer(
+		"Proxy", "0.1.0",
+		mcpserver.WithResourceCapabilities(true, false),
+		mcpserver.WithHooks(&mcpserver.Hooks{}),
+	)
+	service.AttachProxyServer(proxy)
+	proxyHTTP := httptest.NewServer(mcpserver.NewStreamableHTTPServer(proxy))
+	defer proxyHTTP.Close()
+
+	// the downstream client listens for the notifications that the proxy sends outside of any request
+	trans, err := transport.NewStreamableHTTP(proxyHTTP.URL, transport.WithContinuousListening())
+	require.NoError(t, err)
+	client := mcpclient.NewClient(trans)
+	defer client.Close()
+
+	updates := make(chan string, 1)
+	client.OnNotification(func(notification mcp.JSONRPCNotification) {
+		if notification.Method == mcp.MethodNotificationResourceUpdated {
+			updates <- notification.Params.AdditionalFields["uri"].(string)
+		}
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.Start(ctx))
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initReq.Params.ClientInfo = mcp.Implementation{Name: "test-client", Version: "1.0.0"}
+	_, err = client.Initialize(ctx, initReq)
+	require.NoError(t, err)
+	sessionID := trans.GetSessionId()
+
+	// wait for the client's listening stream to register its session with the proxy
+	require.Eventually(t, func() bool {
+		return proxy.SendNotificationToSpecificClient(sessionID, "notifications/ping", nil) == nil
+	}, 2*time.Second, 10*time.Millisecond)
+
+	requestCtx := context.WithValue(ctx, "mode", model.ModeDev)
+	err = service.SubscribeResource(requestCtx, proxy, "mcp-session-unknown", resource.URI)
+	require.ErrorIs(t, err, ErrInvalidSession)
+	require.ErrorIs(t, service.UnsubscribeResource(proxy, "mcp-session-unknown", resource.URI), ErrInvalidSession)
+	assert.Empty(t, upstreamRequests())
+
+	err = service.SubscribeResource(requestCtx, proxy, sessionID, buildResourceURI("docs", "file:///docs/missing.md"))
+	require.ErrorIs(t, err, apierrors.ErrNotFound)
+
+	require.NoError(t, service.SubscribeResource(requestCtx, proxy, sessionID, resource.URI))
+	// subscribing twice holds a single subscription upstream
+	require.NoError(t, service.SubscribeResource(requestCtx, proxy, sessionID, resource.URI))
+	assert.Equal(t, []string{"resources/subscribe file:///docs/readme.md"}, upstreamRequests())
+
+	upstream.SendNotificationToAllClients(
+		mcp.MethodNotificationResourceUpdated, map[string]any{"uri": "file:///docs/readme.md"},
+	)
+	select {
+	case uri := <-updates:
+		assert.Equal(t, resource.URI, uri)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the update of the resource was not relayed to the subscriber")
+	}
+
+	require.NoError(t, service.UnsubscribeResource(proxy, sessionID, resource.URI))
+	assert.Equal(t, []string{
+		"resources/subscribe file:///docs/readme.md",
+		"resources/unsubscribe file:///docs/readme.md",
+	}, upstreamRequests())
+	// the session holding the subscriptions is closed once nobody is subscribed to the server's resources
+	assert.Empty(t, service.subscriptions.upstream)
+}
+
+func TestSubscribeResource_DropsSubscriptionsOfDeletedResources(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	_, upstreamHTTP, upstreamRequests := newSubscribableUpstreamServer(t)
+	defer upstreamHTTP.Close()
+
+	srv := createStreamableHTTPTestServer(t, "docs", upstreamHTTP.URL)
+	require.NoError(t, db.Create(srv).Error)
+	resource := createTestResource(t, db, srv, "file:///docs/readme.md", "readme")
+
+	service := &MCPService{
+		db:                         db,
+		metrics:                    telemetry.NewNoopCustomMetrics(),
+		mcpServerInitReqTimeoutSec: 5,
+		resourceInstances:          make(map[string]mcp.Resource),
+		sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+	}
+	defer service.Shutdown()
+	service.addResourceInstance(mcp.Resource{URI: resource.URI, Name: "docs__readme"})
+
+	proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithResourceCapabilities(true, false))
+	service.subscriptions.trackSession(proxy, "session-1", true)
+	service.subscriptions.trackSession(proxy, "session-2", true)
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	require.NoError(t, service.SubscribeResource(ctx, proxy, "session-1", resource.URI))
+	require.NoError(t, service.SubscribeResource(ctx, proxy, "session-2", resource.URI))
+
+	service.notifyResourceDeletion(resource.URI)
+
+	assert.Equal(t, []string{
+		"resources/subscribe file:///docs/readme.md",
+		"resources/unsubscribe file:///docs/readme.md",
+	}, upstreamRequests())
+	assert.Empty(t, service.subscriptions.subscribers)
+	assert.Empty(t, service.subscriptions.upstream)
+}
+
+func TestSubscribeResource_SlowServerDoesNotBlockOtherServers(t *testing.T) {
+	db := setupTestDBForProxyAdditional(t)
+
+	_, fastHTTP, fastRequests := newSubscribableUpstreamServer(t)
+	defer fastHTTP.Close()
+	_, subscribableHTTP, _ := newSubscribableUpstreamServer(t)
+	defer subscribableHTTP.Close()
+	// the slow server hangs when subscribing until it is released
+	release := make(chan struct{})
+	slowHTTP := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(MethodResourcesSubscribe)) {
+			<-release
+		}
+		subscribableHTTP.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer slowHTTP.Close()
+	defer close(release)
+
+	fast := createStreamableHTTPTestServer(t, "fast", fastHTTP.URL)
+	require.NoError(t, db.Create(fast).Error)
+	fastResource := createTestResource(t, db, fast, "file:///docs/readme.md", "readme")
+	slow := createStreamableHTTPTestServer(t, "slow", slowHTTP.URL)
+	require.NoError(t, db.Create(slow).Error)
+	slowResource := createTestResource(t, db, slow, "file:///docs/readme.md", "readme")
+
+	service := &MCPService{
+		db:                         db,
+		metrics:                    telemetry.NewNoopCustomMetrics(),
+		mcpServerInitReqTimeoutSec: 5,
+		resourceInstances:          make(map[string]mcp.Resource),
+		sessionManager:             NewSessionManager(&SessionManagerConfig{DB: db, InitReqTimeoutSec: 5}),
+	}
+	defer service.Shutdown()
+	service.addResourceInstance(mcp.Resource{URI: fastResource.URI, Name: "fast__readme"})
+	service.addResourceInstance(mcp.Resource{URI: slowResource.URI, Name: "slow__readme"})
+
+	proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithResourceCapabilities(true, false))
+	service.subscriptions.trackSession(proxy, "session-1", true)
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+
+	slowDone := make(chan error, 1)
+	go func() {
+		slowDone <- service.SubscribeResource(ctx, proxy, "session-1", slowResource.URI)
+	}()
+	require.Eventually(t, func() bool {
+		service.subscriptions.mu.Lock()
+		defer service.subscriptions.mu.Unlock()
+		return service.subscriptions.upstream["slow"] != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- service.SubscribeResource(ctx, proxy, "session-1", fastResource.URI)
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribing to a resource of another server was blocked by the slow server")
+	}
+	assert.Equal(t, []string{"resources/subscribe file:///docs/readme.md"}, fastRequests())
+
+	select {
+	case err := <-slowDone:
+		t.Fatalf("expected subscribing to the slow server to still be pending, got %v", err)
+	default:
+	}
+	release <- struct{}{}
+	require.NoError(t, <-slowDone)
+}
+
+// testClientSession is an MCP client session registered with a proxy MCP server.
+type testClientSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (s *testClientSession) Initialize()       {}
+func (s *testClientSession) Initialized() bool { return true }
+func (s *testClientSession) SessionID() string { return s.id }
+func (s *testClientSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return s.notifications
+}
+
+func TestRelayResourceUpdate_OnlyRelaysUpdatesOfSubResources(t *testing.T) {
+	proxy := mcpserver.NewMCPServer("Proxy", "0.1.0", mcpserver.WithResourceCapabilities(true, false))
+	session := &testClientSession{id: "session-1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, proxy.RegisterSession(context.Background(), session))
+
+	service := &MCPService{}
+	subscriber := resourceSubscriber{proxy: proxy, sessionID: session.id}
+	cases := []struct {
+		subscribed, updated string
+		relayed             bool
+	}{
+		{"file:///docs", "file:///docs", true},
+		{"file:///docs", "file:///docs/readme.md", true},
+		{"file:///docs", "file:///docs.secret", false},
+		{"file:///docs", "file:///docsbar/readme.md", false},
+		{"file:///docs/", "file:///docs/readme.md", true},
+		{"file:///docs/", "file:///docs.secret", false},
+	}
+	for _, tc := range cases {
+		uri := buildResourceURI("docs", tc.subscribed)
+		service.subscriptions.subscribers = map[string]map[resourceSubscriber]bool{uri: {subscriber: true}}
+
+		service.relayResourceUpdate("docs", mcp.JSONRPCNotification{
+			Notification: mcp.Notification{
+				Method: mcp.MethodNotificationResourceUpdated,
+				Params: mcp.NotificationParams{AdditionalFields: map[string]any{"uri": tc.updated}},
+			},
+		})
+		select {
+		case n := <-session.notifications:
+			assert.True(t, tc.relayed, "update of %s was relayed to the subscriber of %s", tc.updated, tc.subscribed)
+			assert.Equal(t, uri, n.Params.AdditionalFields["uri"])
+		default:
+			assert.False(t, tc.relayed, "update of %s was not relayed to the subscriber of %s", tc.updated, tc.subscribed)
+		}
+	}
+}
@@ -24,25 +24,26 @@ type ToolDeletionCallback func(toolNames ...string)
 
 // ToolAdditionCallback is a function type that can be registered to be called
 // whenever a tool is added (registered or re-enabled).
+// It is also called when the definition of a tool or the labels of its server change,
+// since that can change which tool groups the tool belongs to.
 // The callback receives the name of the added tool as argument.
 type ToolAdditionCallback func(toolName string) error
 
-// ListTools returns all tools registered in the registry.
+// ListTools returns all tools registered in the registry, along with their MCP servers.
 // It sets each tool's name to its canonical form by prepending its mcp server's name.
 // For example, if a tool named "commit" is provided by a server named "git",
 // its name will be set to "git__commit".
 func (m *MCPService) ListTools() ([]model.Tool, error) {
 	var tools []model.Tool
-	if err := m.db.Find(&tools).Error; err != nil {
+	if err := m.db.Preload("Server").Find(&tools).Error; err != nil {
 		return nil, err
 	}
 	// prepend server name to tool names to ensure we only return the unique names of tools to user
 	for i := range tools {
-		var s model.McpServer
-		if err := m.db.First(&s, "id = ?", tools[i].ServerID).Error; err != nil {
-			return nil, fmt.Errorf("failed to get server for tool %s: %w", tools[i].Name, err)
+		if tools[i].Server.ID == 0 {
+			return nil, fmt.Errorf("failed to get server for tool %s", tools[i].Name)
 		}
-		tools[i].Name = mergeServerToolNames(s.Name, tools[i].Name)
+		tools[i].Name = mergeServerToolNames(tools[i].Server.Name, tools[i].Name)
 	}
 	return tools, nil
 }
@@ -128,6 +129,12 @@ func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[strin
 		m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
 	}()
 
+	// the tool overrides of its server apply to tools invoked through the API as well
+	args, err := overrideToolArguments(name, m.ToolOverride(name), args)
+	if err != nil {
+		return nil, err
+	}
+
 	serverModel, err := m.GetMcpServer(serverName)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -147,7 +154,11 @@ func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[strin
 	callToolReq.Params.Name = toolName
 	callToolReq.Params.Arguments = args
 
-	callToolResp, err := session.client.CallTool(ctx, callToolReq)
+	callToolResp, err := m.callUpstreamTool(ctx, serverModel, session.client, callToolReq)
+	if isCancelled(ctx, err) {
+		outcome = telemetry.ToolCallOutcomeCancelled
+		return nil, err
+	}
 	if err != nil {
 		session.invalidateOnError(err) // Invalidate unhealthy stateful sessions
 		return nil, fmt.Errorf("failed to call tool %s on MCP server %s: %w", toolName, serverName, err)
@@ -243,11 +254,7 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 			// set the tool name to its canonical form in the proxy
 			mcpTool.Name = entity
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddTool(mcpTool, m.MCPProxyToolCallHandler)
-			} else {
-				m.mcpProxyServer.AddTool(mcpTool, m.MCPProxyToolCallHandler)
-			}
+			m.serveProxyTool(m.mcpProxyServer, mcpTool)
 
 			// also add the tool to the in-memory tool instance tracker
 			m.addToolInstance(mcpTool)
@@ -255,17 +262,14 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 			m.notifyToolAddition(mcpTool.Name)
 		} else {
 			// if the tool was disabled, remove it from the appropriate MCP proxy server
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.DeleteTools(entity)
-			} else {
-				m.mcpProxyServer.DeleteTools(entity)
-			}
+			m.DeleteProxyTools(m.mcpProxyServer, entity)
 
 			// also remove the tool from the in-memory tool instance tracker
 			m.deleteToolInstances(entity)
 			// notify any registered callbacks about the tool deletion
 			m.notifyToolDeletion(entity)
 		}
+		m.notifyServerChange(s.Name)
 
 		return []string{entity}, nil
 	}
@@ -301,20 +305,12 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 			// set the tool name to its canonical form in the proxy
 			mcpTool.Name = canonicalToolName
 
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.AddTool(mcpTool, m.MCPProxyToolCallHandler)
-			} else {
-				m.mcpProxyServer.AddTool(mcpTool, m.MCPProxyToolCallHandler)
-			}
+			m.serveProxyTool(m.mcpProxyServer, mcpTool)
 
 			m.addToolInstance(mcpTool)
 			m.notifyToolAddition(mcpTool.Name)
 		} else {
-			if s.Transport == types.TransportSSE {
-				m.sseMcpProxyServer.DeleteTools(canonicalToolName)
-			} else {
-				m.mcpProxyServer.DeleteTools(canonicalToolName)
-			}
+			m.DeleteProxyTools(m.mcpProxyServer, canonicalToolName)
 
 			m.deleteToolInstances(canonicalToolName)
 			m.notifyToolDeletion(canonicalToolName)
@@ -322,6 +318,9 @@ func (m *MCPService) setToolsEnabled(entity string, enabled bool) ([]string, err
 
 		changedToolNames = append(changedToolNames, canonicalToolName)
 	}
+	if len(changedToolNames) > 0 {
+		m.notifyServerChange(s.Name)
+	}
 
 	return changedToolNames, nil
 }
@@ -336,20 +335,7 @@ func (m *MCPService) registerServerTools(ctx context.Context, s *model.McpServer
 	for _, tool := range resp.Tools {
 		canonicalToolName := mergeServerToolNames(s.Name, tool.GetName())
 
-		// extracting json schema is currently on best-effort basis
-		// if it fails, we log the error and continue with the next tool
-		jsonSchema, _ := json.Marshal(tool.InputSchema)
-
-		// extracting annotations is also on best-effort basis
-		annotationsJSON, _ := json.Marshal(tool.Annotations)
-
-		t := &model.Tool{
-			ServerID:    s.ID,
-			Name:        tool.GetName(),
-			Description: tool.Description,
-			InputSchema: jsonSchema,
-			Annotations: annotationsJSON,
-		}
+		t := newToolModel(s, tool)
 		if err := m.db.Create(t).Error; err != nil {
 			// If registration of a tool fails, we should not fail the entire server registration.
 			// Instead, continue with the next tool.
@@ -361,11 +347,7 @@ func (m *MCPService) registerServerTools(ctx context.Context, s *model.McpServer
 		// then add the tool to the appropriate MCP proxy server
 		tool.Name = canonicalToolName
 
-		if s.Transport == types.TransportSSE {
-			m.sseMcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
-		} else {
-			m.mcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
-		}
+		m.serveProxyTool(m.mcpProxyServer, tool)
 
 		// also add the tool to the in-memory tool instance tracker
 		m.addToolInstance(tool)
@@ -375,6 +357,24 @@ func (m *MCPService) registerServerTools(ctx context.Context, s *model.McpServer
 	return nil
 }
 
+// newToolModel creates the DB record of a tool provided by an MCP server.
+func newToolModel(s *model.McpServer, tool mcp.Tool) *model.Tool {
+	// extracting json schema is currently on best-effort basis
+	// if it fails, we log the error and continue with the next tool
+	jsonSchema, _ := json.Marshal(tool.InputSchema)
+
+	// extracting annotations is also on best-effort basis
+	annotationsJSON, _ := json.Marshal(tool.Annotations)
+
+	return &model.Tool{
+		ServerID:    s.ID,
+		Name:        tool.GetName(),
+		Description: tool.Description,
+		InputSchema: jsonSchema,
+		Annotations: annotationsJSON,
+	}
+}
+
 // deregisterServerTools deletes all tools that belong to an MCP server from the DB.
 // It also removes the tools from the MCP proxy server.
 func (m *MCPService) deregisterServerTools(s *model.McpServer) error {
@@ -396,11 +396,7 @@ func (m *MCPService) deregisterServerTools(s *model.McpServer) error {
 		toolNames[i] = tool.Name
 	}
 
-	if s.Transport == types.TransportSSE {
-		m.sseMcpProxyServer.DeleteTools(toolNames...)
-	} else {
-		m.mcpProxyServer.DeleteTools(toolNames...)
-	}
+	m.DeleteProxyTools(m.mcpProxyServer, toolNames...)
 
 	// delete tools from Tool instance tracker
 	m.deleteToolInstances(toolNames...)
@@ -0,0 +1,299 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// canonicalToolNameMetaKey is the key of the _meta field in which a tool exposed under an alias
+// carries its canonical name.
+const canonicalToolNameMetaKey = "com.mcpjungle/canonical_name"
+
+// ApplyToolOverride returns the tool the way it is exposed to MCP clients with the given override.
+// The tool must have its canonical name. The given tool is not modified.
+func ApplyToolOverride(tool mcp.Tool, o *types.ToolOverride) mcp.Tool {
+	if o == nil {
+		return tool
+	}
+
+	if o.Name != "" {
+		meta := &mcp.Meta{AdditionalFields: map[string]any{}}
+		if tool.Meta != nil {
+			meta.ProgressToken = tool.Meta.ProgressToken
+			maps.Copy(meta.AdditionalFields, tool.Meta.AdditionalFields)
+		}
+		// the proxy filters tools by their MCP servers, which it can only tell from the canonical name
+		meta.AdditionalFields[canonicalToolNameMetaKey] = tool.Name
+		tool.Meta = meta
+		tool.Name = ExposedToolName(tool.Name, o)
+	}
+
+	if o.Description != "" {
+		tool.Description = o.Description
+	}
+	if o.AppendDescription != "" {
+		if tool.Description == "" {
+			tool.Description = o.AppendDescription
+		} else {
+			tool.Description += "\n\n" + o.AppendDescription
+		}
+	}
+
+	if len(o.HiddenParams) > 0 || len(o.PinnedParams) > 0 {
+		removed := func(param string) bool {
+			_, pinned := o.PinnedParams[param]
+			return pinned || slices.Contains(o.HiddenParams, param)
+		}
+		properties := make(map[string]any, len(tool.InputSchema.Properties))
+		for param, schema := range tool.InputSchema.Properties {
+			if !removed(param) {
+				properties[param] = schema
+			}
+		}
+		tool.InputSchema.Properties = properties
+		tool.InputSchema.Required = slices.DeleteFunc(slices.Clone(tool.InputSchema.Required), removed)
+	}
+
+	if len(o.DefaultParams) > 0 {
+		properties := maps.Clone(tool.InputSchema.Properties)
+		for param, value := range o.DefaultParams {
+			// parameters the tool does not declare are still sent, but cannot be advertised
+			if schema, ok := properties[param].(map[string]any); ok {
+				schema = maps.Clone(schema)
+				schema["default"] = value
+				properties[param] = schema
+			}
+		}
+		tool.InputSchema.Properties = properties
+		// clients don't have to set parameters that have a default value
+		tool.InputSchema.Required = slices.DeleteFunc(slices.Clone(tool.InputSchema.Required), func(param string) bool {
+			_, ok := o.DefaultParams[param]
+			return ok
+		})
+	}
+	return tool
+}
+
+// ExposedToolName returns the name under which a tool is exposed to MCP clients with the given override.
+// An alias is qualified with the namespace of the tool's MCP server.
+func ExposedToolName(name string, o *types.ToolOverride) string {
+	if o == nil || o.Name == "" {
+		return name
+	}
+	serverName, _, _ := splitServerToolName(name)
+	ns, _ := model.SplitQualifiedName(serverName)
+	return model.QualifiedName(ns, o.Name)
+}
+
+// CanonicalToolName returns the canonical name of a tool served by an MCP proxy server.
+// Only tools exposed under an alias carry their canonical name in _meta. Aliases never contain
+// the server-tool separator, so an upstream tool cannot pass itself off as another server's tool.
+func CanonicalToolName(tool mcp.Tool) string {
+	if strings.Contains(tool.Name, serverToolNameSep) || tool.Meta == nil {
+		return tool.Name
+	}
+	if name, ok := tool.Meta.AdditionalFields[canonicalToolNameMetaKey].(string); ok {
+		return name
+	}
+	return tool.Name
+}
+
+// proxyToolCallHandler returns the handler for calls of a tool exposed with the given override.
+// The handler translates the call back to the canonical tool, drops the hidden parameters and
+// sets the pinned and default ones, before handing it over to MCPProxyToolCallHandler.
+// Calls that set a pinned parameter to a different value are rejected.
+func (m *MCPService) proxyToolCallHandler(canonicalName string, o *types.ToolOverride) server.ToolHandlerFunc {
+	if o == nil ||
+		(o.Name == "" && len(o.HiddenParams) == 0 && len(o.PinnedParams) == 0 && len(o.DefaultParams) == 0) {
+		return m.MCPProxyToolCallHandler
+	}
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		exposedName := request.Params.Name
+		request.Params.Name = canonicalName
+
+		args, err := overrideToolArguments(exposedName, o, request.GetArguments())
+		if err != nil {
+			return nil, err
+		}
+		request.Params.Arguments = args
+
+		return m.MCPProxyToolCallHandler(ctx, request)
+	}
+}
+
+// overrideToolArguments returns the arguments of a call to a tool with the given override, as they are sent to
+// its MCP server: hidden parameters are dropped, defaults fill in the missing parameters and pinned parameters
+// are set to their values. Callers may only pass the value a parameter is pinned to.
+func overrideToolArguments(toolName string, o *types.ToolOverride, arguments map[string]any) (map[string]any, error) {
+	if o == nil {
+		return arguments, nil
+	}
+	args := make(map[string]any)
+	for param, value := range arguments {
+		if slices.Contains(o.HiddenParams, param) {
+			continue
+		}
+		if pinned, ok := o.PinnedParams[param]; ok && !reflect.DeepEqual(value, pinned) {
+			return nil, fmt.Errorf(
+				"parameter %s of tool %s is pinned to %v and cannot be changed: %w",
+				param, toolName, pinned, apierrors.ErrInvalidInput,
+			)
+		}
+		args[param] = value
+	}
+	for param, value := range o.DefaultParams {
+		if _, ok := args[param]; !ok {
+			args[param] = value
+		}
+	}
+	maps.Copy(args, o.PinnedParams)
+	return args, nil
+}
+
+// AddProxyTool serves a tool on an MCP proxy server, applying the given override, if any.
+// The tool must have its canonical name.
+// If the tool was served under a different name before, eg- because its alias changed, it is replaced.
+func (m *MCPService) AddProxyTool(proxy *server.MCPServer, tool mcp.Tool, o *types.ToolOverride) {
+	exposed := ApplyToolOverride(tool, o)
+
+	m.exposedToolNamesMu.Lock()
+	names, ok := m.exposedToolNames[proxy]
+	if !ok {
+		names = make(map[string]string)
+		m.exposedToolNames[proxy] = names
+	}
+	previous, served := names[tool.Name]
+	if exposed.Name == tool.Name {
+		delete(names, tool.Name)
+	} else {
+		names[tool.Name] = exposed.Name
+	}
+	m.exposedToolNamesMu.Unlock()
+
+	if served && previous != exposed.Name {
+		proxy.DeleteTools(previous)
+	}
+	proxy.AddTool(exposed, m.proxyToolCallHandler(tool.Name, o))
+}
+
+// DeleteProxyTools removes tools from an MCP proxy server by their canonical names,
+// regardless of the names they are exposed under.
+func (m *MCPService) DeleteProxyTools(proxy *server.MCPServer, names ...string) {
+	if len(names) == 0 {
+		return
+	}
+	exposed := make([]string, 0, len(names))
+	m.exposedToolNamesMu.Lock()
+	for _, name := range names {
+		if alias, ok := m.exposedToolNames[proxy][name]; ok {
+			exposed = append(exposed, alias)
+			delete(m.exposedToolNames[proxy], name)
+			continue
+		}
+		exposed = append(exposed, name)
+	}
+	m.exposedToolNamesMu.Unlock()
+	proxy.DeleteTools(exposed...)
+}
+
+// ForgetProxyServer drops what is known about the tools served by an MCP proxy server that was discarded.
+// Its resource templates are no longer kept in sync either.
+func (m *MCPService) ForgetProxyServer(proxy *server.MCPServer) {
+	m.forgetResourceTemplateProxy(proxy)
+
+	m.exposedToolNamesMu.Lock()
+	defer m.exposedToolNamesMu.Unlock()
+	delete(m.exposedToolNames, proxy)
+}
+
+// ToolOverride returns the override configured for a tool by its MCP server, or nil if there is none.
+// The name must be the canonical tool name.
+func (m *MCPService) ToolOverride(name string) *types.ToolOverride {
+	serverName, toolName, ok := splitServerToolName(name)
+	if !ok {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	o, ok := m.serverToolOverrides[serverName][toolName]
+	if !ok {
+		return nil
+	}
+	return &o
+}
+
+// serveProxyTool serves a tool on one of the MCP proxy servers, applying the override configured by its MCP server.
+func (m *MCPService) serveProxyTool(proxy *server.MCPServer, tool mcp.Tool) {
+	m.AddProxyTool(proxy, tool, m.ToolOverride(tool.Name))
+}
+
+// reserveServerTools serves all the tools of an MCP server again, eg- after its tool overrides changed,
+// and notifies the tool addition callback about them so that tool groups pick up the change as well.
+func (m *MCPService) reserveServerTools(s *model.McpServer) {
+	m.mu.RLock()
+	var tools []mcp.Tool
+	for name, tool := range m.toolInstances {
+		if serverName, _, ok := splitServerToolName(name); ok && serverName == s.Name {
+			tools = append(tools, tool)
+		}
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(tools, func(a, b mcp.Tool) int { return strings.Compare(a.Name, b.Name) })
+	for _, tool := range tools {
+		m.serveProxyTool(m.mcpProxyServer, tool)
+		m.notifyToolAddition(tool.Name)
+	}
+}
+
+// checkToolAliasesAvailable returns an error if a tool of the given MCP server would be exposed
+// by the MCP proxy under the same alias as a tool of another MCP server.
+func (m *MCPService) checkToolAliasesAvailable(s *model.McpServer) error {
+	overrides, err := s.GetToolOverrides()
+	if err != nil || len(overrides) == 0 {
+		return err
+	}
+	servers, err := m.ListMcpServers()
+	if err != nil {
+		return err
+	}
+	taken := make(map[string]string)
+	for i := range servers {
+		if servers[i].Name == s.Name {
+			continue
+		}
+		others, err := servers[i].GetToolOverrides()
+		if err != nil {
+			log.Printf("[WARN] failed to read tool overrides of MCP server %s: %v", servers[i].Name, err)
+			continue
+		}
+		for tool, o := range others {
+			if o.Name != "" {
+				taken[model.QualifiedName(servers[i].Namespace, o.Name)] = mergeServerToolNames(servers[i].Name, tool)
+			}
+		}
+	}
+	for tool, o := range overrides {
+		if o.Name == "" {
+			continue
+		}
+		if other, ok := taken[model.QualifiedName(s.Namespace, o.Name)]; ok {
+			return fmt.Errorf(
+				"tool %s cannot be exposed under the alias %s, it is already used by the tool %s: %w",
+				mergeServerToolNames(s.Name, tool), o.Name, other, apierrors.ErrInvalidInput,
+			)
+		}
+	}
+	return nil
+}
@@ -14,17 +14,23 @@ import (
 
 	mcpgoclient "github.com/mark3labs/mcp-go/client"
 	mcpgotransport "github.com/mark3labs/mcp-go/client/transport"
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // upstreamOAuthPendingSessionTTL defines how long a pending upstream OAuth
 // registration can remain incomplete before operators need to restart the flow.
 const upstreamOAuthPendingSessionTTL = 10 * time.Minute
 
+// UpstreamOAuthSessionResultRetention defines how long the outcome of an upstream OAuth session
+// is kept after the session expired or the outcome was last updated.
+const UpstreamOAuthSessionResultRetention = 10 * time.Minute
+
 var errUpstreamOAuthDCRUnsupported = errors.New("upstream OAuth provider does not support dynamic client registration")
 
 func upstreamOAuthDCRUnsupportedUserError() error {
@@ -153,9 +159,10 @@ func createServerFromRegisterInput(input *types.RegisterServerInput) (*model.Mcp
 		return nil, err
 	}
 
+	var server *model.McpServer
 	switch transport {
 	case types.TransportStreamableHTTP:
-		return model.NewStreamableHTTPServer(
+		server, err = model.NewStreamableHTTPServer(
 			input.Name,
 			input.Description,
 			input.URL,
@@ -164,7 +171,7 @@ func createServerFromRegisterInput(input *types.RegisterServerInput) (*model.Mcp
 			sessionMode,
 		)
 	case types.TransportStdio:
-		return model.NewStdioServer(
+		server, err = model.NewStdioServer(
 			input.Name,
 			input.Description,
 			input.Command,
@@ -173,7 +180,7 @@ func createServerFromRegisterInput(input *types.RegisterServerInput) (*model.Mcp
 			sessionMode,
 		)
 	default:
-		return model.NewSSEServer(
+		server, err = model.NewSSEServer(
 			input.Name,
 			input.Description,
 			input.URL,
@@ -181,6 +188,22 @@ func createServerFromRegisterInput(input *types.RegisterServerInput) (*model.Mcp
 			sessionMode,
 		)
 	}
+	if err != nil {
+		return nil, err
+	}
+	if err := server.SetLabels(input.Labels); err != nil {
+		return nil, err
+	}
+	if err := server.SetToolOverrides(input.ToolOverrides); err != nil {
+		return nil, err
+	}
+	if err := server.SetAllowedRoots(input.AllowedRoots); err != nil {
+		return nil, err
+	}
+	if err := server.SetLogLevel(input.LogLevel); err != nil {
+		return nil, err
+	}
+	return server, nil
 }
 
 // prepareOAuthConfig builds the mcp-go OAuth client configuration used for
@@ -255,7 +278,7 @@ func (m *MCPService) bootstrapUpstreamOAuth(ctx context.Context, input *types.Re
 			return fmt.Errorf("failed to create OAuth HTTP client for MCP server: %w", err)
 		}
 		defer c.Close()
-		_, err = initializeHTTPClient(ctx, c, conf.URL, m.mcpServerInitReqTimeoutSec)
+		_, err = initializeHTTPClient(ctx, c, conf.URL, mcpgo.ClientCapabilities{}, m.mcpServerInitReqTimeoutSec)
 		if err == nil {
 			return m.finalizeMcpServerRegistration(ctx, server)
 		}
@@ -392,7 +415,7 @@ func (m *MCPService) buildOAuthHandlerForRegisteredClient(ctx context.Context, s
 			return nil, err
 		}
 		defer c.Close()
-		_, err = initializeHTTPClient(ctx, c, conf.URL, m.mcpServerInitReqTimeoutSec)
+		_, err = initializeHTTPClient(ctx, c, conf.URL, mcpgo.ClientCapabilities{}, m.mcpServerInitReqTimeoutSec)
 		if !errors.As(err, &oauthErr) {
 			if err == nil {
 				return nil, fmt.Errorf("unexpectedly initialized upstream server while rebuilding OAuth handler")
@@ -640,6 +663,53 @@ func (m *MCPService) DeletePendingUpstreamOAuthSession(ctx context.Context, sess
 	return nil
 }
 
+// StoreUpstreamOAuthSessionResult records the final outcome of the upstream OAuth session with the given ID,
+// replacing any outcome recorded before. Outcomes older than UpstreamOAuthSessionResultRetention are cleaned up.
+func (m *MCPService) StoreUpstreamOAuthSessionResult(ctx context.Context, result *model.UpstreamOAuthSessionResult) error {
+	if result.SessionID == "" {
+		return fmt.Errorf("session_id is required: %w", apierrors.ErrInvalidInput)
+	}
+
+	db := m.db.WithContext(ctx)
+	cutoff := time.Now().Add(-UpstreamOAuthSessionResultRetention)
+	err := db.Unscoped().
+		Where("expires_at < ? OR updated_at < ?", cutoff, cutoff).
+		Delete(&model.UpstreamOAuthSessionResult{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to clean up upstream OAuth session results: %w", err)
+	}
+
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "status", "error", "server_name", "expires_at"}),
+	}).Create(result).Error
+	if err != nil {
+		return fmt.Errorf("failed to store result of upstream OAuth session: %w", err)
+	}
+	return nil
+}
+
+// GetUpstreamOAuthSessionResult loads the final outcome of an upstream OAuth session by session ID.
+// It returns an ErrNotFound error if the session has no outcome yet, or if it is older than the retention period.
+func (m *MCPService) GetUpstreamOAuthSessionResult(ctx context.Context, sessionID string) (*model.UpstreamOAuthSessionResult, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("session_id is required: %w", apierrors.ErrInvalidInput)
+	}
+
+	cutoff := time.Now().Add(-UpstreamOAuthSessionResultRetention)
+	var result model.UpstreamOAuthSessionResult
+	err := m.db.WithContext(ctx).
+		Where("session_id = ? AND expires_at >= ? AND updated_at >= ?", sessionID, cutoff, cutoff).
+		First(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("upstream OAuth session result not found: %w", apierrors.ErrNotFound)
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
 // processOAuthAuthorizationCode recreates the upstream OAuth handler and uses it
 // to exchange the callback code for stored upstream tokens.
 func (m *MCPService) processOAuthAuthorizationCode(ctx context.Context, server *model.McpServer, input *types.RegisterServerInput, codeVerifier, state, code string) error {
@@ -663,7 +733,7 @@ func (m *MCPService) processOAuthAuthorizationCode(ctx context.Context, server *
 			return fmt.Errorf("failed to create OAuth HTTP client for token exchange: %w", err)
 		}
 		defer c.Close()
-		_, err = c.Initialize(ctx, defaultHTTPInitializeRequest(conf.URL))
+		_, err = c.Initialize(ctx, defaultHTTPInitializeRequest(conf.URL, mcpgo.ClientCapabilities{}))
 		if !errors.As(err, &oauthErr) {
 			if err == nil {
 				return fmt.Errorf("unexpectedly initialized upstream server before OAuth token exchange")
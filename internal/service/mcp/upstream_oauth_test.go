@@ -123,7 +123,6 @@ func newOAuthCapableService(t *testing.T) (*MCPService, *testhelpers.TestDBSetup
 	service, err := NewMCPService(&ServiceConfig{
 		DB:                      setup.DB,
 		McpProxyServer:          mcpserver.NewMCPServer("test-proxy", "0.1.0"),
-		SseMcpProxyServer:       mcpserver.NewMCPServer("test-proxy-sse", "0.1.0"),
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 5,
 	})
@@ -259,7 +258,7 @@ func TestRegisterOAuthClientWithoutEmptyScope_OmitsScopeWhenUnset(t *testing.T)
 	require.NoError(t, err)
 	defer oauthClient.Close()
 
-	_, err = initializeHTTPClient(context.Background(), oauthClient, upstream.server.URL+"/mcp", 5)
+	_, err = initializeHTTPClient(context.Background(), oauthClient, upstream.server.URL+"/mcp", mcp.ClientCapabilities{}, 5)
 	require.Error(t, err)
 
 	var oauthErr *mcpgoclient.OAuthAuthorizationRequiredError
@@ -433,3 +432,32 @@ func TestDeregisterMcpServer_HardDeletesUpstreamOAuthState(t *testing.T) {
 	require.NoError(t, setup.DB.Unscoped().Model(&model.UpstreamOAuthPendingSession{}).Where("server_name = ?", "todoist").Count(&pendingCount).Error)
 	require.Zero(t, pendingCount)
 }
+
+func TestUpstreamOAuthSessionResult_StoreAndGet(t *testing.T) {
+	db := setupTestDBForServerLifecycle(t)
+	require.NoError(t, db.AutoMigrate(&model.UpstreamOAuthSessionResult{}))
+	service := newTestLifecycleService(t, db)
+	ctx := context.Background()
+
+	_, err := service.GetUpstreamOAuthSessionResult(ctx, "s1")
+	require.Error(t, err)
+
+	require.NoError(t, service.StoreUpstreamOAuthSessionResult(ctx, &model.UpstreamOAuthSessionResult{
+		SessionID: "s1", Status: "failed", ServerName: "calc", ExpiresAt: time.Now().Add(5 * time.Minute),
+	}))
+	require.NoError(t, service.StoreUpstreamOAuthSessionResult(ctx, &model.UpstreamOAuthSessionResult{
+		SessionID: "s1", Status: "completed", ServerName: "calc", ExpiresAt: time.Now().Add(5 * time.Minute),
+	}))
+
+	result, err := service.GetUpstreamOAuthSessionResult(ctx, "s1")
+	require.NoError(t, err)
+	require.Equal(t, "completed", result.Status)
+	require.Equal(t, "calc", result.ServerName)
+
+	// results of sessions that expired longer than the retention period ago are gone
+	require.NoError(t, service.StoreUpstreamOAuthSessionResult(ctx, &model.UpstreamOAuthSessionResult{
+		SessionID: "s2", Status: "expired", ExpiresAt: time.Now().Add(-time.Hour),
+	}))
+	_, err = service.GetUpstreamOAuthSessionResult(ctx, "s2")
+	require.Error(t, err)
+}
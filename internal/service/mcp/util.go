@@ -20,6 +20,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
 )
 
@@ -45,7 +46,22 @@ var validServerName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 // Tools in mcpjungle are identified by `<server_name>__<tool_name>` (eg- `github__git_commit`)
 // When a tool is invoked, the text before the first __ is treated as the server name.
 // eg- In `aws__ec2__create_sg`, `aws` is the MCP server's name and `ec2__create_sg` is the tool.
+//
+// The name may be the canonical name of a server in a namespace (eg- `team-a.github`),
+// in which case the namespace and the server's name within it are validated separately.
 func validateServerName(name string) error {
+	namespace, local := model.SplitQualifiedName(name)
+	if namespace != "" || strings.HasPrefix(name, model.NamespaceSep) {
+		if !model.ValidNamespaceName.MatchString(namespace) {
+			return fmt.Errorf(
+				"invalid server name: namespace '%s' must follow the regular expression %s: %w",
+				namespace,
+				model.ValidNamespaceName,
+				apierrors.ErrInvalidInput,
+			)
+		}
+		name = local
+	}
 	if name == "" {
 		return fmt.Errorf("invalid server name: '%s' must not be empty: %w", name, apierrors.ErrInvalidInput)
 	}
@@ -114,6 +130,26 @@ func validateURL(rawURL string) error {
 	}
 }
 
+// validateServerURL validates the URL of an MCP server.
+// Only transports that actually carry a URL in their config are validated.
+func validateServerURL(s *model.McpServer) error {
+	switch s.Transport {
+	case types.TransportStreamableHTTP:
+		conf, err := s.GetStreamableHTTPConfig()
+		if err != nil {
+			return err
+		}
+		return validateURL(conf.URL)
+	case types.TransportSSE:
+		conf, err := s.GetSSEConfig()
+		if err != nil {
+			return err
+		}
+		return validateURL(conf.URL)
+	}
+	return nil
+}
+
 // isLoopbackURL returns true if rawURL resolves to a loopback address.
 // It assumes that rawURL is a valid URL.
 func isLoopbackURL(rawURL string) bool {
@@ -245,23 +281,25 @@ func prepareSHTTPClientOptions(serverName string, conf *model.StreamableHTTPConf
 
 // defaultHTTPInitializeRequest builds the standard initialize payload used when
 // MCPJungle connects to an upstream streamable HTTP server.
-func defaultHTTPInitializeRequest(url string) mcp.InitializeRequest {
+func defaultHTTPInitializeRequest(url string, capabilities mcp.ClientCapabilities) mcp.InitializeRequest {
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
 	initRequest.Params.ClientInfo = mcp.Implementation{
 		Name:    "mcpjungle mcp client for " + url,
 		Version: "0.1",
 	}
-	initRequest.Params.Capabilities = mcp.ClientCapabilities{}
+	initRequest.Params.Capabilities = capabilities
 	return initRequest
 }
 
 // initializeHTTPClient runs the standard initialize request with the configured timeout.
-func initializeHTTPClient(ctx context.Context, c *client.Client, url string, initReqTimeoutSec int) (*mcp.InitializeResult, error) {
+func initializeHTTPClient(
+	ctx context.Context, c *client.Client, url string, capabilities mcp.ClientCapabilities, initReqTimeoutSec int,
+) (*mcp.InitializeResult, error) {
 	initCtx, cancel := context.WithTimeout(ctx, time.Duration(initReqTimeoutSec)*time.Second)
 	defer cancel()
 
-	return c.Initialize(initCtx, defaultHTTPInitializeRequest(url))
+	return c.Initialize(initCtx, defaultHTTPInitializeRequest(url, capabilities))
 }
 
 // createHTTPMcpServerConn creates and initializes a streamable HTTP client for
@@ -273,13 +311,19 @@ func createHTTPMcpServerConn(
 	s *model.McpServer,
 	initReqTimeoutSec int,
 	useStoredUpstreamAuth bool,
-) (*client.Client, error) {
+) (*client.Client, *mcp.InitializeResult, error) {
 	conf, err := s.GetStreamableHTTPConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get streamable HTTP config for MCP server %s: %w", s.Name, err)
+		return nil, nil, fmt.Errorf("failed to get streamable HTTP config for MCP server %s: %w", s.Name, err)
 	}
 
+	capabilities := upstreamClientCapabilities(ctx, s)
 	opts := prepareSHTTPClientOptions(s.Name, conf)
+	if capabilities.Sampling != nil || capabilities.Elicitation != nil || capabilities.Roots != nil {
+		// some servers send their sampling, elicitation and roots requests on the stream the client listens to
+		// rather than on the response stream of the tool call
+		opts = append(opts, transport.WithContinuousListening())
+	}
 
 	var c *client.Client
 
@@ -290,7 +334,7 @@ func createHTTPMcpServerConn(
 			if hasStoredOAuthTokens {
 				scopes, err := scopesFromJSON(tokenModel.Scopes)
 				if err != nil {
-					return nil, fmt.Errorf("failed to decode stored OAuth scopes for server %s: %w", s.Name, err)
+					return nil, nil, fmt.Errorf("failed to decode stored OAuth scopes for server %s: %w", s.Name, err)
 				}
 				oauthConfig := client.OAuthConfig{
 					ClientID:     tokenModel.ClientID,
@@ -306,41 +350,48 @@ func createHTTPMcpServerConn(
 				}
 				c, err = client.NewOAuthStreamableHttpClient(conf.URL, oauthConfig, opts...)
 				if err != nil {
-					return nil, fmt.Errorf("failed to create OAuth streamable HTTP client for MCP server: %w", err)
+					return nil, nil, fmt.Errorf("failed to create OAuth streamable HTTP client for MCP server: %w", err)
 				}
 			}
 		} else if !errors.Is(err, apierrors.ErrNotFound) {
-			return nil, fmt.Errorf("failed to load stored OAuth token for server %s: %w", s.Name, err)
+			return nil, nil, fmt.Errorf("failed to load stored OAuth token for server %s: %w", s.Name, err)
 		}
 	}
 
 	if c == nil {
 		c, err = client.NewStreamableHttpClient(conf.URL, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create streamable HTTP client for MCP server: %w", err)
+			return nil, nil, fmt.Errorf("failed to create streamable HTTP client for MCP server: %w", err)
 		}
 	}
 
-	_, err = initializeHTTPClient(ctx, c, conf.URL, initReqTimeoutSec)
+	// starting the client is what dispatches the server's notifications, eg- about the progress of tool calls.
+	// The stream it listens to outlives the call the connection may be created for, so it doesn't get the call's context.
+	if err = c.Start(context.Background()); err != nil {
+		return nil, nil, fmt.Errorf("failed to start streamable HTTP transport for MCP server: %w", err)
+	}
+	routeClientRequests(ctx, c, s)
+
+	initResult, err := initializeHTTPClient(ctx, c, conf.URL, capabilities, initReqTimeoutSec)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"initialization request to MCP server timed out after %d seconds."+
 					" To increase the timeout, use the MCP_SERVER_INIT_REQ_TIMEOUT_SEC environment variable for the mcpjungle server",
 				initReqTimeoutSec,
 			)
 		}
 		if errors.Is(err, syscall.ECONNREFUSED) && isLoopbackURL(conf.URL) {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"connection to the MCP server %s was refused. "+
 					"If mcpjungle is running inside Docker, use 'host.docker.internal' as your MCP server's hostname",
 				conf.URL,
 			)
 		}
-		return nil, fmt.Errorf("failed to initialize connection with MCP server: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize connection with MCP server: %w", err)
 	}
 
-	return c, nil
+	return c, initResult, nil
 }
 
 // captureStdioServerStderr captures the stderr output of a stdio MCP server in the background
@@ -372,10 +423,10 @@ func captureStdioServerStderr(name string, c *client.Client) {
 }
 
 // runStdioServer runs a stdio MCP server and returns the client.
-func runStdioServer(ctx context.Context, s *model.McpServer, initReqTimeoutSec int) (*client.Client, error) {
+func runStdioServer(ctx context.Context, s *model.McpServer, initReqTimeoutSec int) (*client.Client, *mcp.InitializeResult, error) {
 	conf, err := s.GetStdioConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get stdio config for MCP server %s: %w", s.Name, err)
+		return nil, nil, fmt.Errorf("failed to get stdio config for MCP server %s: %w", s.Name, err)
 	}
 
 	// Convert the environment map to a slice of strings in the format "KEY=VALUE"
@@ -388,38 +439,44 @@ func runStdioServer(ctx context.Context, s *model.McpServer, initReqTimeoutSec i
 
 	c, err := client.NewStdioMCPClient(conf.Command, envVars, conf.Args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stdio client for MCP server: %w", err)
+		return nil, nil, fmt.Errorf("failed to create stdio client for MCP server: %w", err)
 	}
 
 	// currently, we only capture the stderr output in the mcpjungle server logs.
 	// TODO: Propagate the stderr output to the client as well to provide them quicker feedback on errors.
 	captureStdioServerStderr(s.Name, c)
 
+	// the transport is already running, but starting the client is what dispatches the server's notifications
+	if err = c.Start(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to start stdio client for MCP server: %w", err)
+	}
+	routeClientRequests(ctx, c, s)
+
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
 	initRequest.Params.ClientInfo = mcp.Implementation{
 		Name:    "mcpjungle mcp client for stdio",
 		Version: "0.1",
 	}
-	initRequest.Params.Capabilities = mcp.ClientCapabilities{}
+	initRequest.Params.Capabilities = upstreamClientCapabilities(ctx, s)
 
 	initCtx, cancel := context.WithTimeout(ctx, time.Duration(initReqTimeoutSec)*time.Second)
 	defer cancel()
 
-	_, err = c.Initialize(initCtx, initRequest)
+	initResult, err := c.Initialize(initCtx, initRequest)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"initialization request to MCP server timed out after %d seconds,"+
 					" check mcpjungle server logs for any errors from this MCP server."+
 					" To increase the timeout, use the MCP_SERVER_INIT_REQ_TIMEOUT_SEC environment variable for the mcpjungle server",
 				initReqTimeoutSec,
 			)
 		}
-		return nil, fmt.Errorf("failed to initialize connection with MCP server: %w", err)
+		return nil, nil, fmt.Errorf("failed to initialize connection with MCP server: %w", err)
 	}
 
-	return c, nil
+	return c, initResult, nil
 }
 
 // defaultSSEInitializeRequest builds the standard initialize payload used for SSE upstreams.
@@ -441,10 +498,10 @@ func createSSEMcpServerConn(
 	db *gorm.DB,
 	s *model.McpServer,
 	useStoredUpstreamAuth bool,
-) (*client.Client, error) {
+) (*client.Client, *mcp.InitializeResult, error) {
 	conf, err := s.GetSSEConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get SSE transport config for MCP server %s: %w", s.Name, err)
+		return nil, nil, fmt.Errorf("failed to get SSE transport config for MCP server %s: %w", s.Name, err)
 	}
 
 	var (
@@ -465,7 +522,7 @@ func createSSEMcpServerConn(
 			if hasStoredOAuthTokens {
 				scopes, err := scopesFromJSON(tokenModel.Scopes)
 				if err != nil {
-					return nil, fmt.Errorf("failed to decode stored OAuth scopes for server %s: %w", s.Name, err)
+					return nil, nil, fmt.Errorf("failed to decode stored OAuth scopes for server %s: %w", s.Name, err)
 				}
 				oauthConfig := client.OAuthConfig{
 					ClientID:     tokenModel.ClientID,
@@ -481,29 +538,29 @@ func createSSEMcpServerConn(
 				}
 				c, err = client.NewOAuthSSEClient(conf.URL, oauthConfig, opts...)
 				if err != nil {
-					return nil, fmt.Errorf("failed to create OAuth SSE client for MCP server: %w", err)
+					return nil, nil, fmt.Errorf("failed to create OAuth SSE client for MCP server: %w", err)
 				}
 			}
 		} else if !errors.Is(err, apierrors.ErrNotFound) {
-			return nil, fmt.Errorf("failed to load stored OAuth token for server %s: %w", s.Name, err)
+			return nil, nil, fmt.Errorf("failed to load stored OAuth token for server %s: %w", s.Name, err)
 		}
 	}
 	if c == nil {
 		c, err = client.NewSSEMCPClient(conf.URL, opts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create SSE client for MCP server: %w", err)
+			return nil, nil, fmt.Errorf("failed to create SSE client for MCP server: %w", err)
 		}
 	}
 
 	if err = c.Start(ctx); err != nil {
-		return nil, fmt.Errorf("failed to start SSE transport for MCP server: %w", err)
+		return nil, nil, fmt.Errorf("failed to start SSE transport for MCP server: %w", err)
 	}
 
 	initReq := defaultSSEInitializeRequest()
-	_, err = c.Initialize(ctx, initReq)
+	initResult, err := c.Initialize(ctx, initReq)
 	if err != nil {
-		return nil, fmt.Errorf("client failed to initialize connection with SSE MCP server: %w", err)
+		return nil, nil, fmt.Errorf("client failed to initialize connection with SSE MCP server: %w", err)
 	}
 
-	return c, nil
+	return c, initResult, nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/mcpjungle/mcpjungle/internal"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"gorm.io/gorm"
 )
@@ -31,7 +32,13 @@ func (m *McpClientService) ListClients() ([]*model.McpClient, error) {
 
 // CreateClient creates a new MCP client in the database.
 // It also generates a new access token for the client.
+// The client belongs to the namespace its name is qualified with, if any.
 func (m *McpClientService) CreateClient(client model.McpClient) (*model.McpClient, error) {
+	client.Namespace, _ = model.SplitQualifiedName(client.Name)
+	if err := namespace.CheckExists(m.db, client.Namespace); err != nil {
+		return nil, err
+	}
+
 	if client.AccessToken != "" {
 		// user has supplied a custom access token, validate it
 		if err := internal.ValidateAccessToken(client.AccessToken); err != nil {
@@ -52,12 +59,36 @@ func (m *McpClientService) CreateClient(client model.McpClient) (*model.McpClien
 		client.AllowList = []byte("[]")
 	}
 
+	if err := m.checkAllowedGroupsExist(&client); err != nil {
+		return nil, err
+	}
+
 	if err := m.db.Create(&client).Error; err != nil {
 		return nil, err
 	}
 	return &client, nil
 }
 
+// checkAllowedGroupsExist returns an error if a tool group the client is bound to does not exist.
+// The group names are relative to the client's namespace.
+func (m *McpClientService) checkAllowedGroupsExist(client *model.McpClient) error {
+	groups, err := client.GetAllowedGroups()
+	if err != nil {
+		return fmt.Errorf("invalid allowed groups: %v: %w", err, apierrors.ErrInvalidInput)
+	}
+	for _, group := range groups {
+		var count int64
+		name := model.QualifiedName(client.Namespace, group)
+		if err := m.db.Model(&model.ToolGroup{}).Where("name = ?", name).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to look up tool group %s: %w", group, err)
+		}
+		if count == 0 {
+			return fmt.Errorf("tool group %s does not exist: %w", group, apierrors.ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
 // GetClientByToken retrieves an MCP client by its access token from the database.
 // It returns an error if no such client is found.
 func (m *McpClientService) GetClientByToken(token string) (*model.McpClient, error) {
@@ -7,6 +7,7 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"gorm.io/datatypes"
 )
 
 func TestNewMCPClientService(t *testing.T) {
@@ -136,6 +137,30 @@ func TestCreateClientWithInvalidAccessToken(t *testing.T) {
 	}
 }
 
+func TestCreateClientWithAllowedGroups(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewMCPClientService(setup.DB)
+	testhelpers.AssertNoError(t, setup.DB.Create(&model.Namespace{Name: "team-a"}).Error)
+	testhelpers.AssertNoError(t, setup.DB.Create(&model.Namespace{Name: "team-b"}).Error)
+	testhelpers.AssertNoError(t, setup.DB.Create(&model.ToolGroup{Name: "team-a.math"}).Error)
+
+	client, err := svc.CreateClient(model.McpClient{
+		Name:          "team-a.cursor",
+		AllowedGroups: datatypes.JSON(`["math"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, client.IsBoundToGroups(), "expected client to be bound to groups")
+
+	// group names are relative to the client's namespace, so a group outside of it does not exist
+	_, err = svc.CreateClient(model.McpClient{
+		Name:          "team-b.cursor",
+		AllowedGroups: datatypes.JSON(`["math"]`),
+	})
+	testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected ErrInvalidInput")
+}
+
 func TestGetClientByToken(t *testing.T) {
 	db, err := testhelpers.CreateTestDB()
 	testhelpers.AssertNoError(t, err)
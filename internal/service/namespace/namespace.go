@@ -0,0 +1,109 @@
+// Package namespace provides namespace service functionality for the MCPJungle application.
+package namespace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"gorm.io/gorm"
+)
+
+// NamespaceService provides methods to manage namespaces in the database.
+type NamespaceService struct {
+	db *gorm.DB
+}
+
+func NewNamespaceService(db *gorm.DB) *NamespaceService {
+	return &NamespaceService{db: db}
+}
+
+// CreateNamespace creates a new namespace.
+func (n *NamespaceService) CreateNamespace(ns *model.Namespace) error {
+	if !model.ValidNamespaceName.MatchString(ns.Name) {
+		return fmt.Errorf(
+			"invalid namespace name: '%s' must follow the regular expression %s: %w",
+			ns.Name,
+			model.ValidNamespaceName,
+			apierrors.ErrInvalidInput,
+		)
+	}
+	if _, err := n.GetNamespace(ns.Name); err == nil {
+		return fmt.Errorf("namespace %s already exists: %w", ns.Name, apierrors.ErrInvalidInput)
+	}
+	if err := n.db.Create(ns).Error; err != nil {
+		return fmt.Errorf("failed to create namespace: %w", err)
+	}
+	return nil
+}
+
+// ListNamespaces returns all namespaces.
+func (n *NamespaceService) ListNamespaces() ([]model.Namespace, error) {
+	var namespaces []model.Namespace
+	if err := n.db.Order("name").Find(&namespaces).Error; err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	return namespaces, nil
+}
+
+// GetNamespace returns the namespace with the given name.
+func (n *NamespaceService) GetNamespace(name string) (*model.Namespace, error) {
+	var ns model.Namespace
+	if err := n.db.Where("name = ?", name).First(&ns).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("namespace %s not found: %w", name, apierrors.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+	return &ns, nil
+}
+
+// DeleteNamespace deletes the namespace with the given name.
+// A namespace can only be deleted once all MCP servers, tool groups, MCP clients and users in it are removed,
+// otherwise these would become unreachable.
+func (n *NamespaceService) DeleteNamespace(name string) error {
+	if _, err := n.GetNamespace(name); err != nil {
+		return err
+	}
+	for _, m := range []struct {
+		model any
+		kind  string
+	}{
+		{&model.McpServer{}, "MCP servers"},
+		{&model.ToolGroup{}, "tool groups"},
+		{&model.McpClient{}, "MCP clients"},
+		{&model.User{}, "users"},
+	} {
+		var count int64
+		if err := n.db.Model(m.model).Where("namespace = ?", name).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to count %s in namespace %s: %w", m.kind, name, err)
+		}
+		if count > 0 {
+			return fmt.Errorf(
+				"namespace %s still contains %d %s, remove them first: %w", name, count, m.kind, apierrors.ErrInvalidInput,
+			)
+		}
+	}
+	if err := n.db.Unscoped().Where("name = ?", name).Delete(&model.Namespace{}).Error; err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// CheckExists returns an error if the given namespace does not exist.
+// The empty namespace always exists, it holds all entities that are not part of any namespace.
+// It is used by other services before creating an entity in a namespace.
+func CheckExists(db *gorm.DB, name string) error {
+	if name == "" {
+		return nil
+	}
+	var count int64
+	if err := db.Model(&model.Namespace{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check namespace %s: %w", name, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("namespace %s does not exist: %w", name, apierrors.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package namespace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestCreateAndListNamespaces(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewNamespaceService(setup.DB)
+
+	testhelpers.AssertNoError(t, svc.CreateNamespace(&model.Namespace{Name: "team-b"}))
+	testhelpers.AssertNoError(t, svc.CreateNamespace(&model.Namespace{Name: "team-a", Description: "Team A"}))
+
+	namespaces, err := svc.ListNamespaces()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(namespaces))
+	testhelpers.AssertEqual(t, "team-a", namespaces[0].Name)
+	testhelpers.AssertEqual(t, "Team A", namespaces[0].Description)
+	testhelpers.AssertEqual(t, "team-b", namespaces[1].Name)
+}
+
+func TestCreateNamespaceInvalid(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewNamespaceService(setup.DB)
+
+	for _, name := range []string{"", "team_a", "team.a", "-team"} {
+		err := svc.CreateNamespace(&model.Namespace{Name: name})
+		if !errors.Is(err, apierrors.ErrInvalidInput) {
+			t.Errorf("expected ErrInvalidInput for namespace name %q, got %v", name, err)
+		}
+	}
+
+	testhelpers.AssertNoError(t, svc.CreateNamespace(&model.Namespace{Name: "team-a"}))
+	err := svc.CreateNamespace(&model.Namespace{Name: "team-a"})
+	if !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for duplicate namespace, got %v", err)
+	}
+}
+
+func TestGetNamespaceNotFound(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewNamespaceService(setup.DB)
+
+	_, err := svc.GetNamespace("missing")
+	if !errors.Is(err, apierrors.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteNamespace(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewNamespaceService(setup.DB)
+	testhelpers.AssertNoError(t, svc.CreateNamespace(&model.Namespace{Name: "team-a"}))
+
+	// a namespace that still contains entities cannot be deleted
+	user := &model.User{Username: "team-a.alice", Role: types.UserRoleUser, Namespace: "team-a", AccessToken: "tok"}
+	testhelpers.AssertNoError(t, setup.DB.Create(user).Error)
+
+	err := svc.DeleteNamespace("team-a")
+	if !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput when deleting non-empty namespace, got %v", err)
+	}
+
+	testhelpers.AssertNoError(t, setup.DB.Unscoped().Delete(user).Error)
+	testhelpers.AssertNoError(t, svc.DeleteNamespace("team-a"))
+
+	_, err = svc.GetNamespace("team-a")
+	if !errors.Is(err, apierrors.ErrNotFound) {
+		t.Errorf("expected namespace to be deleted, got %v", err)
+	}
+
+	err = svc.DeleteNamespace("team-a")
+	if !errors.Is(err, apierrors.ErrNotFound) {
+		t.Errorf("expected ErrNotFound when deleting missing namespace, got %v", err)
+	}
+}
+
+func TestCheckExists(t *testing.T) {
+	setup := testhelpers.SetupTestDB(t)
+	defer setup.Cleanup()
+
+	svc := NewNamespaceService(setup.DB)
+	testhelpers.AssertNoError(t, svc.CreateNamespace(&model.Namespace{Name: "team-a"}))
+
+	testhelpers.AssertNoError(t, CheckExists(setup.DB, ""))
+	testhelpers.AssertNoError(t, CheckExists(setup.DB, "team-a"))
+	if err := CheckExists(setup.DB, "team-b"); !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for missing namespace, got %v", err)
+	}
+}
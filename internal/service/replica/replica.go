@@ -0,0 +1,339 @@
+// Package replica keeps multiple mcpjungle instances (replicas) that share the same registry database in sync.
+//
+// Every replica serves the registered tools, prompts, resources and tool groups from in-memory MCP proxy servers.
+// When a replica changes an MCP server or a tool group, it records the change in the database.
+// The other replicas pick up the change and reload the affected entity from the database.
+//
+// With postgres, changes are also announced using LISTEN/NOTIFY so that other replicas pick them up immediately.
+// The database is polled for changes as well, which is the only mechanism available with sqlite
+// and covers notifications that were missed, eg- while a replica was reconnecting to the database.
+package replica
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPollInterval is the default interval at which the database is polled for changes made by other replicas.
+	DefaultPollInterval = 5 * time.Second
+
+	// notificationChannel is the postgres channel on which changes are announced.
+	notificationChannel = "mcpjungle_registry_changes"
+
+	// changeRetention is how long changes are kept in the database.
+	// Replicas that start up load the whole registry, so changes are only needed by running replicas.
+	changeRetention = time.Hour
+
+	// pruneInterval is the interval at which changes older than changeRetention are deleted.
+	pruneInterval = 10 * time.Minute
+
+	// maxChangesPerSync limits the number of changes loaded from the database at once.
+	maxChangesPerSync = 500
+
+	// gapTimeout is how long a change whose ID was skipped is waited for.
+	// With postgres, concurrent inserts can commit out of the order of their IDs, so a change with a lower ID
+	// may become visible after one with a higher ID. IDs of inserts that were rolled back are never used though.
+	gapTimeout = time.Minute
+
+	// maxGap is the largest number of consecutive skipped IDs that are waited for.
+	// Larger gaps come from sequence jumps, eg- after postgres restarted, rather than from pending inserts.
+	maxGap = maxChangesPerSync
+)
+
+// Syncer publishes the changes made by this replica and applies the changes made by other replicas.
+type Syncer struct {
+	db *gorm.DB
+
+	// id identifies this replica as the origin of the changes it publishes.
+	id string
+
+	pollInterval time.Duration
+
+	mcpService       *mcp.MCPService
+	toolGroupService *toolgroup.ToolGroupService
+
+	// mu serializes applying changes, lastSeen is the highest ID of the changes that were applied.
+	mu       sync.Mutex
+	lastSeen uint
+	// gaps contains the IDs below lastSeen of changes that were not visible yet when the changes after them
+	// were applied, along with the time they were skipped at. They are applied once they become visible.
+	gaps map[uint]time.Time
+
+	// wakeup is signaled when a change was announced by another replica.
+	wakeup chan struct{}
+}
+
+// NewSyncer creates a new Syncer for the replica using the given database.
+// It should be created before the registry is loaded from the database, so that no change made by
+// another replica in the meantime is missed. Changes made before are already part of the loaded registry.
+// If pollInterval is not positive, DefaultPollInterval is used.
+func NewSyncer(db *gorm.DB, pollInterval time.Duration) (*Syncer, error) {
+	id, err := newReplicaID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate replica ID: %w", err)
+	}
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	var lastSeen uint
+	if err := db.Model(&model.RegistryChange{}).Select("COALESCE(MAX(id), 0)").Scan(&lastSeen).Error; err != nil {
+		return nil, fmt.Errorf("failed to get the latest registry change: %w", err)
+	}
+
+	return &Syncer{
+		db:           db,
+		id:           id,
+		pollInterval: pollInterval,
+		lastSeen:     lastSeen,
+		gaps:         make(map[uint]time.Time),
+		wakeup:       make(chan struct{}, 1),
+	}, nil
+}
+
+// ID returns the ID of this replica.
+func (s *Syncer) ID() string {
+	return s.id
+}
+
+// Register makes the Syncer publish the changes made through the given services
+// and apply the changes made by other replicas to them.
+func (s *Syncer) Register(mcpService *mcp.MCPService, toolGroupService *toolgroup.ToolGroupService) {
+	s.mcpService = mcpService
+	s.toolGroupService = toolGroupService
+
+	mcpService.SetServerChangeCallback(func(serverName string) {
+		s.Publish(model.RegistryChangeServer, serverName)
+	})
+	toolGroupService.SetChangeCallback(func(groupName string) {
+		s.Publish(model.RegistryChangeToolGroup, groupName)
+	})
+}
+
+// Publish records a change to an MCP server or a tool group so that other replicas apply it.
+// The change has already been applied by this replica at this point, so failing to publish it is only logged.
+func (s *Syncer) Publish(entityType model.RegistryChangeEntityType, name string) {
+	change := &model.RegistryChange{EntityType: entityType, EntityName: name, Origin: s.id}
+	if err := s.db.Create(change).Error; err != nil {
+		log.Printf("[ERROR] failed to publish change of %s %s to other replicas: %v", entityType, name, err)
+		return
+	}
+	if !s.isPostgres() {
+		return
+	}
+	err := s.db.Exec("SELECT pg_notify(?, ?)", notificationChannel, strconv.FormatUint(uint64(change.ID), 10)).Error
+	if err != nil {
+		// other replicas still pick up the change when they poll the database next time
+		log.Printf("[WARN] failed to notify other replicas about change of %s %s: %v", entityType, name, err)
+	}
+}
+
+// Run applies the changes made by other replicas until the context is cancelled.
+func (s *Syncer) Run(ctx context.Context) {
+	if s.isPostgres() {
+		go s.listen(ctx)
+	}
+
+	pollTicker := time.NewTicker(s.pollInterval)
+	defer pollTicker.Stop()
+	pruneTicker := time.NewTicker(pruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pollTicker.C:
+		case <-s.wakeup:
+		case <-pruneTicker.C:
+			s.prune()
+			continue
+		}
+		if err := s.Sync(); err != nil {
+			log.Printf("[ERROR] failed to apply registry changes made by other replicas: %v", err)
+		}
+	}
+}
+
+// Sync applies all changes made by other replicas since the last sync.
+// Every changed server and tool group is reloaded once, no matter how often it changed.
+// Servers are reloaded before tool groups, so the groups see the current tools of the servers.
+func (s *Syncer) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expireGaps()
+	for {
+		query := s.db.Where("id > ?", s.lastSeen)
+		if len(s.gaps) > 0 {
+			query = s.db.Where("id > ? OR id IN ?", s.lastSeen, slices.Collect(maps.Keys(s.gaps)))
+		}
+		var changes []model.RegistryChange
+		if err := query.Order("id").Limit(maxChangesPerSync).Find(&changes).Error; err != nil {
+			return fmt.Errorf("failed to load registry changes: %w", err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		s.trackGaps(changes)
+
+		var servers, groups []string
+		seen := make(map[model.RegistryChangeEntityType]map[string]bool)
+		for _, c := range changes {
+			if c.Origin == s.id || seen[c.EntityType][c.EntityName] {
+				continue
+			}
+			if seen[c.EntityType] == nil {
+				seen[c.EntityType] = make(map[string]bool)
+			}
+			seen[c.EntityType][c.EntityName] = true
+
+			switch c.EntityType {
+			case model.RegistryChangeServer:
+				servers = append(servers, c.EntityName)
+			case model.RegistryChangeToolGroup:
+				groups = append(groups, c.EntityName)
+			default:
+				log.Printf("[WARN] ignoring change of unknown entity type %s", c.EntityType)
+			}
+		}
+
+		// a failed reload is only logged, retrying it would not help if the entity is in an invalid state.
+		for _, name := range servers {
+			if err := s.mcpService.ReloadMcpServer(name); err != nil {
+				log.Printf("[ERROR] failed to reload MCP server %s changed by another replica: %v", name, err)
+			}
+		}
+		for _, name := range groups {
+			if err := s.toolGroupService.ReloadToolGroup(name); err != nil {
+				log.Printf("[ERROR] failed to reload tool group %s changed by another replica: %v", name, err)
+			}
+		}
+
+		if len(changes) < maxChangesPerSync {
+			return nil
+		}
+	}
+}
+
+// trackGaps records the IDs skipped by the given changes, which are ordered by ID, as gaps
+// and moves lastSeen past them. Changes that fill a gap remove it.
+func (s *Syncer) trackGaps(changes []model.RegistryChange) {
+	now := time.Now()
+	for _, c := range changes {
+		if c.ID <= s.lastSeen {
+			delete(s.gaps, c.ID)
+			continue
+		}
+		if c.ID-s.lastSeen-1 <= maxGap {
+			for id := s.lastSeen + 1; id < c.ID; id++ {
+				s.gaps[id] = now
+			}
+		}
+		s.lastSeen = c.ID
+	}
+}
+
+// expireGaps stops waiting for the changes that were skipped longer than gapTimeout ago.
+func (s *Syncer) expireGaps() {
+	cutoff := time.Now().Add(-gapTimeout)
+	maps.DeleteFunc(s.gaps, func(_ uint, skippedAt time.Time) bool {
+		return skippedAt.Before(cutoff)
+	})
+}
+
+// prune deletes changes that are old enough to have been applied by all running replicas.
+func (s *Syncer) prune() {
+	cutoff := time.Now().Add(-changeRetention)
+	if err := s.db.Where("created_at < ?", cutoff).Delete(&model.RegistryChange{}).Error; err != nil {
+		log.Printf("[WARN] failed to delete old registry changes: %v", err)
+	}
+}
+
+// listen wakes up the Syncer whenever another replica announces a change, until the context is cancelled.
+// If the connection to the database is lost, it reconnects after the poll interval.
+func (s *Syncer) listen(ctx context.Context) {
+	for {
+		err := s.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("[WARN] stopped listening for registry changes, falling back to polling until reconnected: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+func (s *Syncer) listenOnce(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unsupported postgres driver connection %T", driverConn)
+		}
+		pgConn := c.Conn()
+		if _, err := pgConn.Exec(ctx, "LISTEN "+notificationChannel); err != nil {
+			return fmt.Errorf("failed to listen on channel %s: %w", notificationChannel, err)
+		}
+		// changes announced while the replica was not listening are picked up right away
+		s.wake()
+
+		for {
+			if _, err := pgConn.WaitForNotification(ctx); err != nil {
+				// the connection is still listening on the channel, so it must not be reused by the pool
+				return errors.Join(driver.ErrBadConn, err)
+			}
+			s.wake()
+		}
+	})
+}
+
+// wake signals the Syncer to apply changes. Signals are coalesced while a sync is pending.
+func (s *Syncer) wake() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Syncer) isPostgres() bool {
+	return s.db.Dialector.Name() == "postgres"
+}
+
+func newReplicaID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
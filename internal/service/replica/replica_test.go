@@ -0,0 +1,196 @@
+package replica
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type testReplica struct {
+	mcpService       *mcp.MCPService
+	toolGroupService *toolgroup.ToolGroupService
+	syncer           *Syncer
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+	return db
+}
+
+func newTestReplica(t *testing.T, db *gorm.DB) *testReplica {
+	t.Helper()
+
+	syncer, err := NewSyncer(db, 0)
+	testhelpers.AssertNoError(t, err)
+
+	mcpService, err := mcp.NewMCPService(&mcp.ServiceConfig{
+		DB:                      db,
+		McpProxyServer:          server.NewMCPServer("test proxy", "0.0.1", server.WithToolCapabilities(true)),
+		Metrics:                 telemetry.NewNoopCustomMetrics(),
+		McpServerInitReqTimeout: 10,
+	})
+	testhelpers.AssertNoError(t, err)
+	toolGroupService, err := toolgroup.NewToolGroupService(db, mcpService)
+	testhelpers.AssertNoError(t, err)
+
+	syncer.Register(mcpService, toolGroupService)
+	return &testReplica{mcpService: mcpService, toolGroupService: toolGroupService, syncer: syncer}
+}
+
+func groupToolCount(r *testReplica, name string) int {
+	mcpServer, ok := r.toolGroupService.GetToolGroupMCPServer(name)
+	if !ok {
+		return -1
+	}
+	return len(mcpServer.ListTools())
+}
+
+func TestNewSyncer_SkipsExistingChanges(t *testing.T) {
+	db := newTestDB(t)
+	testhelpers.AssertNoError(t, db.Create(&model.RegistryChange{
+		EntityType: model.RegistryChangeServer, EntityName: "calc", Origin: "other",
+	}).Error)
+
+	syncer, err := NewSyncer(db, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, uint(1), syncer.lastSeen)
+	testhelpers.AssertEqual(t, DefaultPollInterval, syncer.pollInterval)
+	testhelpers.AssertTrue(t, syncer.ID() != "", "expected syncer to have an ID")
+}
+
+func TestSync_AppliesChangesMadeByOtherReplicas(t *testing.T) {
+	db := newTestDB(t)
+
+	srv := model.McpServer{
+		Name:      "calc",
+		Transport: types.TransportStreamableHTTP,
+		Enabled:   true,
+		Config:    datatypes.JSON(`{"url":"http://localhost:8000/mcp"}`),
+	}
+	testhelpers.AssertNoError(t, db.Create(&srv).Error)
+	for _, name := range []string{"add", "subtract"} {
+		tool := model.Tool{Name: name, Enabled: true, ServerID: srv.ID, InputSchema: datatypes.JSON(`{"type":"object"}`)}
+		testhelpers.AssertNoError(t, db.Create(&tool).Error)
+	}
+
+	origin := newTestReplica(t, db)
+	replica := newTestReplica(t, db)
+	testhelpers.AssertFalse(t, origin.syncer.ID() == replica.syncer.ID(), "expected replicas to have different IDs")
+
+	_, err := origin.mcpService.DisableTools("calc__subtract")
+	testhelpers.AssertNoError(t, err)
+	err = origin.toolGroupService.CreateToolGroup(&model.ToolGroup{
+		Name: "math", IncludedTools: datatypes.JSON(`["calc__add"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	// the changes are already applied by the replica that made them
+	var originDeletions []string
+	origin.mcpService.SetToolDeletionCallback(func(names ...string) {
+		originDeletions = append(originDeletions, names...)
+	})
+	testhelpers.AssertNoError(t, origin.syncer.Sync())
+	testhelpers.AssertEqual(t, 0, len(originDeletions))
+
+	_, ok := replica.mcpService.GetToolInstance("calc__subtract")
+	testhelpers.AssertTrue(t, ok, "expected tool to be served before syncing")
+	testhelpers.AssertEqual(t, -1, groupToolCount(replica, "math"))
+
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	_, ok = replica.mcpService.GetToolInstance("calc__subtract")
+	testhelpers.AssertFalse(t, ok, "expected disabled tool to not be served after syncing")
+	_, ok = replica.mcpService.GetToolInstance("calc__add")
+	testhelpers.AssertTrue(t, ok, "expected enabled tool to still be served after syncing")
+	testhelpers.AssertEqual(t, 1, groupToolCount(replica, "math"))
+
+	// changes are only applied once
+	var replicaDeletions []string
+	replica.mcpService.SetToolDeletionCallback(func(names ...string) {
+		replicaDeletions = append(replicaDeletions, names...)
+	})
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	testhelpers.AssertEqual(t, 0, len(replicaDeletions))
+
+	testhelpers.AssertNoError(t, origin.toolGroupService.DeleteToolGroup("math"))
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	testhelpers.AssertEqual(t, -1, groupToolCount(replica, "math"))
+}
+
+func TestPrune_DeletesOldChanges(t *testing.T) {
+	db := newTestDB(t)
+	syncer, err := NewSyncer(db, 0)
+	testhelpers.AssertNoError(t, err)
+
+	syncer.Publish(model.RegistryChangeServer, "old")
+	syncer.Publish(model.RegistryChangeServer, "new")
+	testhelpers.AssertNoError(t, db.Model(&model.RegistryChange{}).
+		Where("entity_name = ?", "old").
+		Update("created_at", time.Now().Add(-2*changeRetention)).Error)
+
+	syncer.prune()
+
+	var changes []model.RegistryChange
+	testhelpers.AssertNoError(t, db.Find(&changes).Error)
+	testhelpers.AssertEqual(t, 1, len(changes))
+	testhelpers.AssertEqual(t, "new", changes[0].EntityName)
+}
+
+func TestSync_AppliesChangesThatBecomeVisibleOutOfOrder(t *testing.T) {
+	db := newTestDB(t)
+
+	srv := model.McpServer{
+		Name:      "calc",
+		Transport: types.TransportStreamableHTTP,
+		Enabled:   true,
+		Config:    datatypes.JSON(`{"url":"http://localhost:8000/mcp"}`),
+	}
+	testhelpers.AssertNoError(t, db.Create(&srv).Error)
+	tool := model.Tool{Name: "add", Enabled: true, ServerID: srv.ID, InputSchema: datatypes.JSON(`{"type":"object"}`)}
+	testhelpers.AssertNoError(t, db.Create(&tool).Error)
+	testhelpers.AssertNoError(t, db.Create(&model.RegistryChange{
+		ID: 1, EntityType: model.RegistryChangeServer, EntityName: "calc", Origin: "other",
+	}).Error)
+
+	replica := newTestReplica(t, db)
+
+	// another replica commits the change with ID 3 before the change with ID 2
+	createGroup := func(name string, changeID uint) {
+		t.Helper()
+		testhelpers.AssertNoError(t, db.Create(&model.ToolGroup{
+			Name: name, IncludedTools: datatypes.JSON(`["calc__add"]`),
+		}).Error)
+		testhelpers.AssertNoError(t, db.Create(&model.RegistryChange{
+			ID: changeID, EntityType: model.RegistryChangeToolGroup, EntityName: name, Origin: "other",
+		}).Error)
+	}
+	createGroup("math", 3)
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	testhelpers.AssertEqual(t, 1, groupToolCount(replica, "math"))
+	testhelpers.AssertEqual(t, uint(3), replica.syncer.lastSeen)
+
+	createGroup("stats", 2)
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	testhelpers.AssertEqual(t, 1, groupToolCount(replica, "stats"))
+	testhelpers.AssertEqual(t, 0, len(replica.syncer.gaps))
+
+	// changes that never become visible are not waited for forever
+	createGroup("geometry", 5)
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	testhelpers.AssertEqual(t, 1, len(replica.syncer.gaps))
+	replica.syncer.gaps[4] = time.Now().Add(-2 * gapTimeout)
+	testhelpers.AssertNoError(t, replica.syncer.Sync())
+	testhelpers.AssertEqual(t, 0, len(replica.syncer.gaps))
+}
@@ -0,0 +1,307 @@
+// Package revision records the configuration history of MCP servers and tool groups
+// as numbered revisions, so that changes can be inspected, compared and rolled back.
+package revision
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var ErrRevisionNotFound = fmt.Errorf("revision not found: %w", apierrors.ErrNotFound)
+
+// RevisionService provides methods to record and query revisions of servers and tool groups.
+type RevisionService struct {
+	db *gorm.DB
+}
+
+func NewRevisionService(db *gorm.DB) *RevisionService {
+	return &RevisionService{db: db}
+}
+
+// Record stores the snapshot as the next revision of the given entity.
+// Recording an update whose snapshot is identical to the latest revision is a no-op,
+// the latest revision is returned instead.
+func (r *RevisionService) Record(
+	entityType model.RevisionEntityType,
+	name string,
+	action model.RevisionAction,
+	author string,
+	snapshot any,
+) (*model.Revision, error) {
+	data, err := marshalSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	rev := &model.Revision{
+		EntityType: entityType,
+		EntityName: name,
+		Action:     action,
+		Author:     author,
+		Snapshot:   data,
+	}
+	if err := r.record(rev); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// RecordBaseline stores the snapshot as revision 1 of an entity that has no history yet.
+// It is called with the configuration of an entity right before it is changed, so that
+// entities created before revisions were recorded can still be rolled back to their original state.
+// If the entity already has revisions or the snapshot is nil, nothing is recorded.
+func (r *RevisionService) RecordBaseline(entityType model.RevisionEntityType, name string, snapshot any) error {
+	data, err := marshalSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	if isNullJSON(data) {
+		return nil
+	}
+
+	var count int64
+	err = r.db.Model(&model.Revision{}).
+		Where("entity_type = ? AND entity_name = ?", entityType, name).
+		Count(&count).Error
+	if err != nil {
+		return fmt.Errorf("failed to count revisions of %s %s: %w", entityType, name, err)
+	}
+	if count > 0 {
+		return nil
+	}
+	return r.record(&model.Revision{
+		EntityType: entityType,
+		EntityName: name,
+		Action:     model.RevisionActionBaseline,
+		Snapshot:   data,
+	})
+}
+
+// RecordRollback stores a new revision that restores the configuration of an earlier revision.
+func (r *RevisionService) RecordRollback(author string, restored *model.Revision) (*model.Revision, error) {
+	rev := &model.Revision{
+		EntityType: restored.EntityType,
+		EntityName: restored.EntityName,
+		Action:     model.RevisionActionRolledBack,
+		Author:     author,
+		Note:       fmt.Sprintf("restored revision %d", restored.Number),
+		Snapshot:   restored.Snapshot,
+	}
+	if err := r.record(rev); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// ListRevisions returns all revisions of an entity, oldest first.
+func (r *RevisionService) ListRevisions(entityType model.RevisionEntityType, name string) ([]model.Revision, error) {
+	var revisions []model.Revision
+	err := r.db.Where("entity_type = ? AND entity_name = ?", entityType, name).
+		Order("number").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions of %s %s: %w", entityType, name, err)
+	}
+	return revisions, nil
+}
+
+// GetRevision returns a specific revision of an entity.
+// If the revision does not exist, it returns ErrRevisionNotFound.
+func (r *RevisionService) GetRevision(entityType model.RevisionEntityType, name string, number int) (*model.Revision, error) {
+	var rev model.Revision
+	err := r.db.Where("entity_type = ? AND entity_name = ? AND number = ?", entityType, name, number).
+		First(&rev).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("revision %d of %s %s: %w", number, entityType, name, ErrRevisionNotFound)
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// Diff compares two revisions of an entity and returns the changed fields, sorted by field name.
+// If to is 0, the latest revision is used. If from is 0, the revision right before to is used.
+func (r *RevisionService) Diff(entityType model.RevisionEntityType, name string, from, to int) (*types.RevisionDiff, error) {
+	if to == 0 {
+		latest, err := r.latestNumber(entityType, name)
+		if err != nil {
+			return nil, err
+		}
+		if latest == 0 {
+			return nil, fmt.Errorf("%s %s has no revisions: %w", entityType, name, ErrRevisionNotFound)
+		}
+		to = latest
+	}
+	if from == 0 {
+		from = to - 1
+	}
+	if from < 1 || to < 1 {
+		return nil, fmt.Errorf("revision numbers must be positive: %w", apierrors.ErrInvalidInput)
+	}
+
+	fromRev, err := r.GetRevision(entityType, name, from)
+	if err != nil {
+		return nil, err
+	}
+	toRev, err := r.GetRevision(entityType, name, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffSnapshots(fromRev.Snapshot, toRev.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare revisions %d and %d: %w", from, to, err)
+	}
+	return &types.RevisionDiff{Name: name, From: from, To: to, Changes: changes}, nil
+}
+
+// record assigns the next revision number to rev and inserts it.
+func (r *RevisionService) record(rev *model.Revision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var latest model.Revision
+		err := tx.Where("entity_type = ? AND entity_name = ?", rev.EntityType, rev.EntityName).
+			Order("number DESC").
+			First(&latest).Error
+		switch {
+		case err == nil:
+			if rev.Action == model.RevisionActionUpdated && sameSnapshot(latest.Snapshot, rev.Snapshot) {
+				*rev = latest
+				return nil
+			}
+			rev.Number = latest.Number + 1
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			rev.Number = 1
+		default:
+			return fmt.Errorf("failed to read latest revision of %s %s: %w", rev.EntityType, rev.EntityName, err)
+		}
+
+		if err := tx.Create(rev).Error; err != nil {
+			return fmt.Errorf("failed to record revision of %s %s: %w", rev.EntityType, rev.EntityName, err)
+		}
+		return nil
+	})
+}
+
+func (r *RevisionService) latestNumber(entityType model.RevisionEntityType, name string) (int, error) {
+	var latest int
+	err := r.db.Model(&model.Revision{}).
+		Where("entity_type = ? AND entity_name = ?", entityType, name).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to read latest revision of %s %s: %w", entityType, name, err)
+	}
+	return latest, nil
+}
+
+// CompareSnapshots reports the differences between two configurations of the same entity,
+// the same way Diff does for recorded revisions.
+func CompareSnapshots(from, to any) ([]types.RevisionChange, error) {
+	fromData, err := marshalSnapshot(from)
+	if err != nil {
+		return nil, err
+	}
+	toData, err := marshalSnapshot(to)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(fromData, toData)
+}
+
+func marshalSnapshot(snapshot any) (datatypes.JSON, error) {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize revision snapshot: %w", err)
+	}
+	return data, nil
+}
+
+func isNullJSON(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null"))
+}
+
+// sameSnapshot compares two snapshots semantically, since databases like postgres
+// may re-format JSON columns.
+func sameSnapshot(a, b datatypes.JSON) bool {
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// diffSnapshots flattens both snapshots into dotted field paths and reports every path
+// whose value differs. Lists are compared as a whole.
+func diffSnapshots(from, to datatypes.JSON) ([]types.RevisionChange, error) {
+	oldFields, err := flattenSnapshot(from)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := flattenSnapshot(to)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]struct{}, len(oldFields)+len(newFields))
+	for f := range oldFields {
+		fields[f] = struct{}{}
+	}
+	for f := range newFields {
+		fields[f] = struct{}{}
+	}
+
+	changes := make([]types.RevisionChange, 0)
+	for f := range fields {
+		oldVal, newVal := oldFields[f], newFields[f]
+		if reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+		changes = append(changes, types.RevisionChange{Field: f, Old: oldVal, New: newVal})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+func flattenSnapshot(data datatypes.JSON) (map[string]any, error) {
+	fields := make(map[string]any)
+	if isNullJSON(data) {
+		return fields, nil
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	flattenValue("", v, fields)
+	return fields, nil
+}
+
+func flattenValue(prefix string, v any, fields map[string]any) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		if prefix != "" {
+			fields[prefix] = v
+		}
+		return
+	}
+	for k, child := range obj {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		flattenValue(path, child, fields)
+	}
+}
@@ -0,0 +1,151 @@
+package revision
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func newTestService(t *testing.T) *RevisionService {
+	t.Helper()
+	setup := testhelpers.SetupTestDB(t)
+	t.Cleanup(setup.Cleanup)
+	return NewRevisionService(setup.DB)
+}
+
+func TestRecordNumbersRevisionsPerEntity(t *testing.T) {
+	svc := newTestService(t)
+
+	rev, err := svc.Record(model.RevisionEntityToolGroup, "g", model.RevisionActionCreated, "alice", &types.ToolGroup{Name: "g"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, rev.Number)
+
+	rev, err = svc.Record(model.RevisionEntityToolGroup, "g", model.RevisionActionUpdated, "bob", &types.ToolGroup{Name: "g", Description: "d"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, rev.Number)
+
+	// a server with the same name has its own history
+	rev, err = svc.Record(model.RevisionEntityServer, "g", model.RevisionActionCreated, "", &types.RegisterServerInput{Name: "g"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, rev.Number)
+
+	revisions, err := svc.ListRevisions(model.RevisionEntityToolGroup, "g")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(revisions))
+	testhelpers.AssertEqual(t, "alice", revisions[0].Author)
+	testhelpers.AssertEqual(t, model.RevisionActionUpdated, revisions[1].Action)
+}
+
+func TestRecordSkipsUnchangedUpdate(t *testing.T) {
+	svc := newTestService(t)
+
+	_, err := svc.Record(model.RevisionEntityToolGroup, "g", model.RevisionActionCreated, "", &types.ToolGroup{Name: "g"})
+	testhelpers.AssertNoError(t, err)
+	rev, err := svc.Record(model.RevisionEntityToolGroup, "g", model.RevisionActionUpdated, "", &types.ToolGroup{Name: "g"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, rev.Number)
+}
+
+func TestRecordBaseline(t *testing.T) {
+	svc := newTestService(t)
+
+	// nil snapshots are ignored
+	var missing *types.ToolGroup
+	testhelpers.AssertNoError(t, svc.RecordBaseline(model.RevisionEntityToolGroup, "g", missing))
+
+	testhelpers.AssertNoError(t, svc.RecordBaseline(model.RevisionEntityToolGroup, "g", &types.ToolGroup{Name: "g"}))
+	testhelpers.AssertNoError(t, svc.RecordBaseline(model.RevisionEntityToolGroup, "g", &types.ToolGroup{Name: "g", Description: "x"}))
+
+	revisions, err := svc.ListRevisions(model.RevisionEntityToolGroup, "g")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(revisions))
+	testhelpers.AssertEqual(t, model.RevisionActionBaseline, revisions[0].Action)
+}
+
+func TestRecordRollback(t *testing.T) {
+	svc := newTestService(t)
+
+	first, err := svc.Record(model.RevisionEntityToolGroup, "g", model.RevisionActionCreated, "", &types.ToolGroup{Name: "g"})
+	testhelpers.AssertNoError(t, err)
+	_, err = svc.Record(model.RevisionEntityToolGroup, "g", model.RevisionActionDeleted, "", nil)
+	testhelpers.AssertNoError(t, err)
+
+	rev, err := svc.RecordRollback("carol", first)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 3, rev.Number)
+	testhelpers.AssertEqual(t, model.RevisionActionRolledBack, rev.Action)
+	testhelpers.AssertEqual(t, "restored revision 1", rev.Note)
+}
+
+func TestGetRevisionNotFound(t *testing.T) {
+	svc := newTestService(t)
+
+	_, err := svc.GetRevision(model.RevisionEntityServer, "missing", 1)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrRevisionNotFound), "expected ErrRevisionNotFound")
+	testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrNotFound), "expected ErrNotFound")
+}
+
+func TestDiff(t *testing.T) {
+	svc := newTestService(t)
+
+	_, err := svc.Record(model.RevisionEntityServer, "s", model.RevisionActionCreated, "", &types.RegisterServerInput{
+		Name:      "s",
+		Transport: "streamable_http",
+		URL:       "http://old",
+		Headers:   map[string]string{"X-A": "1", "X-B": "2"},
+	})
+	testhelpers.AssertNoError(t, err)
+	_, err = svc.Record(model.RevisionEntityServer, "s", model.RevisionActionUpdated, "", &types.RegisterServerInput{
+		Name:        "s",
+		Transport:   "streamable_http",
+		URL:         "http://new",
+		Description: "added",
+		Headers:     map[string]string{"X-A": "1"},
+	})
+	testhelpers.AssertNoError(t, err)
+
+	diff, err := svc.Diff(model.RevisionEntityServer, "s", 0, 0)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, diff.From)
+	testhelpers.AssertEqual(t, 2, diff.To)
+	testhelpers.AssertEqual(t, 3, len(diff.Changes))
+
+	testhelpers.AssertEqual(t, "description", diff.Changes[0].Field)
+	testhelpers.AssertEqual(t, "", diff.Changes[0].Old)
+	testhelpers.AssertEqual(t, "added", diff.Changes[0].New)
+
+	testhelpers.AssertEqual(t, "headers.X-B", diff.Changes[1].Field)
+	testhelpers.AssertEqual(t, "2", diff.Changes[1].Old)
+	testhelpers.AssertTrue(t, diff.Changes[1].New == nil, "expected removed header to have no new value")
+
+	testhelpers.AssertEqual(t, "url", diff.Changes[2].Field)
+
+	_, err = svc.Diff(model.RevisionEntityServer, "s", 1, 5)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrRevisionNotFound), "expected ErrRevisionNotFound")
+
+	_, err = svc.Diff(model.RevisionEntityServer, "s", -1, 2)
+	testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected ErrInvalidInput")
+
+	_, err = svc.Diff(model.RevisionEntityServer, "unknown", 0, 0)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrRevisionNotFound), "expected ErrRevisionNotFound")
+}
+
+func TestCompareSnapshots(t *testing.T) {
+	changes, err := CompareSnapshots(
+		&types.RegisterServerInput{Name: "s", URL: "http://old", BearerToken: "a"},
+		&types.RegisterServerInput{Name: "s", URL: "http://old", BearerToken: "b"},
+	)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(changes))
+	testhelpers.AssertEqual(t, "bearer_token", changes[0].Field)
+
+	changes, err = CompareSnapshots(nil, &types.ToolGroup{Name: "g", Description: "d"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(changes))
+	testhelpers.AssertEqual(t, "description", changes[0].Field)
+	testhelpers.AssertEqual(t, "name", changes[1].Field)
+}
@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/util"
@@ -34,19 +38,38 @@ type ToolGroupService struct {
 
 	mcpService *mcp.MCPService
 
-	// mcpServers manages the MCP proxy servers for all the tool groups
+	// mcpServers manages the MCP proxy servers for all the tool groups.
+	// Each group's proxy server is served over both the streamable HTTP and SSE transports.
 	// key: tool group name, value: MCP proxy server
 	mcpServers map[string]*server.MCPServer
 	// mcpServersMu protects access to the mcpServers map
 	mcpServersMu sync.RWMutex
 
-	// sseMcpServers manages the SSE MCP proxy servers for all the tool groups
-	// key: tool group name, value: MCP proxy server
-	sseMcpServers map[string]*server.MCPServer
-	// sseMcpServerMu protects access to the sseMcpServers map
-	sseMcpServerMu sync.RWMutex
+	// changeCallback is invoked when a tool group is created, updated or deleted by this instance.
+	changeCallback ChangeCallback
+}
+
+// entityResolver resolves the tools, prompts and resources of tool groups.
+// The MCP service resolves the entities of MCP servers, while the groups included by a group
+// are looked up through the tool group service.
+type entityResolver struct {
+	*mcp.MCPService
+	groups *ToolGroupService
+}
+
+func (r entityResolver) GetToolGroup(name string) (*model.ToolGroup, error) {
+	return r.groups.GetToolGroup(name)
+}
+
+// resolver returns the resolver used to compute the effective tools, prompts and resources of tool groups.
+func (s *ToolGroupService) resolver() entityResolver {
+	return entityResolver{MCPService: s.mcpService, groups: s}
 }
 
+// ChangeCallback is invoked after this mcpjungle instance created, updated or deleted a tool group.
+// The callback receives the name of the changed group.
+type ChangeCallback func(groupName string)
+
 func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService) (*ToolGroupService, error) {
 	s := &ToolGroupService{
 		db:         db,
@@ -55,13 +78,17 @@ func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService) (*ToolGroupSer
 		mcpServers:   make(map[string]*server.MCPServer),
 		mcpServersMu: sync.RWMutex{},
 
-		sseMcpServers:  make(map[string]*server.MCPServer),
-		sseMcpServerMu: sync.RWMutex{},
+		changeCallback: func(groupName string) {},
 	}
 
 	// register callbacks with mcp service to be notified when a tool gets added/removed
 	mcpService.SetToolDeletionCallback(s.handleToolDeletion)
 	mcpService.SetToolAdditionCallback(s.handleToolAddition)
+	// similarly, keep the prompts and resources of the tool groups in sync
+	mcpService.SetPromptDeletionCallback(s.handlePromptDeletion)
+	mcpService.SetPromptAdditionCallback(s.handlePromptAddition)
+	mcpService.SetResourceDeletionCallback(s.handleResourceDeletion)
+	mcpService.SetResourceAdditionCallback(s.handleResourceAddition)
 
 	if err := s.initToolGroupMCPServers(); err != nil {
 		return nil, fmt.Errorf("failed to initialize tool group MCP servers: %w", err)
@@ -69,37 +96,75 @@ func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService) (*ToolGroupSer
 	return s, nil
 }
 
-// CreateToolGroup creates a new tool group in the database and a Proxy MCP server that just exposes the specified tools.
+// CreateToolGroup creates a new tool group in the database and a Proxy MCP server that just exposes
+// the specified tools, prompts and resources.
 func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 	// validate the tool group name
 	if len(group.Name) == 0 {
 		return fmt.Errorf("tool group name cannot be empty: %w", apierrors.ErrInvalidInput)
 	}
-	if !ValidGroupName.MatchString(group.Name) {
+	// the name of a group in a namespace is qualified with the namespace's name
+	ns, localName := model.SplitQualifiedName(group.Name)
+	if !ValidGroupName.MatchString(localName) {
 		return fmt.Errorf(
 			"invalid group name: name must start with an alphanumeric character and "+
 				"can only contain alphanumeric characters, underscores, and hyphens: %w",
 			apierrors.ErrInvalidInput,
 		)
 	}
+	if err := namespace.CheckExists(s.db, ns); err != nil {
+		return err
+	}
+	group.Namespace = ns
+
+	if err := group.ValidateToolSelectors(); err != nil {
+		return fmt.Errorf("%v: %w", err, apierrors.ErrInvalidInput)
+	}
+	if err := group.ValidateToolOverrides(); err != nil {
+		return fmt.Errorf("invalid tool overrides: %v: %w", err, apierrors.ErrInvalidInput)
+	}
+	if err := s.validateIncludedGroups(group); err != nil {
+		return err
+	}
 
 	// resolve all effective tools for this group
-	toolNames, err := group.ResolveEffectiveTools(s.mcpService)
+	toolNames, err := group.ResolveEffectiveTools(s.resolver())
 	if err != nil {
 		return fmt.Errorf("failed to resolve effective tools: %w", err)
 	}
-	if len(toolNames) == 0 {
+	promptNames, err := group.ResolveEffectivePrompts(s.resolver())
+	if err != nil {
+		return fmt.Errorf("failed to resolve effective prompts: %w", err)
+	}
+	resourceURIs, err := group.ResolveEffectiveResources(s.resolver())
+	if err != nil {
+		return fmt.Errorf("failed to resolve effective resources: %w", err)
+	}
+	if len(toolNames) == 0 && len(promptNames) == 0 && len(resourceURIs) == 0 {
 		return fmt.Errorf(
-			"tool group must contain at least one tool after resolving servers and exclusions: %w",
+			"tool group must contain at least one tool, prompt or resource after resolving servers and exclusions: %w",
 			apierrors.ErrInvalidInput,
 		)
 	}
+	toolOverride := s.toolOverrideLookup(group)
+	if err := checkExposedToolNames(toolNames, toolOverride); err != nil {
+		return err
+	}
+
+	// prompts and resources are validated the same way as tools below
+	prompts, err := s.groupProxyPrompts(promptNames, false)
+	if err != nil {
+		return err
+	}
+	resources, err := s.groupProxyResources(group, resourceURIs, false)
+	if err != nil {
+		return err
+	}
 
-	// create the proxy MCP servers that expose only specified tools
+	// create the proxy MCP server that exposes only specified tools
 	mcpServer := s.newMCPServer(group.Name)
-	sseMcpServer := s.newSseMCPServer(group.Name)
 
-	// populate the MCP servers with the specified tools
+	// populate the MCP server with the specified tools
 	// this also has a side effect of validating that the tools exist in mcpjungle.
 	// if a tool does not exist, return an error without creating the group.
 	for _, name := range toolNames {
@@ -107,18 +172,10 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 		if !exists {
 			return fmt.Errorf("tool %s does not exist or is disabled: %w", name, apierrors.ErrInvalidInput)
 		}
-
-		parentServer, err := s.mcpService.GetToolParentServer(name)
-		if err != nil {
-			return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
-		}
-
-		if parentServer.Transport == types.TransportSSE {
-			sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-		} else {
-			mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-		}
+		s.mcpService.AddProxyTool(mcpServer, tool, toolOverride(name))
 	}
+	addGroupProxyPrompts(mcpServer, prompts)
+	addGroupProxyResources(mcpServer, resources)
 
 	// first, add the tool group to the database
 	// this also checks for uniqueness of the group's name
@@ -126,9 +183,10 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 		return fmt.Errorf("failed to create tool group: %w", err)
 	}
 
-	// finally, add the proxy MCPs to the tool group MCPs manager so that it is ready to serve
+	// finally, add the proxy MCP to the tool group MCPs manager so that it is ready to serve
 	s.addToolGroupMCPServer(group.Name, mcpServer)
-	s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
+
+	s.changeCallback(group.Name)
 
 	return nil
 }
@@ -145,92 +203,222 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 		return nil, fmt.Errorf("failed to retrieve the tool group: %w", err)
 	}
 
+	// the group stays in its namespace, the names in its definition are relative to it
+	updatedGroup.Namespace = oldGroup.Namespace
+
+	if err := updatedGroup.ValidateToolSelectors(); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, apierrors.ErrInvalidInput)
+	}
+	if err := updatedGroup.ValidateToolOverrides(); err != nil {
+		return nil, fmt.Errorf("invalid tool overrides: %v: %w", err, apierrors.ErrInvalidInput)
+	}
+	// the name is needed to detect whether the updated group would include itself
+	updatedGroup.Name = name
+	if err := s.validateIncludedGroups(updatedGroup); err != nil {
+		return nil, err
+	}
+
 	// determine which tools were added or removed from the group
-	oldToolNames, err := oldGroup.ResolveEffectiveTools(s.mcpService)
+	oldToolNames, err := oldGroup.ResolveEffectiveTools(s.resolver())
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve effective tools of original group: %w", err)
 	}
-	updatedToolNames, err := updatedGroup.ResolveEffectiveTools(s.mcpService)
+	updatedToolNames, err := updatedGroup.ResolveEffectiveTools(s.resolver())
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve effective tools of the updated group: %w", err)
 	}
 
+	toolOverride := s.toolOverrideLookup(updatedGroup)
+	if err := checkExposedToolNames(updatedToolNames, toolOverride); err != nil {
+		return nil, err
+	}
+
 	toolsAdded, toolsRemoved := util.DiffTools(oldToolNames, updatedToolNames)
+	if !sameToolOverrides(oldGroup, updatedGroup) {
+		// the tools that stay in the group must be served again with their new overrides
+		toolsAdded = updatedToolNames
+	}
+
+	// likewise, determine which prompts and resources were added or removed
+	oldPromptNames, err := oldGroup.ResolveEffectivePrompts(s.resolver())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective prompts of original group: %w", err)
+	}
+	updatedPromptNames, err := updatedGroup.ResolveEffectivePrompts(s.resolver())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective prompts of the updated group: %w", err)
+	}
+	promptsAdded, promptsRemoved := util.DiffTools(oldPromptNames, updatedPromptNames)
+
+	oldResourceURIs, err := oldGroup.ResolveEffectiveResources(s.resolver())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective resources of original group: %w", err)
+	}
+	updatedResourceURIs, err := updatedGroup.ResolveEffectiveResources(s.resolver())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective resources of the updated group: %w", err)
+	}
+	resourcesAdded, resourcesRemoved := util.DiffTools(oldResourceURIs, updatedResourceURIs)
 
 	// if nothing was actually changed in the group, no need to proceed further
-	if updatedGroup.Description == oldGroup.Description && len(toolsAdded) == 0 && len(toolsRemoved) == 0 {
+	if len(toolsAdded) == 0 && len(toolsRemoved) == 0 &&
+		len(promptsAdded) == 0 && len(promptsRemoved) == 0 &&
+		len(resourcesAdded) == 0 && len(resourcesRemoved) == 0 &&
+		sameGroupDefinition(oldGroup, updatedGroup) {
 		return oldGroup, nil
 	}
 
-	// determine the changes to make to the tool group's proxy MCP server instances (normal + SSE)
+	// determine the changes to make to the tool group's proxy MCP server
 	// all changes are ultimately made at the end of this method to avoid inconsistent state in case of errors.
 	mcpServer, exists := s.GetToolGroupMCPServer(name)
 	if !exists {
 		return nil, fmt.Errorf("MCP server for tool group %s does not exist", name)
 	}
-	sseMcpServer, exists := s.GetToolGroupSseMCPServer(name)
-	if !exists {
-		return nil, fmt.Errorf("SSE MCP server for tool group %s does not exist", name)
-	}
 
-	// tools added to the group must be added to its MCP server instances
-	var sseToolsToAdd, normalToolsToAdd []mcpgo.Tool
+	// tools added to the group must be added to its MCP server
+	toolsToAdd := make([]mcpgo.Tool, 0, len(toolsAdded))
 	for _, toolName := range toolsAdded {
 		tool, exists := s.mcpService.GetToolInstance(toolName)
 		if !exists {
 			return nil, fmt.Errorf("tool %s does not exist or is disabled: %w", toolName, apierrors.ErrInvalidInput)
 		}
-
-		parentServer, err := s.mcpService.GetToolParentServer(toolName)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get parent MCP server of the tool %s: %w", toolName, err)
-		}
-
-		if parentServer.Transport == types.TransportSSE {
-			sseToolsToAdd = append(sseToolsToAdd, tool)
-		} else {
-			normalToolsToAdd = append(normalToolsToAdd, tool)
-		}
+		toolsToAdd = append(toolsToAdd, tool)
 	}
 
-	// tools removed from the group must be removed from its MCP server instances
-	var sseToolsToRemove, normalToolsToRemove []string
-	for _, toolName := range toolsRemoved {
-		parentServer, err := s.mcpService.GetToolParentServer(toolName)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get parent MCP server of the tool %s: %w", toolName, err)
-		}
-
-		if parentServer.Transport == types.TransportSSE {
-			sseToolsToRemove = append(sseToolsToRemove, toolName)
-		} else {
-			normalToolsToRemove = append(normalToolsToRemove, toolName)
-		}
+	promptsToAdd, err := s.groupProxyPrompts(promptsAdded, false)
+	if err != nil {
+		return nil, err
+	}
+	resourcesToAdd, err := s.groupProxyResources(updatedGroup, resourcesAdded, false)
+	if err != nil {
+		return nil, err
 	}
 
 	// make all the changes together to avoid inconsistent state in case of errors
-	mcpServer.DeleteTools(normalToolsToRemove...)
-	sseMcpServer.DeleteTools(sseToolsToRemove...)
-
-	for _, tool := range normalToolsToAdd {
-		mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-	}
-	for _, tool := range sseToolsToAdd {
-		sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+	mcpServer.DeletePrompts(promptsRemoved...)
+	mcpServer.DeleteResources(resourcesRemoved...)
+	addGroupProxyPrompts(mcpServer, promptsToAdd)
+	addGroupProxyResources(mcpServer, resourcesToAdd)
+
+	// tools removed from the group must be removed from its MCP server
+	s.mcpService.DeleteProxyTools(mcpServer, toolsRemoved...)
+	for _, tool := range toolsToAdd {
+		s.mcpService.AddProxyTool(mcpServer, tool, toolOverride(tool.Name))
 	}
 
 	// as a final step, update the tool group record in the database
 	// we only persist this update after successfully updating the in-memory state
 
-	// ensure the group name remains unchanged in the db record
-	updatedGroup.Name = name
-	if err := s.db.Model(&model.ToolGroup{}).Where("name = ?", name).Updates(updatedGroup).Error; err != nil {
+	// the updated group replaces the whole definition, so fields left empty must be cleared as well
+	err = s.db.Model(&model.ToolGroup{}).
+		Where("name = ?", name).
+		Select(
+			"Description", "IncludedTools", "IncludedServers", "ExcludedTools", "IncludedGroups",
+			"IncludedToolSelectors", "ExcludedToolSelectors",
+			"IncludedPrompts", "ExcludedPrompts", "IncludedResources", "ExcludedResources",
+			"ToolOverrides",
+		).
+		Updates(updatedGroup).Error
+	if err != nil {
 		return nil, fmt.Errorf("failed to update tool group in DB: %w", err)
 	}
+	// the resource templates are looked up in the updated group, so they can only be refreshed now
+	s.mcpService.RefreshResourceTemplates(mcpServer)
+
+	// groups that include this group must serve its new tools, prompts and resources as well
+	s.reloadIncludingGroups(name)
+	s.changeCallback(name)
 
 	return oldGroup, nil
 }
 
+// sameGroupDefinition returns true if both groups have the same description and the same
+// included and excluded tools, tool selectors, prompts and resources, the same included servers and groups
+// and the same tool overrides.
+// Two groups can resolve to the same effective tools while being defined differently,
+// eg- by including a server instead of listing all of its tools.
+func sameGroupDefinition(a, b *model.ToolGroup) bool {
+	if a.Description != b.Description {
+		return false
+	}
+	lists := []func(g *model.ToolGroup) ([]string, error){
+		(*model.ToolGroup).GetTools,
+		(*model.ToolGroup).GetServers,
+		(*model.ToolGroup).GetExcludedTools,
+		(*model.ToolGroup).GetGroups,
+		(*model.ToolGroup).GetPrompts,
+		(*model.ToolGroup).GetExcludedPrompts,
+		(*model.ToolGroup).GetResources,
+		(*model.ToolGroup).GetExcludedResources,
+	}
+	for _, list := range lists {
+		la, errA := list(a)
+		lb, errB := list(b)
+		if errA != nil || errB != nil || !slices.Equal(la, lb) {
+			return false
+		}
+	}
+	selectors := []func(g *model.ToolGroup) ([]types.ToolSelector, error){
+		(*model.ToolGroup).GetToolSelectors,
+		(*model.ToolGroup).GetExcludedToolSelectors,
+	}
+	for _, list := range selectors {
+		la, errA := list(a)
+		lb, errB := list(b)
+		if errA != nil || errB != nil || !reflect.DeepEqual(la, lb) {
+			return false
+		}
+	}
+	return sameToolOverrides(a, b)
+}
+
+// sameToolOverrides returns true if both groups have the same tool overrides.
+func sameToolOverrides(a, b *model.ToolGroup) bool {
+	oa, errA := a.GetToolOverrides()
+	ob, errB := b.GetToolOverrides()
+	if errA != nil || errB != nil {
+		return false
+	}
+	return len(oa) == 0 && len(ob) == 0 || reflect.DeepEqual(oa, ob)
+}
+
+// toolOverrideLookup returns a function that returns the override of a tool in the given group by its canonical name.
+// A tool overridden by the group itself ignores the override configured by its MCP server.
+// Overrides of the groups included by the group are not inherited.
+func (s *ToolGroupService) toolOverrideLookup(group *model.ToolGroup) func(name string) *types.ToolOverride {
+	overrides, err := group.GetToolOverrides()
+	if err != nil {
+		log.Printf("[WARN] failed to read tool overrides of tool group %s: %v", group.Name, err)
+	}
+	// the overrides are keyed by tool names relative to the group's namespace
+	byName := make(map[string]types.ToolOverride, len(overrides))
+	for tool, o := range overrides {
+		byName[model.QualifiedName(group.Namespace, tool)] = o
+	}
+	return func(name string) *types.ToolOverride {
+		if o, ok := byName[name]; ok {
+			return &o
+		}
+		return s.mcpService.ToolOverride(name)
+	}
+}
+
+// checkExposedToolNames returns an error if two of the given tools would be exposed under the same name
+// with their overrides.
+func checkExposedToolNames(toolNames []string, toolOverride func(name string) *types.ToolOverride) error {
+	exposed := make(map[string]string, len(toolNames))
+	for _, name := range toolNames {
+		exposedName := mcp.ExposedToolName(name, toolOverride(name))
+		if other, ok := exposed[exposedName]; ok {
+			return fmt.Errorf(
+				"tools %s and %s cannot both be exposed as %s: %w", other, name, exposedName, apierrors.ErrInvalidInput,
+			)
+		}
+		exposed[exposedName] = name
+	}
+	return nil
+}
+
 // ResolveEffectiveTools resolves all effective tools for the specified tool group.
 // The resulting list is sorted for deterministic API responses and tests.
 func (s *ToolGroupService) ResolveEffectiveTools(name string) ([]string, error) {
@@ -239,7 +427,7 @@ func (s *ToolGroupService) ResolveEffectiveTools(name string) ([]string, error)
 		return nil, err
 	}
 
-	tools, err := group.ResolveEffectiveTools(s.mcpService)
+	tools, err := group.ResolveEffectiveTools(s.resolver())
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve effective tools for group %s: %w", name, err)
 	}
@@ -248,6 +436,40 @@ func (s *ToolGroupService) ResolveEffectiveTools(name string) ([]string, error)
 	return tools, nil
 }
 
+// ResolveEffectivePrompts resolves all effective prompts for the specified tool group.
+// The resulting list is sorted for deterministic API responses and tests.
+func (s *ToolGroupService) ResolveEffectivePrompts(name string) ([]string, error) {
+	group, err := s.GetToolGroup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	prompts, err := group.ResolveEffectivePrompts(s.resolver())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective prompts for group %s: %w", name, err)
+	}
+
+	sort.Strings(prompts)
+	return prompts, nil
+}
+
+// ResolveEffectiveResources resolves the URIs of all effective resources for the specified tool group.
+// The resulting list is sorted for deterministic API responses and tests.
+func (s *ToolGroupService) ResolveEffectiveResources(name string) ([]string, error) {
+	group, err := s.GetToolGroup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := group.ResolveEffectiveResources(s.resolver())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve effective resources for group %s: %w", name, err)
+	}
+
+	sort.Strings(resources)
+	return resources, nil
+}
+
 // GetToolGroup retrieves a tool group by name from the database.
 func (s *ToolGroupService) GetToolGroup(name string) (*model.ToolGroup, error) {
 	var group model.ToolGroup
@@ -270,16 +492,267 @@ func (s *ToolGroupService) ListToolGroups() ([]model.ToolGroup, error) {
 }
 
 func (s *ToolGroupService) DeleteToolGroup(name string) error {
+	includingGroups, err := s.includingGroups(name, false)
+	if err != nil {
+		return err
+	}
+	if len(includingGroups) > 0 {
+		return fmt.Errorf(
+			"tool group %s is included by the groups %s, remove it from them first: %w",
+			name, strings.Join(includingGroups, ", "), apierrors.ErrInvalidInput,
+		)
+	}
+
 	s.deleteToolGroupMCPServers(name)
 
-	err := s.db.Unscoped().Where("name = ?", name).Delete(&model.ToolGroup{}).Error
+	err = s.db.Unscoped().Where("name = ?", name).Delete(&model.ToolGroup{}).Error
 	if err != nil {
 		return fmt.Errorf("failed to delete toolgroup: %w", err)
 	}
+	s.changeCallback(name)
+	return nil
+}
+
+// SetChangeCallback registers a callback function to be called whenever a tool group is created, updated or deleted.
+// It is used to let other mcpjungle instances sharing the same database know about the change.
+func (s *ToolGroupService) SetChangeCallback(callback ChangeCallback) {
+	s.changeCallback = callback
+}
+
+// ReloadToolGroup brings the MCP proxy servers of a tool group in line with its definition in the database.
+// It is used when the group was changed in the database by another mcpjungle instance.
+//
+// Like UpdateToolGroup, the existing proxy servers of the group are updated in place,
+// so its MCP clients are not disrupted. If the group no longer exists, its proxy server is removed.
+// The groups that include the group are reloaded as well.
+// Reloading a group does not invoke the change callback.
+func (s *ToolGroupService) ReloadToolGroup(name string) error {
+	if err := s.reloadToolGroup(name); err != nil {
+		return err
+	}
+	s.reloadIncludingGroups(name)
+	return nil
+}
+
+// validateIncludedGroups checks that the groups included by a group exist and that it does not include itself.
+func (s *ToolGroupService) validateIncludedGroups(group *model.ToolGroup) error {
+	err := group.ValidateIncludedGroups(s.resolver())
+	if errors.Is(err, ErrToolGroupNotFound) || errors.Is(err, model.ErrToolGroupCycle) {
+		return fmt.Errorf("invalid included groups: %w: %w", err, apierrors.ErrInvalidInput)
+	}
+	return err
+}
+
+// includingGroups returns the sorted names of the groups that include the given group.
+// If transitive is true, the groups that include those groups are returned as well, and so on.
+func (s *ToolGroupService) includingGroups(name string, transitive bool) ([]string, error) {
+	groups, err := s.ListToolGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tool groups from DB: %w", err)
+	}
+
+	found := make(map[string]bool)
+	pending := []string{name}
+	for len(pending) > 0 {
+		target := pending[0]
+		pending = pending[1:]
+		for i := range groups {
+			included, err := groups[i].GetGroups()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get included groups of group %s: %w", groups[i].Name, err)
+			}
+			for _, g := range included {
+				if model.QualifiedName(groups[i].Namespace, g) != target || found[groups[i].Name] {
+					continue
+				}
+				found[groups[i].Name] = true
+				if transitive {
+					pending = append(pending, groups[i].Name)
+				}
+			}
+		}
+	}
+	// a cycle can only be stored by concurrent updates, but must not make a group depend on itself
+	delete(found, name)
+
+	result := make([]string, 0, len(found))
+	for g := range found {
+		result = append(result, g)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// reloadIncludingGroups reloads the proxy servers of all the groups that include the given group,
+// directly or through other groups, after the group changed.
+// Errors are logged since the change of the group itself was already applied.
+func (s *ToolGroupService) reloadIncludingGroups(name string) {
+	includingGroups, err := s.includingGroups(name, true)
+	if err != nil {
+		log.Printf("[ERROR] failed to find the groups that include tool group %s: %v", name, err)
+		return
+	}
+	for _, g := range includingGroups {
+		if err := s.reloadToolGroup(g); err != nil {
+			log.Printf("[ERROR] failed to reload tool group %s after its included group %s changed: %v", g, name, err)
+		}
+	}
+}
+
+// reloadToolGroup brings the MCP proxy server of a single tool group in line with its definition in the database.
+func (s *ToolGroupService) reloadToolGroup(name string) error {
+	group, err := s.GetToolGroup(name)
+	if err != nil {
+		if errors.Is(err, ErrToolGroupNotFound) {
+			s.deleteToolGroupMCPServers(name)
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve the tool group: %w", err)
+	}
+	toolOverride := s.toolOverrideLookup(group)
+
+	toolNames, err := group.ResolveEffectiveTools(s.resolver())
+	if err != nil {
+		return fmt.Errorf("failed to resolve effective tools of group %s: %w", name, err)
+	}
+
+	// determine the tools the group's proxy server must serve
+	tools := make(map[string]mcpgo.Tool)
+	for _, toolName := range toolNames {
+		tool, exists := s.mcpService.GetToolInstance(toolName)
+		if !exists {
+			// like during startup, tools that do not exist or are disabled are skipped
+			continue
+		}
+		tools[toolName] = tool
+	}
+
+	promptNames, err := group.ResolveEffectivePrompts(s.resolver())
+	if err != nil {
+		return fmt.Errorf("failed to resolve effective prompts of group %s: %w", name, err)
+	}
+	prompts, err := s.groupProxyPrompts(promptNames, true)
+	if err != nil {
+		return err
+	}
+	resourceURIs, err := group.ResolveEffectiveResources(s.resolver())
+	if err != nil {
+		return fmt.Errorf("failed to resolve effective resources of group %s: %w", name, err)
+	}
+	resources, err := s.groupProxyResources(group, resourceURIs, true)
+	if err != nil {
+		return err
+	}
+
+	mcpServer, exists := s.GetToolGroupMCPServer(name)
+	if !exists {
+		mcpServer = s.newMCPServer(name)
+		s.addToolGroupMCPServer(name, mcpServer)
+	}
+
+	s.syncGroupProxyTools(mcpServer, tools, toolOverride)
+
+	// the proxy server cannot list its prompts and resources, so they are replaced as a whole
+	mcpServer.SetPrompts(prompts...)
+	mcpServer.SetResources(resources...)
+	s.mcpService.RefreshResourceTemplates(mcpServer)
 	return nil
 }
 
+// groupProxyPrompts looks up the given prompts to serve them from a tool group's proxy server.
+// A prompt that does not exist or is disabled results in an error, unless skipMissing is true.
+func (s *ToolGroupService) groupProxyPrompts(names []string, skipMissing bool) ([]server.ServerPrompt, error) {
+	var prompts []server.ServerPrompt
+	for _, name := range names {
+		prompt, exists := s.mcpService.GetPromptInstance(name)
+		if !exists {
+			if skipMissing {
+				continue
+			}
+			return nil, fmt.Errorf("prompt %s does not exist or is disabled: %w", name, apierrors.ErrInvalidInput)
+		}
+		prompts = append(prompts, server.ServerPrompt{Prompt: prompt, Handler: s.mcpService.MCPProxyPromptHandler})
+	}
+	return prompts, nil
+}
+
+// groupProxyResources looks up the given resources to serve them from a tool group's proxy server.
+// Unlike tool and prompt names, resource URIs are not relative to the group's namespace,
+// so resources of servers outside the group's namespace are treated as if they did not exist.
+// A resource that does not exist or is disabled results in an error, unless skipMissing is true.
+func (s *ToolGroupService) groupProxyResources(
+	group *model.ToolGroup, uris []string, skipMissing bool,
+) ([]server.ServerResource, error) {
+	var resources []server.ServerResource
+	for _, uri := range uris {
+		resource, exists := s.mcpService.GetResourceInstance(uri)
+		if exists {
+			parentServer, err := s.mcpService.GetResourceParentServer(uri)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get parent MCP server of the resource %s: %w", uri, err)
+			}
+			exists = model.InNamespace(parentServer.Name, group.Namespace)
+		}
+		if !exists {
+			if skipMissing {
+				continue
+			}
+			return nil, fmt.Errorf("resource %s does not exist or is disabled: %w", uri, apierrors.ErrInvalidInput)
+		}
+		resources = append(resources, server.ServerResource{Resource: resource, Handler: s.mcpService.MCPProxyResourceHandler})
+	}
+	return resources, nil
+}
+
+// addGroupProxyPrompts adds prompts to a tool group's proxy server.
+// Nothing is added if there are no prompts, so that clients are not needlessly notified of a list change.
+func addGroupProxyPrompts(mcpServer *server.MCPServer, prompts []server.ServerPrompt) {
+	if len(prompts) > 0 {
+		mcpServer.AddPrompts(prompts...)
+	}
+}
+
+// addGroupProxyResources adds resources to a tool group's proxy server.
+// Nothing is added if there are no resources, so that clients are not needlessly notified of a list change.
+func addGroupProxyResources(mcpServer *server.MCPServer, resources []server.ServerResource) {
+	if len(resources) > 0 {
+		mcpServer.AddResources(resources...)
+	}
+}
+
+// syncGroupProxyTools makes a tool group's proxy server serve exactly the given tools, keyed by canonical name.
+// Tools without an override that are already served with the same definition are left untouched.
+func (s *ToolGroupService) syncGroupProxyTools(
+	mcpServer *server.MCPServer,
+	tools map[string]mcpgo.Tool,
+	toolOverride func(name string) *types.ToolOverride,
+) {
+	current := mcpServer.ListTools()
+
+	exposed := make(map[string]bool, len(tools))
+	for name, tool := range tools {
+		exposed[mcp.ExposedToolName(tool.Name, toolOverride(name))] = true
+	}
+	var removed []string
+	for name, served := range current {
+		if !exposed[name] {
+			removed = append(removed, mcp.CanonicalToolName(served.Tool))
+		}
+	}
+	s.mcpService.DeleteProxyTools(mcpServer, removed...)
+
+	for name, tool := range tools {
+		o := toolOverride(name)
+		// the handler of an overridden tool depends on the override, so it is always replaced
+		if served, ok := current[name]; ok && o == nil && reflect.DeepEqual(served.Tool, tool) {
+			continue
+		}
+		s.mcpService.AddProxyTool(mcpServer, tool, o)
+	}
+}
+
 // GetToolGroupMCPServer retrieves the MCP proxy server for a given tool group name.
+// The same proxy server serves the group's streamable HTTP and SSE endpoints.
 func (s *ToolGroupService) GetToolGroupMCPServer(name string) (*server.MCPServer, bool) {
 	s.mcpServersMu.RLock()
 	defer s.mcpServersMu.RUnlock()
@@ -287,75 +760,119 @@ func (s *ToolGroupService) GetToolGroupMCPServer(name string) (*server.MCPServer
 	return mcpServer, exists
 }
 
-// GetToolGroupSseMCPServer retrieves the SSE MCP proxy server for a given tool group name.
-func (s *ToolGroupService) GetToolGroupSseMCPServer(name string) (*server.MCPServer, bool) {
-	s.sseMcpServerMu.RLock()
-	defer s.sseMcpServerMu.RUnlock()
-	mcpServer, exists := s.sseMcpServers[name]
-	return mcpServer, exists
-}
-
 // newMCPServer creates a new MCP proxy server for a given tool group name.
 // The advertised version is tied to the mcpjungle server version (pkg/version)
 // so each tool-group proxy reports the host's version instead of a hardcoded
 // string.
 func (s *ToolGroupService) newMCPServer(groupName string) *server.MCPServer {
-	return server.NewMCPServer(
+	completions := s.groupCompletionProvider(groupName)
+	srv := server.NewMCPServer(
 		fmt.Sprintf("MCPJungle proxy MCP server for tool group: %s", groupName),
 		version.GetVersion(),
-		server.WithResourceCapabilities(false, false),
+		server.WithResourceCapabilities(true, true),
 		server.WithToolCapabilities(true),
 		server.WithPromptCapabilities(true),
 		server.WithToolFilter(mcp.ProxyToolFilter),
+		server.WithPromptFilter(mcp.ProxyPromptFilter),
+		server.WithHooks(mcp.ProxyResourceFilterHooks()),
+		server.WithCompletions(),
+		server.WithLogging(),
+		server.WithPromptCompletionProvider(completions),
+		server.WithResourceCompletionProvider(completions),
 	)
+	s.mcpService.AttachProxyServer(srv)
+	s.mcpService.ServeServerInstructions(srv, func() ([]string, error) {
+		return s.groupServers(groupName)
+	})
+	return srv
 }
 
-// newSseMCPServer creates a new SSE MCP proxy server for a given tool group name.
-func (s *ToolGroupService) newSseMCPServer(groupName string) *server.MCPServer {
-	return server.NewMCPServer(
-		fmt.Sprintf("MCPJungle proxy MCP server for SSE transport for tool group: %s", groupName),
-		version.GetVersion(),
-		server.WithResourceCapabilities(false, false),
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
-		server.WithToolFilter(mcp.ProxyToolFilter),
-	)
+// groupServers returns the names of the MCP servers that provide the effective tools, prompts and resources
+// of a tool group.
+func (s *ToolGroupService) groupServers(groupName string) ([]string, error) {
+	tools, err := s.ResolveEffectiveTools(groupName)
+	if err != nil {
+		return nil, err
+	}
+	prompts, err := s.ResolveEffectivePrompts(groupName)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := s.ResolveEffectiveResources(groupName)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.EntityServers(tools, prompts, resources), nil
+}
+
+// groupCompletionProvider returns the completion provider of a tool group's proxy server,
+// which only completes the arguments of the group's effective prompts and of the resource templates it serves.
+func (s *ToolGroupService) groupCompletionProvider(groupName string) *mcp.ProxyCompletionProvider {
+	servesPrompt := func(name string) bool {
+		prompts, err := s.ResolveEffectivePrompts(groupName)
+		return err == nil && slices.Contains(prompts, name)
+	}
+	servesResourceTemplates := func(serverName string) bool {
+		servers, err := s.groupTemplateServers(groupName)
+		return err == nil && slices.Contains(servers, serverName)
+	}
+	return s.mcpService.NewGroupCompletionProvider(servesPrompt, servesResourceTemplates)
+}
+
+// groupTemplateServers returns the names of the MCP servers whose resource templates a tool group serves,
+// ie, the servers it includes as a whole, directly or through its included groups.
+func (s *ToolGroupService) groupTemplateServers(groupName string) ([]string, error) {
+	group, err := s.GetToolGroup(groupName)
+	if err != nil {
+		return nil, err
+	}
+	return group.ResolveIncludedServers(s.resolver())
 }
 
 // addToolGroupMCPServer adds or updates the MCP proxy server for a given tool group name.
 // If a group with the same name already exists, it will be replaced.
+// The proxy server starts serving the resource templates of the servers included by the group,
+// so the group must already exist in the database.
 // This method is safe to call concurrently.
 func (s *ToolGroupService) addToolGroupMCPServer(name string, mcpServer *server.MCPServer) {
 	s.mcpServersMu.Lock()
-	defer s.mcpServersMu.Unlock()
 	s.mcpServers[name] = mcpServer
-}
+	s.mcpServersMu.Unlock()
 
-// addToolGroupSseMCPServer adds or updates the SSE MCP proxy server for a given tool group name.
-// If a group with the same name already exists, it will be replaced.
-// This method is safe to call concurrently.
-func (s *ToolGroupService) addToolGroupSseMCPServer(name string, mcpServer *server.MCPServer) {
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
-	s.sseMcpServers[name] = mcpServer
+	s.mcpService.ServeResourceTemplates(mcpServer, func() ([]string, error) {
+		return s.groupTemplateServers(name)
+	})
 }
 
-// deleteToolGroupMCPServers removes the MCP proxy servers for a given tool group name.
+// deleteToolGroupMCPServers removes the MCP proxy server for a given tool group name.
 func (s *ToolGroupService) deleteToolGroupMCPServers(name string) {
-	// first, acquire both locks to ensure complete cleanup of the group
 	s.mcpServersMu.Lock()
 	defer s.mcpServersMu.Unlock()
 
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
-
-	// proceed to delete both normal & sse proxies for the group, then release the locks
+	if mcpServer, ok := s.mcpServers[name]; ok {
+		s.mcpService.ForgetProxyServer(mcpServer)
+	}
 	delete(s.mcpServers, name)
-	delete(s.sseMcpServers, name)
+}
+
+// LoadToolGroupsFromDB creates the MCP proxy servers for all tool groups in the database.
+// Like mcp.MCPService.LoadProxyFromDB, it is meant for a freshly populated database, eg- after restoring a backup.
+func (s *ToolGroupService) LoadToolGroupsFromDB() error {
+	if err := s.initToolGroupMCPServers(); err != nil {
+		return err
+	}
+	// other mcpjungle instances sharing the database must load the new groups as well
+	groups, err := s.ListToolGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list tool groups from DB: %w", err)
+	}
+	for _, group := range groups {
+		s.changeCallback(group.Name)
+	}
+	return nil
 }
 
 // initToolGroupMCPServers initializes the MCP proxy servers for all existing tool groups in the database.
-// It initializes both the mcpServers and sseMcpServers.
 func (s *ToolGroupService) initToolGroupMCPServers() error {
 	groups, err := s.ListToolGroups()
 	if err != nil {
@@ -364,9 +881,8 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 
 	for _, group := range groups {
 		mcpServer := s.newMCPServer(group.Name)
-		sseMcpServer := s.newSseMCPServer(group.Name)
 
-		toolNames, err := group.ResolveEffectiveTools(s.mcpService)
+		toolNames, err := group.ResolveEffectiveTools(s.resolver())
 		if err != nil {
 			// If resolution of any server or specific tool fails for a group, the error is logged and
 			// the group is added as an empty MCP server to the proxy.
@@ -379,10 +895,10 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 				err,
 			)
 			s.addToolGroupMCPServer(group.Name, mcpServer)
-			s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
 			continue
 		}
 		// TODO: Log a warning if a group has no tools, ie, len(toolNames) == 0
+		toolOverride := s.toolOverrideLookup(&group)
 
 		for _, name := range toolNames {
 			tool, exists := s.mcpService.GetToolInstance(name)
@@ -392,46 +908,63 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 				// TODO: Add a warning log here.
 				continue
 			}
+			s.mcpService.AddProxyTool(mcpServer, tool, toolOverride(name))
+		}
 
-			parentServer, err := s.mcpService.GetToolParentServer(name)
-			if err != nil {
-				return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
-			}
-
-			if parentServer.Transport == types.TransportSSE {
-				sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-			} else {
-				mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
-			}
+		// like tools, prompts and resources that cannot be resolved must not prevent server startup
+		if err := s.initGroupPromptsAndResources(&group, mcpServer); err != nil {
+			log.Printf(
+				"[ERROR] failed to resolve effective prompts and resources for tool group %s during startup: %v",
+				group.Name,
+				err,
+			)
 		}
 
 		s.addToolGroupMCPServer(group.Name, mcpServer)
-		s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
 	}
 
 	return nil
 }
 
+// initGroupPromptsAndResources adds the effective prompts and resources of a tool group
+// to its newly created proxy server. Prompts and resources that do not exist or are disabled are skipped.
+func (s *ToolGroupService) initGroupPromptsAndResources(group *model.ToolGroup, mcpServer *server.MCPServer) error {
+	promptNames, err := group.ResolveEffectivePrompts(s.resolver())
+	if err != nil {
+		return err
+	}
+	prompts, err := s.groupProxyPrompts(promptNames, true)
+	if err != nil {
+		return err
+	}
+	resourceURIs, err := group.ResolveEffectiveResources(s.resolver())
+	if err != nil {
+		return err
+	}
+	resources, err := s.groupProxyResources(group, resourceURIs, true)
+	if err != nil {
+		return err
+	}
+
+	addGroupProxyPrompts(mcpServer, prompts)
+	addGroupProxyResources(mcpServer, resources)
+	return nil
+}
+
 // handleToolDeletion is a callback that is called when one or more tools is deleted or disabled.
 // It removes the tools from all tool group MCP proxy servers.
 func (s *ToolGroupService) handleToolDeletion(tools ...string) {
 	s.mcpServersMu.RLock()
 	defer s.mcpServersMu.RUnlock()
 
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
-
 	for _, mcpServer := range s.mcpServers {
-		mcpServer.DeleteTools(tools...)
-	}
-
-	for _, sseMcpServer := range s.sseMcpServers {
-		sseMcpServer.DeleteTools(tools...)
+		s.mcpService.DeleteProxyTools(mcpServer, tools...)
 	}
 }
 
 // handleToolAddition is a callback that is called when a tool is added or (re)enabled in mcpjungle.
-// this callback adds the new tool to MCP proxy servers of all groups that include it.
+// this callback adds the new tool to MCP proxy servers of all groups that include it,
+// and removes it from the proxy servers of the groups that no longer do, eg- after its annotations changed.
 func (s *ToolGroupService) handleToolAddition(newTool string) error {
 	// get all tool groups from the database
 	groups, err := s.ListToolGroups()
@@ -439,22 +972,35 @@ func (s *ToolGroupService) handleToolAddition(newTool string) error {
 		return fmt.Errorf("failed to list tool groups from DB: %w", err)
 	}
 
+	// the groups' rules are matched against the added tool only, which needs its server's labels
+	tool, err := s.mcpService.GetTool(newTool)
+	if err != nil {
+		return fmt.Errorf("failed to get tool %s: %w", newTool, err)
+	}
+	toolServer, err := s.mcpService.GetToolParentServer(newTool)
+	if err != nil {
+		return fmt.Errorf("failed to get MCP server of tool %s: %w", newTool, err)
+	}
+	tool.Server = *toolServer
+
 	// find all groups that include the added tool
+	// the tool may also have been updated or its server relabeled, in which case it must leave
+	// the groups whose selectors no longer match it
 	groupsToUpdate := make([]string, 0, len(groups))
+	groupsToLeave := make([]string, 0, len(groups))
+	toolOverrides := make(map[string]func(name string) *types.ToolOverride, len(groups))
 	for i := range groups {
 		name := groups[i].Name
-		groupTools, err := groups[i].ResolveEffectiveTools(s.mcpService)
+		includesTool, err := groups[i].IncludesTool(s.resolver(), tool)
 		if err != nil {
-			return fmt.Errorf("failed to resolve effective tools for group %s: %w", name, err)
+			return fmt.Errorf("failed to match tool %s against group %s: %w", newTool, name, err)
 		}
-		for _, t := range groupTools {
-			if t != newTool {
-				continue
-			}
+		if includesTool {
 			// current group includes the added tool, so add the tool instance to the group's MCP server
 			groupsToUpdate = append(groupsToUpdate, name)
-			// no need to check other tools in this group anymore, so exit the loop and move on to the next group
-			break
+			toolOverrides[name] = s.toolOverrideLookup(&groups[i])
+		} else {
+			groupsToLeave = append(groupsToLeave, name)
 		}
 	}
 
@@ -464,32 +1010,117 @@ func (s *ToolGroupService) handleToolAddition(newTool string) error {
 		return fmt.Errorf("tool instance %s does not exist", newTool)
 	}
 
-	parentServer, err := s.mcpService.GetToolParentServer(newTool)
+	// add the new tool instance to all relevant MCP proxy servers
+	s.mcpServersMu.RLock()
+	defer s.mcpServersMu.RUnlock()
+
+	for _, name := range groupsToUpdate {
+		if mcpServer, exists := s.mcpServers[name]; exists {
+			s.mcpService.AddProxyTool(mcpServer, newToolInstance, toolOverrides[name](newTool))
+		}
+	}
+
+	// DeleteTools is a no-op for proxy servers that don't serve the tool
+	for _, name := range groupsToLeave {
+		if mcpServer, exists := s.mcpServers[name]; exists {
+			s.mcpService.DeleteProxyTools(mcpServer, newTool)
+		}
+	}
+
+	return nil
+}
+
+// handlePromptDeletion is a callback that is called when one or more prompts is deleted or disabled.
+// It removes the prompts from all tool group MCP proxy servers.
+func (s *ToolGroupService) handlePromptDeletion(prompts ...string) {
+	s.mcpServersMu.RLock()
+	defer s.mcpServersMu.RUnlock()
+
+	for _, mcpServer := range s.mcpServers {
+		mcpServer.DeletePrompts(prompts...)
+	}
+}
+
+// handlePromptAddition is a callback that is called when a prompt is added or (re)enabled in mcpjungle.
+// It adds the new prompt to MCP proxy servers of all groups that include it.
+func (s *ToolGroupService) handlePromptAddition(newPrompt string) error {
+	groups, err := s.ListToolGroups()
 	if err != nil {
-		return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", newTool, err)
+		return fmt.Errorf("failed to list tool groups from DB: %w", err)
 	}
 
-	// add the new tool instance to all relevant MCP proxy servers
+	groupsToUpdate := make([]string, 0, len(groups))
+	for i := range groups {
+		groupPrompts, err := groups[i].ResolveEffectivePrompts(s.resolver())
+		if err != nil {
+			return fmt.Errorf("failed to resolve effective prompts for group %s: %w", groups[i].Name, err)
+		}
+		if slices.Contains(groupPrompts, newPrompt) {
+			groupsToUpdate = append(groupsToUpdate, groups[i].Name)
+		}
+	}
+	if len(groupsToUpdate) == 0 {
+		return nil
+	}
+
+	prompts, err := s.groupProxyPrompts([]string{newPrompt}, false)
+	if err != nil {
+		return err
+	}
+	s.addToGroupProxies(groupsToUpdate, func(mcpServer *server.MCPServer) {
+		addGroupProxyPrompts(mcpServer, prompts)
+	})
+	return nil
+}
+
+// handleResourceDeletion is a callback that is called when one or more resources is deleted or disabled.
+// It removes the resources from all tool group MCP proxy servers.
+func (s *ToolGroupService) handleResourceDeletion(uris ...string) {
 	s.mcpServersMu.RLock()
 	defer s.mcpServersMu.RUnlock()
 
-	s.sseMcpServerMu.Lock()
-	defer s.sseMcpServerMu.Unlock()
+	for _, mcpServer := range s.mcpServers {
+		mcpServer.DeleteResources(uris...)
+	}
+}
+
+// handleResourceAddition is a callback that is called when a resource is added or (re)enabled in mcpjungle.
+// It adds the new resource to MCP proxy servers of all groups that include it.
+func (s *ToolGroupService) handleResourceAddition(newURI string) error {
+	groups, err := s.ListToolGroups()
+	if err != nil {
+		return fmt.Errorf("failed to list tool groups from DB: %w", err)
+	}
 
-	for _, name := range groupsToUpdate {
-		if parentServer.Transport == types.TransportSSE {
-			sseMcpServer, exists := s.sseMcpServers[name]
-			if exists {
-				sseMcpServer.AddTool(newToolInstance, s.mcpService.MCPProxyToolCallHandler)
-			}
+	for i := range groups {
+		groupResources, err := groups[i].ResolveEffectiveResources(s.resolver())
+		if err != nil {
+			return fmt.Errorf("failed to resolve effective resources for group %s: %w", groups[i].Name, err)
+		}
+		if !slices.Contains(groupResources, newURI) {
 			continue
 		}
 
-		mcpServer, exists := s.mcpServers[name]
-		if exists {
-			mcpServer.AddTool(newToolInstance, s.mcpService.MCPProxyToolCallHandler)
+		// whether the resource can be served depends on the namespace of each group
+		resources, err := s.groupProxyResources(&groups[i], []string{newURI}, true)
+		if err != nil {
+			return err
 		}
+		s.addToGroupProxies([]string{groups[i].Name}, func(mcpServer *server.MCPServer) {
+			addGroupProxyResources(mcpServer, resources)
+		})
 	}
-
 	return nil
 }
+
+// addToGroupProxies calls add with the proxy server of each of the given tool groups.
+func (s *ToolGroupService) addToGroupProxies(groups []string, add func(mcpServer *server.MCPServer)) {
+	s.mcpServersMu.RLock()
+	defer s.mcpServersMu.RUnlock()
+
+	for _, name := range groups {
+		if mcpServer, exists := s.mcpServers[name]; exists {
+			add(mcpServer)
+		}
+	}
+}
@@ -1,16 +1,21 @@
 package toolgroup
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
+	"sort"
 	"testing"
 
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/version"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
@@ -146,7 +151,7 @@ func setupInMemoryDB(t *testing.T) *gorm.DB {
 	if err != nil {
 		t.Fatalf("failed to open in-memory db: %v", err)
 	}
-	if err := db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.ToolGroup{}, &model.Prompt{}, &model.Resource{}); err != nil {
+	if err := db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.ToolGroup{}, &model.Prompt{}, &model.Resource{}, &model.ResourceTemplate{}); err != nil {
 		t.Fatalf("failed to migrate test models: %v", err)
 	}
 	return db
@@ -163,19 +168,10 @@ func newTestMCPService(t *testing.T, db *gorm.DB) *mcp.MCPService {
 		server.WithPromptCapabilities(true),
 		server.WithToolFilter(mcp.ProxyToolFilter),
 	)
-	sseProxyServer := server.NewMCPServer(
-		"test sse proxy",
-		"0.0.1",
-		server.WithResourceCapabilities(false, false),
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
-		server.WithToolFilter(mcp.ProxyToolFilter),
-	)
 
 	svc, err := mcp.NewMCPService(&mcp.ServiceConfig{
 		DB:                      db,
 		McpProxyServer:          proxyServer,
-		SseMcpProxyServer:       sseProxyServer,
 		Metrics:                 telemetry.NewNoopCustomMetrics(),
 		McpServerInitReqTimeout: 10,
 	})
@@ -325,12 +321,88 @@ func TestNewToolGroupService_DegradedPersistedGroupDoesNotFailStartup(t *testing
 		t.Fatalf("expected degraded group proxy to expose 0 tools, got %d", len(degradedProxy.ListTools()))
 	}
 
-	degradedSSEProxy, ok := svc.GetToolGroupSseMCPServer("degraded-group")
+}
+
+func TestCreateToolGroup_ServesToolsOfAllTransports(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	stdioServer, err := model.NewStdioServer("stdio-server", "", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create stdio server model: %v", err)
+	}
+	sseServer, err := model.NewSSEServer("sse-server", "", "https://example.com/sse", "", types.SessionModeStateless)
+	if err != nil {
+		t.Fatalf("failed to create SSE server model: %v", err)
+	}
+	for _, srv := range []*model.McpServer{stdioServer, sseServer} {
+		if err := db.Create(srv).Error; err != nil {
+			t.Fatalf("failed to persist server %s: %v", srv.Name, err)
+		}
+		tool := model.Tool{ServerID: srv.ID, Name: "sum", InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool of server %s: %v", srv.Name, err)
+		}
+	}
+
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:            "mixed-group",
+		IncludedServers: datatypes.JSON([]byte(`["stdio-server", "sse-server"]`)),
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+
+	// the group's single proxy server, served on both its /mcp and /sse endpoints, exposes the tools of both servers
+	proxy, ok := svc.GetToolGroupMCPServer("mixed-group")
 	if !ok {
-		t.Fatal("expected degraded group SSE MCP proxy to be initialized")
+		t.Fatal("expected group MCP proxy to be created")
+	}
+	tools := proxy.ListTools()
+	for _, name := range []string{"stdio-server__sum", "sse-server__sum"} {
+		if _, ok := tools[name]; !ok {
+			t.Fatalf("expected group proxy to expose %s, got keys %v", name, reflect.ValueOf(tools).MapKeys())
+		}
+	}
+}
+
+func TestGroupServers_ReturnsServersOfEffectiveEntities(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	for _, name := range []string{"calc", "weather"} {
+		srv, err := model.NewStdioServer(name, "", "echo", nil, nil, "")
+		if err != nil {
+			t.Fatalf("failed to create server model: %v", err)
+		}
+		if err := db.Create(srv).Error; err != nil {
+			t.Fatalf("failed to persist server %s: %v", name, err)
+		}
+		tool := model.Tool{ServerID: srv.ID, Name: "run", InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool of server %s: %v", name, err)
+		}
+	}
+
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	if err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "calc-group",
+		IncludedTools: datatypes.JSON([]byte(`["calc__run"]`)),
+	}); err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
 	}
-	if len(degradedSSEProxy.ListTools()) != 0 {
-		t.Fatalf("expected degraded group SSE proxy to expose 0 tools, got %d", len(degradedSSEProxy.ListTools()))
+
+	servers, err := svc.groupServers("calc-group")
+	if err != nil {
+		t.Fatalf("failed to get servers of group: %v", err)
+	}
+	if !reflect.DeepEqual(servers, []string{"calc"}) {
+		t.Fatalf("expected servers [calc], got %v", servers)
 	}
 }
 
@@ -364,13 +436,634 @@ func TestNewMCPServer_AdvertisesCurrentVersion(t *testing.T) {
 	)
 }
 
-func TestNewSseMCPServer_AdvertisesCurrentVersion(t *testing.T) {
-	s := &ToolGroupService{}
+func TestUpdateToolGroup_PersistsDefinitionWithSameEffectiveTools(t *testing.T) {
+	db := setupInMemoryDB(t)
 
-	testhelpers.AssertMCPServerInfo(
-		t,
-		s.newSseMCPServer("group-a"),
-		"MCPJungle proxy MCP server for SSE transport for tool group: group-a",
-		version.GetVersion(),
-	)
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	tool := model.Tool{ServerID: srv.ID, Name: "sum", InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+	if err := db.Create(&tool).Error; err != nil {
+		t.Fatalf("failed to persist tool: %v", err)
+	}
+	group := model.ToolGroup{
+		Name:          "math",
+		Description:   "math tools",
+		IncludedTools: datatypes.JSON(`["calc__sum"]`),
+	}
+	if err := db.Create(&group).Error; err != nil {
+		t.Fatalf("failed to persist group: %v", err)
+	}
+
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+
+	// including the server instead of its only tool resolves to the same effective tools,
+	// but the new definition and the cleared description must still be persisted
+	_, err = svc.UpdateToolGroup("math", &model.ToolGroup{IncludedServers: datatypes.JSON(`["calc"]`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := svc.GetToolGroup("math")
+	if err != nil {
+		t.Fatalf("failed to get updated group: %v", err)
+	}
+	if updated.Description != "" {
+		t.Fatalf("expected description to be cleared, got %q", updated.Description)
+	}
+	servers, _ := updated.GetServers()
+	tools, _ := updated.GetTools()
+	if !reflect.DeepEqual(servers, []string{"calc"}) || len(tools) != 0 {
+		t.Fatalf("expected group to include only server calc, got servers %v and tools %v", servers, tools)
+	}
+}
+
+func TestReloadToolGroup_AppliesChangesMadeByAnotherInstance(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	for _, name := range []string{"sum", "sub"} {
+		tool := model.Tool{ServerID: srv.ID, Name: name, InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool: %v", err)
+		}
+	}
+
+	// both services share the same database, like two mcpjungle replicas
+	origin, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	replica, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+
+	var changed []string
+	origin.SetChangeCallback(func(groupName string) {
+		changed = append(changed, groupName)
+	})
+
+	groupToolNames := func() []string {
+		t.Helper()
+		mcpServer, ok := replica.GetToolGroupMCPServer("math")
+		if !ok {
+			return nil
+		}
+		var names []string
+		for name := range mcpServer.ListTools() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	err = origin.CreateToolGroup(&model.ToolGroup{Name: "math", IncludedTools: datatypes.JSON(`["calc__sum"]`)})
+	if err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"math"}) {
+		t.Fatalf("expected change of group math to be reported, got %v", changed)
+	}
+	if names := groupToolNames(); names != nil {
+		t.Fatalf("expected replica to not serve the group before reloading, got tools %v", names)
+	}
+
+	if err := replica.ReloadToolGroup("math"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := groupToolNames(); !reflect.DeepEqual(names, []string{"calc__sum"}) {
+		t.Fatalf("expected group to serve calc__sum, got %v", names)
+	}
+
+	_, err = origin.UpdateToolGroup("math", &model.ToolGroup{IncludedServers: datatypes.JSON(`["calc"]`)})
+	if err != nil {
+		t.Fatalf("failed to update tool group: %v", err)
+	}
+	if err := replica.ReloadToolGroup("math"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if names := groupToolNames(); !reflect.DeepEqual(names, []string{"calc__sub", "calc__sum"}) {
+		t.Fatalf("expected group to serve all tools of calc, got %v", names)
+	}
+
+	if err := origin.DeleteToolGroup("math"); err != nil {
+		t.Fatalf("failed to delete tool group: %v", err)
+	}
+	if err := replica.ReloadToolGroup("math"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := replica.GetToolGroupMCPServer("math"); ok {
+		t.Fatal("expected deleted group to not be served anymore")
+	}
+}
+
+// listGroupProxy lists the prompts or resources served by a tool group's proxy server,
+// depending on the given MCP method.
+func listGroupProxy(t *testing.T, mcpServer *server.MCPServer, method string) []string {
+	t.Helper()
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	msg := []byte(`{"jsonrpc":"2.0","id":1,"method":"` + method + `"}`)
+	raw, err := json.Marshal(mcpServer.HandleMessage(ctx, msg))
+	if err != nil {
+		t.Fatalf("failed to marshal %s response: %v", method, err)
+	}
+	var resp struct {
+		Result struct {
+			Prompts   []mcpgo.Prompt   `json:"prompts"`
+			Resources []mcpgo.Resource `json:"resources"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatalf("failed to unmarshal %s response %s: %v", method, raw, err)
+	}
+	names := []string{}
+	for _, p := range resp.Result.Prompts {
+		names = append(names, p.Name)
+	}
+	for _, r := range resp.Result.Resources {
+		names = append(names, r.URI)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestToolGroup_PromptsAndResources(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	for _, name := range []string{"explain", "summarize"} {
+		prompt := model.Prompt{ServerID: srv.ID, Name: name, Arguments: []byte(`[]`), Enabled: true}
+		if err := db.Create(&prompt).Error; err != nil {
+			t.Fatalf("failed to persist prompt: %v", err)
+		}
+	}
+	resource := model.Resource{
+		ServerID: srv.ID, URI: "mcpj://res/calc/abc", OriginalURI: "file:///abc", Name: "abc", Enabled: true,
+	}
+	if err := db.Create(&resource).Error; err != nil {
+		t.Fatalf("failed to persist resource: %v", err)
+	}
+
+	mcpService := newTestMCPService(t, db)
+	svc, err := NewToolGroupService(db, mcpService)
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+
+	// a group may consist of prompts and resources only
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:              "docs",
+		IncludedServers:   datatypes.JSON(`["calc"]`),
+		ExcludedPrompts:   datatypes.JSON(`["calc__summarize"]`),
+		IncludedResources: datatypes.JSON(`["mcpj://res/calc/abc"]`),
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	mcpServer, _ := svc.GetToolGroupMCPServer("docs")
+	if got := listGroupProxy(t, mcpServer, "prompts/list"); !reflect.DeepEqual(got, []string{"calc__explain"}) {
+		t.Fatalf("expected group to serve prompt calc__explain, got %v", got)
+	}
+	if got := listGroupProxy(t, mcpServer, "resources/list"); !reflect.DeepEqual(got, []string{"mcpj://res/calc/abc"}) {
+		t.Fatalf("expected group to serve resource mcpj://res/calc/abc, got %v", got)
+	}
+
+	// disabling and re-enabling prompts and resources keeps the group in sync
+	if _, err := mcpService.DisablePrompts("calc__explain"); err != nil {
+		t.Fatalf("failed to disable prompt: %v", err)
+	}
+	if _, err := mcpService.DisableResources("mcpj://res/calc/abc"); err != nil {
+		t.Fatalf("failed to disable resource: %v", err)
+	}
+	if got := listGroupProxy(t, mcpServer, "prompts/list"); len(got) != 0 {
+		t.Fatalf("expected disabled prompt to be removed from the group, got %v", got)
+	}
+	if got := listGroupProxy(t, mcpServer, "resources/list"); len(got) != 0 {
+		t.Fatalf("expected disabled resource to be removed from the group, got %v", got)
+	}
+
+	if _, err := mcpService.EnablePrompts("calc__explain"); err != nil {
+		t.Fatalf("failed to enable prompt: %v", err)
+	}
+	if _, err := mcpService.EnableResources("mcpj://res/calc/abc"); err != nil {
+		t.Fatalf("failed to enable resource: %v", err)
+	}
+	if got := listGroupProxy(t, mcpServer, "prompts/list"); !reflect.DeepEqual(got, []string{"calc__explain"}) {
+		t.Fatalf("expected re-enabled prompt to be added back to the group, got %v", got)
+	}
+	if got := listGroupProxy(t, mcpServer, "resources/list"); !reflect.DeepEqual(got, []string{"mcpj://res/calc/abc"}) {
+		t.Fatalf("expected re-enabled resource to be added back to the group, got %v", got)
+	}
+
+	// updating the group swaps its prompts and drops its resources
+	_, err = svc.UpdateToolGroup("docs", &model.ToolGroup{IncludedPrompts: datatypes.JSON(`["calc__summarize"]`)})
+	if err != nil {
+		t.Fatalf("failed to update tool group: %v", err)
+	}
+	if got := listGroupProxy(t, mcpServer, "prompts/list"); !reflect.DeepEqual(got, []string{"calc__summarize"}) {
+		t.Fatalf("expected group to serve only calc__summarize, got %v", got)
+	}
+	if got := listGroupProxy(t, mcpServer, "resources/list"); len(got) != 0 {
+		t.Fatalf("expected group to serve no resources, got %v", got)
+	}
+
+	// another instance sharing the database serves the same prompts and resources after reloading
+	replica, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	if err := replica.ReloadToolGroup("docs"); err != nil {
+		t.Fatalf("failed to reload tool group: %v", err)
+	}
+	replicaServer, _ := replica.GetToolGroupMCPServer("docs")
+	if got := listGroupProxy(t, replicaServer, "prompts/list"); !reflect.DeepEqual(got, []string{"calc__summarize"}) {
+		t.Fatalf("expected replica to serve only calc__summarize, got %v", got)
+	}
+}
+
+func TestCreateToolGroup_MissingPromptOrResourceReturnsInvalidInput(t *testing.T) {
+	db := setupInMemoryDB(t)
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+
+	for _, group := range []*model.ToolGroup{
+		{Name: "prompts", IncludedPrompts: datatypes.JSON(`["calc__missing"]`)},
+		{Name: "resources", IncludedResources: datatypes.JSON(`["mcpj://res/calc/missing"]`)},
+	} {
+		err := svc.CreateToolGroup(group)
+		if !errors.Is(err, apierrors.ErrInvalidInput) {
+			t.Fatalf("expected invalid input error for group %s, got %v", group.Name, err)
+		}
+	}
+}
+
+func TestToolGroup_SelectorsFollowNewToolsAndServerLabels(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := srv.SetLabels([]string{"prod"}); err != nil {
+		t.Fatalf("failed to set server labels: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	createTool := func(name, annotations string) {
+		t.Helper()
+		tool := model.Tool{
+			ServerID:    srv.ID,
+			Name:        name,
+			InputSchema: []byte(`{"type":"object"}`),
+			Annotations: datatypes.JSON(annotations),
+			Enabled:     true,
+		}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool: %v", err)
+		}
+	}
+	createTool("sum", `{"readOnlyHint":true}`)
+	createTool("reset", `{"destructiveHint":true}`)
+
+	mcpService := newTestMCPService(t, db)
+	svc, err := NewToolGroupService(db, mcpService)
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:                  "readonly",
+		IncludedToolSelectors: datatypes.JSON(`[{"annotations":{"readOnlyHint":true},"server_labels":["prod"]}]`),
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	groupToolNames := func() []string {
+		t.Helper()
+		mcpServer, _ := svc.GetToolGroupMCPServer("readonly")
+		names := []string{}
+		for name := range mcpServer.ListTools() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	if names := groupToolNames(); !reflect.DeepEqual(names, []string{"calc__sum"}) {
+		t.Fatalf("expected group to serve calc__sum, got %v", names)
+	}
+
+	// a newly discovered tool that matches the selector joins the group
+	createTool("avg", `{"readOnlyHint":true}`)
+	if err := mcpService.ReloadMcpServer("calc"); err != nil {
+		t.Fatalf("failed to reload server: %v", err)
+	}
+	if names := groupToolNames(); !reflect.DeepEqual(names, []string{"calc__avg", "calc__sum"}) {
+		t.Fatalf("expected new read-only tool to join the group, got %v", names)
+	}
+
+	// once the server loses its label, its tools leave the group
+	if err := db.Model(&model.McpServer{}).Where("name = ?", "calc").Update("labels", datatypes.JSON(`["staging"]`)).Error; err != nil {
+		t.Fatalf("failed to update server labels: %v", err)
+	}
+	if err := mcpService.ReloadMcpServer("calc"); err != nil {
+		t.Fatalf("failed to reload server: %v", err)
+	}
+	if names := groupToolNames(); len(names) != 0 {
+		t.Fatalf("expected tools to leave the group after the server was relabeled, got %v", names)
+	}
+}
+
+func TestToolGroup_SelectorsSkipDisabledTools(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	for _, name := range []string{"sum", "avg"} {
+		tool := model.Tool{ServerID: srv.ID, Name: name, InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool: %v", err)
+		}
+	}
+
+	mcpService := newTestMCPService(t, db)
+	svc, err := NewToolGroupService(db, mcpService)
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	if _, err := mcpService.DisableTools("calc__avg"); err != nil {
+		t.Fatalf("failed to disable tool: %v", err)
+	}
+
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:                  "calc",
+		IncludedToolSelectors: datatypes.JSON(`[{"pattern":"calc__*"}]`),
+	})
+	if err != nil {
+		t.Fatalf("expected group whose selector matches a disabled tool to be created, got %v", err)
+	}
+	groupToolNames := func() []string {
+		t.Helper()
+		mcpServer, _ := svc.GetToolGroupMCPServer("calc")
+		names := []string{}
+		for name := range mcpServer.ListTools() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	if names := groupToolNames(); !reflect.DeepEqual(names, []string{"calc__sum"}) {
+		t.Fatalf("expected group to serve only the enabled tool, got %v", names)
+	}
+
+	// once the tool is enabled again, it joins the group
+	if _, err := mcpService.EnableTools("calc__avg"); err != nil {
+		t.Fatalf("failed to enable tool: %v", err)
+	}
+	if names := groupToolNames(); !reflect.DeepEqual(names, []string{"calc__avg", "calc__sum"}) {
+		t.Fatalf("expected enabled tool to join the group, got %v", names)
+	}
+}
+
+func TestCreateToolGroup_InvalidSelectorReturnsInvalidInput(t *testing.T) {
+	db := setupInMemoryDB(t)
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+
+	err = svc.CreateToolGroup(&model.ToolGroup{Name: "bad", IncludedToolSelectors: datatypes.JSON(`[{"regex":"("}]`)})
+	if !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Fatalf("expected invalid input error, got %v", err)
+	}
+}
+
+func TestToolGroup_IncludedGroups(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	for _, name := range []string{"sum", "sub", "mul"} {
+		tool := model.Tool{ServerID: srv.ID, Name: name, InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool: %v", err)
+		}
+	}
+
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	groupToolNames := func(svc *ToolGroupService, name string) []string {
+		t.Helper()
+		mcpServer, ok := svc.GetToolGroupMCPServer(name)
+		if !ok {
+			t.Fatalf("expected group %s to be served", name)
+		}
+		names := []string{}
+		for toolName := range mcpServer.ListTools() {
+			names = append(names, toolName)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	if err := svc.CreateToolGroup(&model.ToolGroup{Name: "base", IncludedTools: datatypes.JSON(`["calc__sum"]`)}); err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:           "middle",
+		IncludedGroups: datatypes.JSON(`["base"]`),
+		IncludedTools:  datatypes.JSON(`["calc__sub"]`),
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	if err := svc.CreateToolGroup(&model.ToolGroup{Name: "top", IncludedGroups: datatypes.JSON(`["middle"]`)}); err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	if names := groupToolNames(svc, "top"); !reflect.DeepEqual(names, []string{"calc__sub", "calc__sum"}) {
+		t.Fatalf("expected composed group to serve the tools of its included groups, got %v", names)
+	}
+
+	// updating the base group updates all the groups composed of it
+	_, err = svc.UpdateToolGroup("base", &model.ToolGroup{IncludedTools: datatypes.JSON(`["calc__mul"]`)})
+	if err != nil {
+		t.Fatalf("failed to update tool group: %v", err)
+	}
+	if names := groupToolNames(svc, "top"); !reflect.DeepEqual(names, []string{"calc__mul", "calc__sub"}) {
+		t.Fatalf("expected composed group to follow its base group, got %v", names)
+	}
+
+	// another instance sharing the database only reloads the base group, its composed groups follow
+	replica, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	_, err = svc.UpdateToolGroup("base", &model.ToolGroup{IncludedTools: datatypes.JSON(`["calc__sum"]`)})
+	if err != nil {
+		t.Fatalf("failed to update tool group: %v", err)
+	}
+	if err := replica.ReloadToolGroup("base"); err != nil {
+		t.Fatalf("failed to reload tool group: %v", err)
+	}
+	if names := groupToolNames(replica, "top"); !reflect.DeepEqual(names, []string{"calc__sub", "calc__sum"}) {
+		t.Fatalf("expected replica's composed group to follow its base group, got %v", names)
+	}
+
+	// cycles are rejected
+	_, err = svc.UpdateToolGroup("base", &model.ToolGroup{IncludedGroups: datatypes.JSON(`["top"]`)})
+	if !errors.Is(err, apierrors.ErrInvalidInput) || !errors.Is(err, model.ErrToolGroupCycle) {
+		t.Fatalf("expected cycle to be rejected as invalid input, got %v", err)
+	}
+	err = svc.CreateToolGroup(&model.ToolGroup{Name: "broken", IncludedGroups: datatypes.JSON(`["missing"]`)})
+	if !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Fatalf("expected missing included group to be rejected as invalid input, got %v", err)
+	}
+
+	// a group cannot be deleted while other groups include it
+	if err := svc.DeleteToolGroup("base"); !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Fatalf("expected deletion of an included group to be rejected, got %v", err)
+	}
+	if err := svc.DeleteToolGroup("top"); err != nil {
+		t.Fatalf("failed to delete tool group: %v", err)
+	}
+}
+
+func TestToolGroup_ToolOverrides(t *testing.T) {
+	db := setupInMemoryDB(t)
+
+	srv, err := model.NewStdioServer("calc", "Calculator", "echo", nil, nil, "")
+	if err != nil {
+		t.Fatalf("failed to create server model: %v", err)
+	}
+	if err := srv.SetToolOverrides(map[string]types.ToolOverride{"sum": {Name: "add_numbers"}}); err != nil {
+		t.Fatalf("failed to set tool overrides: %v", err)
+	}
+	if err := db.Create(srv).Error; err != nil {
+		t.Fatalf("failed to persist server: %v", err)
+	}
+	for _, name := range []string{"sum", "avg"} {
+		tool := model.Tool{ServerID: srv.ID, Name: name, InputSchema: []byte(`{"type":"object"}`), Enabled: true}
+		if err := db.Create(&tool).Error; err != nil {
+			t.Fatalf("failed to persist tool: %v", err)
+		}
+	}
+
+	svc, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	groupTools := func(svc *ToolGroupService, name string) map[string]*server.ServerTool {
+		t.Helper()
+		mcpServer, ok := svc.GetToolGroupMCPServer(name)
+		if !ok {
+			t.Fatalf("expected group %s to be served", name)
+		}
+		return mcpServer.ListTools()
+	}
+	toolNames := func(tools map[string]*server.ServerTool) []string {
+		names := []string{}
+		for name := range tools {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	// the group inherits the alias configured by the server and adds its own override
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "stats",
+		IncludedTools: datatypes.JSON(`["calc__sum","calc__avg"]`),
+		ToolOverrides: datatypes.JSON(`{"calc__avg":{"name":"mean","description":"Average of the numbers"}}`),
+	})
+	if err != nil {
+		t.Fatalf("failed to create tool group: %v", err)
+	}
+	tools := groupTools(svc, "stats")
+	if names := toolNames(tools); !reflect.DeepEqual(names, []string{"add_numbers", "mean"}) {
+		t.Fatalf("expected group to serve the tools under their aliases, got %v", names)
+	}
+	if desc := tools["mean"].Tool.Description; desc != "Average of the numbers" {
+		t.Fatalf("expected description to be replaced, got %q", desc)
+	}
+
+	// dropping the group's override exposes the tool under its canonical name again
+	_, err = svc.UpdateToolGroup("stats", &model.ToolGroup{IncludedTools: datatypes.JSON(`["calc__sum","calc__avg"]`)})
+	if err != nil {
+		t.Fatalf("failed to update tool group: %v", err)
+	}
+	if names := toolNames(groupTools(svc, "stats")); !reflect.DeepEqual(names, []string{"add_numbers", "calc__avg"}) {
+		t.Fatalf("expected group override to be dropped, got %v", names)
+	}
+
+	// another instance sharing the database picks up the overrides when reloading the group
+	replica, err := NewToolGroupService(db, newTestMCPService(t, db))
+	if err != nil {
+		t.Fatalf("failed to create tool group service: %v", err)
+	}
+	_, err = svc.UpdateToolGroup("stats", &model.ToolGroup{
+		IncludedTools: datatypes.JSON(`["calc__sum","calc__avg"]`),
+		ToolOverrides: datatypes.JSON(`{"calc__sum":{"description":"Sum of the numbers"}}`),
+	})
+	if err != nil {
+		t.Fatalf("failed to update tool group: %v", err)
+	}
+	if err := replica.ReloadToolGroup("stats"); err != nil {
+		t.Fatalf("failed to reload tool group: %v", err)
+	}
+	// a group override replaces the server's override, including its alias
+	if names := toolNames(groupTools(replica, "stats")); !reflect.DeepEqual(names, []string{"calc__avg", "calc__sum"}) {
+		t.Fatalf("expected replica to serve the tools with the group's overrides, got %v", names)
+	}
+
+	// two tools cannot be exposed under the same name
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "clash",
+		IncludedTools: datatypes.JSON(`["calc__sum","calc__avg"]`),
+		ToolOverrides: datatypes.JSON(`{"calc__avg":{"name":"add_numbers"}}`),
+	})
+	if !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Fatalf("expected clashing aliases to be rejected as invalid input, got %v", err)
+	}
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "invalid",
+		IncludedTools: datatypes.JSON(`["calc__sum"]`),
+		ToolOverrides: datatypes.JSON(`{"calc__sum":{"name":"calc__total"}}`),
+	})
+	if !errors.Is(err, apierrors.ErrInvalidInput) {
+		t.Fatalf("expected invalid alias to be rejected as invalid input, got %v", err)
+	}
 }
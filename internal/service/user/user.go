@@ -7,6 +7,7 @@ import (
 
 	"github.com/mcpjungle/mcpjungle/internal"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/namespace"
 	"github.com/mcpjungle/mcpjungle/pkg/apierrors"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
@@ -52,11 +53,25 @@ func (u *UserService) GetUserByAccessToken(token string) (*model.User, error) {
 }
 
 // CreateUser creates a new user with the specified username.
-// This method currently only supports creating a standard user, ie, user with the "user" role.
+// Users are created with the "user" role, unless they belong to a namespace, in which case
+// they can also be created with the "admin" role to administer that namespace.
+// Only the admin created during server initialization administers the whole mcpjungle server.
 func (u *UserService) CreateUser(input *model.User) (*model.User, error) {
 	user := model.User{
-		Username: input.Username,
-		Role:     types.UserRoleUser,
+		Username:  input.Username,
+		Role:      types.UserRoleUser,
+		Namespace: input.Namespace,
+	}
+	if input.Role == types.UserRoleAdmin {
+		if input.Namespace == "" {
+			return nil, fmt.Errorf("only users in a namespace can be created as admins: %w", apierrors.ErrInvalidInput)
+		}
+		user.Role = types.UserRoleAdmin
+	} else if input.Role != "" && input.Role != types.UserRoleUser {
+		return nil, fmt.Errorf("invalid user role %s: %w", input.Role, apierrors.ErrInvalidInput)
+	}
+	if err := namespace.CheckExists(u.db, user.Namespace); err != nil {
+		return nil, err
 	}
 	if input.AccessToken == "" {
 		// no custom access token provided, generate a new one
@@ -106,6 +121,18 @@ func (u *UserService) UpdateUser(input *model.User) (*model.User, error) {
 	return &user, nil
 }
 
+// GetUser retrieves the user with the specified username.
+func (u *UserService) GetUser(username string) (*model.User, error) {
+	var user model.User
+	if err := u.db.Where("username = ?", username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("user with username %s not found: %w", username, apierrors.ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return &user, nil
+}
+
 // ListUsers retrieves all users from the database.
 func (u *UserService) ListUsers() ([]model.User, error) {
 	var users []model.User
@@ -116,7 +143,8 @@ func (u *UserService) ListUsers() ([]model.User, error) {
 }
 
 // DeleteUser removes a user with the specified username from the database.
-// If a user's role is admin, the deletion will be rejected.
+// If the user administers the whole mcpjungle server, the deletion will be rejected.
+// Admins of a namespace can be deleted.
 func (u *UserService) DeleteUser(username string) error {
 	var user model.User
 	err := u.db.Where("username = ?", username).First(&user).Error
@@ -127,7 +155,7 @@ func (u *UserService) DeleteUser(username string) error {
 		return fmt.Errorf("failed to find user: %w", err)
 	}
 
-	if user.Role == types.UserRoleAdmin {
+	if user.Role == types.UserRoleAdmin && user.Namespace == "" {
 		return fmt.Errorf("cannot delete an admin user: %w", apierrors.ErrInvalidInput)
 	}
 
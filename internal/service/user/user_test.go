@@ -283,3 +283,26 @@ func TestUpdateUserNoAccessToken(t *testing.T) {
 		t.Error("Expected update to fail for non-existent user")
 	}
 }
+
+func TestCreateNamespaceAdminUser(t *testing.T) {
+	setup, _ := testhelpers.SetupUserTest(t)
+	defer setup.Cleanup()
+	svc := NewUserService(setup.DB)
+
+	// admins can only be created within a namespace
+	_, err := svc.CreateUser(&model.User{Username: "root2", Role: types.UserRoleAdmin})
+	testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected ErrInvalidInput")
+
+	// the namespace must exist
+	_, err = svc.CreateUser(&model.User{Username: "team-a.lead", Role: types.UserRoleAdmin, Namespace: "team-a"})
+	testhelpers.AssertTrue(t, errors.Is(err, apierrors.ErrInvalidInput), "expected ErrInvalidInput")
+
+	testhelpers.AssertNoError(t, setup.DB.Create(&model.Namespace{Name: "team-a"}).Error)
+	user, err := svc.CreateUser(&model.User{Username: "team-a.lead", Role: types.UserRoleAdmin, Namespace: "team-a"})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, types.UserRoleAdmin, user.Role)
+	testhelpers.AssertEqual(t, "team-a", user.Namespace)
+
+	// namespace admins can be deleted, unlike the global admin
+	testhelpers.AssertNoError(t, svc.DeleteUser("team-a.lead"))
+}
@@ -5,7 +5,7 @@ import (
 	"time"
 )
 
-// ToolCallOutcome represents the outcome of a tool call, either success or error.
+// ToolCallOutcome represents the outcome of a tool call: success, error or cancelled.
 type (
 	ToolCallOutcome   string
 	PromptCallOutcome string
@@ -16,6 +16,8 @@ const (
 	ToolCallOutcomeSuccess ToolCallOutcome = "success"
 	// ToolCallOutcomeError indicates a failed tool call
 	ToolCallOutcomeError ToolCallOutcome = "error"
+	// ToolCallOutcomeCancelled indicates a tool call cancelled by the client before it completed
+	ToolCallOutcomeCancelled ToolCallOutcome = "cancelled"
 )
 
 const (
@@ -28,7 +30,7 @@ const (
 // CustomMetrics defines the interface for recording custom metrics from mcpjungle.
 // It provides convenience methods for recording metrics related to http server, mcp servers, tools, usage, etc.
 type CustomMetrics interface {
-	// RecordToolCall records a tool invocation, its latency, and its outcome (success or error).
+	// RecordToolCall records a tool invocation, its latency, and its outcome (success, error or cancelled).
 	RecordToolCall(ctx context.Context, serverName, toolName string, outcome ToolCallOutcome, elapsedTime time.Duration)
 
 	// RecordPromptCall records a prompt invocation, its latency, and its outcome (success or error).
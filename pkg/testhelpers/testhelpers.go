@@ -263,8 +263,13 @@ func SetupTestDB(t *testing.T) *TestDBSetup {
 		&model.ToolGroup{},
 		&model.Prompt{},
 		&model.Resource{},
+		&model.ResourceTemplate{},
 		&model.UpstreamOAuthPendingSession{},
 		&model.UpstreamOAuthToken{},
+		&model.Revision{},
+		&model.RegistryChange{},
+		&model.UpstreamOAuthSessionResult{},
+		&model.Namespace{},
 	)
 	AssertNoError(t, err)
 
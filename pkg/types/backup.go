@@ -0,0 +1,189 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BackupArchiveVersion is the current version of the backup archive format.
+// It must be incremented whenever the archive structure changes in a backwards-incompatible way.
+const BackupArchiveVersion = 1
+
+// BackupArchive is a portable snapshot of the entire mcpjungle registry.
+// Records reference each other by name instead of database IDs, so an archive taken
+// from one database backend (eg- sqlite) can be restored into another (eg- postgres).
+type BackupArchive struct {
+	// Version is the archive format version, see BackupArchiveVersion.
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ServerConfig *BackupServerConfig `json:"server_config,omitempty"`
+
+	// Namespaces is absent from archives created by older versions of mcpjungle.
+	// The servers, tool groups and clients of a namespace are identified by their canonical names.
+	Namespaces []BackupNamespace `json:"namespaces,omitempty"`
+
+	McpServers []BackupMcpServer `json:"mcp_servers"`
+	Tools      []BackupTool      `json:"tools"`
+	Prompts    []BackupPrompt    `json:"prompts"`
+	Resources  []BackupResource  `json:"resources"`
+
+	// ResourceTemplates is absent from archives created by older versions of mcpjungle.
+	ResourceTemplates []BackupResourceTemplate `json:"resource_templates,omitempty"`
+
+	ToolGroups []BackupToolGroup `json:"tool_groups"`
+	McpClients []BackupMcpClient `json:"mcp_clients"`
+	Users      []BackupUser      `json:"users"`
+
+	UpstreamOAuthTokens []BackupUpstreamOAuthToken `json:"upstream_oauth_tokens"`
+
+	// Revisions is the configuration history of servers and tool groups.
+	// It is absent from archives created by older versions of mcpjungle.
+	Revisions []BackupRevision `json:"revisions,omitempty"`
+}
+
+type BackupServerConfig struct {
+	Mode        string `json:"mode"`
+	Initialized bool   `json:"initialized"`
+}
+
+type BackupNamespace struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type BackupMcpServer struct {
+	Name        string          `json:"name"`
+	Transport   string          `json:"transport"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description"`
+	Config      json.RawMessage `json:"config"`
+	SessionMode string          `json:"session_mode"`
+	Labels      json.RawMessage `json:"labels,omitempty"`
+
+	ToolOverrides json.RawMessage `json:"tool_overrides,omitempty"`
+	AllowedRoots  json.RawMessage `json:"allowed_roots,omitempty"`
+	LogLevel      string          `json:"log_level,omitempty"`
+	ServerInfo    json.RawMessage `json:"server_info,omitempty"`
+}
+
+type BackupTool struct {
+	// Server is the name of the MCP server that provides this tool.
+	Server      string          `json:"server"`
+	Name        string          `json:"name"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"input_schema,omitempty"`
+	Annotations json.RawMessage `json:"annotations,omitempty"`
+}
+
+type BackupPrompt struct {
+	// Server is the name of the MCP server that provides this prompt.
+	Server      string          `json:"server"`
+	Name        string          `json:"name"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description"`
+	Arguments   json.RawMessage `json:"arguments,omitempty"`
+}
+
+type BackupResource struct {
+	// Server is the name of the MCP server that provides this resource.
+	Server      string          `json:"server"`
+	URI         string          `json:"uri"`
+	OriginalURI string          `json:"original_uri"`
+	Name        string          `json:"name"`
+	Enabled     bool            `json:"enabled"`
+	Description string          `json:"description"`
+	MIMEType    string          `json:"mime_type"`
+	Annotations json.RawMessage `json:"annotations,omitempty"`
+	Meta        json.RawMessage `json:"meta,omitempty"`
+}
+
+type BackupResourceTemplate struct {
+	// Server is the name of the MCP server that provides this resource template.
+	Server              string          `json:"server"`
+	URITemplate         string          `json:"uri_template"`
+	OriginalURITemplate string          `json:"original_uri_template"`
+	Name                string          `json:"name"`
+	Description         string          `json:"description"`
+	MIMEType            string          `json:"mime_type"`
+	Annotations         json.RawMessage `json:"annotations,omitempty"`
+	Meta                json.RawMessage `json:"meta,omitempty"`
+}
+
+type BackupToolGroup struct {
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	IncludedTools   json.RawMessage `json:"included_tools,omitempty"`
+	IncludedServers json.RawMessage `json:"included_servers,omitempty"`
+	ExcludedTools   json.RawMessage `json:"excluded_tools,omitempty"`
+	IncludedGroups  json.RawMessage `json:"included_groups,omitempty"`
+
+	IncludedToolSelectors json.RawMessage `json:"included_tool_selectors,omitempty"`
+	ExcludedToolSelectors json.RawMessage `json:"excluded_tool_selectors,omitempty"`
+
+	IncludedPrompts   json.RawMessage `json:"included_prompts,omitempty"`
+	ExcludedPrompts   json.RawMessage `json:"excluded_prompts,omitempty"`
+	IncludedResources json.RawMessage `json:"included_resources,omitempty"`
+	ExcludedResources json.RawMessage `json:"excluded_resources,omitempty"`
+
+	ToolOverrides json.RawMessage `json:"tool_overrides,omitempty"`
+}
+
+type BackupMcpClient struct {
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	AccessToken   string          `json:"access_token"`
+	AllowList     json.RawMessage `json:"allow_list"`
+	AllowedGroups json.RawMessage `json:"allowed_groups,omitempty"`
+}
+
+type BackupUser struct {
+	Username    string `json:"username"`
+	Role        string `json:"role"`
+	Namespace   string `json:"namespace,omitempty"`
+	AccessToken string `json:"access_token"`
+}
+
+type BackupUpstreamOAuthToken struct {
+	ServerName   string          `json:"server_name"`
+	Transport    string          `json:"transport"`
+	ClientID     string          `json:"client_id"`
+	ClientSecret string          `json:"client_secret"`
+	RedirectURI  string          `json:"redirect_uri"`
+	Scopes       json.RawMessage `json:"scopes,omitempty"`
+	AccessToken  string          `json:"access_token"`
+	TokenType    string          `json:"token_type"`
+	RefreshToken string          `json:"refresh_token"`
+	Scope        string          `json:"scope"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+}
+
+type BackupRevision struct {
+	EntityType string          `json:"entity_type"`
+	EntityName string          `json:"entity_name"`
+	Number     int             `json:"number"`
+	Action     string          `json:"action"`
+	Author     string          `json:"author,omitempty"`
+	Note       string          `json:"note,omitempty"`
+	Snapshot   json.RawMessage `json:"snapshot,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// RestoreResult summarizes what was restored from a backup archive.
+type RestoreResult struct {
+	Namespaces          int `json:"namespaces"`
+	McpServers          int `json:"mcp_servers"`
+	Tools               int `json:"tools"`
+	Prompts             int `json:"prompts"`
+	Resources           int `json:"resources"`
+	ResourceTemplates   int `json:"resource_templates"`
+	ToolGroups          int `json:"tool_groups"`
+	McpClients          int `json:"mcp_clients"`
+	Users               int `json:"users"`
+	UpstreamOAuthTokens int `json:"upstream_oauth_tokens"`
+	Revisions           int `json:"revisions"`
+
+	// Warnings lists records that were skipped or adjusted during the restore.
+	Warnings []string `json:"warnings,omitempty"`
+}
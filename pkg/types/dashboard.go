@@ -1,5 +1,7 @@
 package types
 
+import "encoding/json"
+
 type DashboardStatus string
 
 const (
@@ -66,6 +68,7 @@ type DashboardServer struct {
 	ConnectionSummary  string                       `json:"connection_summary"`
 	ConfigSummary      DashboardServerConfigSummary `json:"config_summary"`
 	NamespacedExamples []string                     `json:"namespaced_examples,omitempty"`
+	ServerInfo         *UpstreamServerInfo          `json:"server_info,omitempty"`
 }
 
 type DashboardServersResponse struct {
@@ -142,3 +145,11 @@ type DashboardDiagnosticsResponse struct {
 	ResourceCount        int                  `json:"resource_count"`
 	EmptyState           *DashboardEmptyState `json:"empty_state,omitempty"`
 }
+
+// DashboardClientConnection is the config needed to connect a specific kind of MCP client to mcpjungle.
+type DashboardClientConnection struct {
+	Client     string          `json:"client"`
+	Endpoint   string          `json:"endpoint"`
+	ConfigFile string          `json:"config_file,omitempty"`
+	Fragment   json.RawMessage `json:"fragment"`
+}
@@ -12,6 +12,10 @@ type McpClient struct {
 
 	// AllowList is a list of MCP Servers that this client is allowed to access from MCPJungle.
 	AllowList []string `json:"allow_list"`
+
+	// AllowedGroups is a list of Tool Groups this client is bound to.
+	// A client bound to groups can only connect to the MCP endpoints of those groups.
+	AllowedGroups []string `json:"allowed_groups,omitempty"`
 }
 
 // AccessTokenRef describes how to load a secret access token from an external source.
@@ -36,6 +40,11 @@ type McpClientConfig struct {
 	// Use the wildcard operator "*" to allow access to all MCP servers in MCPJungle.
 	AllowMcpServers []string `json:"allowed_servers"`
 
+	// AllowedGroups is a list of Tool Groups this client is bound to.
+	// If set, the client can only connect to the MCP endpoints of these groups
+	// and is denied access to the global MCP proxy.
+	AllowedGroups []string `json:"allowed_groups,omitempty"`
+
 	// AccessToken allows you to provide a custom access token the client can use
 	// to authenticate with MCPJungle.
 	// It is not recommended to use this field in production environments, since
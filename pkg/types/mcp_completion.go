@@ -0,0 +1,25 @@
+package types
+
+// CompleteRequest represents a request for the completions of the value of a prompt argument
+// or of a variable in the URI template of a resource template.
+// Exactly one of Prompt and ResourceTemplate must be set.
+type CompleteRequest struct {
+	// Prompt is the name of the prompt whose argument is completed.
+	Prompt string `json:"prompt,omitempty"`
+	// ResourceTemplate is the URI template (mcpj://tmpl/...) of the resource template whose variable is completed.
+	ResourceTemplate string `json:"resource_template,omitempty"`
+
+	Argument string `json:"argument"`
+	// Value is the partial value of the argument entered so far.
+	Value string `json:"value"`
+	// Context contains the values of the arguments that have already been resolved.
+	Context map[string]string `json:"context,omitempty"`
+}
+
+// CompleteResult represents the completions suggested by the upstream MCP server.
+type CompleteResult struct {
+	Values []string `json:"values"`
+	// Total is the total number of completions available, which can exceed the number of values returned.
+	Total   int  `json:"total,omitempty"`
+	HasMore bool `json:"has_more,omitempty"`
+}
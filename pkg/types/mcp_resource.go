@@ -11,6 +11,17 @@ type Resource struct {
 	Meta        map[string]any `json:"meta,omitempty"`
 }
 
+// ResourceTemplate represents a resource template provided by an MCP Server registered in the registry.
+// MCP clients read the resources described by a template by expanding its URI template.
+type ResourceTemplate struct {
+	URITemplate string         `json:"uri_template"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	MIMEType    string         `json:"mime_type"`
+	Annotations map[string]any `json:"annotations,omitempty"`
+	Meta        map[string]any `json:"meta,omitempty"`
+}
+
 // ResourceGetRequest represents a request to fetch resource metadata.
 type ResourceGetRequest struct {
 	URI string `json:"uri"`
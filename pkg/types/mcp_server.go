@@ -1,6 +1,10 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // McpServerTransport represents the transport protocol used by an MCP server.
 // All transport types supported by mcpjungle are defined in this file with this type.
@@ -42,6 +46,18 @@ type McpServer struct {
 	Env     map[string]string `json:"env"`
 
 	SessionMode string `json:"session_mode"`
+
+	Labels []string `json:"labels,omitempty"`
+
+	ToolOverrides map[string]ToolOverride `json:"tool_overrides,omitempty"`
+
+	AllowedRoots []string `json:"allowed_roots,omitempty"`
+
+	LogLevel string `json:"log_level,omitempty"`
+
+	// ServerInfo describes the server as it reported itself when it was last registered or updated,
+	// including its usage instructions for LLMs. It is empty for servers registered by older versions of mcpjungle.
+	ServerInfo *UpstreamServerInfo `json:"server_info,omitempty"`
 }
 
 // RegisterServerInput is the input structure for registering a new MCP server with mcpjungle.
@@ -84,6 +100,23 @@ type RegisterServerInput struct {
 	// SessionMode controls how mcpjungle manages connections to this MCP server.
 	SessionMode string `json:"session_mode,omitempty"`
 
+	// Labels is an optional list of labels attached to the server, eg- "prod".
+	// Tool groups can select tools by the labels of their servers.
+	Labels []string `json:"labels,omitempty"`
+
+	// ToolOverrides optionally changes how the server's tools are exposed by the gateway, keyed by tool name
+	// (without the server name prefix). Tool groups inherit these overrides unless they override a tool themselves.
+	ToolOverrides map[string]ToolOverride `json:"tool_overrides,omitempty"`
+
+	// AllowedRoots optionally lists the root URIs, eg- "file:///home/user/projects", that MCP clients may share
+	// with the server when it asks them for their roots. Roots of a client outside these are never passed on,
+	// and no roots are passed on if the list is empty.
+	AllowedRoots []string `json:"allowed_roots,omitempty"`
+
+	// LogLevel optionally sets the minimum level of the log messages that the server sends to mcpjungle,
+	// eg- "warning". It is applied with logging/setLevel to stateful sessions with servers that support logging.
+	LogLevel string `json:"log_level,omitempty"`
+
 	// OAuthRedirectURI is the redirect URI used if the upstream server requires OAuth.
 	// This is usually provided by the registering client, e.g. a localhost callback
 	// owned by the CLI or a public callback owned by the gateway.
@@ -104,6 +137,13 @@ type ServerMetadata struct {
 	Version string `json:"version"`
 }
 
+// SetServerLogLevelInput is the request body for changing the log level of an MCP server.
+type SetServerLogLevelInput struct {
+	// Level is the minimum level of the log messages that the server sends to mcpjungle.
+	// An empty level unsets it.
+	Level string `json:"level"`
+}
+
 // EnableDisableServerResult represents the result of enabling or disabling an MCP server
 type EnableDisableServerResult struct {
 	// Name is the name of the server that was enabled/disabled
@@ -114,6 +154,96 @@ type EnableDisableServerResult struct {
 	PromptsAffected []string `json:"prompts_affected"`
 }
 
+// ServerEntityChanges lists the names of the tools, prompts or resources of an MCP server
+// that were affected by an update of the server's configuration.
+type ServerEntityChanges struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	// Updated contains the entities whose definition (eg- description or input schema) changed upstream.
+	Updated []string `json:"updated,omitempty"`
+}
+
+// IsEmpty returns true if no entities were affected.
+func (c *ServerEntityChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Updated) == 0
+}
+
+// UpdateServerResult represents the result of updating the configuration of a registered MCP server in place.
+type UpdateServerResult struct {
+	Server *McpServer `json:"server"`
+
+	// ConfigChanges contains the names of the configuration fields that changed, eg- "url" or "headers.Authorization".
+	// Values are not included because they may contain secrets.
+	ConfigChanges []string `json:"config_changes,omitempty"`
+
+	Tools     ServerEntityChanges `json:"tools"`
+	Prompts   ServerEntityChanges `json:"prompts"`
+	Resources ServerEntityChanges `json:"resources"`
+
+	// SessionClosed is true if a stateful session with the old configuration was closed.
+	// A new session is created with the new configuration on the next tool call.
+	SessionClosed bool `json:"session_closed,omitempty"`
+}
+
+// ServerValidationReport describes what registering an MCP server would result in.
+// It is produced by a dry-run registration, which connects to the upstream server
+// and discovers its capabilities without changing anything in the registry.
+type ServerValidationReport struct {
+	Name      string `json:"name"`
+	Transport string `json:"transport"`
+
+	// Valid is false if registering the server would fail. Errors explains why.
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"`
+	// Warnings contains problems that do not prevent the registration,
+	// eg- an existing server being replaced or prompts that could not be listed.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// AuthorizationRequired is true if the upstream server requires OAuth authorization.
+	// Its capabilities can only be discovered once the authorization is completed during registration.
+	AuthorizationRequired bool `json:"authorization_required,omitempty"`
+
+	ServerInfo *UpstreamServerInfo `json:"server_info,omitempty"`
+
+	// Tools and Prompts contain the canonical names under which the entities would be registered.
+	Tools             []ValidatedEntity           `json:"tools"`
+	Prompts           []ValidatedEntity           `json:"prompts"`
+	Resources         []ValidatedResource         `json:"resources"`
+	ResourceTemplates []ValidatedResourceTemplate `json:"resource_templates"`
+}
+
+// UpstreamServerInfo describes an upstream MCP server as reported by it during initialization.
+type UpstreamServerInfo struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	ProtocolVersion string `json:"protocol_version"`
+	Instructions    string `json:"instructions,omitempty"`
+	// Capabilities lists the capabilities advertised by the server, eg- "tools", "prompts" or "logging".
+	Capabilities []string `json:"capabilities"`
+}
+
+// ValidatedEntity is a tool or prompt discovered during a dry-run registration.
+type ValidatedEntity struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ValidatedResource is a resource discovered during a dry-run registration.
+type ValidatedResource struct {
+	// URI is the MCPJungle URI under which the resource would be registered.
+	URI         string `json:"uri"`
+	OriginalURI string `json:"original_uri"`
+	Name        string `json:"name"`
+	MIMEType    string `json:"mime_type,omitempty"`
+}
+
+// ValidatedResourceTemplate is a resource template discovered during a dry-run registration.
+type ValidatedResourceTemplate struct {
+	URITemplate string `json:"uri_template"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
 // ValidateTransport validates the input string and returns the corresponding model.McpServerTransport.
 // It returns an error if the input is invalid or empty.
 func ValidateTransport(input string) (McpServerTransport, error) {
@@ -150,3 +280,15 @@ func ValidateSessionMode(input string) (SessionMode, error) {
 		)
 	}
 }
+
+// logLevels are the levels of MCP log messages, from the least to the most severe.
+var logLevels = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+// ValidateLogLevel validates the input string as the level of MCP log messages.
+// An empty input is valid and means that no level is set.
+func ValidateLogLevel(input string) error {
+	if input == "" || slices.Contains(logLevels, input) {
+		return nil
+	}
+	return fmt.Errorf("unsupported log level: %s (acceptable values: %s)", input, strings.Join(logLevels, ", "))
+}
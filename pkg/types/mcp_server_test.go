@@ -198,3 +198,14 @@ func TestValidateSessionMode(t *testing.T) {
 		})
 	}
 }
+
+func TestServerEntityChangesIsEmpty(t *testing.T) {
+	var changes ServerEntityChanges
+	if !changes.IsEmpty() {
+		t.Error("expected zero value to be empty")
+	}
+	changes.Updated = []string{"calc__sum"}
+	if changes.IsEmpty() {
+		t.Error("expected changes with updated entities not to be empty")
+	}
+}
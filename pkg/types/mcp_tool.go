@@ -1,5 +1,11 @@
 package types
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 // ToolInputSchema defines the schema for the input parameters of a tool
 type ToolInputSchema struct {
 	Type       string         `json:"type"`
@@ -25,3 +31,61 @@ type ToolInvokeResult struct {
 	Content           []map[string]any `json:"content"`
 	StructuredContent any              `json:"structuredContent,omitempty"`
 }
+
+// ToolOverride changes how the MCP proxy exposes a tool to MCP clients and the arguments it is called with.
+// When the tool is called, hidden parameters are dropped, pinned values are enforced and defaults are filled in
+// before the call is sent upstream. The overrides of a tool's server also apply when it is invoked through the API,
+// which still addresses the tool by its canonical name rather than its alias.
+type ToolOverride struct {
+	// Name is an alias the tool is exposed under instead of its canonical name, eg- "create_pr".
+	// Like all names in mcpjungle, the alias of a tool in a namespace is qualified with the namespace's name.
+	Name string `json:"name,omitempty"`
+	// Description replaces the tool's description.
+	Description string `json:"description,omitempty"`
+	// AppendDescription is appended to the tool's description, eg- to give the LLM a usage hint.
+	AppendDescription string `json:"append_description,omitempty"`
+	// HiddenParams are input parameters removed from the tool's input schema.
+	// Values that clients send for them anyway are dropped, so the upstream server uses its defaults.
+	HiddenParams []string `json:"hidden_params,omitempty"`
+	// PinnedParams are input parameters set to fixed values.
+	// They are removed from the tool's input schema and the given values are always sent to the upstream server.
+	// Calls that set a pinned parameter to a different value are rejected.
+	PinnedParams map[string]any `json:"pinned_params,omitempty"`
+	// DefaultParams are values sent for input parameters that clients leave out.
+	// The parameters stay in the tool's input schema, which advertises the given values as their defaults.
+	DefaultParams map[string]any `json:"default_params,omitempty"`
+}
+
+// String returns a human-readable form of the override, eg- `name=create_pr pinned=owner:"acme"`.
+func (o ToolOverride) String() string {
+	var parts []string
+	if o.Name != "" {
+		parts = append(parts, "name="+o.Name)
+	}
+	if o.Description != "" {
+		parts = append(parts, fmt.Sprintf("description=%q", o.Description))
+	}
+	if o.AppendDescription != "" {
+		parts = append(parts, fmt.Sprintf("append_description=%q", o.AppendDescription))
+	}
+	if len(o.HiddenParams) > 0 {
+		parts = append(parts, "hidden="+strings.Join(o.HiddenParams, ","))
+	}
+	if len(o.PinnedParams) > 0 {
+		pinned := make([]string, 0, len(o.PinnedParams))
+		for param, value := range o.PinnedParams {
+			pinned = append(pinned, fmt.Sprintf("%s:%#v", param, value))
+		}
+		sort.Strings(pinned)
+		parts = append(parts, "pinned="+strings.Join(pinned, ","))
+	}
+	if len(o.DefaultParams) > 0 {
+		defaults := make([]string, 0, len(o.DefaultParams))
+		for param, value := range o.DefaultParams {
+			defaults = append(defaults, fmt.Sprintf("%s:%#v", param, value))
+		}
+		sort.Strings(defaults)
+		parts = append(parts, "defaults="+strings.Join(defaults, ","))
+	}
+	return strings.Join(parts, " ")
+}
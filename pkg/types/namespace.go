@@ -0,0 +1,8 @@
+package types
+
+// Namespace isolates the MCP servers, tool groups, MCP clients and users of one tenant
+// from those of other tenants sharing the same mcpjungle server.
+type Namespace struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Revision is a numbered snapshot of the configuration of an MCP server or a tool group.
+type Revision struct {
+	Number int `json:"number"`
+
+	// Action is the kind of change that produced this revision.
+	// One of "baseline", "created", "updated", "deleted" or "rolled_back".
+	Action string `json:"action"`
+
+	// Author is the user who made the change, empty if it was made without authentication.
+	Author    string    `json:"author,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Snapshot is the configuration of the entity after the change, null for deletions.
+	// For servers, it has the shape of RegisterServerInput, for tool groups the shape of ToolGroup.
+	Snapshot json.RawMessage `json:"snapshot,omitempty"`
+}
+
+// RevisionChange describes a single configuration field that differs between two revisions.
+// Nested fields are addressed with dots, eg- "headers.Authorization".
+type RevisionChange struct {
+	Field string `json:"field"`
+	// Old is the value in the older revision, nil if the field was not set.
+	Old any `json:"old,omitempty"`
+	// New is the value in the newer revision, nil if the field was removed.
+	New any `json:"new,omitempty"`
+}
+
+// RevisionDiff lists the differences between two revisions of the same entity.
+type RevisionDiff struct {
+	Name    string           `json:"name"`
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+	Changes []RevisionChange `json:"changes"`
+}
+
+// RollbackInput is the payload for rolling back a server or tool group to an earlier revision.
+type RollbackInput struct {
+	Revision int `json:"revision"`
+
+	// OAuthRedirectURI optionally overrides the OAuth redirect URI stored in the revision of a server,
+	// in case the restored server requires upstream OAuth authorization again.
+	OAuthRedirectURI string `json:"oauth_redirect_uri,omitempty"`
+}
+
+// RollbackResult describes the outcome of a rollback.
+type RollbackResult struct {
+	Name string `json:"name"`
+	// RestoredRevision is the revision whose configuration was restored.
+	RestoredRevision int `json:"restored_revision"`
+	// Revision is the new revision recorded for the rollback itself.
+	Revision int `json:"revision,omitempty"`
+
+	// AuthorizationRequired is set if the restored server requires upstream OAuth authorization
+	// before it can be registered again.
+	AuthorizationRequired *UpstreamOAuthAuthorizationRequired `json:"authorization_required,omitempty"`
+}
@@ -1,22 +1,91 @@
 package types
 
-// ToolGroup represents a group (collection) of MCP Tools.
-// A group can contain a subset of all available tools in the MCPJungle system.
-// This allows you to expose a limited set of tools to certain mcp clients.
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ToolGroup represents a group (collection) of MCP Tools, Prompts and Resources.
+// A group can contain a subset of all available tools, prompts and resources in the MCPJungle system.
+// This allows you to expose a limited set of them to certain mcp clients.
 // This struct is also the basis for the JSON configuration file used to register a new tool group.
 type ToolGroup struct {
 	// Name is the unique name of the tool group (mandatory).
 	Name string `json:"name"`
 	// IncludedTools is a list of tools included in this group.
 	IncludedTools []string `json:"included_tools,omitempty"`
-	// IncludedServers is a list of MCP server names. All tools from these servers will be included.
+	// IncludedServers is a list of MCP server names.
+	// All tools, prompts and resources from these servers will be included.
 	IncludedServers []string `json:"included_servers,omitempty"`
 	// ExcludedTools is a list of tools to exclude from the group (useful with IncludedServers).
 	ExcludedTools []string `json:"excluded_tools,omitempty"`
 
+	// IncludedGroups is a list of other tool groups.
+	// All their effective tools, prompts and resources will be included in this group.
+	IncludedGroups []string `json:"included_groups,omitempty"`
+
+	// IncludedToolSelectors is a list of selectors. All tools matching any of them will be included.
+	IncludedToolSelectors []ToolSelector `json:"included_tool_selectors,omitempty"`
+	// ExcludedToolSelectors is a list of selectors. All tools matching any of them will be excluded.
+	ExcludedToolSelectors []ToolSelector `json:"excluded_tool_selectors,omitempty"`
+
+	// IncludedPrompts is a list of prompts included in this group.
+	IncludedPrompts []string `json:"included_prompts,omitempty"`
+	// ExcludedPrompts is a list of prompts to exclude from the group (useful with IncludedServers).
+	ExcludedPrompts []string `json:"excluded_prompts,omitempty"`
+
+	// IncludedResources is a list of mcpjungle resource URIs included in this group.
+	IncludedResources []string `json:"included_resources,omitempty"`
+	// ExcludedResources is a list of resource URIs to exclude from the group (useful with IncludedServers).
+	ExcludedResources []string `json:"excluded_resources,omitempty"`
+
+	// ToolOverrides changes how tools are exposed by this group, keyed by tool name.
+	// A tool overridden by the group ignores the override configured by its MCP server.
+	ToolOverrides map[string]ToolOverride `json:"tool_overrides,omitempty"`
+
 	Description string `json:"description"`
 }
 
+// ToolSelector selects tools by rules instead of by their names.
+// A tool matches the selector only if it satisfies all the rules set in it.
+// Selectors are evaluated whenever the tools of a group are resolved,
+// so tools registered after the group was created automatically join it if they match.
+type ToolSelector struct {
+	// Pattern is a glob pattern matched against the tool name, eg- "github__list_*".
+	// Like the other names in a group, the tool name is relative to the group's namespace.
+	Pattern string `json:"pattern,omitempty"`
+	// Regex is a regular expression matched against the tool name.
+	// It matches anywhere in the name unless it is anchored with ^ and $.
+	Regex string `json:"regex,omitempty"`
+	// Annotations maps tool annotation hints to their required values, eg- {"readOnlyHint": true}.
+	// Hints a tool does not declare take the default values defined by the MCP specification.
+	Annotations map[string]bool `json:"annotations,omitempty"`
+	// ServerLabels is a list of labels the tool's MCP server must have.
+	ServerLabels []string `json:"server_labels,omitempty"`
+}
+
+// String returns a compact, deterministic description of the selector's rules, eg- for CLI output.
+func (s ToolSelector) String() string {
+	var rules []string
+	if s.Pattern != "" {
+		rules = append(rules, "pattern="+s.Pattern)
+	}
+	if s.Regex != "" {
+		rules = append(rules, "regex="+s.Regex)
+	}
+	hints := make([]string, 0, len(s.Annotations))
+	for hint, value := range s.Annotations {
+		hints = append(hints, fmt.Sprintf("%s=%t", hint, value))
+	}
+	sort.Strings(hints)
+	rules = append(rules, hints...)
+	if len(s.ServerLabels) > 0 {
+		rules = append(rules, "server_labels="+strings.Join(s.ServerLabels, ","))
+	}
+	return strings.Join(rules, " ")
+}
+
 // ToolGroupEndpoints contains the endpoints a MCP client can use to access a tool group.
 type ToolGroupEndpoints struct {
 	StreamableHTTPEndpoint string `json:"streamable_http_endpoint"`
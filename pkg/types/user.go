@@ -33,11 +33,18 @@ type UserConfig struct {
 type User struct {
 	Username string `json:"username"`
 	Role     string `json:"role"`
+
+	// Namespace is the namespace the user belongs to, it is empty if the user is not part of any namespace.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type CreateOrUpdateUserRequest struct {
 	Username    string `json:"username"`
 	AccessToken string `json:"access_token,omitempty"`
+
+	// Role is the role of the user to create, it defaults to "user".
+	// Only users created in a namespace can be admins, they administer just that namespace.
+	Role string `json:"role,omitempty"`
 }
 
 type CreateOrUpdateUserResponse struct {
@@ -1128,6 +1128,22 @@ export default function App() {
                                       <code>{server.config_summary.env_keys?.join(", ") || "None"}</code>
                                     </dd>
                                   </div>
+                                  <div>
+                                    <dt>Upstream server</dt>
+                                    <dd>
+                                      <code>
+                                        {server.server_info
+                                          ? `${server.server_info.name} ${server.server_info.version}`
+                                          : "Unknown"}
+                                      </code>
+                                    </dd>
+                                  </div>
+                                  {server.server_info?.instructions ? (
+                                    <div>
+                                      <dt>Instructions</dt>
+                                      <dd>{server.server_info.instructions}</dd>
+                                    </div>
+                                  ) : null}
                                 </dl>
                               </div>
                             ) : null}
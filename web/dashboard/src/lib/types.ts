@@ -42,6 +42,14 @@ export interface DashboardServerConfigSummary {
   sanitized_summary: string;
 }
 
+export interface UpstreamServerInfo {
+  name: string;
+  version: string;
+  protocol_version: string;
+  instructions?: string;
+  capabilities: string[];
+}
+
 export interface DashboardServer {
   name: string;
   transport: string;
@@ -54,6 +62,7 @@ export interface DashboardServer {
   updated_at?: string;
   connection_summary: string;
   config_summary: DashboardServerConfigSummary;
+  server_info?: UpstreamServerInfo;
 }
 
 export interface DashboardServersResponse {